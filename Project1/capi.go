@@ -0,0 +1,140 @@
+//go:build cshared
+
+package main
+
+// Build the simulation core as a C shared library with:
+//
+//	go build -tags cshared -buildmode=c-shared -o libscheduler.so .
+//
+// which also writes libscheduler.h declaring the functions below. See
+// python/scheduler.py for a ctypes wrapper.
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unsafe"
+
+	"github.com/MelvinTowo/Process-scheduler-in-GO/Project1/scheduler"
+)
+
+// cOptions are the engine options a C caller can set, as a JSON object.
+type cOptions struct {
+	// MPL is the degree of multiprogramming; 0 admits every process.
+	MPL             int     `json:"mpl"`
+	SwitchCost      int64   `json:"switch_cost"`
+	DispatchLatency int64   `json:"dispatch_latency"`
+	IOProb          float64 `json:"io_prob"`
+	IOMean          float64 `json:"io_mean"`
+	Seed            int64   `json:"seed"`
+}
+
+// parseOptions turns a JSON object of cOptions into engine options. An
+// empty string sets none.
+func parseOptions(s string) ([]scheduler.Option, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var o cOptions
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&o); err != nil {
+		return nil, fmt.Errorf("%w: options: %v", scheduler.ErrInvalidArgs, err)
+	}
+	if o.MPL < 0 || o.SwitchCost < 0 || o.DispatchLatency < 0 || o.IOProb < 0 || o.IOProb > 1 {
+		return nil, fmt.Errorf("%w: options must not be negative, and io_prob at most 1", scheduler.ErrInvalidArgs)
+	}
+
+	var opts []scheduler.Option
+	if o.MPL > 0 {
+		opts = append(opts, scheduler.WithMultiprogramming(o.MPL))
+	}
+	if o.SwitchCost > 0 {
+		opts = append(opts, scheduler.WithSwitchCost(o.SwitchCost))
+	}
+	if o.DispatchLatency > 0 {
+		opts = append(opts, scheduler.WithDispatchLatency(o.DispatchLatency))
+	}
+	if o.IOProb > 0 {
+		if o.IOMean <= 0 {
+			return nil, fmt.Errorf("%w: io_prob needs a positive io_mean", scheduler.ErrInvalidArgs)
+		}
+		opts = append(opts, scheduler.WithRandomIO(o.IOProb, o.IOMean, o.Seed))
+	}
+	return opts, nil
+}
+
+// SchedulerRun schedules the processes in csv (the same format as the CLI's
+// input file) and returns the reports as a JSON array. policy names one
+// policy as the run subcommand does (fcfs, sjf, priority,
+// preemptive-priority, srtf, hrrn, rr or priority-rr); empty runs every
+// algorithm. quantum is the round-robin time quantum, the default if it is
+// 0. options is a JSON object of engine options, such as
+// {"mpl": 2, "switch_cost": 1}, and needs a policy. On failure it returns
+// a JSON object with an "error" field instead. The result must be released
+// with SchedulerFree.
+//
+//export SchedulerRun
+func SchedulerRun(csv, policy *C.char, quantum C.longlong, options *C.char) *C.char {
+	w, err := scheduler.ReadWorkload(strings.NewReader(C.GoString(csv)))
+	if err != nil {
+		return cError(err)
+	}
+	q := int64(quantum)
+	if q == 0 {
+		q = scheduler.DefaultQuantum
+	}
+	if q < 0 {
+		return cError(fmt.Errorf("%w: the quantum must be positive", scheduler.ErrInvalidArgs))
+	}
+	opts, err := parseOptions(C.GoString(options))
+	if err != nil {
+		return cError(err)
+	}
+
+	var reports []scheduler.Report
+	if name := C.GoString(policy); name == "" {
+		if len(opts) > 0 {
+			return cError(fmt.Errorf("%w: options need a policy", scheduler.ErrInvalidArgs))
+		}
+		if err := scheduler.CheckProcesses(w.Processes); err != nil {
+			return cError(err)
+		}
+		reports = scheduler.RunSchedulers(w.Processes, q)
+	} else {
+		p, err := scheduler.PolicyByName(name, q)
+		if err != nil {
+			return cError(err)
+		}
+		res, err := scheduler.Simulate(context.Background(), w, p, opts...)
+		if err != nil {
+			return cError(err)
+		}
+		reports = []scheduler.Report{res.Report}
+	}
+
+	b, err := json.Marshal(reports)
+	if err != nil {
+		return cError(err)
+	}
+
+	return C.CString(string(b))
+}
+
+// SchedulerFree releases a string returned by SchedulerRun.
+//
+//export SchedulerFree
+func SchedulerFree(s *C.char) {
+	C.free(unsafe.Pointer(s))
+}
+
+func cError(err error) *C.char {
+	b, _ := json.Marshal(map[string]string{"error": err.Error()})
+	return C.CString(string(b))
+}
@@ -0,0 +1,7 @@
+module github.com/MelvinTowo/Process-scheduler-in-GO/Project1
+
+go 1.21
+
+require github.com/olekukonko/tablewriter v0.0.5
+
+require github.com/mattn/go-runewidth v0.0.9 // indirect
@@ -0,0 +1,64 @@
+"""ctypes wrapper around the scheduler's C shared library.
+
+Build the library from the Project1 directory first:
+
+    go build -tags cshared -buildmode=c-shared -o libscheduler.so .
+
+then, from a notebook:
+
+    import scheduler
+    reports = scheduler.run([(1, 5, 0, 2), (2, 9, 3, 1), (3, 6, 6, 3)])
+    {r["title"]: r["average_wait"] for r in reports}
+    scheduler.run(processes, policy="rr", quantum=4, switch_cost=1)
+"""
+
+import ctypes
+import json
+import os
+
+_LIB_PATH = os.environ.get(
+    "SCHEDULER_LIB",
+    os.path.join(os.path.dirname(os.path.abspath(__file__)), "..", "libscheduler.so"),
+)
+
+_lib = ctypes.CDLL(_LIB_PATH)
+_lib.SchedulerRun.argtypes = [ctypes.c_char_p, ctypes.c_char_p, ctypes.c_longlong, ctypes.c_char_p]
+_lib.SchedulerRun.restype = ctypes.c_void_p
+_lib.SchedulerFree.argtypes = [ctypes.c_void_p]
+_lib.SchedulerFree.restype = None
+
+
+class SchedulerError(Exception):
+    pass
+
+
+def run(processes, policy="", quantum=0, **options):
+    """Schedule processes and return the reports.
+
+    processes is either CSV text in the CLI's input format or an iterable of
+    (id, burst, arrival[, priority]) tuples. policy names one algorithm
+    (fcfs, sjf, priority, preemptive-priority, srtf, hrrn, rr or
+    priority-rr); by default every algorithm runs. quantum is the
+    round-robin time quantum, 0 for the default. The keyword options are
+    engine options for a named policy: mpl, switch_cost, dispatch_latency,
+    io_prob, io_mean and seed. Running every algorithm needs every burst
+    to be positive. Each report is a dict with the title, gantt
+    slices, per-process rows and averages.
+    """
+    if not isinstance(processes, str):
+        processes = "\n".join(",".join(str(v) for v in p) for p in processes)
+
+    ptr = _lib.SchedulerRun(
+        processes.encode(),
+        policy.encode(),
+        quantum,
+        json.dumps(options).encode() if options else b"",
+    )
+    try:
+        result = json.loads(ctypes.string_at(ptr).decode())
+    finally:
+        _lib.SchedulerFree(ptr)
+
+    if isinstance(result, dict) and "error" in result:
+        raise SchedulerError(result["error"])
+    return result
@@ -0,0 +1,140 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+//region Quantum advisor
+
+// adviseMaxTries bounds how many quanta the advisor simulates in its coarse
+// grid, so wide ranges over long bursts stay quick.
+const adviseMaxTries = 64
+
+// maximizedMetrics are the report metrics where higher is better; the
+// advisor minimizes every other one.
+var maximizedMetrics = map[string]bool{"throughput": true, "utilization": true}
+
+// QuantumAdvice is the round-robin quantum that did best on a metric among
+// those tried near the current one.
+type QuantumAdvice struct {
+	Metric       string
+	Current      int64
+	CurrentValue float64
+	Best         int64
+	BestValue    float64
+	Lo, Hi       int64
+	Tries        int
+}
+
+// Improvement is how much better the best quantum does than the current
+// one, as a percentage of the current value.
+func (a QuantumAdvice) Improvement() float64 {
+	if a.CurrentValue == 0 {
+		return 0
+	}
+	d := a.CurrentValue - a.BestValue
+	if maximizedMetrics[a.Metric] {
+		d = -d
+	}
+	if a.CurrentValue < 0 {
+		d = -d
+	}
+	return 100 * d / a.CurrentValue
+}
+
+// adviseQuantum searches the quanta from a quarter to four times current,
+// but no further than the longest burst, beyond which round-robin is
+// first-come, first-served, for the one doing best on metric. It tries a
+// grid of at most adviseMaxTries quanta, then every quantum around the
+// best of them. Ties go to the quantum closest to current, so the advice
+// only moves when it helps.
+func adviseQuantum(processes []Process, current int64, metric string) (QuantumAdvice, error) {
+	measure, ok := reportMetrics[metric]
+	if !ok {
+		names := make([]string, 0, len(reportMetrics))
+		for name := range reportMetrics {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return QuantumAdvice{}, fmt.Errorf("%w: unknown metric %q (have %s)", ErrInvalidArgs, metric, strings.Join(names, ", "))
+	}
+	if current <= 0 {
+		return QuantumAdvice{}, fmt.Errorf("%w: quantum must be positive, got %d", ErrInvalidArgs, current)
+	}
+
+	var longest int64
+	for _, p := range processes {
+		if p.BurstDuration > longest {
+			longest = p.BurstDuration
+		}
+	}
+	a := QuantumAdvice{Metric: metric, Current: current, Lo: current / 4, Hi: current * 4}
+	if a.Lo < 1 {
+		a.Lo = 1
+	}
+	if a.Hi > longest {
+		a.Hi = longest
+	}
+	if a.Hi < current {
+		a.Hi = current
+	}
+
+	tried := make(map[int64]float64)
+	try := func(q int64) {
+		if _, ok := tried[q]; ok || q < a.Lo || q > a.Hi {
+			return
+		}
+		v := measure(simulate("", processes, RRPolicy{Quantum: q}))
+		tried[q] = v
+		a.Tries++
+		if q == current {
+			a.CurrentValue = v
+		}
+		if a.Tries == 1 || a.better(v, q) {
+			a.Best, a.BestValue = q, v
+		}
+	}
+
+	step := (a.Hi - a.Lo + adviseMaxTries - 1) / adviseMaxTries
+	if step < 1 {
+		step = 1
+	}
+	try(current)
+	for q := a.Lo; q <= a.Hi; q += step {
+		try(q)
+	}
+	try(a.Hi)
+	for q, best := a.Best-step+1, a.Best; q < best+step; q++ {
+		try(q)
+	}
+	return a, nil
+}
+
+// better reports whether v, measured at quantum q, beats the best so far.
+func (a QuantumAdvice) better(v float64, q int64) bool {
+	switch {
+	case v == a.BestValue:
+		return abs64(q-a.Current) < abs64(a.Best-a.Current)
+	case maximizedMetrics[a.Metric]:
+		return v > a.BestValue
+	default:
+		return v < a.BestValue
+	}
+}
+
+// outputQuantumAdvice prints the advice, with the improvement over the
+// current quantum.
+func outputQuantumAdvice(w io.Writer, a QuantumAdvice) {
+	_, _ = fmt.Fprintf(w, "Quantum advice (%s, %d quanta tried in %d-%d)\n", a.Metric, a.Tries, a.Lo, a.Hi)
+	if a.Best == a.Current {
+		_, _ = fmt.Fprintf(w, "The current quantum %d is best: %s %.2f\n", a.Current, a.Metric, a.CurrentValue)
+		return
+	}
+	_, _ = fmt.Fprintf(w, "Use quantum %d: %s %.2f against %.2f with quantum %d (%.1f%% better)\n",
+		a.Best, a.Metric, a.BestValue, a.CurrentValue, a.Current, a.Improvement())
+}
+
+//endregion
@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"errors"
+	"testing"
+)
+
+func Test_adviseQuantum(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 30}, {ProcessID: 2, BurstDuration: 30},
+		{ProcessID: 3, ArrivalTime: 5, BurstDuration: 2}, {ProcessID: 4, ArrivalTime: 12, BurstDuration: 2},
+		{ProcessID: 5, ArrivalTime: 20, BurstDuration: 3},
+	}
+	tests := []struct {
+		name    string
+		current int64
+		metric  string
+		want    QuantumAdvice
+	}{
+		{
+			name:    "wait",
+			current: 4,
+			metric:  "avg_wait",
+			want:    QuantumAdvice{Metric: "avg_wait", Current: 4, CurrentValue: 18.6, Best: 2, BestValue: 16.6, Lo: 1, Hi: 16, Tries: 16},
+		},
+		{
+			name:    "response",
+			current: 4,
+			metric:  "avg_response",
+			want:    QuantumAdvice{Metric: "avg_response", Current: 4, CurrentValue: 5, Best: 1, BestValue: 0.8, Lo: 1, Hi: 16, Tries: 16},
+		},
+		{
+			name:    "tie keeps current",
+			current: 4,
+			metric:  "makespan",
+			want:    QuantumAdvice{Metric: "makespan", Current: 4, CurrentValue: 67, Best: 4, BestValue: 67, Lo: 1, Hi: 16, Tries: 16},
+		},
+		{
+			name:    "capped at longest burst",
+			current: 20,
+			metric:  "avg_wait",
+			want:    QuantumAdvice{Metric: "avg_wait", Current: 20, CurrentValue: 30.6, Best: 6, BestValue: 18.2, Lo: 5, Hi: 30, Tries: 26},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := adviseQuantum(processes, tt.current, tt.metric)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("adviseQuantum() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	for _, bad := range []struct {
+		quantum int64
+		metric  string
+	}{{4, "nope"}, {0, "avg_wait"}} {
+		if _, err := adviseQuantum(processes, bad.quantum, bad.metric); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("adviseQuantum(%d, %q) error = %v, want ErrInvalidArgs", bad.quantum, bad.metric, err)
+		}
+	}
+}
+
+func TestQuantumAdvice_Improvement(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		a    QuantumAdvice
+		want float64
+	}{
+		{name: "minimized", a: QuantumAdvice{Metric: "avg_wait", CurrentValue: 20, BestValue: 15}, want: 25},
+		{name: "maximized", a: QuantumAdvice{Metric: "throughput", CurrentValue: 0.5, BestValue: 0.6}, want: 20},
+		{name: "zero", a: QuantumAdvice{Metric: "avg_wait"}, want: 0},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.a.Improvement(); got < tt.want-1e-9 || got > tt.want+1e-9 {
+				t.Errorf("Improvement() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
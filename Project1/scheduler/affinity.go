@@ -0,0 +1,46 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//region CPU affinity
+
+// parseAffinity parses the affinity column: a hexadecimal CPU mask like
+// taskset's, with or without 0x, where bit n allows CPU n. Empty means
+// any CPU.
+func parseAffinity(s string) (uint64, error) {
+	if s = strings.TrimSpace(s); s == "" {
+		return 0, nil
+	}
+	hex := strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	v, err := strconv.ParseUint(hex, 16, 64)
+	if err != nil || v == 0 {
+		return 0, fmt.Errorf("%w: affinity must be a hexadecimal mask of at least one CPU, got %q", ErrInvalidArgs, s)
+	}
+	return v, nil
+}
+
+// allowed reports whether the process may run on cpu.
+func (p Process) allowed(cpu int) bool {
+	return p.Affinity == 0 || cpu < 64 && p.Affinity&(1<<uint(cpu)) != 0
+}
+
+// affinityCPUs lists the CPUs the process may run on, out of cpus, or
+// "any" if it may run on them all.
+func (p Process) affinityCPUs(cpus int) string {
+	var list []string
+	for cpu := 0; cpu < cpus; cpu++ {
+		if p.allowed(cpu) {
+			list = append(list, fmt.Sprint(cpu))
+		}
+	}
+	if len(list) == cpus {
+		return "any"
+	}
+	return strings.Join(list, ",")
+}
+
+//endregion
@@ -0,0 +1,102 @@
+package scheduler
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_simulateCoresAffinity(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		processes    []Process
+		opts         []CoreOption
+		wantGantt    []TimeSlice
+		wantAffinity []string
+		wantNote     string
+	}{
+		{
+			name: "a pinned process waits while another CPU idles",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 2, Affinity: 0x1}, {ProcessID: 2, BurstDuration: 2, Affinity: 0x1},
+				{ProcessID: 3, BurstDuration: 1},
+			},
+			wantGantt:    []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 3, Start: 0, Stop: 1, CPU: 1}, {PID: 2, Start: 2, Stop: 4}},
+			wantAffinity: []string{"0", "0", "any"},
+			wantNote:     "Ticks delayed only by affinity, waiting while the idle CPUs were ones it may not use: 2 for 1",
+		},
+		{
+			name:         "placement keeps to the allowed CPUs",
+			processes:    []Process{{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, BurstDuration: 2, Affinity: 0x1}},
+			opts:         []CoreOption{WithPartitioning()},
+			wantGantt:    []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}},
+			wantAffinity: []string{"any", "0"},
+			wantNote:     "Placed first-fit by utilization: CPU 0 2.00 (<1, 2>), CPU 1 0.00 (<>); load imbalance 100.0%",
+		},
+		{
+			name:         "an idle CPU doesn't steal what it may not run",
+			processes:    []Process{{ProcessID: 1, BurstDuration: 2, Affinity: 0x1}, {ProcessID: 2, BurstDuration: 2, Affinity: 0x1}},
+			opts:         []CoreOption{WithStealing(1)},
+			wantGantt:    []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}},
+			wantAffinity: []string{"0", "0"},
+			wantNote:     "Steals from queues of at least 1: none",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := simulateCores("", tt.processes, FCFSPolicy{}, 2, tt.opts...)
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("simulateCores() Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			if got := r.Columns[len(r.Columns)-1]; got.Header != "Affinity" || !reflect.DeepEqual(got.Values, tt.wantAffinity) {
+				t.Errorf("simulateCores() last column = %v, want affinity %q", got, tt.wantAffinity)
+			}
+			if !strings.Contains(strings.Join(r.Notes, "\n"), tt.wantNote) {
+				t.Errorf("simulateCores() notes = %q, want %q among them", r.Notes, tt.wantNote)
+			}
+		})
+	}
+}
+
+func Test_multicoreReportsAffinity(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, BurstDuration: 1, Affinity: 0x4}}
+	if _, err := multicoreReports(processes, 2, 2, "global", 1); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("multicoreReports(affinity CPU 2 of 2) error = %v, want ErrInvalidArgs", err)
+	}
+}
+
+func Test_loadProcessesAffinity(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader("1,5,0,0,,,,,,0x3\n2,5,0,0,,,,,,c\n3,5,0,0,,,,,,\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []uint64
+	for _, p := range processes {
+		got = append(got, p.Affinity)
+	}
+	if want := []uint64{0x3, 0xc, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("loadProcesses() affinities = %v, want %v", got, want)
+	}
+
+	var b bytes.Buffer
+	if err := outputProcessesCSV(&b, processes); err != nil {
+		t.Fatal(err)
+	}
+	want := "1,5,0,0,100,batch,0,,0,0x3\n2,5,0,0,100,batch,0,,0,0xc\n3,5,0,0,100,batch,0,,0,\n"
+	if b.String() != want {
+		t.Errorf("outputProcessesCSV() = %q, want %q", b.String(), want)
+	}
+
+	for _, bad := range []string{"0", "0xg"} {
+		if _, err := loadProcesses(strings.NewReader("1,5,0,0,,,,,," + bad + "\n")); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("loadProcesses(affinity %q) error = %v, want ErrInvalidArgs", bad, err)
+		}
+	}
+}
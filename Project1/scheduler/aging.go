@@ -0,0 +1,126 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+//region Priority with aging
+
+type (
+	// AgingPolicy is non-preemptive priority scheduling with aging: a
+	// ready task's effective priority improves (drops) by Increment for
+	// every tick it has waited since it last joined the ready queue, so a
+	// low-priority task eventually outranks any stream of newer important
+	// ones. Ties go to the earliest in the ready queue.
+	AgingPolicy struct {
+		Increment float64
+		// Starve is how long a task must wait without aging to count as
+		// starved; 0 means twice the average wait without aging.
+		Starve int64
+		// steps are each task's effective priorities over time.
+		steps map[*Task][]agingStep
+	}
+
+	// agingStep is the whole effective priority a task had reached at Time.
+	agingStep struct {
+		Time     int64
+		Priority int64
+	}
+)
+
+// effective is t's priority at now after aging.
+func (p *AgingPolicy) effective(now int64, t *Task) float64 {
+	return float64(t.Priority) - p.Increment*float64(now-t.Queued)
+}
+
+func (p *AgingPolicy) Pick(now int64, running *Task, ready []*Task) *Task {
+	if p.steps == nil {
+		p.steps = make(map[*Task][]agingStep)
+	}
+	for _, t := range ready {
+		level := int64(math.Ceil(p.effective(now, t) - 1e-9))
+		if steps := p.steps[t]; len(steps) == 0 || steps[len(steps)-1].Priority != level {
+			p.steps[t] = append(steps, agingStep{Time: now, Priority: level})
+		}
+	}
+	if running != nil {
+		return running
+	}
+	return minTask(ready, func(a, b *Task) bool { return p.effective(now, a) < p.effective(now, b) })
+}
+
+// annotate adds each task's effective priority when it was dispatched and
+// its wait without aging, flagging those that would have starved, and
+// notes how each aged task's effective priority fell over time.
+func (p *AgingPolicy) annotate(r *Report, tasks []*Task) {
+	processes := make([]Process, len(tasks))
+	for i, t := range tasks {
+		processes[i] = t.Process
+	}
+	plain := simulate("", processes, PriorityPolicy{})
+	waits := make(map[int64]int64, len(plain.Rows))
+	var total int64
+	for _, row := range plain.Rows {
+		waits[row.ProcessID] = row.Wait
+		total += row.Wait
+	}
+	threshold := p.Starve
+	if threshold <= 0 && len(plain.Rows) > 0 {
+		threshold = 2 * total / int64(len(plain.Rows))
+	}
+
+	aged, without := Column{Header: "Aged priority"}, Column{Header: "Wait without aging"}
+	var timeline, starved []string
+	for _, t := range tasks {
+		steps := p.steps[t]
+		level := t.Priority
+		if len(steps) > 0 {
+			level = steps[len(steps)-1].Priority
+		}
+		if level != t.Priority {
+			aged.Values = append(aged.Values, fmt.Sprintf("%d→%d", t.Priority, level))
+			var at []string
+			for _, s := range steps {
+				at = append(at, fmt.Sprintf("%d at t=%d", s.Priority, s.Time))
+			}
+			timeline = append(timeline, fmt.Sprintf("%d: %s, ran at t=%d", t.ProcessID, strings.Join(at, ", "), t.FirstRun))
+		} else {
+			aged.Values = append(aged.Values, fmt.Sprint(t.Priority))
+		}
+
+		wait := waits[t.ProcessID]
+		if wait > threshold && wait > t.Exit-t.ArrivalTime-t.BurstDuration {
+			without.Values = append(without.Values, fmt.Sprintf("%d starved", wait))
+			starved = append(starved, fmt.Sprint(t.ProcessID))
+		} else {
+			without.Values = append(without.Values, fmt.Sprint(wait))
+		}
+	}
+	r.Columns = append(r.Columns, aged, without)
+	if len(timeline) > 0 {
+		r.Notes = append(r.Notes, "Effective priority over time: "+strings.Join(timeline, "; "))
+	}
+	if len(starved) > 0 {
+		r.Notes = append(r.Notes, fmt.Sprintf("Would have starved without aging (waiting over %d): %s", threshold, strings.Join(starved, ", ")))
+	} else {
+		r.Notes = append(r.Notes, fmt.Sprintf("None would have starved without aging (waiting over %d)", threshold))
+	}
+}
+
+// AgingSchedule outputs the schedule of processes under priority with
+// aging as a Gantt chart and a table of timing.
+func AgingSchedule(w io.Writer, title string, processes []Process, increment float64, starve int64) {
+	outputReport(w, Aging(title, processes, increment, starve))
+}
+
+// Aging schedules processes by priority, improving a waiting process's
+// priority by increment per tick, and compares the waits without aging,
+// flagging those over starve as starved.
+func Aging(title string, processes []Process, increment float64, starve int64) Report {
+	return simulate(title, processes, &AgingPolicy{Increment: increment, Starve: starve})
+}
+
+//endregion
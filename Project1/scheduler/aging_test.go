@@ -0,0 +1,86 @@
+package scheduler
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAging(t *testing.T) {
+	t.Parallel()
+	stream := []Process{
+		{ProcessID: 1, BurstDuration: 3, Priority: 1}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 4, Priority: 5}, {ProcessID: 4, ArrivalTime: 3, BurstDuration: 3, Priority: 1},
+		{ProcessID: 5, ArrivalTime: 6, BurstDuration: 3, Priority: 1}, {ProcessID: 6, ArrivalTime: 9, BurstDuration: 3, Priority: 1},
+		{ProcessID: 7, ArrivalTime: 12, BurstDuration: 3},
+	}
+	tests := []struct {
+		name        string
+		processes   []Process
+		increment   float64
+		starve      int64
+		wantGantt   []TimeSlice
+		wantAged    []string
+		wantWithout []string
+		wantNotes   []string
+	}{
+		{
+			name:        "aging lifts a low priority process over a stream",
+			processes:   stream,
+			increment:   0.5,
+			wantGantt:   []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 6}, {PID: 4, Start: 6, Stop: 9}, {PID: 5, Start: 9, Stop: 12}, {PID: 3, Start: 12, Stop: 16}, {PID: 6, Start: 16, Stop: 19}, {PID: 7, Start: 19, Stop: 22}},
+			wantAged:    []string{"1", "1→0", "5→0", "1→0", "1→0", "1→-2", "0→-3"},
+			wantWithout: []string{"0", "2", "17 starved", "3", "3", "6", "0"},
+			wantNotes: []string{
+				"Effective priority over time: 2: 1 at t=1, 0 at t=3, ran at t=3; 3: 5 at t=1, 4 at t=3, 3 at t=5, 2 at t=7, 1 at t=9, 0 at t=11, ran at t=12; " +
+					"4: 1 at t=3, 0 at t=5, ran at t=6; 5: 1 at t=6, 0 at t=8, ran at t=9; 6: 1 at t=9, 0 at t=11, -1 at t=13, -2 at t=15, ran at t=16; " +
+					"7: 0 at t=12, -1 at t=14, -2 at t=16, -3 at t=18, ran at t=19",
+				"Would have starved without aging (waiting over 8): 3",
+			},
+		},
+		{
+			name:        "a slow rate leaves plain priority order",
+			processes:   stream,
+			increment:   0.01,
+			starve:      20,
+			wantGantt:   []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 6}, {PID: 4, Start: 6, Stop: 9}, {PID: 5, Start: 9, Stop: 12}, {PID: 7, Start: 12, Stop: 15}, {PID: 6, Start: 15, Stop: 18}, {PID: 3, Start: 18, Stop: 22}},
+			wantAged:    []string{"1", "1", "5", "1", "1", "1", "0"},
+			wantWithout: []string{"0", "2", "17", "3", "3", "6", "0"},
+			wantNotes:   []string{"None would have starved without aging (waiting over 20)"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := Aging("Priority with aging", tt.processes, tt.increment, tt.starve)
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("Aging() Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			if len(r.Columns) != 2 {
+				t.Fatalf("Aging() columns = %v, want aged priority and wait without aging", r.Columns)
+			}
+			if !reflect.DeepEqual(r.Columns[0].Values, tt.wantAged) {
+				t.Errorf("Aging() aged priorities = %q, want %q", r.Columns[0].Values, tt.wantAged)
+			}
+			if !reflect.DeepEqual(r.Columns[1].Values, tt.wantWithout) {
+				t.Errorf("Aging() waits without aging = %q, want %q", r.Columns[1].Values, tt.wantWithout)
+			}
+			if !reflect.DeepEqual(r.Notes, tt.wantNotes) {
+				t.Errorf("Aging() notes = %q, want %q", r.Notes, tt.wantNotes)
+			}
+		})
+	}
+}
+
+func TestAgingSchedule(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	AgingSchedule(&w, "Priority with aging 1/tick", []Process{{ProcessID: 1, BurstDuration: 2, Priority: 3}, {ProcessID: 2, BurstDuration: 1, Priority: 1}}, 1, 0)
+	for _, want := range []string{"Priority with aging 1/tick", "AGED PRIORITY", "Effective priority over time: 1: 3 at t=0, 2 at t=1, ran at t=1"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("AgingSchedule() = %q, want it to contain %q", w.String(), want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+//region Go API
+
+type (
+	// Workload is the input of Simulate: the processes to schedule and any
+	// "#key=value" settings read with them.
+	Workload struct {
+		Processes []Process
+		Metadata  map[string]string
+	}
+
+	// Result is the outcome of Simulate: the report the CLI prints, and the
+	// final state of every task in arrival order.
+	Result struct {
+		Report
+		Tasks []*Task
+	}
+)
+
+// NewWorkload returns a workload of processes.
+func NewWorkload(processes ...Process) Workload {
+	return Workload{Processes: processes, Metadata: make(map[string]string)}
+}
+
+// ReadWorkload reads a workload in any format the CLI accepts: CSV or a
+// JSON array, with optional "#key=value" header lines.
+func ReadWorkload(r io.Reader) (Workload, error) {
+	processes, metadata, err := loadWorkload(r, 0)
+	if err != nil {
+		return Workload{}, err
+	}
+	return Workload{Processes: processes, Metadata: metadata}, nil
+}
+
+// Simulate schedules the workload with policy and returns the result. It is
+// the single entry point for embedding the simulator:
+//
+//	w, err := scheduler.ReadWorkload(strings.NewReader("1,5,0,1\n2,3,1,2\n"))
+//	if err != nil {
+//		return err
+//	}
+//	res, err := scheduler.Simulate(ctx, w, scheduler.RRPolicy{Quantum: 2})
+//
+// The run stops with ctx's error if ctx is done before every process
+// finishes, and with an observer's error if one aborts it.
+func Simulate(ctx context.Context, w Workload, policy Policy, opts ...Option) (Result, error) {
+	e, err := newEngine(ctx, w, policy, opts)
+	if err != nil {
+		return Result{}, err
+	}
+	tasks := e.run(w.Processes)
+	if err := ctx.Err(); err != nil {
+		return Result{}, err
+	}
+	if e.aborted != nil {
+		return Result{}, e.aborted
+	}
+	return Result{Report: e.report(policyTitle(policy), tasks), Tasks: tasks}, nil
+}
+
+// newEngine checks the arguments of Simulate and sets up the engine for them.
+func newEngine(ctx context.Context, w Workload, policy Policy, opts []Option) (*engine, error) {
+	if policy == nil {
+		return nil, fmt.Errorf("%w: no policy", ErrInvalidArgs)
+	}
+	for _, p := range w.Processes {
+		if p.BurstDuration < 0 || p.ArrivalTime < 0 {
+			return nil, fmt.Errorf("%w: process %d has a negative burst or arrival", ErrInvalidArgs, p.ProcessID)
+		}
+	}
+
+	e := &engine{policy: policy, ctx: ctx}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e, nil
+}
+
+//endregion
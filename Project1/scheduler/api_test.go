@@ -0,0 +1,83 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_Simulate(t *testing.T) {
+	t.Parallel()
+	w, err := ReadWorkload(strings.NewReader("#name=demo\n1,5,0,1\n2,3,1,2\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		workload  Workload
+		policy    Policy
+		wantTitle string
+		wantGantt []TimeSlice
+		wantErr   error
+	}{
+		{
+			name:      "reader",
+			ctx:       context.Background(),
+			workload:  w,
+			policy:    RRPolicy{Quantum: 2},
+			wantTitle: "Round-robin, quantum 2",
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 1, Start: 4, Stop: 6},
+				{PID: 2, Start: 6, Stop: 7}, {PID: 1, Start: 7, Stop: 8},
+			},
+		},
+		{
+			name:      "slice",
+			ctx:       context.Background(),
+			workload:  NewWorkload(Process{ProcessID: 1, BurstDuration: 2}, Process{ProcessID: 2, BurstDuration: 1}),
+			policy:    SJFPolicy{},
+			wantTitle: "Shortest-job-first",
+			wantGantt: []TimeSlice{{PID: 2, Start: 0, Stop: 1}, {PID: 1, Start: 1, Stop: 3}},
+		},
+		{
+			name:     "cancelled",
+			ctx:      cancelled,
+			workload: w,
+			policy:   FCFSPolicy{},
+			wantErr:  context.Canceled,
+		},
+		{
+			name:     "no policy",
+			ctx:      context.Background(),
+			workload: w,
+			wantErr:  ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			res, err := Simulate(tt.ctx, tt.workload, tt.policy)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Simulate() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if res.Title != tt.wantTitle {
+				t.Errorf("Title = %q, want %q", res.Title, tt.wantTitle)
+			}
+			if !reflect.DeepEqual(res.Gantt, tt.wantGantt) {
+				t.Errorf("Gantt = %v, want %v", res.Gantt, tt.wantGantt)
+			}
+			if len(res.Tasks) != len(tt.workload.Processes) {
+				t.Errorf("got %d tasks, want %d", len(res.Tasks), len(tt.workload.Processes))
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+//go:build linux
+
+package scheduler
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+//region Applying policies to real processes
+
+// The Linux scheduling policies apply can set.
+const (
+	schedOther = 0
+	schedFIFO  = 1
+	schedRR    = 2
+	schedBatch = 3
+	schedIdle  = 5
+)
+
+var schedPolicyNames = map[int]string{
+	schedOther: "SCHED_OTHER",
+	schedFIFO:  "SCHED_FIFO",
+	schedRR:    "SCHED_RR",
+	schedBatch: "SCHED_BATCH",
+	schedIdle:  "SCHED_IDLE",
+}
+
+// schedSetting is how apply schedules one real process: a realtime policy
+// with a realtime priority (1 to 99, higher runs first), or a normal one
+// with a nice value (-20 to 19, lower runs first).
+type schedSetting struct {
+	Policy   int
+	Priority int
+	Nice     int
+}
+
+func (s schedSetting) String() string {
+	if s.Policy == schedFIFO || s.Policy == schedRR {
+		return fmt.Sprintf("%s priority %d", schedPolicyNames[s.Policy], s.Priority)
+	}
+	return fmt.Sprintf("%s nice %d", schedPolicyNames[s.Policy], s.Nice)
+}
+
+// runApply implements the apply subcommand:
+//
+//	apply [-dry-run] [-policy class|fifo|rr|batch|other|idle] [-pids 1=1234,2=5678] processes.csv
+//
+// It sets the Linux scheduling policy of real processes from the
+// workload: each process's class (fifo and rr are the realtime policies,
+// batch is SCHED_BATCH and interactive SCHED_OTHER) unless -policy names
+// one for all, and its priority column, lower numbers first as in the
+// simulation. Processes are matched to real PIDs by -pids, or by their ID
+// if it isn't given. Realtime policies need CAP_SYS_NICE, as does lowering
+// a nice value.
+func runApply(w io.Writer, args ...string) error {
+	fs := flag.NewFlagSet("apply", flag.ContinueOnError)
+	fs.SetOutput(w)
+	dryRun := fs.Bool("dry-run", false, "print what would be set without changing anything")
+	policy := fs.String("policy", "class", "policy for every process: fifo, rr, batch, other or idle; class uses each process's class")
+	pidMap := fs.String("pids", "", "real PID of each workload process, like \"1=1234,2=5678\"")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: usage: apply [-dry-run] [-policy class] [-pids 1=1234] processes.csv", ErrInvalidArgs)
+	}
+	pids, err := parsePIDMap(*pidMap)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("%v: error opening scheduling file", err)
+	}
+	defer func() { _ = f.Close() }()
+	processes, err := loadProcesses(f)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range processes {
+		s, err := schedSettingFor(p, *policy)
+		if err != nil {
+			return err
+		}
+		pid := int(p.ProcessID)
+		if mapped, ok := pids[p.ProcessID]; ok {
+			pid = mapped
+		} else if len(pids) > 0 {
+			continue
+		}
+		current, err := getScheduler(pid)
+		if err != nil {
+			return fmt.Errorf("PID %d (process %d): %w", pid, p.ProcessID, err)
+		}
+		if *dryRun {
+			_, _ = fmt.Fprintf(w, "PID %d (process %d): %s, would set %v\n", pid, p.ProcessID, current, s)
+			continue
+		}
+		if err := setScheduler(pid, s); err != nil {
+			return fmt.Errorf("PID %d (process %d): %w", pid, p.ProcessID, err)
+		}
+		_, _ = fmt.Fprintf(w, "PID %d (process %d): %s, set %v\n", pid, p.ProcessID, current, s)
+	}
+	return nil
+}
+
+// schedSettingFor maps a workload process to a Linux policy: the named one,
+// or the one its class stands for if policy is "class". Priorities 0 and
+// up map to realtime priorities 99 and down, and to nice values from 0 up.
+func schedSettingFor(p Process, policy string) (schedSetting, error) {
+	if policy == "class" {
+		switch {
+		case p.RealTime != "":
+			policy = p.RealTime
+		case p.Interactive, p.System:
+			policy = "other"
+		default:
+			policy = "batch"
+		}
+	}
+	s := schedSetting{
+		Priority: int(clamp(99-p.Priority, 1, 99)),
+		Nice:     int(clamp(p.Priority, -20, 19)),
+	}
+	switch strings.ToLower(policy) {
+	case "fifo":
+		s.Policy, s.Nice = schedFIFO, 0
+	case "rr":
+		s.Policy, s.Nice = schedRR, 0
+	case "batch":
+		s.Policy, s.Priority = schedBatch, 0
+	case "other":
+		s.Policy, s.Priority = schedOther, 0
+	case "idle":
+		s.Policy, s.Priority = schedIdle, 0
+	default:
+		return s, fmt.Errorf("%w: unknown policy %q", ErrInvalidArgs, policy)
+	}
+	return s, nil
+}
+
+func clamp(v, lo, hi int64) int64 {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+// getScheduler describes pid's current policy.
+func getScheduler(pid int) (string, error) {
+	policy, _, errno := syscall.RawSyscall(syscall.SYS_SCHED_GETSCHEDULER, uintptr(pid), 0, 0)
+	if errno != 0 {
+		return "", errno
+	}
+	// The reset-on-fork flag may be or'ed in.
+	name, ok := schedPolicyNames[int(policy)&^0x40000000]
+	if !ok {
+		name = fmt.Sprintf("policy %d", policy)
+	}
+	nice, err := syscall.Getpriority(syscall.PRIO_PROCESS, pid)
+	if err != nil {
+		return "", err
+	}
+	// The raw getpriority system call returns 20 - nice.
+	return fmt.Sprintf("was %s nice %d", name, 20-nice), nil
+}
+
+// setScheduler applies s to pid with sched_setscheduler, and setpriority
+// for the nice value of the normal policies. The syscall package has no
+// sched_setattr, which would do both at once.
+func setScheduler(pid int, s schedSetting) error {
+	param := struct{ priority int32 }{int32(s.Priority)}
+	_, _, errno := syscall.RawSyscall(syscall.SYS_SCHED_SETSCHEDULER,
+		uintptr(pid), uintptr(s.Policy), uintptr(unsafe.Pointer(&param)))
+	if errno != 0 {
+		return fmt.Errorf("sched_setscheduler: %w", errno)
+	}
+	if s.Policy == schedFIFO || s.Policy == schedRR {
+		return nil
+	}
+	if err := syscall.Setpriority(syscall.PRIO_PROCESS, pid, s.Nice); err != nil {
+		return fmt.Errorf("setpriority: %w", err)
+	}
+	return nil
+}
+
+// parsePIDMap parses a comma separated list of <process>=<real pid> pairs.
+func parsePIDMap(list string) (map[int64]int, error) {
+	pids := make(map[int64]int)
+	for _, f := range strings.Split(list, ",") {
+		if f = strings.TrimSpace(f); f == "" {
+			continue
+		}
+		kv := strings.SplitN(f, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("%w: want <process>=<pid>, got %q", ErrInvalidArgs, f)
+		}
+		id, err := strconv.ParseInt(strings.TrimSpace(kv[0]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+		}
+		pid, err := strconv.Atoi(strings.TrimSpace(kv[1]))
+		if err != nil || pid <= 0 {
+			return nil, fmt.Errorf("%w: bad PID %q", ErrInvalidArgs, kv[1])
+		}
+		pids[id] = pid
+	}
+	return pids, nil
+}
+
+//endregion
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package scheduler
+
+import (
+	"fmt"
+	"io"
+)
+
+// runApply needs sched_setscheduler, which only Linux has.
+func runApply(io.Writer, ...string) error {
+	return fmt.Errorf("%w: apply needs Linux", ErrInvalidArgs)
+}
@@ -0,0 +1,82 @@
+//go:build linux
+
+package scheduler
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_schedSettingFor(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		process Process
+		policy  string
+		want    string
+		wantErr bool
+	}{
+		{name: "fifo class", process: Process{Priority: 2, RealTime: rtFIFO}, policy: "class", want: "SCHED_FIFO priority 97"},
+		{name: "rr class", process: Process{Priority: 200, RealTime: rtRR}, policy: "class", want: "SCHED_RR priority 1"},
+		{name: "batch class", process: Process{Priority: 5}, policy: "class", want: "SCHED_BATCH nice 5"},
+		{name: "interactive class", process: Process{Priority: 40, Interactive: true}, policy: "class", want: "SCHED_OTHER nice 19"},
+		{name: "forced", process: Process{Priority: 3, RealTime: rtFIFO}, policy: "idle", want: "SCHED_IDLE nice 3"},
+		{name: "unknown", process: Process{}, policy: "deadline", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := schedSettingFor(tt.process, tt.policy)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("schedSettingFor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("schedSettingFor() = %v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_runApply(t *testing.T) {
+	t.Parallel()
+	sleeper := exec.Command("sleep", "30")
+	if err := sleeper.Start(); err != nil {
+		t.Skip(err)
+	}
+	defer func() {
+		_ = sleeper.Process.Kill()
+		_ = sleeper.Wait()
+	}()
+	path := filepath.Join(t.TempDir(), "w.csv")
+	if err := os.WriteFile(path, []byte("1,5,0,7,100,batch\n2,5,0,1,100,batch\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	pids := fmt.Sprintf("1=%d", sleeper.Process.Pid)
+
+	var dry bytes.Buffer
+	if err := runApply(&dry, "-dry-run", "-pids", pids, path); err != nil {
+		t.Fatal(err)
+	}
+	want := fmt.Sprintf("PID %d (process 1): was SCHED_OTHER nice 0, would set SCHED_BATCH nice 7\n", sleeper.Process.Pid)
+	if dry.String() != want {
+		t.Errorf("dry run = %q, want %q", dry.String(), want)
+	}
+
+	var out bytes.Buffer
+	if err := runApply(&out, "-pids", pids, path); err != nil {
+		t.Fatal(err)
+	}
+	current, err := getScheduler(sleeper.Process.Pid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current != "was SCHED_BATCH nice 7" {
+		t.Errorf("after apply the sleeper %s, want SCHED_BATCH nice 7", strings.TrimPrefix(current, "was "))
+	}
+}
@@ -0,0 +1,133 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//region Metric assertions
+
+// assertion is a comparison of one summary metric against a number, such as
+// "avg_wait<=20". Prefixed with an algorithm, as in "round-robin.avg_wait<=20",
+// it applies only to the reports whose slugified titles start with it;
+// otherwise it applies to every report.
+type assertion struct {
+	Text      string
+	Algorithm string
+	Metric    string
+	Op        string
+	Value     float64
+}
+
+// assertOps are the comparison operators, two-character ones first so they match before their prefixes.
+var assertOps = []string{"<=", ">=", "==", "!=", "<", ">"}
+
+// reportMetrics are the metrics assertions can refer to.
+var reportMetrics = map[string]func(r Report) float64{
+	"avg_wait":           func(r Report) float64 { return r.Wait },
+	"avg_turnaround":     func(r Report) float64 { return r.Turnaround },
+	"throughput":         func(r Report) float64 { return r.Throughput },
+	"makespan":           func(r Report) float64 { return float64(r.makespan()) },
+	"avg_response_ratio": func(r Report) float64 { return r.averageResponseRatio() },
+	"avg_response":       averageResponse,
+	"processes":          func(r Report) float64 { return float64(len(r.Rows)) },
+	"max_wait": func(r Report) float64 {
+		var m int64
+		for _, row := range r.Rows {
+			if row.Wait > m {
+				m = row.Wait
+			}
+		}
+		return float64(m)
+	},
+	"switches":    func(r Report) float64 { return float64(contextSwitches(r.Gantt)) },
+	"utilization": utilization,
+	"deadline_misses": func(r Report) float64 {
+		n := 0
+		for _, row := range r.Rows {
+			if row.Deadline > 0 && row.Exit > row.Deadline {
+				n++
+			}
+		}
+		return float64(n)
+	},
+}
+
+// parseAssertions parses a comma separated list of assertions.
+func parseAssertions(spec string) ([]assertion, error) {
+	var assertions []assertion
+	for _, text := range strings.Split(spec, ",") {
+		if text = strings.TrimSpace(text); text == "" {
+			continue
+		}
+		a := assertion{Text: text}
+		for _, op := range assertOps {
+			if i := strings.Index(text, op); i > 0 {
+				a.Op = op
+				a.Metric = strings.TrimSpace(text[:i])
+				v, err := strconv.ParseFloat(strings.TrimSpace(text[i+len(op):]), 64)
+				if err != nil {
+					return nil, fmt.Errorf("%w: assertion %q: %v", ErrInvalidArgs, text, err)
+				}
+				a.Value = v
+				break
+			}
+		}
+		if a.Op == "" {
+			return nil, fmt.Errorf("%w: assertion %q has no comparison", ErrInvalidArgs, text)
+		}
+		if i := strings.LastIndex(a.Metric, "."); i >= 0 {
+			a.Algorithm, a.Metric = slugify(a.Metric[:i]), a.Metric[i+1:]
+		}
+		if _, ok := reportMetrics[a.Metric]; !ok {
+			names := make([]string, 0, len(reportMetrics))
+			for name := range reportMetrics {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return nil, fmt.Errorf("%w: assertion %q: unknown metric %q (have %s)",
+				ErrInvalidArgs, text, a.Metric, strings.Join(names, ", "))
+		}
+		assertions = append(assertions, a)
+	}
+	return assertions, nil
+}
+
+// checkAssertions returns a message for every report that fails an assertion.
+func checkAssertions(assertions []assertion, reports []Report) []string {
+	var failures []string
+	for _, a := range assertions {
+		for _, r := range reports {
+			if a.Algorithm != "" && !strings.HasPrefix(slugify(r.Title), a.Algorithm) {
+				continue
+			}
+			v := reportMetrics[a.Metric](r)
+			if !compare(v, a.Op, a.Value) {
+				failures = append(failures, fmt.Sprintf("%s: %s is %.4g, want %s %g", r.Title, a.Metric, v, a.Op, a.Value))
+			}
+		}
+	}
+	return failures
+}
+
+func compare(v float64, op string, want float64) bool {
+	switch op {
+	case "<=":
+		return v <= want
+	case ">=":
+		return v >= want
+	case "==":
+		return v == want
+	case "!=":
+		return v != want
+	case "<":
+		return v < want
+	case ">":
+		return v > want
+	}
+	return false
+}
+
+//endregion
@@ -0,0 +1,52 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_checkAssertions(t *testing.T) {
+	t.Parallel()
+	reports := []Report{
+		{Title: "Shortest-job-first", Rows: []Row{{Exit: 10, Deadline: 11}}, Wait: 4},
+		{Title: "Round-robin", Rows: []Row{{Exit: 12, Deadline: 11}}, Wait: 6},
+	}
+	tests := []struct {
+		name    string
+		spec    string
+		want    []string
+		wantErr bool
+	}{
+		{name: "all pass", spec: "avg_wait<=6, makespan>=10"},
+		{
+			name: "one algorithm fails",
+			spec: "avg_wait<5",
+			want: []string{"Round-robin: avg_wait is 6, want < 5"},
+		},
+		{
+			name: "scoped to an algorithm",
+			spec: "shortest-job-first.makespan==12",
+			want: []string{"Shortest-job-first: makespan is 10, want == 12"},
+		},
+		{
+			name: "deadline misses",
+			spec: "deadline_misses==0",
+			want: []string{"Round-robin: deadline_misses is 1, want == 0"},
+		},
+		{name: "unknown metric", spec: "lateness==0", wantErr: true},
+		{name: "no operator", spec: "avg_wait", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assertions, err := parseAssertions(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAssertions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := checkAssertions(assertions, reports); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("checkAssertions() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+//region Decision audit log
+
+type (
+	// AuditEntry explains one scheduling decision: who could run, what the
+	// policy compared them on, and why the chosen task won.
+	AuditEntry struct {
+		Policy     string           `json:"policy"`
+		Time       int64            `json:"time"`
+		Running    int64            `json:"running,omitempty"`
+		Candidates []AuditCandidate `json:"candidates"`
+		// Key is what the policy compares candidates on, if it says.
+		Key    string `json:"key,omitempty"`
+		Chosen int64  `json:"chosen"`
+		Reason string `json:"reason"`
+	}
+
+	// AuditCandidate is a runnable task's state at a decision.
+	AuditCandidate struct {
+		PID       int64    `json:"pid"`
+		Arrival   int64    `json:"arrival"`
+		Burst     int64    `json:"burst"`
+		Remaining int64    `json:"remaining"`
+		Priority  int64    `json:"priority"`
+		Queued    int64    `json:"queued"`
+		Slice     int64    `json:"slice"`
+		Key       *float64 `json:"key,omitempty"`
+	}
+
+	// auditKeyer is implemented by the policies that pick the ready task
+	// with the lowest key, ties going to the earliest in the ready queue.
+	auditKeyer interface {
+		auditKey() (name string, key func(t *Task) float64)
+	}
+
+	// preemptiveKeyer marks the auditKeyers that also compare the running
+	// task with the ready ones, keeping it on ties.
+	preemptiveKeyer interface {
+		auditKeyer
+		preemptive()
+	}
+)
+
+func (FCFSPolicy) auditKey() (string, func(*Task) float64) {
+	return "queued", func(t *Task) float64 { return float64(t.Queued) }
+}
+
+func (SJFPolicy) auditKey() (string, func(*Task) float64) {
+	return "burst", func(t *Task) float64 { return float64(t.BurstDuration) }
+}
+
+func (PriorityPolicy) auditKey() (string, func(*Task) float64) {
+	return "priority", func(t *Task) float64 { return float64(t.Priority) }
+}
+
+func (RRPolicy) auditKey() (string, func(*Task) float64) {
+	return "queued", func(t *Task) float64 { return float64(t.Queued) }
+}
+
+// WithAudit calls log with an explanation of every decision: every tick
+// with more than one task that could run.
+func WithAudit(log func(AuditEntry)) Option {
+	return func(e *engine) {
+		e.audit = log
+	}
+}
+
+// auditDecision explains pick, chosen from running and ready at now.
+func (e *engine) auditDecision(now int64, running *Task, ready []*Task, pick *Task) {
+	if e.audit == nil || len(ready) == 0 || running == nil && len(ready) == 1 {
+		return
+	}
+	entry := AuditEntry{Policy: policyTitle(e.policy), Time: now}
+	candidates := ready
+	if running != nil {
+		entry.Running = running.ProcessID
+		candidates = append([]*Task{running}, ready...)
+	}
+	var key func(*Task) float64
+	if k, ok := e.policy.(auditKeyer); ok {
+		entry.Key, key = k.auditKey()
+	}
+	for _, t := range candidates {
+		c := AuditCandidate{
+			PID: t.ProcessID, Arrival: t.ArrivalTime, Burst: t.BurstDuration, Remaining: t.Remaining,
+			Priority: t.Priority, Queued: t.Queued, Slice: t.Slice,
+		}
+		if key != nil {
+			v := key(t)
+			c.Key = &v
+		}
+		entry.Candidates = append(entry.Candidates, c)
+	}
+	if pick != nil {
+		entry.Chosen = pick.ProcessID
+	}
+	entry.Reason = auditReason(e.policy, entry.Key, key, running, ready, pick)
+	e.audit(entry)
+}
+
+// auditReason says in words why pick won.
+func auditReason(policy Policy, name string, key func(*Task) float64, running *Task, ready []*Task, pick *Task) string {
+	if pick == nil {
+		return "the policy left the CPU idle"
+	}
+	if rr, ok := policy.(RRPolicy); ok && running != nil {
+		if rr.Quantum <= 0 {
+			return "without a quantum the running task keeps the CPU"
+		}
+		if pick == running {
+			return fmt.Sprintf("the running task has run %d of its %d tick quantum", running.Slice%rr.Quantum, rr.Quantum)
+		}
+		return fmt.Sprintf("the running task used its %d tick quantum; the head of the ready queue runs", rr.Quantum)
+	}
+	if key == nil {
+		if pick == running {
+			return "the policy kept the running task"
+		}
+		return "the policy chose this task"
+	}
+
+	best, ties := key(pick), 0
+	for _, t := range ready {
+		if t != pick && key(t) == best {
+			ties++
+		}
+	}
+	if _, ok := policy.(preemptiveKeyer); ok && pick == running {
+		if ties > 0 {
+			return fmt.Sprintf("lowest %s, %g; tied with a ready task, the running task keeps the CPU", name, best)
+		}
+		return fmt.Sprintf("lowest %s, %g; the running task keeps the CPU", name, best)
+	}
+	if pick == running {
+		for _, t := range ready {
+			if key(t) < best {
+				return fmt.Sprintf("non-preemptive: the running task keeps the CPU although a ready task has a lower %s", name)
+			}
+		}
+		return "non-preemptive: the running task keeps the CPU"
+	}
+	reason := fmt.Sprintf("lowest %s, %g", name, best)
+	switch {
+	case ties == 1:
+		reason += "; tied with another task, the earlier in the ready queue wins"
+	case ties > 1:
+		reason += fmt.Sprintf("; tied with %d other tasks, the earliest in the ready queue wins", ties)
+	}
+	if running != nil {
+		reason += fmt.Sprintf("; preempts process %d", running.ProcessID)
+	}
+	return reason
+}
+
+// writeAuditLog runs the classic policies, SRTF and preemptive priority
+// over processes and writes the explanation of every decision to path as
+// JSON lines.
+func writeAuditLog(path string, processes []Process, quantum int64) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("%w: creating audit log", err)
+	}
+	if err := auditPolicies(f, processes, append(classicPolicies(quantum), SRTFPolicy{}, PreemptivePriorityPolicy{})); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("%w: closing audit log", err)
+	}
+	return nil
+}
+
+// auditPolicies runs each policy over processes, writing every decision to w.
+func auditPolicies(w io.Writer, processes []Process, policies []Policy) error {
+	enc := json.NewEncoder(w)
+	var err error
+	for _, p := range policies {
+		simulate("", processes, p, WithAudit(func(entry AuditEntry) {
+			if err == nil {
+				err = enc.Encode(entry)
+			}
+		}))
+	}
+	if err != nil {
+		return fmt.Errorf("%w: writing audit log", err)
+	}
+	return nil
+}
+
+//endregion
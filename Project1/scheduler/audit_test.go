@@ -0,0 +1,79 @@
+package scheduler
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_auditPolicies(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader("1,2,0,2\n2,3,0,1\n3,1,1,1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		policy      Policy
+		wantReasons []string
+	}{
+		{
+			policy: SJFPolicy{},
+			wantReasons: []string{
+				"0 -> 1: lowest burst, 2",
+				"1 -> 1: non-preemptive: the running task keeps the CPU although a ready task has a lower burst",
+				"2 -> 3: lowest burst, 1",
+			},
+		},
+		{
+			policy: PriorityPolicy{},
+			wantReasons: []string{
+				"0 -> 2: lowest priority, 1",
+				"1 -> 2: non-preemptive: the running task keeps the CPU",
+				"2 -> 2: non-preemptive: the running task keeps the CPU",
+				"3 -> 3: lowest priority, 1",
+			},
+		},
+		{
+			policy: RRPolicy{Quantum: 1},
+			wantReasons: []string{
+				"0 -> 1: lowest queued, 0; tied with another task, the earlier in the ready queue wins",
+				"1 -> 2: the running task used its 1 tick quantum; the head of the ready queue runs",
+				"2 -> 3: the running task used its 1 tick quantum; the head of the ready queue runs",
+				"3 -> 1: lowest queued, 1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(policyTitle(tt.policy), func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			if err := auditPolicies(&w, processes, []Policy{tt.policy}); err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			dec := json.NewDecoder(&w)
+			for dec.More() {
+				var entry AuditEntry
+				if err := dec.Decode(&entry); err != nil {
+					t.Fatal(err)
+				}
+				if entry.Policy != policyTitle(tt.policy) || len(entry.Candidates) < 2 {
+					t.Errorf("entry %+v", entry)
+				}
+				got = append(got, strings.Join([]string{
+					jsonNumber(entry.Time), "->", jsonNumber(entry.Chosen) + ":", entry.Reason}, " "))
+			}
+			if !reflect.DeepEqual(got, tt.wantReasons) {
+				t.Errorf("decisions =\n%q\nwant\n%q", got, tt.wantReasons)
+			}
+		})
+	}
+}
+
+func jsonNumber(n int64) string {
+	b, _ := json.Marshal(n)
+	return string(b)
+}
@@ -0,0 +1,366 @@
+package scheduler
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+//region Deadlock avoidance and detection
+
+// ResourceState is a snapshot of resource allocation between processes.
+type ResourceState struct {
+	Available []int64
+	IDs       []int64
+	// Allocation[i] is what process i holds. Demand[i] is its declared
+	// maximum for the Banker's algorithm, or its outstanding request for
+	// deadlock detection.
+	Allocation [][]int64
+	Demand     [][]int64
+	// Request is an optional pending request: process RequestID asks for
+	// Request on top of its allocation.
+	RequestID int64
+	Request   []int64
+}
+
+// runBanker implements the banker subcommand:
+//
+//	banker [-detect] state.csv
+//
+// state.csv starts with "available,<r1>,<r2>,..." followed by a line per
+// process of "<id>,<allocation...>,<max...>" (or "<id>,<allocation...>,<request...>"
+// with -detect). An optional "request,<id>,<r1>,..." line asks whether that
+// request can be granted. Without -detect it runs the Banker's safety
+// algorithm; with it, deadlock detection.
+func runBanker(w io.Writer, args ...string) error {
+	fs := flag.NewFlagSet("banker", flag.ContinueOnError)
+	fs.SetOutput(w)
+	detect := fs.Bool("detect", false, "treat the last columns as outstanding requests and detect deadlock")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: usage: banker [-detect] state.csv", ErrInvalidArgs)
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("%v: error opening resource state", err)
+	}
+	defer func() { _ = f.Close() }()
+	s, err := loadResourceState(f)
+	if err != nil {
+		return err
+	}
+
+	if *detect {
+		outputResourceState(w, s, "Request")
+		deadlocked, order := detectDeadlock(s)
+		if len(deadlocked) == 0 {
+			_, _ = fmt.Fprintf(w, "No deadlock: processes can finish in the order %s\n", formatIDs(order))
+		} else {
+			_, _ = fmt.Fprintf(w, "Deadlock: processes %s are deadlocked\n", formatIDs(deadlocked))
+		}
+		return nil
+	}
+
+	outputResourceState(w, s, "Max")
+	if order, safe := bankerSafe(s); safe {
+		_, _ = fmt.Fprintf(w, "Safe: safe sequence %s\n", formatIDs(order))
+	} else {
+		_, _ = fmt.Fprintln(w, "Unsafe: no safe sequence exists")
+	}
+	if s.Request != nil {
+		granted, order, reason := bankerRequest(s)
+		if granted {
+			_, _ = fmt.Fprintf(w, "Request from %d for %v can be granted: safe sequence %s\n", s.RequestID, s.Request, formatIDs(order))
+		} else {
+			_, _ = fmt.Fprintf(w, "Request from %d for %v must wait: %s\n", s.RequestID, s.Request, reason)
+		}
+	}
+	return nil
+}
+
+func loadResourceState(r io.Reader) (ResourceState, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return ResourceState{}, fmt.Errorf("%w: reading CSV", err)
+	}
+
+	var s ResourceState
+	ints := func(line int, fields []string) ([]int64, error) {
+		v := make([]int64, len(fields))
+		for i, f := range fields {
+			n, err := strconv.ParseInt(f, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d", err, line)
+			}
+			v[i] = n
+		}
+		return v, nil
+	}
+	for i, row := range rows {
+		line := i + 1
+		switch strings.ToLower(row[0]) {
+		case "available":
+			if s.Available, err = ints(line, row[1:]); err != nil {
+				return s, err
+			}
+		case "request":
+			v, err := ints(line, row[1:])
+			if err != nil {
+				return s, err
+			}
+			if len(v) != len(s.Available)+1 {
+				return s, fmt.Errorf("%w: line %d: want request,<id> and %d resources", ErrInvalidArgs, line, len(s.Available))
+			}
+			s.RequestID, s.Request = v[0], v[1:]
+		default:
+			if s.Available == nil {
+				return s, fmt.Errorf("%w: line %d: the available line must come first", ErrInvalidArgs, line)
+			}
+			v, err := ints(line, row)
+			if err != nil {
+				return s, err
+			}
+			m := len(s.Available)
+			if len(v) != 1+2*m {
+				return s, fmt.Errorf("%w: line %d: want an ID and %d allocation and %d demand values", ErrInvalidArgs, line, m, m)
+			}
+			s.IDs = append(s.IDs, v[0])
+			s.Allocation = append(s.Allocation, v[1:1+m])
+			s.Demand = append(s.Demand, v[1+m:])
+		}
+	}
+	if len(s.IDs) == 0 {
+		return s, fmt.Errorf("%w: no processes", ErrInvalidArgs)
+	}
+	return s, nil
+}
+
+// need returns what process i may still request: its maximum minus its allocation.
+func (s ResourceState) need(i int) []int64 {
+	n := make([]int64, len(s.Available))
+	for j := range n {
+		n[j] = s.Demand[i][j] - s.Allocation[i][j]
+	}
+	return n
+}
+
+// finishOrder repeatedly lets the lowest-indexed process whose outstanding
+// demand fits in the free resources finish and release its allocation. It
+// returns the IDs that finished, in order, and the indexes that could not.
+func finishOrder(s ResourceState, demand func(i int) []int64) (order []int64, stuck []int) {
+	work := append([]int64(nil), s.Available...)
+	finished := make([]bool, len(s.IDs))
+	for progress := true; progress; {
+		progress = false
+		for i := range s.IDs {
+			if finished[i] || !fits(demand(i), work) {
+				continue
+			}
+			for j := range work {
+				work[j] += s.Allocation[i][j]
+			}
+			finished[i] = true
+			order = append(order, s.IDs[i])
+			progress = true
+			break
+		}
+	}
+	for i := range finished {
+		if !finished[i] {
+			stuck = append(stuck, i)
+		}
+	}
+	return order, stuck
+}
+
+func fits(want, have []int64) bool {
+	for j := range want {
+		if want[j] > have[j] {
+			return false
+		}
+	}
+	return true
+}
+
+// bankerSafe runs the Banker's safety algorithm, returning a safe sequence if there is one.
+func bankerSafe(s ResourceState) ([]int64, bool) {
+	order, stuck := finishOrder(s, s.need)
+	return order, len(stuck) == 0
+}
+
+// bankerRequest decides whether s.Request can be granted without leaving the system unsafe.
+func bankerRequest(s ResourceState) (bool, []int64, string) {
+	p := -1
+	for i, id := range s.IDs {
+		if id == s.RequestID {
+			p = i
+		}
+	}
+	if p < 0 {
+		return false, nil, fmt.Sprintf("no process %d", s.RequestID)
+	}
+	if !fits(s.Request, s.need(p)) {
+		return false, nil, "it exceeds the process's declared maximum"
+	}
+	if !fits(s.Request, s.Available) {
+		return false, nil, "not enough resources are available"
+	}
+
+	// Pretend to grant it and check the resulting state is safe.
+	granted := s
+	granted.Available = make([]int64, len(s.Available))
+	granted.Allocation = append([][]int64(nil), s.Allocation...)
+	granted.Allocation[p] = make([]int64, len(s.Available))
+	for j := range s.Available {
+		granted.Available[j] = s.Available[j] - s.Request[j]
+		granted.Allocation[p][j] = s.Allocation[p][j] + s.Request[j]
+	}
+	order, safe := bankerSafe(granted)
+	if !safe {
+		return false, nil, "granting it would leave the system unsafe"
+	}
+	return true, order, ""
+}
+
+// detectDeadlock runs deadlock detection with Demand as each process's
+// outstanding request. A process holding nothing can never be part of a deadlock.
+func detectDeadlock(s ResourceState) (deadlocked, order []int64) {
+	order, stuck := finishOrder(s, func(i int) []int64 { return s.Demand[i] })
+	for _, i := range stuck {
+		deadlocked = append(deadlocked, s.IDs[i])
+	}
+	return deadlocked, order
+}
+
+func formatIDs(ids []int64) string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = fmt.Sprint(id)
+	}
+	return "<" + strings.Join(s, ", ") + ">"
+}
+
+// BankerSync is a Synchronizer that hands out resources with the Banker's
+// algorithm. Each process in State starts with its allocation and claims
+// up to its maximum evenly over its burst, so it holds all of it for its
+// last tick, then releases everything when it finishes. A tick that needs
+// more is only run if bankerRequest grants the extra: otherwise the process
+// blocks until another one finishes. Processes not in State never block.
+type BankerSync struct {
+	State ResourceState
+	// Deferred counts the requests that had to wait to keep the state safe.
+	Deferred int
+}
+
+// index returns pid's place in State, or -1.
+func (b *BankerSync) index(pid int64) int {
+	for i, id := range b.State.IDs {
+		if id == pid {
+			return i
+		}
+	}
+	return -1
+}
+
+func (b *BankerSync) Acquire(t *Task) bool {
+	i := b.index(t.ProcessID)
+	if i < 0 || t.BurstDuration <= 0 {
+		return true
+	}
+	done := t.BurstDuration - t.Remaining
+	request := make([]int64, len(b.State.Available))
+	needed := false
+	for j, max := range b.State.Demand[i] {
+		held := (max*(done+1) + t.BurstDuration - 1) / t.BurstDuration
+		if d := held - b.State.Allocation[i][j]; d > 0 {
+			request[j], needed = d, true
+		}
+	}
+	if !needed {
+		return true
+	}
+	s := b.State
+	s.RequestID, s.Request = t.ProcessID, request
+	if granted, _, _ := bankerRequest(s); !granted {
+		b.Deferred++
+		return false
+	}
+	for j, d := range request {
+		b.State.Available[j] -= d
+		b.State.Allocation[i][j] += d
+	}
+	return true
+}
+
+// Release frees a finished process's resources and wakes every blocked
+// process to try its request again.
+func (b *BankerSync) Release(t *Task, blocked []*Task) []*Task {
+	i := b.index(t.ProcessID)
+	if i < 0 || t.Remaining > 0 {
+		return nil
+	}
+	for j, held := range b.State.Allocation[i] {
+		b.State.Available[j] += held
+		b.State.Allocation[i][j] = 0
+	}
+	return append([]*Task(nil), blocked...)
+}
+
+// bankerReports runs each short-term policy with the processes claiming
+// resources from a copy of s through a BankerSync.
+func bankerReports(processes []Process, s ResourceState, quantum int64) []Report {
+	var reports []Report
+	for _, p := range []struct {
+		title  string
+		policy Policy
+	}{
+		{"First-come, first-serve", FCFSPolicy{}},
+		{"Shortest-job-first", SJFPolicy{}},
+		{"Priority", PriorityPolicy{}},
+		{"Round-robin", RRPolicy{Quantum: quantum}},
+	} {
+		state := s
+		state.Available = append([]int64(nil), s.Available...)
+		state.Allocation = make([][]int64, len(s.Allocation))
+		for i, a := range s.Allocation {
+			state.Allocation[i] = append([]int64(nil), a...)
+		}
+		b := &BankerSync{State: state}
+		r := simulate(fmt.Sprintf("%s (Banker's algorithm)", p.title), processes, p.policy, WithSync(b))
+		r.Notes = append(r.Notes, fmt.Sprintf("%d resource requests deferred to keep the state safe", b.Deferred))
+		reports = append(reports, r)
+	}
+	return reports
+}
+
+func outputResourceState(w io.Writer, s ResourceState, demand string) {
+	_, _ = fmt.Fprintf(w, "Available %v\n", s.Available)
+	table := tablewriter.NewWriter(w)
+	header := []string{"ID", "Allocation", demand}
+	if demand == "Max" {
+		header = append(header, "Need")
+	}
+	table.SetHeader(header)
+	for i, id := range s.IDs {
+		row := []string{fmt.Sprint(id), fmt.Sprint(s.Allocation[i]), fmt.Sprint(s.Demand[i])}
+		if demand == "Max" {
+			row = append(row, fmt.Sprint(s.need(i)))
+		}
+		table.Append(row)
+	}
+	table.Render()
+}
+
+//endregion
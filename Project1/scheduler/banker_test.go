@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_bankerSafe(t *testing.T) {
+	t.Parallel()
+	s, err := loadResourceState(strings.NewReader(`available,3,3,2
+0,0,1,0,7,5,3
+1,2,0,0,3,2,2
+2,3,0,2,9,0,2
+3,2,1,1,2,2,2
+4,0,0,2,4,3,3
+request,1,1,0,2`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	order, safe := bankerSafe(s)
+	if want := []int64{1, 3, 0, 2, 4}; !safe || !reflect.DeepEqual(order, want) {
+		t.Errorf("bankerSafe() = %v, %v, want %v, true", order, safe, want)
+	}
+	if granted, _, reason := bankerRequest(s); !granted {
+		t.Errorf("request from 1 refused: %s", reason)
+	}
+
+	s.RequestID, s.Request = 4, []int64{3, 3, 0}
+	if granted, _, _ := bankerRequest(s); granted {
+		t.Error("request from 4 for [3 3 0] granted, want it refused as unsafe")
+	}
+}
+
+func Test_detectDeadlock(t *testing.T) {
+	t.Parallel()
+	const state = `available,0,0,0
+0,0,1,0,0,0,0
+1,2,0,0,2,0,2
+2,3,0,3,0,0,%s
+3,2,1,1,1,0,0
+4,0,0,2,0,0,2`
+	tests := []struct {
+		name           string
+		p2Request      string
+		wantDeadlocked []int64
+	}{
+		{name: "no deadlock", p2Request: "0"},
+		{name: "process 2 requests one more", p2Request: "1", wantDeadlocked: []int64{1, 2, 3, 4}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s, err := loadResourceState(strings.NewReader(strings.Replace(state, "%s", tt.p2Request, 1)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, _ := detectDeadlock(s); !reflect.DeepEqual(got, tt.wantDeadlocked) {
+				t.Errorf("detectDeadlock() = %v, want %v", got, tt.wantDeadlocked)
+			}
+		})
+	}
+}
+
+func Test_bankerReports(t *testing.T) {
+	t.Parallel()
+	// Each process claims one of the two resources a tick, up to two. Both
+	// holding one would leave neither able to finish, so 2 is deferred.
+	s, err := loadResourceState(strings.NewReader("available,2\n1,0,2\n2,0,2\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	processes := []Process{{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, BurstDuration: 2}, {ProcessID: 3, BurstDuration: 1}}
+	reports := bankerReports(processes, s, 1)
+	if len(reports) != 4 {
+		t.Fatalf("got %d reports, want 4", len(reports))
+	}
+	rr := reports[3]
+	if want := []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 3, Start: 1, Stop: 2}, {PID: 1, Start: 2, Stop: 3}, {PID: 2, Start: 3, Stop: 5}}; !reflect.DeepEqual(rr.Gantt, want) {
+		t.Errorf("RR Gantt = %v, want %v", rr.Gantt, want)
+	}
+	if want := "1 resource requests deferred"; !strings.Contains(strings.Join(rr.Notes, "\n"), want) {
+		t.Errorf("notes = %q, want %q", rr.Notes, want)
+	}
+	if !reflect.DeepEqual(s.Available, []int64{2}) || !reflect.DeepEqual(s.Allocation, [][]int64{{0}, {0}}) {
+		t.Errorf("state changed to %v, %v", s.Available, s.Allocation)
+	}
+}
@@ -0,0 +1,93 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//region Bounded buffer
+
+// BoundedBuffer is a producer-consumer buffer guarded by counting
+// semaphores. Every tick a producer runs puts one item in the buffer and
+// every tick a consumer runs takes one out; a producer blocks while the
+// buffer is full and a consumer while it is empty. Other processes never block.
+type BoundedBuffer struct {
+	Capacity  int64
+	Producers map[int64]bool
+	Consumers map[int64]bool
+	// Items is how many items are in the buffer.
+	Items int64
+}
+
+func (b *BoundedBuffer) Acquire(t *Task) bool {
+	switch {
+	case b.Producers[t.ProcessID]:
+		return b.Items < b.Capacity
+	case b.Consumers[t.ProcessID]:
+		return b.Items > 0
+	}
+	return true
+}
+
+// Release wakes the blocked consumers after a produce and the blocked producers after a consume.
+func (b *BoundedBuffer) Release(t *Task, blocked []*Task) []*Task {
+	var waiting map[int64]bool
+	switch {
+	case b.Producers[t.ProcessID]:
+		b.Items++
+		waiting = b.Consumers
+	case b.Consumers[t.ProcessID]:
+		b.Items--
+		waiting = b.Producers
+	default:
+		return nil
+	}
+
+	var woken []*Task
+	for _, w := range blocked {
+		if waiting[w.ProcessID] {
+			woken = append(woken, w)
+		}
+	}
+	return woken
+}
+
+// bufferReports runs each short-term policy with the producers and consumers sharing a buffer of capacity slots.
+func bufferReports(processes []Process, capacity int64, producers, consumers map[int64]bool, quantum int64) []Report {
+	var reports []Report
+	for _, s := range []struct {
+		title  string
+		policy Policy
+	}{
+		{"First-come, first-serve", FCFSPolicy{}},
+		{"Shortest-job-first", SJFPolicy{}},
+		{"Priority", PriorityPolicy{}},
+		{"Round-robin", RRPolicy{Quantum: quantum}},
+	} {
+		b := &BoundedBuffer{Capacity: capacity, Producers: producers, Consumers: consumers}
+		r := simulate(fmt.Sprintf("%s (bounded buffer of %d)", s.title, capacity), processes, s.policy, WithSync(b))
+		r.Notes = append(r.Notes, fmt.Sprintf("%d items left in the buffer", b.Items))
+		reports = append(reports, r)
+	}
+
+	return reports
+}
+
+// parsePIDs parses a comma separated list of process IDs into a set.
+func parsePIDs(list string) (map[int64]bool, error) {
+	pids := make(map[int64]bool)
+	for _, f := range strings.Split(list, ",") {
+		if f = strings.TrimSpace(f); f == "" {
+			continue
+		}
+		pid, err := strconv.ParseInt(f, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+		}
+		pids[pid] = true
+	}
+	return pids, nil
+}
+
+//endregion
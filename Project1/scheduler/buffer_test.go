@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_BoundedBuffer(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 4},
+	}
+	tests := []struct {
+		name      string
+		capacity  int64
+		producers map[int64]bool
+		consumers map[int64]bool
+		wantGantt []TimeSlice
+		wantRows  int
+	}{
+		{
+			name:      "producer fills the buffer then blocks",
+			capacity:  2,
+			producers: map[int64]bool{1: true},
+			consumers: map[int64]bool{2: true},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4},
+				{PID: 1, Start: 4, Stop: 6}, {PID: 2, Start: 6, Stop: 8},
+			},
+			wantRows: 2,
+		},
+		{
+			name:      "consumer blocks on an empty buffer",
+			capacity:  4,
+			producers: map[int64]bool{2: true},
+			consumers: map[int64]bool{1: true},
+			wantGantt: []TimeSlice{{PID: 2, Start: 0, Stop: 4}, {PID: 1, Start: 4, Stop: 8}},
+			wantRows:  2,
+		},
+		{
+			name:      "two consumers deadlock",
+			capacity:  1,
+			consumers: map[int64]bool{1: true, 2: true},
+			wantRows:  0,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			b := &BoundedBuffer{Capacity: tt.capacity, Producers: tt.producers, Consumers: tt.consumers}
+			r := simulate(tt.name, processes, FCFSPolicy{}, WithSync(b))
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			if len(r.Rows) != tt.wantRows {
+				t.Errorf("%d rows, want %d", len(r.Rows), tt.wantRows)
+			}
+		})
+	}
+}
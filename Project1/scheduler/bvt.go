@@ -0,0 +1,167 @@
+package scheduler
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+//region Borrowed virtual time
+
+// BVTPolicy is Borrowed Virtual Time scheduling (Duda and Cheriton). Each
+// task's actual virtual time advances by defaultWeight/weight per tick it
+// runs, and the task with the lowest effective virtual time, its actual
+// virtual time minus its warp, runs. Warp lets a latency-sensitive task
+// borrow against its future share to be dispatched sooner. The running task
+// is only preempted once another's effective virtual time is Allowance
+// lower, which limits context switching. Tasks keep their warp for as long
+// as they run; there is no warp time limit.
+type BVTPolicy struct {
+	// Warp is the virtual time each process borrows, by process ID.
+	Warp      map[int64]float64
+	Allowance float64
+	// base is each task's virtual time when it joined.
+	base map[*Task]float64
+}
+
+// actual is t's actual virtual time.
+func (p *BVTPolicy) actual(t *Task) float64 {
+	ran := t.BurstDuration - t.Remaining
+	return p.base[t] + float64(ran*defaultWeight)/float64(t.weight())
+}
+
+func (p *BVTPolicy) effective(t *Task) float64 {
+	return p.actual(t) - p.Warp[t.ProcessID]
+}
+
+func (p *BVTPolicy) Pick(_ int64, running *Task, ready []*Task) *Task {
+	if p.base == nil {
+		p.base = make(map[*Task]float64)
+	}
+	runnable := ready
+	if running != nil {
+		runnable = append([]*Task{running}, ready...)
+	}
+
+	// A task joining starts at the scheduler virtual time, the lowest
+	// actual virtual time of the tasks already runnable, so it can't claim
+	// the CPU for the time it wasn't there.
+	svt, joined := math.Inf(1), false
+	for _, t := range runnable {
+		if _, ok := p.base[t]; ok {
+			joined = true
+			svt = math.Min(svt, p.actual(t))
+		}
+	}
+	if !joined {
+		svt = 0
+	}
+	for _, t := range runnable {
+		if _, ok := p.base[t]; !ok {
+			p.base[t] = svt
+		}
+	}
+
+	best := minTask(runnable, func(a, b *Task) bool { return p.effective(a) < p.effective(b) })
+	if running != nil && best != running && p.effective(best) > p.effective(running)-p.Allowance {
+		return running
+	}
+	return best
+}
+
+// annotate shows each task's warp and response time, and how fairly the CPU was shared.
+func (p *BVTPolicy) annotate(r *Report, tasks []*Task) {
+	warp := Column{Header: "Warp"}
+	for _, t := range tasks {
+		warp.Values = append(warp.Values, fmt.Sprint(p.Warp[t.ProcessID]))
+	}
+	r.Columns = append(r.Columns, warp, responseColumn(tasks))
+	r.Notes = append(r.Notes, fmt.Sprintf("Fairness (Jain's index of weighted slowdown, 1 is perfectly fair): %.3f", slowdownFairness(tasks)))
+}
+
+// slowdownFairness is Jain's fairness index over each task's turnaround
+// divided by its burst and scaled by its weight.
+func slowdownFairness(tasks []*Task) float64 {
+	var slowdowns []float64
+	for _, t := range tasks {
+		if t.BurstDuration > 0 {
+			slowdowns = append(slowdowns, float64(t.Exit-t.ArrivalTime)/float64(t.BurstDuration)*float64(t.weight())/defaultWeight)
+		}
+	}
+	return jainIndex(slowdowns)
+}
+
+// jainIndex is Jain's fairness index of xs: 1 when they are all equal, down
+// to 1/len(xs) when one of them has everything.
+func jainIndex(xs []float64) float64 {
+	var sum, squares float64
+	for _, x := range xs {
+		sum += x
+		squares += x * x
+	}
+	if squares == 0 {
+		return 1
+	}
+	return sum * sum / (float64(len(xs)) * squares)
+}
+
+// bvtReports runs BVT with the given warps and, to show what warping
+// costs in fairness, without any (which is weighted fair sharing) and under
+// CFS with the given target latency and minimum granularity.
+func bvtReports(processes []Process, warp map[int64]float64, latency, granularity int64) []Report {
+	cfs := CFS(fmt.Sprintf("CFS against borrowed virtual time, latency %d, granularity %d", latency, granularity), processes, latency, granularity)
+	addFairness(&cfs, processes)
+	return []Report{
+		simulate("Borrowed virtual time", processes, &BVTPolicy{Warp: warp, Allowance: 2}),
+		simulate("Borrowed virtual time without warp", processes, &BVTPolicy{Allowance: 2}),
+		cfs,
+	}
+}
+
+// addFairness adds the Response column and fairness note BVT's annotate
+// adds to a report from another policy, so the two can be compared.
+func addFairness(r *Report, processes []Process) {
+	byID := make(map[int64]Process, len(processes))
+	for _, p := range processes {
+		byID[p.ProcessID] = p
+	}
+	first := make(map[int64]int64)
+	for _, s := range r.Gantt {
+		if f, ok := first[s.PID]; !s.Overhead && (!ok || s.Start < f) {
+			first[s.PID] = s.Start
+		}
+	}
+	tasks := make([]*Task, len(r.Rows))
+	for i, row := range r.Rows {
+		tasks[i] = &Task{Process: byID[row.ProcessID], FirstRun: first[row.ProcessID], Exit: row.Exit}
+	}
+	r.Columns = append(r.Columns, responseColumn(tasks))
+	r.Notes = append(r.Notes, fmt.Sprintf("Fairness (Jain's index of weighted slowdown, 1 is perfectly fair): %.3f", slowdownFairness(tasks)))
+}
+
+// parseWarps parses a comma separated list of <pid>=<warp> pairs.
+func parseWarps(list string) (map[int64]float64, error) {
+	warps := make(map[int64]float64)
+	for _, f := range strings.Split(list, ",") {
+		if f = strings.TrimSpace(f); f == "" {
+			continue
+		}
+		kv := strings.SplitN(f, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("%w: want <pid>=<warp>, got %q", ErrInvalidArgs, f)
+		}
+		pid, err := strconv.ParseInt(kv[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+		}
+		w, err := strconv.ParseFloat(kv[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+		}
+		warps[pid] = w
+	}
+	return warps, nil
+}
+
+//endregion
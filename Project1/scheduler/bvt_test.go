@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_BVTPolicy(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 8},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 8},
+		{ProcessID: 3, ArrivalTime: 4, BurstDuration: 2},
+	}
+	tests := []struct {
+		name      string
+		warp      map[int64]float64
+		wantGantt []TimeSlice
+	}{
+		{
+			name: "no warp shares fairly",
+			// Process 3 joins at the lowest virtual time, 2, and waits its turn.
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 6}, {PID: 1, Start: 6, Stop: 8},
+				{PID: 3, Start: 8, Stop: 10}, {PID: 2, Start: 10, Stop: 12}, {PID: 1, Start: 12, Stop: 16},
+				{PID: 2, Start: 16, Stop: 18},
+			},
+		},
+		{
+			name: "warped process is dispatched on arrival",
+			warp: map[int64]float64{3: 10},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 3, Start: 4, Stop: 6},
+				{PID: 1, Start: 6, Stop: 8}, {PID: 2, Start: 8, Stop: 12}, {PID: 1, Start: 12, Stop: 16},
+				{PID: 2, Start: 16, Stop: 18},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := simulate(tt.name, processes, &BVTPolicy{Warp: tt.warp, Allowance: 2})
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+		})
+	}
+}
+
+func Test_bvtReports(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 8},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 8},
+	}
+	reports := bvtReports(processes, nil, 24, 3)
+	if len(reports) != 3 {
+		t.Fatalf("got %d reports, want BVT, BVT without warp and CFS", len(reports))
+	}
+	cfs := reports[2]
+	if got := cfs.Columns[len(cfs.Columns)-1].Header; got != "Response" {
+		t.Errorf("last CFS column = %q, want Response", got)
+	}
+	if note := cfs.Notes[len(cfs.Notes)-1]; !strings.HasPrefix(note, "Fairness (Jain's index") {
+		t.Errorf("last CFS note = %q, want the fairness index", note)
+	}
+}
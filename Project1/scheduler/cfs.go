@@ -0,0 +1,299 @@
+package scheduler
+
+import (
+	"container/heap"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+//region Completely fair scheduler
+
+// niceWeights is Linux's sched_prio_to_weight: the load weight of each
+// nice value from -20 to 19. Every nice level is worth about 10% of CPU
+// time against a task one level away, and nice 0 weighs nice0Weight.
+var niceWeights = [40]int64{
+	88761, 71755, 56483, 46273, 36291,
+	29154, 23254, 18705, 14949, 11916,
+	9548, 7620, 6100, 4904, 3906,
+	3121, 2501, 1991, 1586, 1277,
+	1024, 820, 655, 526, 423,
+	335, 272, 215, 172, 137,
+	110, 87, 70, 56, 45,
+	36, 29, 23, 18, 15,
+}
+
+const (
+	nice0Weight = 1024
+	// vruntimeScale is how many vruntime units a nice 0 task accrues per
+	// tick, so weighted vruntime stays an integer.
+	vruntimeScale = 1024
+)
+
+// parseNice parses the nice column: -20 to 19, with empty meaning 0.
+func parseNice(s string) (int64, error) {
+	if s = strings.TrimSpace(s); s == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || v < -20 || v > 19 {
+		return 0, fmt.Errorf("%w: nice must be -20 to 19, got %q", ErrInvalidArgs, s)
+	}
+	return v, nil
+}
+
+// loadWeight is the process's CFS load weight, from its nice value.
+func (p Process) loadWeight() int64 {
+	switch {
+	case p.Nice < -20:
+		return niceWeights[0]
+	case p.Nice > 19:
+		return niceWeights[39]
+	}
+	return niceWeights[p.Nice+20]
+}
+
+type (
+	// CFSPolicy is modelled on Linux's completely fair scheduler. Each task
+	// accrues virtual runtime, vruntime, for every tick it runs, at a rate
+	// inversely proportional to its load weight, and the task with the
+	// lowest vruntime runs next. Ready tasks are kept in a min-heap keyed
+	// on vruntime. Every Latency ticks each runnable task should run once,
+	// for a slice proportional to its weight, but slices are never shorter
+	// than Granularity: with many tasks the period stretches instead.
+	// The running task is preempted when its slice is up, or when a task
+	// that arrives or wakes up is more than Granularity behind it. A task
+	// that arrives or wakes up starts at the lowest vruntime in the queue,
+	// so it can't claim the time it wasn't runnable for. With Groups, the
+	// tasks' weights are flattened from the group hierarchy at every pick,
+	// so each group gets its share whichever of its tasks are runnable, the
+	// way Linux schedules task groups.
+	CFSPolicy struct {
+		Latency     int64
+		Granularity int64
+		Groups      *Group
+		// weights are the runnable tasks' flattened weights with Groups.
+		weights  map[*Task]int64
+		queue    cfsQueue
+		entities map[*Task]*cfsEntity
+		// load is the total weight of the queued tasks.
+		load        int64
+		minVruntime int64
+		seq         int64
+		tick        tickCharger
+		preemptions int
+	}
+
+	cfsEntity struct {
+		task     *Task
+		vruntime int64
+		// seq orders tasks with equal vruntime by when they were queued;
+		// index is the entity's place in the heap, -1 when not queued.
+		seq   int64
+		index int
+	}
+
+	// cfsQueue is a min-heap of entities by vruntime: the leftmost node of
+	// Linux's red-black tree is its root.
+	cfsQueue []*cfsEntity
+)
+
+func (q cfsQueue) Len() int { return len(q) }
+
+func (q cfsQueue) Less(i, j int) bool {
+	if q[i].vruntime != q[j].vruntime {
+		return q[i].vruntime < q[j].vruntime
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q cfsQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index, q[j].index = i, j
+}
+
+func (q *cfsQueue) Push(x interface{}) {
+	e := x.(*cfsEntity)
+	e.index = len(*q)
+	*q = append(*q, e)
+}
+
+func (q *cfsQueue) Pop() interface{} {
+	old := *q
+	e := old[len(old)-1]
+	e.index = -1
+	*q = old[:len(old)-1]
+	return e
+}
+
+// enqueue adds e to the run queue.
+func (p *CFSPolicy) enqueue(e *cfsEntity) {
+	p.seq++
+	e.seq = p.seq
+	heap.Push(&p.queue, e)
+	p.load += p.weight(e.task)
+}
+
+// dequeue takes the task with the lowest vruntime off the run queue.
+func (p *CFSPolicy) dequeue() *Task {
+	e := heap.Pop(&p.queue).(*cfsEntity)
+	p.load -= p.weight(e.task)
+	return e.task
+}
+
+// slice is how long the running task t may run before the others get
+// their turn: its weight's share of the scheduling period, but at least
+// the granularity.
+func (p *CFSPolicy) slice(t *Task) int64 {
+	period := p.Latency
+	if n := int64(len(p.queue) + 1); n*p.Granularity > period {
+		period = n * p.Granularity
+	}
+	s := period * p.weight(t) / (p.load + p.weight(t))
+	if s < p.Granularity {
+		s = p.Granularity
+	}
+	return s
+}
+
+func (p *CFSPolicy) Pick(now int64, running *Task, ready []*Task) *Task {
+	if p.entities == nil {
+		p.entities = make(map[*Task]*cfsEntity)
+	}
+	if t := p.tick.due(now); t != nil {
+		p.charge(t)
+	}
+	if p.Groups != nil {
+		runnable := ready
+		if running != nil {
+			runnable = append([]*Task{running}, ready...)
+		}
+		p.weights = p.Groups.flatten(runnable, (*Task).loadWeight, nice0Weight)
+		p.load = 0
+		for _, e := range p.queue {
+			p.load += p.weight(e.task)
+		}
+	}
+	pick := p.pick(running, ready)
+	p.tick.picked(now, pick)
+	return pick
+}
+
+// weight is t's load weight, flattened from the groups if there are any.
+func (p *CFSPolicy) weight(t *Task) int64 {
+	if w, ok := p.weights[t]; ok && p.Groups != nil {
+		return w
+	}
+	return t.loadWeight()
+}
+
+// charge adds a tick run by t to its vruntime, weighted by its load.
+func (p *CFSPolicy) charge(t *Task) {
+	p.entities[t].vruntime += vruntimeScale * nice0Weight / p.weight(t)
+}
+
+func (p *CFSPolicy) pick(running *Task, ready []*Task) *Task {
+	var current *cfsEntity
+	if running != nil {
+		current = p.entities[running]
+		if current.index >= 0 {
+			// The engine kept it running over the task picked last.
+			heap.Remove(&p.queue, current.index)
+			p.load -= p.weight(running)
+		}
+	}
+
+	// The queue's vruntime only moves forward, with the lowest of the
+	// running and queued tasks.
+	lowest, known := int64(0), false
+	if current != nil {
+		lowest, known = current.vruntime, true
+	}
+	if len(p.queue) > 0 && (!known || p.queue[0].vruntime < lowest) {
+		lowest, known = p.queue[0].vruntime, true
+	}
+	if known && lowest > p.minVruntime {
+		p.minVruntime = lowest
+	}
+
+	// Tasks that arrived or woke up since the last pick join the queue at
+	// the lowest vruntime, or keep their own if it is higher.
+	var woken []*cfsEntity
+	for _, t := range ready {
+		e, ok := p.entities[t]
+		if !ok {
+			e = &cfsEntity{task: t, index: -1}
+			p.entities[t] = e
+		}
+		if e.index < 0 {
+			if e.vruntime < p.minVruntime {
+				e.vruntime = p.minVruntime
+			}
+			p.enqueue(e)
+			woken = append(woken, e)
+		}
+	}
+	if running == nil && len(p.queue) == 0 {
+		return nil
+	}
+	if current == nil {
+		return p.dequeue()
+	}
+	if len(p.queue) == 0 {
+		return running
+	}
+
+	preempt := running.Slice >= p.slice(running)
+	for _, e := range woken {
+		if current.vruntime-e.vruntime > p.Granularity*vruntimeScale {
+			preempt = true
+		}
+	}
+	// Like Linux, which puts the running task back in the tree before
+	// picking the leftmost, ties go to the queued task.
+	if !preempt || p.queue[0].vruntime > current.vruntime {
+		return running
+	}
+	p.preemptions++
+	p.enqueue(current)
+	return p.dequeue()
+}
+
+// annotate adds each task's nice value, load weight and final vruntime in
+// ticks at nice 0, and notes the tunables and how often the running task
+// was preempted.
+func (p *CFSPolicy) annotate(r *Report, tasks []*Task) {
+	if t := p.tick.flush(); t != nil {
+		p.charge(t)
+	}
+	nice, weight, vruntime := Column{Header: "Nice"}, Column{Header: "Weight"}, Column{Header: "vruntime"}
+	for _, t := range tasks {
+		nice.Values = append(nice.Values, fmt.Sprint(t.Nice))
+		weight.Values = append(weight.Values, fmt.Sprint(t.loadWeight()))
+		var v int64
+		if e := p.entities[t]; e != nil {
+			v = e.vruntime
+		}
+		vruntime.Values = append(vruntime.Values, strconv.FormatFloat(float64(v)/vruntimeScale, 'f', 2, 64))
+	}
+	r.Columns = append(r.Columns, nice, weight, vruntime)
+	if p.Groups != nil {
+		r.Columns = append(r.Columns, groupColumn(p.Groups, tasks))
+	}
+	r.Notes = append(r.Notes, fmt.Sprintf("Target latency %d, minimum granularity %d: %d preemptions", p.Latency, p.Granularity, p.preemptions))
+}
+
+// CFSSchedule outputs the completely fair schedule of processes as a Gantt
+// chart and a table of timing.
+func CFSSchedule(w io.Writer, title string, processes []Process, latency, granularity int64) {
+	outputReport(w, CFS(title, processes, latency, granularity))
+}
+
+// CFS schedules processes with the completely fair scheduler, weighting
+// them by their nice values.
+func CFS(title string, processes []Process, latency, granularity int64) Report {
+	return simulate(title, processes, &CFSPolicy{Latency: latency, Granularity: granularity})
+}
+
+//endregion
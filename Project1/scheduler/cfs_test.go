@@ -0,0 +1,162 @@
+package scheduler
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseNice(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr error
+	}{
+		{in: "", want: 0},
+		{in: " -20", want: -20},
+		{in: "19", want: 19},
+		{in: "20", wantErr: ErrInvalidArgs},
+		{in: "-21", wantErr: ErrInvalidArgs},
+		{in: "low", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseNice(tt.in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseNice(%q) error = %v, want %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseNice(%q) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCFS(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		processes   []Process
+		latency     int64
+		granularity int64
+		// wantGantt is the start of the Gantt chart.
+		wantGantt []TimeSlice
+		// Process 1 must get between minShare and maxShare of the first
+		// window ticks.
+		window             int64
+		minShare, maxShare float64
+	}{
+		{
+			name:        "equal nice values take turns of equal slices",
+			processes:   []Process{{ProcessID: 1, BurstDuration: 6}, {ProcessID: 2, BurstDuration: 6}},
+			latency:     6,
+			granularity: 1,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 6}, {PID: 1, Start: 6, Stop: 9}, {PID: 2, Start: 9, Stop: 12},
+			},
+			window:   12,
+			minShare: 0.5,
+			maxShare: 0.5,
+		},
+		{
+			name:        "the granularity bounds the slices",
+			processes:   []Process{{ProcessID: 1, BurstDuration: 8}, {ProcessID: 2, BurstDuration: 8}},
+			latency:     2,
+			granularity: 4,
+			wantGantt:   []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 8}, {PID: 1, Start: 8, Stop: 12}},
+			window:      16,
+			minShare:    0.5,
+			maxShare:    0.5,
+		},
+		{
+			name:        "nice 5 gets about a third of nice 0",
+			processes:   []Process{{ProcessID: 1, BurstDuration: 200}, {ProcessID: 2, BurstDuration: 200, Nice: 5}},
+			latency:     24,
+			granularity: 3,
+			wantGantt:   []TimeSlice{{PID: 1, Start: 0, Stop: 18}, {PID: 2, Start: 18, Stop: 24}},
+			window:      200,
+			minShare:    0.72,
+			maxShare:    0.78,
+		},
+		{
+			name:        "a newcomer starts at the queue's vruntime",
+			processes:   []Process{{ProcessID: 1, BurstDuration: 20}, {ProcessID: 2, ArrivalTime: 10, BurstDuration: 2}},
+			latency:     24,
+			granularity: 3,
+			wantGantt:   []TimeSlice{{PID: 1, Start: 0, Stop: 12}, {PID: 2, Start: 12, Stop: 14}, {PID: 1, Start: 14, Stop: 22}},
+			window:      22,
+			minShare:    20.0 / 22,
+			maxShare:    20.0 / 22,
+		},
+		{
+			name: "an arrival behind the running task preempts it",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 30}, {ProcessID: 2, BurstDuration: 30, Nice: 5},
+				{ProcessID: 3, ArrivalTime: 5, BurstDuration: 10, Nice: -5},
+			},
+			latency:     24,
+			granularity: 3,
+			wantGantt:   []TimeSlice{{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 5, Stop: 8}, {PID: 3, Start: 8, Stop: 18}},
+			window:      5,
+			minShare:    1,
+			maxShare:    1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := CFS("CFS", tt.processes, tt.latency, tt.granularity)
+			if len(r.Gantt) < len(tt.wantGantt) || !reflect.DeepEqual(r.Gantt[:len(tt.wantGantt)], tt.wantGantt) {
+				t.Errorf("CFS() Gantt = %v, want it to start %v", r.Gantt, tt.wantGantt)
+			}
+			var ran int64
+			for _, s := range r.Gantt {
+				stop := s.Stop
+				if stop > tt.window {
+					stop = tt.window
+				}
+				if s.PID == 1 && s.Start < stop {
+					ran += stop - s.Start
+				}
+			}
+			if share := float64(ran) / float64(tt.window); share < tt.minShare-1e-9 || share > tt.maxShare+1e-9 {
+				t.Errorf("CFS() gave process 1 %.3f of the first %d ticks, want %.3f to %.3f", share, tt.window, tt.minShare, tt.maxShare)
+			}
+		})
+	}
+}
+
+func TestCFSSchedule(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	CFSSchedule(&w, "CFS", []Process{{ProcessID: 1, BurstDuration: 2, Nice: -1}}, 24, 3)
+	for _, want := range []string{"|   -1 |   1277 |     1.60 |", "Target latency 24, minimum granularity 3: 0 preemptions"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("CFSSchedule() = %q, want it to contain %q", w.String(), want)
+		}
+	}
+}
+
+func Test_loadProcessesNice(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader("1,5,0,0,,,-3\n2,5,0,1,200,interactive,\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Process{
+		{ProcessID: 1, BurstDuration: 5, Nice: -3},
+		{ProcessID: 2, BurstDuration: 5, Priority: 1, Weight: 200, Interactive: true},
+	}
+	if !reflect.DeepEqual(processes, want) {
+		t.Errorf("loadProcesses() = %+v, want %+v", processes, want)
+	}
+	if _, err := loadProcesses(strings.NewReader("1,5,0,0,,,25\n")); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("loadProcesses(nice 25) error = %v, want ErrInvalidArgs", err)
+	}
+}
@@ -0,0 +1,258 @@
+package scheduler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//region Container CPU weight import
+
+// shareGroup is a cgroup or container whose CPU share becomes one process of the workload.
+type shareGroup struct {
+	Name   string
+	Weight int64
+	// Usage is the CPU time consumed so far in microseconds, when known.
+	Usage int64
+}
+
+// runImportCgroups implements the import-cgroups subcommand, which writes a
+// weighted workload CSV (ID, burst, arrival, priority, weight) for the
+// proportional-share schedulers from either:
+//
+//	import-cgroups [-root /sys/fs/cgroup]   leaf cgroups of a cgroup v2 hierarchy
+//	import-cgroups -kube pods.json          containers in `kubectl get pods -A -o json` output
+//
+// Cgroup weights only compete between siblings, so each leaf's weight is its
+// effective share of the whole machine in per-mille. Kubernetes CPU requests
+// are converted to cpu.weight the same way the kubelet does. Every process
+// arrives at 0 with a burst of -burst ticks, or with its consumed CPU time
+// when -usage is set. The process ID to cgroup mapping is written to stderr.
+func runImportCgroups(w io.Writer, args ...string) error {
+	fs := flag.NewFlagSet("import-cgroups", flag.ContinueOnError)
+	fs.SetOutput(w)
+	root := fs.String("root", "/sys/fs/cgroup", "cgroup v2 mount point to walk")
+	kube := fs.String("kube", "", "read containers from `kubectl get pods -o json` output instead")
+	burst := fs.Int64("burst", 100, "burst duration given to every process")
+	usage := fs.Bool("usage", false, "use each cgroup's consumed CPU time (cpu.stat usage_usec) as its burst")
+	tick := fs.Int64("tick-usec", 1000, "microseconds per tick when converting usage to bursts")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	if *tick <= 0 {
+		return fmt.Errorf("%w: -tick-usec must be positive", ErrInvalidArgs)
+	}
+
+	var (
+		groups []shareGroup
+		err    error
+	)
+	if *kube != "" {
+		groups, err = readKubeGroups(*kube)
+	} else {
+		groups, err = readCgroupGroups(*root)
+	}
+	if err != nil {
+		return err
+	}
+	if len(groups) == 0 {
+		return fmt.Errorf("%w: no CPU-weighted groups found", ErrInvalidArgs)
+	}
+
+	out := csv.NewWriter(w)
+	for i, g := range groups {
+		b := *burst
+		if *usage {
+			b = g.Usage / *tick
+			if b < 1 {
+				b = 1
+			}
+		}
+		_, _ = fmt.Fprintf(os.Stderr, "%d\t%s\n", i+1, g.Name)
+		if err := out.Write([]string{
+			fmt.Sprint(i + 1),
+			fmt.Sprint(b),
+			"0",
+			"0",
+			fmt.Sprint(g.Weight),
+		}); err != nil {
+			return fmt.Errorf("%w: writing CSV", err)
+		}
+	}
+	out.Flush()
+
+	return out.Error()
+}
+
+// readCgroupGroups walks a cgroup v2 hierarchy and returns its leaf cgroups
+// with their effective machine-wide share, in per-mille, as the weight.
+func readCgroupGroups(root string) ([]shareGroup, error) {
+	var groups []shareGroup
+	var walk func(dir string, share float64) error
+	walk = func(dir string, share float64) error {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return fmt.Errorf("%w: reading cgroup", err)
+		}
+		type child struct {
+			path   string
+			weight int64
+		}
+		var (
+			children []child
+			total    int64
+		)
+		for _, e := range entries {
+			if !e.IsDir() {
+				continue
+			}
+			path := filepath.Join(dir, e.Name())
+			weight, err := readCgroupInt(filepath.Join(path, "cpu.weight"))
+			if err != nil {
+				// The cpu controller isn't enabled for this subtree.
+				continue
+			}
+			children = append(children, child{path: path, weight: weight})
+			total += weight
+		}
+
+		if len(children) == 0 {
+			if dir == root {
+				return nil
+			}
+			name, _ := filepath.Rel(root, dir)
+			g := shareGroup{
+				Name:   name,
+				Weight: int64(math.Max(1, math.Round(share*1000))),
+			}
+			g.Usage, _ = readCPUUsage(filepath.Join(dir, "cpu.stat"))
+			groups = append(groups, g)
+			return nil
+		}
+		for _, c := range children {
+			if err := walk(c.path, share*float64(c.weight)/float64(total)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := walk(root, 1); err != nil {
+		return nil, err
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+
+	return groups, nil
+}
+
+func readCgroupInt(path string) (int64, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(b)), 10, 64)
+}
+
+// readCPUUsage returns usage_usec from a cgroup's cpu.stat.
+func readCPUUsage(path string) (int64, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	for _, line := range strings.Split(string(b), "\n") {
+		if f := strings.Fields(line); len(f) == 2 && f[0] == "usage_usec" {
+			return strconv.ParseInt(f[1], 10, 64)
+		}
+	}
+	return 0, fmt.Errorf("%w: no usage_usec in %s", ErrInvalidArgs, path)
+}
+
+// kubePodList is the subset of `kubectl get pods -o json` output we need.
+type kubePodList struct {
+	Items []struct {
+		Metadata struct {
+			Namespace string `json:"namespace"`
+			Name      string `json:"name"`
+		} `json:"metadata"`
+		Spec struct {
+			Containers []struct {
+				Name      string `json:"name"`
+				Resources struct {
+					Requests map[string]string `json:"requests"`
+				} `json:"resources"`
+			} `json:"containers"`
+		} `json:"spec"`
+	} `json:"items"`
+}
+
+// readKubeGroups returns one group per container, weighted by its CPU request.
+func readKubeGroups(path string) ([]shareGroup, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading pod list", err)
+	}
+	var pods kubePodList
+	if err := json.Unmarshal(b, &pods); err != nil {
+		return nil, fmt.Errorf("%w: decoding %s", err, path)
+	}
+
+	var groups []shareGroup
+	for _, pod := range pods.Items {
+		for _, c := range pod.Spec.Containers {
+			milli, err := parseCPUQuantity(c.Resources.Requests["cpu"])
+			if err != nil {
+				return nil, err
+			}
+			groups = append(groups, shareGroup{
+				Name:   pod.Metadata.Namespace + "/" + pod.Metadata.Name + "/" + c.Name,
+				Weight: milliCPUToWeight(milli),
+			})
+		}
+	}
+
+	return groups, nil
+}
+
+// parseCPUQuantity parses a Kubernetes CPU quantity ("250m", "1", "0.5") into millicores.
+// An empty quantity means no request.
+func parseCPUQuantity(q string) (int64, error) {
+	if q == "" {
+		return 0, nil
+	}
+	if strings.HasSuffix(q, "m") {
+		m, err := strconv.ParseInt(strings.TrimSuffix(q, "m"), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w: CPU quantity %q", err, q)
+		}
+		return m, nil
+	}
+	cores, err := strconv.ParseFloat(q, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: CPU quantity %q", err, q)
+	}
+	return int64(math.Round(cores * 1000)), nil
+}
+
+// milliCPUToWeight converts a CPU request to the cgroup v2 cpu.weight the
+// kubelet would assign: millicores to cgroup v1 shares, then shares mapped
+// from [2, 262144] onto [1, 10000].
+func milliCPUToWeight(milli int64) int64 {
+	shares := milli * 1024 / 1000
+	if shares < 2 {
+		shares = 2
+	}
+	if shares > 262144 {
+		shares = 262144
+	}
+	return 1 + ((shares-2)*9999)/262142
+}
+
+//endregion
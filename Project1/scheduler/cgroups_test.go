@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func Test_readCgroupGroups(t *testing.T) {
+	t.Parallel()
+	root := t.TempDir()
+	write := func(rel, content string) {
+		path := filepath.Join(root, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// system gets a third of the machine; its two children split that 1:3.
+	write("system/cpu.weight", "100\n")
+	write("system/a/cpu.weight", "50\n")
+	write("system/b/cpu.weight", "150\n")
+	write("system/b/cpu.stat", "usage_usec 4200\nuser_usec 4000\n")
+	write("user/cpu.weight", "200\n")
+	write("nocpu/memory.max", "max\n")
+
+	got, err := readCgroupGroups(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []shareGroup{
+		{Name: "system/a", Weight: 83},
+		{Name: "system/b", Weight: 250, Usage: 4200},
+		{Name: "user", Weight: 667},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readCgroupGroups() = %v, want %v", got, want)
+	}
+}
+
+func Test_milliCPUToWeight(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		quantity string
+		want     int64
+	}{
+		{quantity: "", want: 1},
+		{quantity: "100m", want: 4},
+		{quantity: "1", want: 39},
+		{quantity: "0.5", want: 20},
+		{quantity: "1000", want: 10000},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.quantity, func(t *testing.T) {
+			t.Parallel()
+			milli, err := parseCPUQuantity(tt.quantity)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := milliCPUToWeight(milli); got != tt.want {
+				t.Errorf("milliCPUToWeight(%d) = %d, want %d", milli, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+//region Checkpoints
+
+// runCheckpointed implements the run subcommand, for simulations long
+// enough to be worth checkpointing:
+//
+//	run [-policy rr] [-quantum 10] [-mpl 0] [-scale 0] [-checkpoint state.json] [-checkpoint-every 10000] processes.csv
+//	run -resume state.json [-checkpoint state.json] [-checkpoint-every 10000]
+//
+// The workload is read as by the main command: its # settings, such as
+// #policy=srtf or #scale=10, apply unless a flag overrides them, and
+// decimal times are scaled to whole ticks. With -checkpoint the state is
+// saved there every -checkpoint-every ticks, replacing the previous
+// checkpoint atomically; -resume carries on from a checkpoint (or a
+// snapshot) instead of starting from zero.
+func runCheckpointed(w io.Writer, args ...string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	fs.SetOutput(w)
+	policyFlag := fs.String("policy", "rr", "scheduling policy: fcfs, sjf, priority, preemptive-priority, srtf, hrrn, rr or priority-rr")
+	quantum := fs.Int64("quantum", 10, "round-robin time quantum")
+	mpl := fs.Int("mpl", 0, "admit at most this many processes at once")
+	scale := fs.Int64("scale", 0, "ticks per time unit of the workload; 0 picks the smallest power of ten that makes its times whole")
+	checkpoint := fs.String("checkpoint", "", "file to save checkpoints to")
+	every := fs.Int64("checkpoint-every", 10000, "ticks of simulated time between checkpoints")
+	resume := fs.String("resume", "", "checkpoint to resume from")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	if *every <= 0 {
+		return fmt.Errorf("%w: -checkpoint-every must be positive", ErrInvalidArgs)
+	}
+
+	var (
+		opts    []Option
+		saveErr error
+	)
+	if *checkpoint != "" {
+		opts = append(opts, WithCheckpoints(*every, func(snap Snapshot) {
+			if saveErr == nil {
+				saveErr = saveCheckpoint(*checkpoint, snap)
+			}
+		}))
+	}
+
+	var r Report
+	if *resume != "" {
+		snap, err := readSnapshot(*resume)
+		if err != nil {
+			return err
+		}
+		if r, err = resumeSnapshot(fmt.Sprintf("%s (resumed at %d)", snap.Policy, snap.Now), snap, opts...); err != nil {
+			return err
+		}
+	} else {
+		if fs.NArg() != 1 {
+			return fmt.Errorf("%w: usage: run [-policy name] [-checkpoint file] [-checkpoint-every N] processes.csv | run -resume file", ErrInvalidArgs)
+		}
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			return fmt.Errorf("%v: error opening scheduling file", err)
+		}
+		defer func() { _ = f.Close() }()
+		processes, metadata, err := loadWorkload(f, *scale)
+		if err != nil {
+			return err
+		}
+		if err := applyMetadata(fs, metadata); err != nil {
+			return err
+		}
+		policy, err := PolicyByName(*policyFlag, *quantum)
+		if err != nil {
+			return err
+		}
+		if *scale > 1 {
+			_, _ = fmt.Fprintf(w, "Times are in ticks of 1/%d of the workload's time unit\n", *scale)
+		}
+		r = simulate(policyTitle(policy), processes, policy, append(opts, WithMultiprogramming(*mpl))...)
+	}
+	if saveErr != nil {
+		return saveErr
+	}
+
+	outputReport(w, r)
+	return nil
+}
+
+func saveCheckpoint(path string, snap Snapshot) error {
+	b, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("%w: encoding checkpoint", err)
+	}
+	return writeAtomic(path, b, "checkpoint")
+}
+
+//endregion
@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_runCheckpointed_metadata(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		workload string
+		args     []string
+		want     []string
+	}{
+		{
+			name:     "policy setting",
+			workload: "#policy=fcfs\n1,4,0,1\n2,2,1,1\n",
+			want:     []string{"First-come, first-serve", "Makespan: 6\n"},
+		},
+		{
+			name:     "flag overrides the setting",
+			workload: "#policy=fcfs\n1,4,0,1\n2,2,1,1\n",
+			args:     []string{"-policy", "srtf"},
+			want:     []string{"Shortest-remaining-time-first", "Makespan: 6\n"},
+		},
+		{
+			name:     "scale setting",
+			workload: "#scale=10\n#policy=fcfs\n1,0.5,0,1\n2,1.5,0,1\n",
+			want:     []string{"Times are in ticks of 1/10 of the workload's time unit\n", "Makespan: 20\n"},
+		},
+		{
+			name:     "decimal times",
+			workload: "1,0.5,0,1\n2,1.5,0,1\n",
+			args:     []string{"-policy", "fcfs"},
+			want:     []string{"Times are in ticks of 1/10 of the workload's time unit\n", "Makespan: 20\n"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "processes.csv")
+			if err := os.WriteFile(path, []byte(tt.workload), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			var b strings.Builder
+			if err := runCheckpointed(&b, append(tt.args, path)...); err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(b.String(), want) {
+					t.Errorf("output = %q, want it to contain %q", b.String(), want)
+				}
+			}
+		})
+	}
+}
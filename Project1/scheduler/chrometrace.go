@@ -0,0 +1,127 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+//region Chrome trace export
+
+type (
+	// chromeTrace is the JSON Object Format of the Trace Event Format, read
+	// by chrome://tracing and ui.perfetto.dev.
+	chromeTrace struct {
+		TraceEvents     []chromeEvent `json:"traceEvents"`
+		DisplayTimeUnit string        `json:"displayTimeUnit"`
+	}
+	chromeEvent struct {
+		Name string `json:"name"`
+		Cat  string `json:"cat,omitempty"`
+		// Ph is the phase: X for a complete slice, i for an instant, M for metadata.
+		Ph string `json:"ph"`
+		// Ts and Dur are in microseconds.
+		Ts    float64                `json:"ts"`
+		Dur   float64                `json:"dur,omitempty"`
+		PID   int                    `json:"pid"`
+		TID   int64                  `json:"tid"`
+		Scope string                 `json:"s,omitempty"`
+		Args  map[string]interface{} `json:"args,omitempty"`
+	}
+)
+
+// buildChromeTrace lays out each report as a trace process, named after
+// the algorithm, with a thread per simulated process holding the slices it
+// ran and an instant at its arrival. A run on several CPUs also gets a
+// thread per CPU, with negative tids -1, -2 and so on, holding the slices
+// it ran, and a "migrate" instant where a process starts a slice on another
+// CPU than it last ran on. Every tick lasts tick.
+func buildChromeTrace(reports []Report, tick time.Duration) chromeTrace {
+	us := float64(tick) / float64(time.Microsecond)
+	trace := chromeTrace{TraceEvents: []chromeEvent{}, DisplayTimeUnit: "ms"}
+	for i, r := range reports {
+		pid := i + 1
+		trace.TraceEvents = append(trace.TraceEvents, chromeEvent{
+			Name: "process_name", Ph: "M", PID: pid,
+			Args: map[string]interface{}{"name": r.Title},
+		}, chromeEvent{
+			Name: "process_sort_index", Ph: "M", PID: pid,
+			Args: map[string]interface{}{"sort_index": i},
+		})
+		for _, row := range r.Rows {
+			trace.TraceEvents = append(trace.TraceEvents, chromeEvent{
+				Name: "thread_name", Ph: "M", PID: pid, TID: row.ProcessID,
+				Args: map[string]interface{}{"name": fmt.Sprintf("Process %d", row.ProcessID)},
+			}, chromeEvent{
+				Name: "arrive", Cat: "scheduler", Ph: "i", Scope: "t",
+				Ts: float64(row.Arrival) * us, PID: pid, TID: row.ProcessID,
+				Args: map[string]interface{}{"burst": row.Burst, "priority": row.Priority},
+			})
+		}
+		cpus := cpuCount(r.Gantt)
+		if cpus > 1 {
+			for cpu := 0; cpu < cpus; cpu++ {
+				trace.TraceEvents = append(trace.TraceEvents, chromeEvent{
+					Name: "thread_name", Ph: "M", PID: pid, TID: cpuTID(cpu),
+					Args: map[string]interface{}{"name": fmt.Sprintf("CPU %d", cpu)},
+				})
+			}
+		}
+		migrated := migrations(r.Gantt)
+		switched := false
+		for i, s := range r.Gantt {
+			name, cat := fmt.Sprintf("Process %d", s.PID), "cpu"
+			if s.Overhead {
+				// Context switches get a thread of their own, tid 0, which no process uses.
+				name, cat = "Context switch", "overhead"
+				if !switched {
+					switched = true
+					trace.TraceEvents = append(trace.TraceEvents, chromeEvent{
+						Name: "thread_name", Ph: "M", PID: pid, TID: 0,
+						Args: map[string]interface{}{"name": "Context switches"},
+					})
+				}
+			}
+			trace.TraceEvents = append(trace.TraceEvents, chromeEvent{
+				Name: name, Cat: cat, Ph: "X",
+				Ts: float64(s.Start) * us, Dur: float64(s.Stop-s.Start) * us, PID: pid, TID: s.PID,
+				Args: map[string]interface{}{"start": s.Start, "stop": s.Stop, "cpu": s.CPU},
+			})
+			if cpus > 1 && !s.Overhead {
+				trace.TraceEvents = append(trace.TraceEvents, chromeEvent{
+					Name: name, Cat: cat, Ph: "X",
+					Ts: float64(s.Start) * us, Dur: float64(s.Stop-s.Start) * us, PID: pid, TID: cpuTID(s.CPU),
+					Args: map[string]interface{}{"start": s.Start, "stop": s.Stop, "pid": s.PID},
+				})
+			}
+			if migrated[i] {
+				trace.TraceEvents = append(trace.TraceEvents, chromeEvent{
+					Name: "migrate", Cat: "scheduler", Ph: "i", Scope: "t",
+					Ts: float64(s.Start) * us, PID: pid, TID: s.PID,
+					Args: map[string]interface{}{"cpu": s.CPU},
+				})
+			}
+		}
+	}
+	return trace
+}
+
+// cpuTID is the trace thread of a CPU, negative so it is no process's.
+func cpuTID(cpu int) int64 {
+	return -1 - int64(cpu)
+}
+
+// writeChromeTrace writes reports to path in the Trace Event Format.
+func writeChromeTrace(path string, reports []Report, tick time.Duration) error {
+	b, err := json.Marshal(buildChromeTrace(reports, tick))
+	if err != nil {
+		return fmt.Errorf("%w: encoding trace", err)
+	}
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		return fmt.Errorf("%w: writing trace", err)
+	}
+	return nil
+}
+
+//endregion
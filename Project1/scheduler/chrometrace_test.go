@@ -0,0 +1,109 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_buildChromeTrace(t *testing.T) {
+	t.Parallel()
+	reports := []Report{{
+		Title: "Round-robin",
+		Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 3}},
+		Rows:  []Row{{ProcessID: 1, Burst: 2}, {ProcessID: 2, Burst: 1, Arrival: 1, Priority: 3}},
+	}}
+	tests := []struct {
+		name string
+		tick time.Duration
+		want []string
+	}{
+		{
+			name: "millisecond ticks",
+			tick: time.Millisecond,
+			want: []string{
+				`{"name":"process_name","ph":"M","ts":0,"pid":1,"tid":0,"args":{"name":"Round-robin"}}`,
+				`{"name":"process_sort_index","ph":"M","ts":0,"pid":1,"tid":0,"args":{"sort_index":0}}`,
+				`{"name":"thread_name","ph":"M","ts":0,"pid":1,"tid":1,"args":{"name":"Process 1"}}`,
+				`{"name":"arrive","cat":"scheduler","ph":"i","ts":0,"pid":1,"tid":1,"s":"t","args":{"burst":2,"priority":0}}`,
+				`{"name":"thread_name","ph":"M","ts":0,"pid":1,"tid":2,"args":{"name":"Process 2"}}`,
+				`{"name":"arrive","cat":"scheduler","ph":"i","ts":1000,"pid":1,"tid":2,"s":"t","args":{"burst":1,"priority":3}}`,
+				`{"name":"Process 1","cat":"cpu","ph":"X","ts":0,"dur":2000,"pid":1,"tid":1,"args":{"cpu":0,"start":0,"stop":2}}`,
+				`{"name":"Process 2","cat":"cpu","ph":"X","ts":2000,"dur":1000,"pid":1,"tid":2,"args":{"cpu":0,"start":2,"stop":3}}`,
+			},
+		},
+		{
+			name: "microsecond ticks",
+			tick: time.Microsecond,
+			want: []string{
+				`{"name":"process_name","ph":"M","ts":0,"pid":1,"tid":0,"args":{"name":"Round-robin"}}`,
+				`{"name":"process_sort_index","ph":"M","ts":0,"pid":1,"tid":0,"args":{"sort_index":0}}`,
+				`{"name":"thread_name","ph":"M","ts":0,"pid":1,"tid":1,"args":{"name":"Process 1"}}`,
+				`{"name":"arrive","cat":"scheduler","ph":"i","ts":0,"pid":1,"tid":1,"s":"t","args":{"burst":2,"priority":0}}`,
+				`{"name":"thread_name","ph":"M","ts":0,"pid":1,"tid":2,"args":{"name":"Process 2"}}`,
+				`{"name":"arrive","cat":"scheduler","ph":"i","ts":1,"pid":1,"tid":2,"s":"t","args":{"burst":1,"priority":3}}`,
+				`{"name":"Process 1","cat":"cpu","ph":"X","ts":0,"dur":2,"pid":1,"tid":1,"args":{"cpu":0,"start":0,"stop":2}}`,
+				`{"name":"Process 2","cat":"cpu","ph":"X","ts":2,"dur":1,"pid":1,"tid":2,"args":{"cpu":0,"start":2,"stop":3}}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			trace := buildChromeTrace(reports, tt.tick)
+			if trace.DisplayTimeUnit != "ms" {
+				t.Errorf("displayTimeUnit = %q", trace.DisplayTimeUnit)
+			}
+			var got []string
+			for _, ev := range trace.TraceEvents {
+				b, err := json.Marshal(ev)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, string(b))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("events =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_buildChromeTrace_cpus(t *testing.T) {
+	t.Parallel()
+	reports := []Report{{
+		Title: "Round-robin on 2 CPUs",
+		Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 0, Stop: 2, CPU: 1}, {PID: 1, Start: 2, Stop: 3, CPU: 1}},
+		Rows:  []Row{{ProcessID: 1, Burst: 2}, {ProcessID: 2, Burst: 2}},
+	}}
+	var got []string
+	for _, ev := range buildChromeTrace(reports, time.Microsecond).TraceEvents {
+		b, err := json.Marshal(ev)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, string(b))
+	}
+	want := []string{
+		`{"name":"process_name","ph":"M","ts":0,"pid":1,"tid":0,"args":{"name":"Round-robin on 2 CPUs"}}`,
+		`{"name":"process_sort_index","ph":"M","ts":0,"pid":1,"tid":0,"args":{"sort_index":0}}`,
+		`{"name":"thread_name","ph":"M","ts":0,"pid":1,"tid":1,"args":{"name":"Process 1"}}`,
+		`{"name":"arrive","cat":"scheduler","ph":"i","ts":0,"pid":1,"tid":1,"s":"t","args":{"burst":2,"priority":0}}`,
+		`{"name":"thread_name","ph":"M","ts":0,"pid":1,"tid":2,"args":{"name":"Process 2"}}`,
+		`{"name":"arrive","cat":"scheduler","ph":"i","ts":0,"pid":1,"tid":2,"s":"t","args":{"burst":2,"priority":0}}`,
+		`{"name":"thread_name","ph":"M","ts":0,"pid":1,"tid":-1,"args":{"name":"CPU 0"}}`,
+		`{"name":"thread_name","ph":"M","ts":0,"pid":1,"tid":-2,"args":{"name":"CPU 1"}}`,
+		`{"name":"Process 1","cat":"cpu","ph":"X","ts":0,"dur":1,"pid":1,"tid":1,"args":{"cpu":0,"start":0,"stop":1}}`,
+		`{"name":"Process 1","cat":"cpu","ph":"X","ts":0,"dur":1,"pid":1,"tid":-1,"args":{"pid":1,"start":0,"stop":1}}`,
+		`{"name":"Process 2","cat":"cpu","ph":"X","ts":0,"dur":2,"pid":1,"tid":2,"args":{"cpu":1,"start":0,"stop":2}}`,
+		`{"name":"Process 2","cat":"cpu","ph":"X","ts":0,"dur":2,"pid":1,"tid":-2,"args":{"pid":2,"start":0,"stop":2}}`,
+		`{"name":"Process 1","cat":"cpu","ph":"X","ts":2,"dur":1,"pid":1,"tid":1,"args":{"cpu":1,"start":2,"stop":3}}`,
+		`{"name":"Process 1","cat":"cpu","ph":"X","ts":2,"dur":1,"pid":1,"tid":-2,"args":{"pid":1,"start":2,"stop":3}}`,
+		`{"name":"migrate","cat":"scheduler","ph":"i","ts":2,"pid":1,"tid":1,"s":"t","args":{"cpu":1}}`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("events =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,129 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+)
+
+//region Batch and interactive classes
+
+// setClass parses the class column: "system" (or "s"), "interactive" (or
+// "i"), "batch" (or "b", or empty), or the realtime classes "fifo" and
+// "rr" (or "rt-fifo" and "rt-rr").
+func (p *Process) setClass(s string) error {
+	p.System, p.Interactive, p.RealTime = false, false, ""
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "system", "s":
+		p.System = true
+	case "interactive", "i":
+		p.Interactive = true
+	case "batch", "b", "":
+	case "fifo", "rt-fifo":
+		p.RealTime = rtFIFO
+	case "rr", "rt-rr":
+		p.RealTime = rtRR
+	default:
+		return fmt.Errorf("%w: unknown class %q", ErrInvalidArgs, s)
+	}
+	return nil
+}
+
+// class names the process's class the way setClass reads it.
+func (p Process) class() string {
+	switch {
+	case p.RealTime != "":
+		return p.RealTime
+	case p.System:
+		return "system"
+	case p.Interactive:
+		return "interactive"
+	}
+	return "batch"
+}
+
+// ClassPolicy runs interactive tasks round-robin with a small quantum and
+// batch tasks with Batch in the background, only when no interactive task
+// is ready. An arriving interactive task preempts a batch one, which
+// resumes before any other batch task.
+type ClassPolicy struct {
+	Quantum int64
+	Batch   Policy
+	// background is the batch task that last ran.
+	background *Task
+}
+
+func (p *ClassPolicy) Pick(now int64, running *Task, ready []*Task) *Task {
+	var interactive, batch []*Task
+	for _, t := range ready {
+		if t.Interactive {
+			interactive = append(interactive, t)
+		} else {
+			batch = append(batch, t)
+		}
+	}
+
+	if running != nil && running.Interactive {
+		return RRPolicy{Quantum: p.Quantum}.Pick(now, running, interactive)
+	}
+	if len(interactive) > 0 {
+		return interactive[0]
+	}
+	if running == nil && p.background != nil && p.background.Remaining > 0 {
+		for _, t := range batch {
+			if t == p.background {
+				return t
+			}
+		}
+	}
+	if running == nil && len(batch) == 0 {
+		return nil
+	}
+	p.background = p.Batch.Pick(now, running, batch)
+	return p.background
+}
+
+// annotate adds each task's class and response time, and reports response
+// time for the interactive class and throughput for the batch class.
+func (p *ClassPolicy) annotate(r *Report, tasks []*Task) {
+	class := Column{Header: "Class"}
+	var (
+		interactive, batch int
+		totalResponse      float64
+		lastBatchExit      int64
+	)
+	for _, t := range tasks {
+		if t.Interactive {
+			class.Values = append(class.Values, "interactive")
+			interactive++
+			totalResponse += float64(t.FirstRun - t.ArrivalTime)
+			continue
+		}
+		class.Values = append(class.Values, "batch")
+		batch++
+		if t.Exit > lastBatchExit {
+			lastBatchExit = t.Exit
+		}
+	}
+	r.Columns = append(r.Columns, class, responseColumn(tasks))
+
+	if interactive > 0 {
+		r.Notes = append(r.Notes, fmt.Sprintf("Interactive: %d processes, average response %.2f",
+			interactive, totalResponse/float64(interactive)))
+	}
+	if batch > 0 && lastBatchExit > 0 {
+		r.Notes = append(r.Notes, fmt.Sprintf("Batch: %d processes, throughput %.2f/t",
+			batch, float64(batch)/float64(lastBatchExit)))
+	}
+}
+
+// classReports runs the combined scheduler with interactive tasks on a
+// round-robin of quantum and batch tasks under FCFS and under SJF.
+func classReports(processes []Process, quantum int64) []Report {
+	title := fmt.Sprintf("Interactive round-robin (quantum %d) over batch ", quantum)
+	return []Report{
+		simulate(title+"FCFS", processes, &ClassPolicy{Quantum: quantum, Batch: FCFSPolicy{}}),
+		simulate(title+"SJF", processes, &ClassPolicy{Quantum: quantum, Batch: SJFPolicy{}}),
+	}
+}
+
+//endregion
@@ -0,0 +1,53 @@
+package scheduler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_ClassPolicy(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader("1,10,0,0,0,batch\n2,3,0,0,0,batch\n3,2,2,0,0,interactive\n4,2,3,0,0,i\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		batch     Policy
+		wantGantt []TimeSlice
+	}{
+		{
+			name:  "batch FCFS",
+			batch: FCFSPolicy{},
+			// Process 1 is preempted by the interactive arrivals and resumes ahead of process 2.
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2}, {PID: 3, Start: 2, Stop: 3}, {PID: 4, Start: 3, Stop: 4},
+				{PID: 3, Start: 4, Stop: 5}, {PID: 4, Start: 5, Stop: 6}, {PID: 1, Start: 6, Stop: 14},
+				{PID: 2, Start: 14, Stop: 17},
+			},
+		},
+		{
+			name:  "batch SJF",
+			batch: SJFPolicy{},
+			wantGantt: []TimeSlice{
+				{PID: 2, Start: 0, Stop: 2}, {PID: 3, Start: 2, Stop: 3}, {PID: 4, Start: 3, Stop: 4},
+				{PID: 3, Start: 4, Stop: 5}, {PID: 4, Start: 5, Stop: 6}, {PID: 2, Start: 6, Stop: 7},
+				{PID: 1, Start: 7, Stop: 17},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := simulate(tt.name, processes, &ClassPolicy{Quantum: 1, Batch: tt.batch})
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			if want := []string{"batch", "batch", "interactive", "interactive"}; !reflect.DeepEqual(r.Columns[0].Values, want) {
+				t.Errorf("classes = %v, want %v", r.Columns[0].Values, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package scheduler
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+//region Clocks
+
+type (
+	// Clock paces a simulation: the engine waits on it before every tick.
+	Clock interface {
+		// Wait returns once the tick starting at now may be simulated, or
+		// with ctx's error if ctx is done first.
+		Wait(ctx context.Context, now int64) error
+	}
+
+	// SimulatedClock never waits, so the simulation runs as fast as it can.
+	// Runs without WithClock behave the same.
+	SimulatedClock struct{}
+
+	// WallClock maps every tick to Tick of real time, counted from the first
+	// tick it is asked for, so a run takes as long as its makespan would. It
+	// sleeps until each tick's start rather than for a tick at a time, so
+	// slow ticks don't add up to drift.
+	WallClock struct {
+		Tick time.Duration
+		// origin is the real time of tick originTick.
+		origin     time.Time
+		originTick int64
+		started    bool
+	}
+)
+
+func (SimulatedClock) Wait(ctx context.Context, _ int64) error {
+	return ctx.Err()
+}
+
+func (c *WallClock) Wait(ctx context.Context, now int64) error {
+	if !c.started {
+		c.origin, c.originTick, c.started = time.Now(), now, true
+	}
+	timer := time.NewTimer(time.Until(c.origin.Add(time.Duration(now-c.originTick) * c.Tick)))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// WithClock paces the simulation with c.
+func WithClock(c Clock) Option {
+	return func(e *engine) {
+		e.clock = c
+	}
+}
+
+// runWatch implements the watch subcommand:
+//
+//	watch [-tick 200ms] [-policy rr] [-quantum 10] processes.csv
+//
+// It runs the workload with one policy on a wall clock, printing every
+// event as it happens, for live demos.
+func runWatch(w io.Writer, args ...string) error {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	fs.SetOutput(w)
+	tick := fs.Duration("tick", 200*time.Millisecond, "real duration of one tick")
+	policyName := fs.String("policy", "rr", "policy to run: fcfs, sjf, priority, preemptive-priority, srtf, hrrn, rr or priority-rr")
+	quantum := fs.Int64("quantum", DefaultQuantum, "round-robin time quantum")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	if fs.NArg() != 1 || *tick <= 0 {
+		return fmt.Errorf("%w: usage: watch [-tick 200ms] [-policy rr] [-quantum 10] processes.csv", ErrInvalidArgs)
+	}
+	policy, err := PolicyByName(*policyName, *quantum)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("%v: error opening scheduling file", err)
+	}
+	defer func() { _ = f.Close() }()
+	workload, err := ReadWorkload(f)
+	if err != nil {
+		return err
+	}
+
+	events, err := SimulateStream(context.Background(), workload, policy, WithClock(&WallClock{Tick: *tick}))
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(w, "%s, one tick every %v\n", policyTitle(policy), *tick)
+	for ev := range events {
+		_, _ = fmt.Fprintln(w, describeEvent(ev))
+	}
+	return nil
+}
+
+// describeEvent renders ev as a line of the watch output.
+func describeEvent(ev Event) string {
+	switch ev := ev.(type) {
+	case ArriveEvent:
+		return fmt.Sprintf("t=%d: process %d arrives", ev.Time, ev.PID)
+	case DispatchEvent:
+		return fmt.Sprintf("t=%d: process %d runs", ev.Time, ev.PID)
+	case PreemptEvent:
+		return fmt.Sprintf("t=%d: process %d is preempted", ev.Time, ev.PID)
+	case CompleteEvent:
+		return fmt.Sprintf("t=%d: process %d completes", ev.Time, ev.PID)
+	case IOStartEvent:
+		return fmt.Sprintf("t=%d: process %d does I/O until t=%d", ev.Time, ev.PID, ev.Stop)
+	case IOWakeEvent:
+		return fmt.Sprintf("t=%d: process %d finishes its I/O", ev.Time, ev.PID)
+	case IdleEvent:
+		return fmt.Sprintf("t=%d: idle until t=%d", ev.Start, ev.Stop)
+	}
+	return fmt.Sprintf("t=%d: %v", ev.At(), ev)
+}
+
+//endregion
@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_WallClock(t *testing.T) {
+	t.Parallel()
+	w, err := ReadWorkload(strings.NewReader("1,2,0,1\n2,1,5,1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		wantErr error
+		// minElapsed is how long the run must take at least.
+		minElapsed time.Duration
+	}{
+		// Ticks 0 to 5 are paced, including the idle gap before the second arrival.
+		{name: "paced", timeout: time.Minute, minElapsed: 5 * 10 * time.Millisecond},
+		{name: "cancelled", timeout: 15 * time.Millisecond, wantErr: context.DeadlineExceeded},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+			start := time.Now()
+			_, err := Simulate(ctx, w, FCFSPolicy{}, WithClock(&WallClock{Tick: 10 * time.Millisecond}))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Simulate() error = %v, want %v", err, tt.wantErr)
+			}
+			if elapsed := time.Since(start); elapsed < tt.minElapsed {
+				t.Errorf("run took %v, want at least %v", elapsed, tt.minElapsed)
+			}
+		})
+	}
+}
+
+func Test_runWatch(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "w.csv")
+	if err := os.WriteFile(path, []byte("1,2,0,1\n2,1,1,1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := runWatch(&out, "-tick", "1ms", "-policy", "fcfs", path); err != nil {
+		t.Fatal(err)
+	}
+	want := `First-come, first-serve, one tick every 1ms
+t=0: process 1 arrives
+t=0: process 1 runs
+t=1: process 2 arrives
+t=2: process 1 completes
+t=2: process 2 runs
+t=3: process 2 completes
+`
+	if out.String() != want {
+		t.Errorf("runWatch() = %q, want %q", out.String(), want)
+	}
+}
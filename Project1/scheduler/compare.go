@@ -0,0 +1,208 @@
+package scheduler
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+//region Baseline comparison
+
+// ErrRegressed is returned by compare when a metric got worse than the baseline allows.
+var ErrRegressed = errors.New("metrics regressed")
+
+// tolerance is how far a metric may move in the worse direction before it
+// counts as a regression: Value itself, or Value percent of the baseline.
+type tolerance struct {
+	Value   float64
+	Percent bool
+}
+
+func (t tolerance) allows(baseline, change float64) bool {
+	limit := t.Value
+	if t.Percent {
+		limit = math.Abs(baseline) * t.Value / 100
+	}
+	return change <= limit+1e-9
+}
+
+func (t tolerance) String() string {
+	if t.Percent {
+		return strconv.FormatFloat(t.Value, 'f', -1, 64) + "%"
+	}
+	return strconv.FormatFloat(t.Value, 'f', -1, 64)
+}
+
+// parseTolerances parses a comma separated list of per-metric tolerances
+// like "avg_wait=0.5,throughput=5%".
+func parseTolerances(spec string) (map[string]tolerance, error) {
+	tolerances := make(map[string]tolerance)
+	for _, f := range strings.Split(spec, ",") {
+		if f = strings.TrimSpace(f); f == "" {
+			continue
+		}
+		kv := strings.SplitN(f, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("%w: want <metric>=<tolerance>, got %q", ErrInvalidArgs, f)
+		}
+		name := strings.TrimSpace(kv[0])
+		if _, ok := reportMetrics[name]; !ok {
+			return nil, fmt.Errorf("%w: unknown metric %q", ErrInvalidArgs, name)
+		}
+		text := strings.TrimSpace(kv[1])
+		t := tolerance{Percent: strings.HasSuffix(text, "%")}
+		v, err := strconv.ParseFloat(strings.TrimSuffix(text, "%"), 64)
+		if err != nil || v < 0 {
+			return nil, fmt.Errorf("%w: bad tolerance %q", ErrInvalidArgs, kv[1])
+		}
+		t.Value = v
+		tolerances[name] = t
+	}
+	return tolerances, nil
+}
+
+// metricChange is one metric of one algorithm compared with its baseline.
+type metricChange struct {
+	Algorithm     string
+	Metric        string
+	Baseline, Got float64
+	Tolerance     tolerance
+	Missing       bool
+	Regressed     bool
+	Improved      bool
+}
+
+// compareBaseline compares every metric of every baseline report with the
+// report of the same title in current. Moves in the worse direction beyond
+// the metric's tolerance, or def for metrics without one, are regressions,
+// as is a baseline algorithm missing from current. The process count has
+// no better direction, so any change to it is a regression.
+func compareBaseline(baseline, current []Report, def tolerance, tolerances map[string]tolerance) []metricChange {
+	names := make([]string, 0, len(reportMetrics))
+	for name := range reportMetrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var changes []metricChange
+	for _, want := range baseline {
+		got, found := findReport(current, want.Title)
+		if !found {
+			changes = append(changes, metricChange{Algorithm: want.Title, Missing: true, Regressed: true})
+			continue
+		}
+		for _, name := range names {
+			c := metricChange{
+				Algorithm: want.Title,
+				Metric:    name,
+				Baseline:  reportMetrics[name](want),
+				Got:       reportMetrics[name](got),
+				Tolerance: def,
+			}
+			if t, ok := tolerances[name]; ok {
+				c.Tolerance = t
+			}
+			worse := c.Got - c.Baseline
+			if maximizedMetrics[name] {
+				worse = -worse
+			}
+			if name == "processes" {
+				worse = math.Abs(worse)
+			}
+			c.Regressed = !c.Tolerance.allows(c.Baseline, worse)
+			c.Improved = worse < 0 && !c.Tolerance.allows(c.Baseline, -worse)
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// runCompare implements the compare subcommand:
+//
+//	compare -baseline results.json [-quantum 10] [-tolerance 0.01]
+//	        [-tolerances avg_wait=0.5,throughput=5%] processes.csv
+//
+// It reruns the schedulers on the workload and compares every summary
+// metric with the baseline, as written by -json, failing if any got
+// worse by more than its tolerance. It guards refactors of the scheduling
+// engine against changing results by accident.
+func runCompare(w io.Writer, args ...string) error {
+	fs := flag.NewFlagSet("compare", flag.ContinueOnError)
+	fs.SetOutput(w)
+	baselinePath := fs.String("baseline", "", "JSON results, as written by -json, to compare with")
+	quantum := fs.Int64("quantum", DefaultQuantum, "round-robin time quantum the baseline was run with")
+	def := fs.Float64("tolerance", 0.01, "allowed worsening of metrics without their own tolerance")
+	spec := fs.String("tolerances", "", "comma separated per-metric tolerances like \"avg_wait=0.5,throughput=5%\"; a % suffix makes them relative to the baseline")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	if *baselinePath == "" || fs.NArg() != 1 || *def < 0 {
+		return fmt.Errorf("%w: usage: compare -baseline results.json [-tolerance 0.01] [-tolerances avg_wait=0.5] processes.csv", ErrInvalidArgs)
+	}
+	tolerances, err := parseTolerances(*spec)
+	if err != nil {
+		return err
+	}
+	baseline, err := readReports(*baselinePath)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("%v: error opening scheduling file", err)
+	}
+	defer func() { _ = f.Close() }()
+	processes, err := loadProcesses(f)
+	if err != nil {
+		return err
+	}
+	if err := CheckProcesses(processes); err != nil {
+		return err
+	}
+
+	changes := compareBaseline(baseline, RunSchedulers(processes, *quantum), tolerance{Value: *def}, tolerances)
+	regressions := outputComparison(w, changes)
+	if regressions > 0 {
+		return fmt.Errorf("%w: %d against %s", ErrRegressed, regressions, *baselinePath)
+	}
+	return nil
+}
+
+// outputComparison lists the metrics that changed and returns how many regressed.
+func outputComparison(w io.Writer, changes []metricChange) int {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Metric", "Baseline", "Now", "Tolerance", "Verdict"})
+	regressions, improved, unchanged := 0, 0, 0
+	for _, c := range changes {
+		if c.Regressed {
+			regressions++
+		}
+		switch {
+		case c.Missing:
+			table.Append([]string{c.Algorithm, "", "", "", "", "missing"})
+		case c.Regressed:
+			table.Append([]string{c.Algorithm, c.Metric, fmt.Sprintf("%.4g", c.Baseline), fmt.Sprintf("%.4g", c.Got), c.Tolerance.String(), "REGRESSED"})
+		case c.Improved:
+			improved++
+			table.Append([]string{c.Algorithm, c.Metric, fmt.Sprintf("%.4g", c.Baseline), fmt.Sprintf("%.4g", c.Got), c.Tolerance.String(), "improved"})
+		default:
+			unchanged++
+		}
+	}
+	if table.NumLines() > 0 {
+		table.Render()
+	}
+	_, _ = fmt.Fprintf(w, "Compared with the baseline: %d metrics within tolerance, %d improved, %d regressed\n", unchanged, improved, regressions)
+	return regressions
+}
+
+//endregion
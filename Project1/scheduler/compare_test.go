@@ -0,0 +1,120 @@
+package scheduler
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseTolerances(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		spec    string
+		want    map[string]tolerance
+		wantErr bool
+	}{
+		{name: "empty", spec: "", want: map[string]tolerance{}},
+		{
+			name: "absolute and relative",
+			spec: "avg_wait=0.5, throughput=5%",
+			want: map[string]tolerance{"avg_wait": {Value: 0.5}, "throughput": {Value: 5, Percent: true}},
+		},
+		{name: "unknown metric", spec: "speed=1", wantErr: true},
+		{name: "negative", spec: "avg_wait=-1", wantErr: true},
+		{name: "malformed", spec: "avg_wait", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseTolerances(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseTolerances() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseTolerances() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_compareBaseline(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 8}, {ProcessID: 2, ArrivalTime: 2, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 4},
+	}
+	baseline := []Report{simulate("Policy", processes, FCFSPolicy{})}
+	// SJF over the same workload waits less, so it improves on the FCFS
+	// baseline without switching more.
+	better := []Report{simulate("policy", processes, SJFPolicy{})}
+	// Round-robin switches more.
+	switchier := []Report{simulate("Policy", processes, RRPolicy{Quantum: 1})}
+
+	regressed := func(changes []metricChange) []string {
+		var names []string
+		for _, c := range changes {
+			if c.Regressed {
+				names = append(names, c.Algorithm+" "+c.Metric)
+			}
+		}
+		return names
+	}
+	tests := []struct {
+		name       string
+		current    []Report
+		tolerances map[string]tolerance
+		want       []string
+	}{
+		{name: "unchanged", current: baseline},
+		{name: "better", current: better},
+		{name: "worse", current: switchier, want: []string{"Policy switches"}},
+		{name: "within tolerance", current: switchier, tolerances: map[string]tolerance{"switches": {Value: 1000, Percent: true}}},
+		{name: "missing", current: nil, want: []string{"Policy "}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := regressed(compareBaseline(baseline, tt.current, tolerance{Value: 0.01}, tt.tolerances))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("compareBaseline() regressed %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_runCompare(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	workload := filepath.Join(dir, "processes.csv")
+	if err := os.WriteFile(workload, []byte("1,8,0,1\n2,2,1,2\n3,4,2,3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(workload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	processes, err := loadProcesses(f)
+	_ = f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseline := filepath.Join(dir, "results.json")
+	if err := writeReports(baseline, RunSchedulers(processes, 2)); err != nil {
+		t.Fatal(err)
+	}
+
+	var b strings.Builder
+	if err := runCompare(&b, "-baseline", baseline, "-quantum", "2", workload); err != nil {
+		t.Errorf("runCompare() against its own results = %v\n%s", err, b.String())
+	}
+	b.Reset()
+	if err := runCompare(&b, "-baseline", baseline, "-quantum", "1", workload); !errors.Is(err, ErrRegressed) {
+		t.Errorf("runCompare() with another quantum = %v, want ErrRegressed\n%s", err, b.String())
+	}
+}
@@ -0,0 +1,122 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+//region Config profiles
+
+// defaultConfigPath is where profiles are read from unless -config says otherwise.
+const defaultConfigPath = "scheduler.json"
+
+type (
+	// Config is the config file: a set of named profiles.
+	Config struct {
+		Profiles map[string]Profile `json:"profiles"`
+	}
+
+	// Profile is a recurring experiment: the workload to load, which
+	// algorithms to report, and the flags to run with, such as parameters
+	// like "mpl" or outputs like "json". Flags given on the command line
+	// override the profile's.
+	Profile struct {
+		Workload string `json:"workload"`
+		// Algorithms are the reports to keep, by slugified title prefix
+		// (e.g. "round-robin"); empty keeps them all.
+		Algorithms []string          `json:"algorithms,omitempty"`
+		Flags      map[string]string `json:"flags,omitempty"`
+	}
+)
+
+func loadConfig(path string) (Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("%w: reading config", err)
+	}
+	var c Config
+	if err := json.Unmarshal(b, &c); err != nil {
+		return Config{}, fmt.Errorf("%w: parsing config %s", err, path)
+	}
+	return c, nil
+}
+
+// profile returns the profile called name.
+func (c Config) profile(name string) (Profile, error) {
+	p, ok := c.Profiles[name]
+	if !ok {
+		names := make([]string, 0, len(c.Profiles))
+		for n := range c.Profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return Profile{}, fmt.Errorf("%w: no profile %q (have %s)", ErrInvalidArgs, name, strings.Join(names, ", "))
+	}
+	return p, nil
+}
+
+// apply sets the profile's flags on fs, skipping those set on the command line.
+func (p Profile) apply(fs *flag.FlagSet) error {
+	return setDefaults(fs, p.Flags, "profile flag")
+}
+
+// applyMetadata sets the flags named by a workload's metadata on fs,
+// skipping those already set on the command line or by a profile. Other
+// keys are reported and ignored.
+func applyMetadata(fs *flag.FlagSet, metadata map[string]string) error {
+	known := make(map[string]string)
+	for name, v := range metadata {
+		if fs.Lookup(name) == nil {
+			log.Printf("ignoring unknown workload setting %q", name)
+			continue
+		}
+		known[name] = v
+	}
+	return setDefaults(fs, known, "workload setting")
+}
+
+// setDefaults sets each of values on fs unless that flag is already set.
+func setDefaults(fs *flag.FlagSet, values map[string]string, what string) error {
+	explicit := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if explicit[name] {
+			continue
+		}
+		if err := fs.Set(name, values[name]); err != nil {
+			return fmt.Errorf("%w: %s %s: %v", ErrInvalidArgs, what, name, err)
+		}
+	}
+	return nil
+}
+
+// selectReports keeps the reports the profile asks for.
+func (p Profile) selectReports(reports []Report) []Report {
+	if len(p.Algorithms) == 0 {
+		return reports
+	}
+	var kept []Report
+	for _, r := range reports {
+		slug := slugify(r.Title)
+		for _, a := range p.Algorithms {
+			if strings.HasPrefix(slug, slugify(a)) {
+				kept = append(kept, r)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+//endregion
@@ -0,0 +1,76 @@
+package scheduler
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_Profile(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "scheduler.json")
+	config := `{"profiles": {"lab3": {
+		"workload": "lab3.csv",
+		"algorithms": ["Round-robin", "shortest-job-first"],
+		"flags": {"mpl": "2", "json": "lab3.json"}
+	}}}`
+	if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	c, err := loadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.profile("lab4"); err == nil {
+		t.Error("profile(lab4) succeeded, want an error")
+	}
+	p, err := c.profile("lab3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	mpl := fs.Int("mpl", 0, "")
+	jsonPath := fs.String("json", "", "")
+	if err := fs.Parse([]string{"-mpl", "3"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.apply(fs); err != nil {
+		t.Fatal(err)
+	}
+	if *mpl != 3 || *jsonPath != "lab3.json" {
+		t.Errorf("after apply, mpl = %d, json = %q; want the command line's 3 and the profile's lab3.json", *mpl, *jsonPath)
+	}
+
+	reports := p.selectReports([]Report{
+		{Title: "First-come, first-serve"},
+		{Title: "Shortest-job-first"},
+		{Title: "Round-robin (two-level, MPL 3)"},
+	})
+	if len(reports) != 2 || reports[0].Title != "Shortest-job-first" || reports[1].Title != "Round-robin (two-level, MPL 3)" {
+		t.Errorf("selectReports() = %v", reports)
+	}
+}
+
+func Test_applyMetadata(t *testing.T) {
+	t.Parallel()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	quantum := fs.Int64("quantum", 10, "")
+	mpl := fs.Int("mpl", 0, "")
+	unit := fs.String("unit", "", "")
+	if err := fs.Parse([]string{"-quantum", "3"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := applyMetadata(fs, map[string]string{"quantum": "4", "mpl": "2", "unit": "ms"}); err != nil {
+		t.Fatal(err)
+	}
+	if *quantum != 3 || *mpl != 2 || *unit != "ms" {
+		t.Errorf("quantum = %d, mpl = %d, unit = %q; want the command line's 3 and the workload's 2 and ms", *quantum, *mpl, *unit)
+	}
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Int("mpl", 0, "")
+	if err := applyMetadata(fs, map[string]string{"mpl": "two"}); err == nil {
+		t.Error("applyMetadata(mpl=two) succeeded, want an error")
+	}
+}
@@ -0,0 +1,124 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+//region Convoy detection
+
+const (
+	// convoyRatio is how many times longer than a process's burst a job must
+	// run uninterrupted to count as holding it up.
+	convoyRatio = 3
+	// convoyMin is how many short processes a long job must hold up at once
+	// to form a convoy.
+	convoyMin = 2
+)
+
+// Convoy is a long job running while several much shorter processes wait
+// behind it.
+type Convoy struct {
+	PID         int64
+	Burst       int64
+	Start, Stop int64
+	// Delayed are the short processes that waited while it ran.
+	Delayed []int64
+	// AddedWait is how long they waited, in total, while it ran.
+	AddedWait int64
+}
+
+// detectConvoys finds the convoys in r: every slice in which a process ran
+// while at least convoyMin processes with a burst convoyRatio times shorter
+// than the slice were waiting. Slices rather than bursts are compared, so a
+// long job that is preempted often enough forms no convoy.
+func detectConvoys(r Report) []Convoy {
+	bursts := make(map[int64]int64)
+	for _, row := range r.Rows {
+		bursts[row.ProcessID] = row.Burst
+	}
+
+	var convoys []Convoy
+	for _, s := range r.Gantt {
+		c := Convoy{PID: s.PID, Burst: bursts[s.PID], Start: s.Start, Stop: s.Stop}
+		for _, row := range r.Rows {
+			if row.ProcessID == s.PID || row.Burst*convoyRatio > s.Stop-s.Start {
+				continue
+			}
+			from, to := row.Arrival, row.Exit
+			if from < s.Start {
+				from = s.Start
+			}
+			if to > s.Stop {
+				to = s.Stop
+			}
+			if to > from {
+				c.Delayed = append(c.Delayed, row.ProcessID)
+				c.AddedWait += to - from
+			}
+		}
+		if len(c.Delayed) >= convoyMin {
+			convoys = append(convoys, c)
+		}
+	}
+	return convoys
+}
+
+// outputConvoys lists the convoys in each report, and flags the workload
+// when first-come, first-served suffers from them.
+func outputConvoys(w io.Writer, reports []Report) {
+	_, _ = fmt.Fprintf(w, "Convoys (a job running while %d or more jobs at least %d times shorter than its run wait)\n", convoyMin, convoyRatio)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Long process", "Burst", "Ran", "Delayed", "Added wait"})
+	found := make(map[string]int64)
+	for _, r := range reports {
+		for i, c := range detectConvoys(r) {
+			title := r.Title
+			if i > 0 {
+				title = ""
+			}
+			table.Append([]string{
+				title,
+				fmt.Sprint(c.PID),
+				fmt.Sprint(c.Burst),
+				fmt.Sprintf("%d-%d", c.Start, c.Stop),
+				formatIDs(c.Delayed),
+				fmt.Sprint(c.AddedWait),
+			})
+			found[r.Title] += c.AddedWait
+		}
+	}
+	table.Render()
+
+	if len(reports) == 0 {
+		return
+	}
+	fcfs := reports[0]
+	if found[fcfs.Title] == 0 {
+		_, _ = fmt.Fprintf(w, "No convoys under %s\n", fcfs.Title)
+		return
+	}
+	_, _ = fmt.Fprintf(w, "Convoys add %d ticks of wait under %s: the workload may be better served by", found[fcfs.Title], fcfs.Title)
+	for i, r := range reports[1:] {
+		sep := ","
+		if i == 0 {
+			sep = ""
+		}
+		_, _ = fmt.Fprintf(w, "%s %s (average wait %.2f against %.2f)", sep, r.Title, r.Wait, fcfs.Wait)
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// convoyReports runs first-come, first-served, shortest-job-first and
+// round-robin over processes to look for convoys in.
+func convoyReports(processes []Process, quantum int64) []Report {
+	var reports []Report
+	for _, p := range []Policy{FCFSPolicy{}, SJFPolicy{}, RRPolicy{Quantum: quantum}} {
+		reports = append(reports, simulate(policyTitle(p), processes, p))
+	}
+	return reports
+}
+
+//endregion
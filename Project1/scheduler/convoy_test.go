@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_detectConvoys(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		policy    Policy
+		want      []Convoy
+	}{
+		{
+			name:      "long job first",
+			processes: []Process{{ProcessID: 1, BurstDuration: 12}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 2}, {ProcessID: 3, ArrivalTime: 2, BurstDuration: 3}},
+			policy:    FCFSPolicy{},
+			want:      []Convoy{{PID: 1, Burst: 12, Start: 0, Stop: 12, Delayed: []int64{2, 3}, AddedWait: 21}},
+		},
+		{
+			name:      "short jobs first",
+			processes: []Process{{ProcessID: 1, ArrivalTime: 1, BurstDuration: 12}, {ProcessID: 2, BurstDuration: 2}, {ProcessID: 3, BurstDuration: 3}},
+			policy:    FCFSPolicy{},
+		},
+		{
+			name:      "one short job",
+			processes: []Process{{ProcessID: 1, BurstDuration: 12}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 2}},
+			policy:    FCFSPolicy{},
+		},
+		{
+			name:      "not much shorter",
+			processes: []Process{{ProcessID: 1, BurstDuration: 12}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 5}, {ProcessID: 3, ArrivalTime: 2, BurstDuration: 5}},
+			policy:    FCFSPolicy{},
+		},
+		{
+			name:      "round-robin breaks it up",
+			processes: []Process{{ProcessID: 1, BurstDuration: 12}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 2}, {ProcessID: 3, ArrivalTime: 2, BurstDuration: 3}},
+			policy:    RRPolicy{Quantum: 1},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := simulate("", tt.processes, tt.policy)
+			if got := detectConvoys(r); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("detectConvoys() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_outputConvoys(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, BurstDuration: 12}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 2}, {ProcessID: 3, ArrivalTime: 2, BurstDuration: 3}}
+	var b strings.Builder
+	outputConvoys(&b, convoyReports(processes, 2))
+	if !strings.Contains(b.String(), "Convoys add 21 ticks of wait under First-come") {
+		t.Errorf("outputConvoys() = %q, want FCFS flagged", b.String())
+	}
+
+	b.Reset()
+	outputConvoys(&b, convoyReports(processes[1:], 2))
+	if !strings.Contains(b.String(), "No convoys under First-come") {
+		t.Errorf("outputConvoys() = %q, want no convoys", b.String())
+	}
+}
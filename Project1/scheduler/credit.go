@@ -0,0 +1,168 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+//region Credit scheduler
+
+// The states of a task under the credit scheduler, most urgent first.
+const (
+	creditBoost = iota
+	creditUnder
+	creditOver
+)
+
+// creditStates names the credit scheduler's states.
+var creditStates = [...]string{creditBoost: "BOOST", creditUnder: "UNDER", creditOver: "OVER"}
+
+// CreditPolicy is modelled on the Xen hypervisor's credit scheduler. Every
+// Period ticks an accounting event shares Period credits among the active
+// tasks in proportion to their weights, and the running task burns a credit
+// for every tick it runs. Credits are capped at one period's worth either
+// way, so a task can't bank an idle spell. A task with credit left is
+// UNDER, one that has overspent is OVER, and UNDER tasks run before OVER
+// ones, round-robin with Slice. A task waking from I/O while UNDER is
+// BOOSTed ahead of both, preempting the running task, until it has run a
+// tick.
+type CreditPolicy struct {
+	Period int64
+	Slice  int64
+	credit map[*Task]int64
+	boost  map[*Task]bool
+	// runnable are the tasks running or ready at the last pick, to tell
+	// tasks waking from I/O.
+	runnable map[*Task]bool
+	// tick is charged at the next pick or accounting.
+	tick tickCharger
+	// earned, over and boosts are each task's credits earned, ticks run
+	// while OVER, and boosts.
+	earned, over map[*Task]int64
+	boosts       map[*Task]int
+	accounts     []string
+}
+
+func (p *CreditPolicy) init() {
+	if p.credit == nil {
+		p.credit, p.boost, p.runnable = make(map[*Task]int64), make(map[*Task]bool), make(map[*Task]bool)
+		p.earned, p.over, p.boosts = make(map[*Task]int64), make(map[*Task]int64), make(map[*Task]int)
+	}
+}
+
+// state is t's credit scheduler state.
+func (p *CreditPolicy) state(t *Task) int {
+	switch {
+	case p.boost[t]:
+		return creditBoost
+	case p.credit[t] < 0:
+		return creditOver
+	}
+	return creditUnder
+}
+
+// settle charges t, if any, for the tick it was picked for, ending its boost.
+func (p *CreditPolicy) settle(t *Task) {
+	if t != nil {
+		p.credit[t]--
+		p.boost[t] = false
+	}
+}
+
+func (p *CreditPolicy) period() int64 { return p.Period }
+
+// account shares Period credits among the active tasks by weight.
+func (p *CreditPolicy) account(now int64, active []*Task) {
+	p.init()
+	p.settle(p.tick.due(now))
+	var total int64
+	for _, t := range active {
+		total += t.weight()
+	}
+	var credits []string
+	for _, t := range active {
+		share := p.Period * t.weight() / total
+		p.earned[t] += share
+		c := p.credit[t] + share
+		if c > p.Period {
+			c = p.Period
+		} else if c < -p.Period {
+			c = -p.Period
+		}
+		p.credit[t] = c
+		credits = append(credits, fmt.Sprintf("%d %d %s", t.ProcessID, c, creditStates[p.state(t)]))
+	}
+	if len(credits) > 0 {
+		p.accounts = append(p.accounts, fmt.Sprintf("t=%d: %s", now, strings.Join(credits, ", ")))
+	}
+}
+
+func (p *CreditPolicy) Pick(now int64, running *Task, ready []*Task) *Task {
+	p.init()
+	p.settle(p.tick.due(now))
+	for _, t := range ready {
+		if !p.runnable[t] && t.FirstRun >= 0 && p.credit[t] >= 0 {
+			p.boost[t] = true
+			p.boosts[t]++
+		}
+	}
+	p.runnable = make(map[*Task]bool, len(ready)+1)
+	for _, t := range ready {
+		p.runnable[t] = true
+	}
+
+	best := minTask(ready, func(a, b *Task) bool { return p.state(a) < p.state(b) })
+	pick := best
+	if running != nil {
+		p.runnable[running] = true
+		switch {
+		case best == nil || p.state(best) > p.state(running):
+			pick = running
+		case p.state(best) == p.state(running) && (p.Slice <= 0 || running.Slice%p.Slice != 0):
+			pick = running
+		}
+	}
+	if pick != nil {
+		if p.state(pick) == creditOver {
+			p.over[pick]++
+		}
+		p.tick.picked(now, pick)
+	}
+	return pick
+}
+
+// annotate adds each task's weight, the credits it earned, its credit at
+// the end, the ticks it ran OVER and how often it was boosted, and lists
+// the credits after each accounting.
+func (p *CreditPolicy) annotate(r *Report, tasks []*Task) {
+	p.init()
+	p.settle(p.tick.flush())
+	weight, earned, credit := Column{Header: "Weight"}, Column{Header: "Credits earned"}, Column{Header: "Final credit"}
+	over, boosts := Column{Header: "Ran OVER"}, Column{Header: "Boosts"}
+	for _, t := range tasks {
+		weight.Values = append(weight.Values, fmt.Sprint(t.weight()))
+		earned.Values = append(earned.Values, fmt.Sprint(p.earned[t]))
+		credit.Values = append(credit.Values, fmt.Sprint(p.credit[t]))
+		over.Values = append(over.Values, fmt.Sprint(p.over[t]))
+		boosts.Values = append(boosts.Values, fmt.Sprint(p.boosts[t]))
+	}
+	r.Columns = append(r.Columns, weight, earned, credit, over, boosts)
+	if len(p.accounts) > 0 {
+		r.Notes = append(r.Notes, "Credits after accounting: "+strings.Join(p.accounts, "; "))
+	}
+}
+
+// CreditSchedule outputs the credit scheduler's schedule of processes as a
+// Gantt chart and a table of timing.
+func CreditSchedule(w io.Writer, title string, processes []Process, slice, period int64, opts ...Option) {
+	outputReport(w, Credit(title, processes, slice, period, opts...))
+}
+
+// Credit schedules processes with the credit scheduler, sharing credits by
+// the weight column every period ticks and round-robin with slice.
+func Credit(title string, processes []Process, slice, period int64, opts ...Option) Report {
+	return simulate(title, processes, &CreditPolicy{Period: period, Slice: slice}, opts...)
+}
+
+//endregion
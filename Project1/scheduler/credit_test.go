@@ -0,0 +1,120 @@
+package scheduler
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCredit(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		slice     int64
+		period    int64
+		wantGantt []TimeSlice
+		// wantColumns are the weight, credits earned, final credit, ran
+		// OVER and boosts columns.
+		wantColumns [][]string
+		wantNotes   []string
+	}{
+		{
+			name: "credits shared by weight",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 40, Weight: 200}, {ProcessID: 2, BurstDuration: 40},
+				{ProcessID: 3, ArrivalTime: 5, BurstDuration: 10},
+			},
+			slice:  10,
+			period: 30,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 10}, {PID: 2, Start: 10, Stop: 20}, {PID: 3, Start: 20, Stop: 21},
+				{PID: 1, Start: 21, Stop: 31}, {PID: 2, Start: 31, Stop: 39}, {PID: 3, Start: 39, Stop: 46},
+				{PID: 1, Start: 46, Stop: 66}, {PID: 2, Start: 66, Stop: 73}, {PID: 3, Start: 73, Stop: 75},
+				{PID: 2, Start: 75, Stop: 90},
+			},
+			wantColumns: [][]string{{"200", "100", "100"}, {"50", "24", "14"}, {"10", "-16", "4"}, {"0", "15", "0"}, {"0", "0", "0"}},
+			wantNotes: []string{"Credits after accounting: t=0: 1 20 UNDER, 2 10 UNDER; " +
+				"t=30: 1 16 UNDER, 2 7 UNDER, 3 6 UNDER; t=60: 1 16 UNDER, 2 6 UNDER, 3 6 UNDER"},
+		},
+		{
+			name:      "an UNDER arrival preempts an OVER task",
+			processes: []Process{{ProcessID: 1, ArrivalTime: 50, BurstDuration: 20}, {ProcessID: 2, ArrivalTime: 52, BurstDuration: 5}},
+			slice:     5,
+			period:    30,
+			wantGantt: []TimeSlice{{PID: 1, Start: 50, Stop: 52}, {PID: 2, Start: 52, Stop: 57}, {PID: 1, Start: 57, Stop: 75}},
+			// Nothing is active at the accountings at 0 and 30, so both
+			// overspend until the one at 60.
+			wantColumns: [][]string{{"100", "100"}, {"30", "0"}, {"10", "-5"}, {"4", "4"}, {"0", "0"}},
+			wantNotes:   []string{"Credits after accounting: t=60: 1 25 UNDER"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := Credit("Credit scheduler", tt.processes, tt.slice, tt.period)
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("Credit() Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			var columns [][]string
+			for _, c := range r.Columns {
+				columns = append(columns, c.Values)
+			}
+			if !reflect.DeepEqual(columns, tt.wantColumns) {
+				t.Errorf("Credit() columns = %q, want %q", columns, tt.wantColumns)
+			}
+			if !reflect.DeepEqual(r.Notes, tt.wantNotes) {
+				t.Errorf("Credit() notes = %q, want %q", r.Notes, tt.wantNotes)
+			}
+		})
+	}
+}
+
+func TestCreditPolicy_boost(t *testing.T) {
+	t.Parallel()
+	p := &CreditPolicy{Period: 30, Slice: 10}
+	a, b := &Task{Process: Process{ProcessID: 1}, FirstRun: -1}, &Task{Process: Process{ProcessID: 2}, FirstRun: -1}
+	p.account(0, []*Task{a, b})
+	if got := p.Pick(0, nil, []*Task{a, b}); got != a {
+		t.Fatalf("Pick() = %v, want the head of the queue", got)
+	}
+	a.FirstRun, a.Slice = 0, 1
+	if got := p.Pick(1, a, []*Task{b}); got != a {
+		t.Fatalf("Pick() mid-slice = %v, want the running task kept", got)
+	}
+	// a blocks for I/O; b runs, then a wakes UNDER and is boosted.
+	b.FirstRun, b.Slice = 2, 1
+	if got := p.Pick(2, nil, []*Task{b}); got != b {
+		t.Fatalf("Pick() = %v, want 2", got)
+	}
+	if got := p.Pick(3, b, []*Task{a}); got != a || p.boosts[a] != 1 {
+		t.Fatalf("Pick() on waking = %v with %d boosts, want 1 boosted", got, p.boosts[a])
+	}
+	a.Slice = 1
+	if got := p.Pick(4, a, []*Task{b}); got != a || p.state(a) != creditUnder {
+		t.Errorf("Pick() after a boosted tick = %v in %s, want 1 kept UNDER", got, creditStates[p.state(a)])
+	}
+}
+
+func TestCreditWithIO(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, BurstDuration: 40, Weight: 200}, {ProcessID: 2, BurstDuration: 40}, {ProcessID: 3, ArrivalTime: 5, BurstDuration: 10}}
+	r := Credit("Credit scheduler", processes, 3, 10, WithRandomIO(0.2, 3, 5))
+	boosts := r.Columns[len(r.Columns)-1]
+	if boosts.Header != "Boosts" || reflect.DeepEqual(boosts.Values, []string{"0", "0", "0"}) {
+		t.Errorf("Credit() with I/O boosts = %v, want tasks waking from I/O boosted", boosts)
+	}
+}
+
+func TestCreditSchedule(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	CreditSchedule(&w, "Credit scheduler, slice 1, accounting every 2", []Process{{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, BurstDuration: 1}}, 1, 2)
+	for _, want := range []string{"Credit scheduler, slice 1, accounting every 2", "CREDITS EARNED", "Credits after accounting: t=0: 1 1 UNDER, 2 1 UNDER"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("CreditSchedule() = %q, want it to contain %q", w.String(), want)
+		}
+	}
+}
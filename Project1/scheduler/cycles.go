@@ -0,0 +1,159 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+)
+
+//region CPU–I/O burst cycles
+
+// isBurstCycle reports whether the burst column s is a burst cycle like
+// "cpu:5,io:3,cpu:4" rather than a single CPU burst.
+func isBurstCycle(s string) bool {
+	return strings.Contains(s, ":")
+}
+
+// burstCycleFields splits a burst cycle into its "kind:time" bursts, which
+// may be separated by commas, semicolons or spaces.
+func burstCycleFields(s string) []string {
+	return strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ';' || r == ' ' })
+}
+
+// burstCycleTimes returns the times in the burst cycle s as written, for
+// picking the scale.
+func burstCycleTimes(s string) []string {
+	var times []string
+	for _, f := range burstCycleFields(s) {
+		if i := strings.IndexByte(f, ':'); i >= 0 {
+			times = append(times, f[i+1:])
+		}
+	}
+	return times
+}
+
+// parseBurstCycle parses a burst cycle of alternating CPU and I/O bursts
+// that starts and ends with a CPU burst, like "cpu:5,io:3,cpu:4", to its
+// times in ticks, scale per unit.
+func parseBurstCycle(s string, scale int64) ([]int64, error) {
+	var bursts []int64
+	for i, f := range burstCycleFields(s) {
+		want := "cpu"
+		if i%2 == 1 {
+			want = "io"
+		}
+		kv := strings.SplitN(f, ":", 2)
+		if len(kv) != 2 || strings.ToLower(strings.TrimSpace(kv[0])) != want {
+			return nil, fmt.Errorf("%w: burst cycle must alternate cpu and io bursts starting with cpu, got %q", ErrInvalidArgs, s)
+		}
+		v, err := parseScaled(kv[1], scale)
+		if err != nil {
+			return nil, fmt.Errorf("%w: burst cycle time %q", err, f)
+		}
+		bursts = append(bursts, v)
+	}
+	if err := checkBurstCycle(bursts); err != nil {
+		return nil, fmt.Errorf("%w, got %q", err, s)
+	}
+	return bursts, nil
+}
+
+// checkBurstCycle checks bursts alternate CPU and I/O, starting and ending
+// with CPU, and are all positive. Empty is a single CPU burst and passes.
+func checkBurstCycle(bursts []int64) error {
+	if len(bursts)%2 == 0 && len(bursts) > 0 {
+		return fmt.Errorf("%w: burst cycle must end with a cpu burst", ErrInvalidArgs)
+	}
+	for _, b := range bursts {
+		if b <= 0 {
+			return fmt.Errorf("%w: burst cycle times must be positive", ErrInvalidArgs)
+		}
+	}
+	return nil
+}
+
+// formatBurstCycle writes bursts the way parseBurstCycle reads them.
+func formatBurstCycle(bursts []int64) string {
+	fields := make([]string, len(bursts))
+	for i, b := range bursts {
+		kind := "cpu"
+		if i%2 == 1 {
+			kind = "io"
+		}
+		fields[i] = fmt.Sprintf("%s:%d", kind, b)
+	}
+	return strings.Join(fields, ",")
+}
+
+// cpuTotal returns the total of the CPU bursts in bursts.
+func cpuTotal(bursts []int64) int64 {
+	var total int64
+	for i := 0; i < len(bursts); i += 2 {
+		total += bursts[i]
+	}
+	return total
+}
+
+// ioAfter returns how long the I/O burst the process starts once it has
+// had done ticks of CPU lasts, or 0 if it isn't at the end of a CPU burst
+// followed by I/O.
+func (p Process) ioAfter(done int64) int64 {
+	var cpu int64
+	for i := 0; i+1 < len(p.Bursts); i += 2 {
+		if cpu += p.Bursts[i]; cpu == done {
+			return p.Bursts[i+1]
+		}
+		if cpu > done {
+			break
+		}
+	}
+	return 0
+}
+
+// addCycleColumns adds each task's time in I/O and response time, and how
+// busy the CPU was while tasks did I/O, if any task has a burst cycle.
+func addCycleColumns(r *Report, tasks []*Task) {
+	col := Column{Header: "I/O"}
+	var total, bursts int64
+	for _, t := range tasks {
+		bursts += int64(len(t.Bursts) / 2)
+	}
+	if bursts == 0 {
+		return
+	}
+	for _, t := range tasks {
+		col.Values = append(col.Values, fmt.Sprint(t.IO))
+		total += t.IO
+	}
+	if len(tasks) > 0 {
+		col.Footer = fmt.Sprintf("Average\n%.2f", float64(total)/float64(len(tasks)))
+	}
+	r.Columns = append(r.Columns, col, responseColumn(tasks))
+	r.Notes = append(r.Notes, fmt.Sprintf("Burst cycles: %d I/O bursts taking %d ticks; CPU utilization %.1f%%",
+		bursts, total, 100*utilization(*r)))
+}
+
+// burstCycleReports runs first-come, first-served, round-robin and a
+// multilevel feedback queue on processes' CPU–I/O burst cycles.
+func burstCycleReports(processes []Process, quantum int64) []Report {
+	var reports []Report
+	for _, p := range []Policy{
+		FCFSPolicy{},
+		RRPolicy{Quantum: quantum},
+		&MLFQPolicy{Config: MLFQConfig{Quanta: []int64{quantum, 2 * quantum, 0}}},
+	} {
+		reports = append(reports, simulate(policyTitle(p)+" with burst cycles", processes, p))
+	}
+	return reports
+}
+
+// hasBurstCycles reports whether any of processes does I/O between CPU bursts.
+func hasBurstCycles(processes []Process) bool {
+	for _, p := range processes {
+		if len(p.Bursts) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+//endregion
@@ -0,0 +1,192 @@
+package scheduler
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseBurstCycle(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in      string
+		scale   int64
+		want    []int64
+		wantErr error
+	}{
+		{in: "cpu:5,io:3,cpu:4", scale: 1, want: []int64{5, 3, 4}},
+		{in: "CPU:1.5; io:2 cpu:1", scale: 10, want: []int64{15, 20, 10}},
+		{in: "cpu:5", scale: 1, want: []int64{5}},
+		{in: "io:3,cpu:4", scale: 1, wantErr: ErrInvalidArgs},
+		{in: "cpu:5,io:3", scale: 1, wantErr: ErrInvalidArgs},
+		{in: "cpu:5,cpu:3,cpu:4", scale: 1, wantErr: ErrInvalidArgs},
+		{in: "cpu:0,io:1,cpu:1", scale: 1, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseBurstCycle(tt.in, tt.scale)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseBurstCycle(%q) error = %v, want %v", tt.in, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseBurstCycle(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+	if _, err := parseBurstCycle("cpu:soon", 1); err == nil {
+		t.Error("parseBurstCycle(cpu:soon) error = nil, want one")
+	}
+}
+
+func TestProcess_ioAfter(t *testing.T) {
+	t.Parallel()
+	p := Process{Bursts: []int64{2, 3, 1, 4, 2}}
+	for done, want := range []int64{0, 0, 3, 4, 0, 0} {
+		if got := p.ioAfter(int64(done)); got != want {
+			t.Errorf("ioAfter(%d) = %d, want %d", done, got, want)
+		}
+	}
+	if got := (Process{BurstDuration: 2}).ioAfter(2); got != 0 {
+		t.Errorf("ioAfter() without a cycle = %d, want 0", got)
+	}
+}
+
+func Test_loadProcessesBurstCycle(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader("1,\"cpu:2,io:3,cpu:1\",0,1\n2,4,0,2\n3,cpu:5,1,3\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var bursts [][]int64
+	var totals []int64
+	for _, p := range processes {
+		bursts = append(bursts, p.Bursts)
+		totals = append(totals, p.BurstDuration)
+	}
+	if want := [][]int64{{2, 3, 1}, nil, nil}; !reflect.DeepEqual(bursts, want) {
+		t.Errorf("loadProcesses() bursts = %v, want %v", bursts, want)
+	}
+	if want := []int64{3, 4, 5}; !reflect.DeepEqual(totals, want) {
+		t.Errorf("loadProcesses() burst durations = %v, want %v", totals, want)
+	}
+
+	var b bytes.Buffer
+	if err := outputProcessesCSV(&b, processes); err != nil {
+		t.Fatal(err)
+	}
+	if want := "1,\"cpu:2,io:3,cpu:1\",0,1\n2,4,0,2\n3,5,1,3\n"; b.String() != want {
+		t.Errorf("outputProcessesCSV() = %q, want %q", b.String(), want)
+	}
+
+	processes, scale, err := loadScaledProcesses(strings.NewReader("1,\"cpu:0.5,io:1,cpu:1\",0\n"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scale != 10 || !reflect.DeepEqual(processes[0].Bursts, []int64{5, 10, 10}) || processes[0].BurstDuration != 15 {
+		t.Errorf("loadScaledProcesses() = %v at scale %d, want bursts [5 10 10] at scale 10", processes, scale)
+	}
+
+	processes, err = loadProcesses(strings.NewReader(`[{"id": 1, "arrival": 0, "bursts": [2, 1, 3]}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(processes[0].Bursts, []int64{2, 1, 3}) || processes[0].BurstDuration != 5 {
+		t.Errorf("loadProcesses(JSON) = %v, want bursts [2 1 3] totalling 5", processes)
+	}
+	if _, err := loadProcesses(strings.NewReader(`[{"id": 1, "bursts": [2, 1]}]`)); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("loadProcesses(JSON) error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
+
+func TestBurstCycles(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 3, Bursts: []int64{1, 3, 1, 3, 1}},
+		{ProcessID: 2, BurstDuration: 8},
+	}
+	tests := []struct {
+		name      string
+		policy    Policy
+		wantGantt []TimeSlice
+		wantIO    []string
+		wantWait  []int64
+		wantNote  string
+	}{
+		{
+			name:   "FCFS",
+			policy: FCFSPolicy{},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 9}, {PID: 1, Start: 9, Stop: 10},
+				{PID: 1, Start: 13, Stop: 14},
+			},
+			wantIO:   []string{"6", "0"},
+			wantWait: []int64{5, 1},
+			wantNote: "Burst cycles: 2 I/O bursts taking 6 ticks; CPU utilization 78.6%",
+		},
+		{
+			name:   "RR",
+			policy: RRPolicy{Quantum: 2},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 5}, {PID: 1, Start: 5, Stop: 6},
+				{PID: 2, Start: 6, Stop: 10}, {PID: 1, Start: 10, Stop: 11},
+			},
+			wantIO:   []string{"6", "0"},
+			wantWait: []int64{2, 2},
+			wantNote: "Burst cycles: 2 I/O bursts taking 6 ticks; CPU utilization 100.0%",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := simulate(tt.name, processes, tt.policy)
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			if len(r.Columns) == 0 || !reflect.DeepEqual(r.Columns[0].Values, tt.wantIO) {
+				t.Errorf("columns = %+v, want I/O %v", r.Columns, tt.wantIO)
+			}
+			var waits []int64
+			for _, row := range r.Rows {
+				waits = append(waits, row.Wait)
+			}
+			if !reflect.DeepEqual(waits, tt.wantWait) {
+				t.Errorf("waits = %v, want %v", waits, tt.wantWait)
+			}
+			if len(r.Notes) == 0 || r.Notes[0] != tt.wantNote {
+				t.Errorf("notes = %q, want %q", r.Notes, tt.wantNote)
+			}
+		})
+	}
+}
+
+func Test_burstCycleReports(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 3, Bursts: []int64{1, 3, 1, 3, 1}},
+		{ProcessID: 2, BurstDuration: 8},
+	}
+	if !hasBurstCycles(processes) || hasBurstCycles(processes[1:]) {
+		t.Error("hasBurstCycles() is wrong")
+	}
+	reports := burstCycleReports(processes, 2)
+	var titles []string
+	for _, r := range reports {
+		titles = append(titles, r.Title)
+	}
+	want := []string{
+		"First-come, first-serve with burst cycles",
+		"Round-robin, quantum 2 with burst cycles",
+		"MLFQ, quanta 2/4/FCFS with burst cycles",
+	}
+	if !reflect.DeepEqual(titles, want) {
+		t.Errorf("titles = %q, want %q", titles, want)
+	}
+	if reports[0].Turnaround <= reports[1].Turnaround {
+		t.Errorf("FCFS turnaround %.2f, want it worse than round-robin's %.2f", reports[0].Turnaround, reports[1].Turnaround)
+	}
+}
@@ -0,0 +1,192 @@
+package scheduler
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+//region Disk scheduling
+
+// DiskSchedule is the order a disk head serves cylinder requests in.
+type DiskSchedule struct {
+	Title string `json:"title"`
+	// Sequence is every cylinder the head visits, starting at its initial
+	// position and including any disk ends it sweeps to.
+	Sequence []int64 `json:"sequence"`
+	// Movement is the total number of cylinders the head travels.
+	Movement int64 `json:"movement"`
+}
+
+// runDisk implements the disk subcommand:
+//
+//	disk -head 53 [-cylinders 200] [-down] requests.csv
+//
+// requests.csv lists the requested cylinders, comma separated, on one or
+// more lines. Every algorithm's seek sequence and total head movement is written to w.
+func runDisk(w io.Writer, args ...string) error {
+	fs := flag.NewFlagSet("disk", flag.ContinueOnError)
+	fs.SetOutput(w)
+	head := fs.Int64("head", 0, "initial head cylinder")
+	cylinders := fs.Int64("cylinders", 200, "number of cylinders on the disk")
+	down := fs.Bool("down", false, "sweep toward cylinder 0 first (SCAN, C-SCAN, LOOK, C-LOOK)")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: usage: disk -head N [-cylinders N] [-down] requests.csv", ErrInvalidArgs)
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("%v: error opening requests file", err)
+	}
+	defer func() { _ = f.Close() }()
+	requests, err := loadDiskRequests(f)
+	if err != nil {
+		return err
+	}
+	for _, c := range append(requests, *head) {
+		if c < 0 || c >= *cylinders {
+			return fmt.Errorf("%w: cylinder %d outside 0-%d", ErrInvalidArgs, c, *cylinders-1)
+		}
+	}
+
+	outputDisk(w, diskSchedules(requests, *head, *cylinders, !*down))
+	return nil
+}
+
+func loadDiskRequests(r io.Reader) ([]int64, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading CSV", err)
+	}
+	var requests []int64
+	for i := range rows {
+		for _, field := range rows[i] {
+			if field = strings.TrimSpace(field); field == "" {
+				continue
+			}
+			c, err := strconv.ParseInt(field, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d", err, i+1)
+			}
+			requests = append(requests, c)
+		}
+	}
+	if len(requests) == 0 {
+		return nil, fmt.Errorf("%w: no requests", ErrInvalidArgs)
+	}
+	return requests, nil
+}
+
+// diskSchedules runs every disk scheduling algorithm; up sets the initial sweep direction.
+func diskSchedules(requests []int64, head, cylinders int64, up bool) []DiskSchedule {
+	return []DiskSchedule{
+		diskFCFS(requests, head),
+		diskSSTF(requests, head),
+		diskSweep("SCAN", requests, head, cylinders, up, true, false),
+		diskSweep("C-SCAN", requests, head, cylinders, up, true, true),
+		diskSweep("LOOK", requests, head, cylinders, up, false, false),
+		diskSweep("C-LOOK", requests, head, cylinders, up, false, true),
+	}
+}
+
+func diskFCFS(requests []int64, head int64) DiskSchedule {
+	return newDiskSchedule("FCFS", head, requests)
+}
+
+// diskSSTF serves the pending request closest to the head next, the lower cylinder on ties.
+func diskSSTF(requests []int64, head int64) DiskSchedule {
+	pending := append([]int64(nil), requests...)
+	order := make([]int64, 0, len(requests))
+	at := head
+	for len(pending) > 0 {
+		best := 0
+		for i := range pending {
+			d, bd := abs64(pending[i]-at), abs64(pending[best]-at)
+			if d < bd || d == bd && pending[i] < pending[best] {
+				best = i
+			}
+		}
+		at = pending[best]
+		order = append(order, at)
+		pending = append(pending[:best], pending[best+1:]...)
+	}
+	return newDiskSchedule("SSTF", head, order)
+}
+
+// diskSweep implements the elevator family. toEnd makes the head travel to
+// the last cylinder (SCAN, C-SCAN) rather than the last request (LOOK,
+// C-LOOK); circular makes it jump back to the other end and keep sweeping in
+// the same direction rather than reversing. The jump counts as head movement.
+func diskSweep(title string, requests []int64, head, cylinders int64, up, toEnd, circular bool) DiskSchedule {
+	var ahead, behind []int64
+	for _, c := range requests {
+		if (up && c >= head) || (!up && c <= head) {
+			ahead = append(ahead, c)
+		} else {
+			behind = append(behind, c)
+		}
+	}
+	// ahead is served in the sweep direction; behind after turning around.
+	sort.Slice(ahead, func(i, j int) bool { return (ahead[i] < ahead[j]) == up })
+	sort.Slice(behind, func(i, j int) bool { return (behind[i] < behind[j]) == (up == circular) })
+
+	first, last := int64(0), cylinders-1
+	if up {
+		first, last = last, first
+	}
+	order := append([]int64(nil), ahead...)
+	if len(behind) > 0 {
+		if toEnd && (len(order) == 0 || order[len(order)-1] != first) {
+			order = append(order, first)
+		}
+		if circular && toEnd {
+			order = append(order, last)
+		}
+		order = append(order, behind...)
+	}
+	return newDiskSchedule(title, head, order)
+}
+
+func newDiskSchedule(title string, head int64, order []int64) DiskSchedule {
+	s := DiskSchedule{Title: title, Sequence: append([]int64{head}, order...)}
+	for i := 1; i < len(s.Sequence); i++ {
+		s.Movement += abs64(s.Sequence[i] - s.Sequence[i-1])
+	}
+	return s
+}
+
+func abs64(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func outputDisk(w io.Writer, schedules []DiskSchedule) {
+	_, _ = fmt.Fprintln(w, "Disk schedule")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Seek sequence", "Head movement"})
+	table.SetAutoWrapText(false)
+	for _, s := range schedules {
+		seq := make([]string, len(s.Sequence))
+		for i, c := range s.Sequence {
+			seq[i] = fmt.Sprint(c)
+		}
+		table.Append([]string{s.Title, strings.Join(seq, " -> "), fmt.Sprint(s.Movement)})
+	}
+	table.Render()
+}
+
+//endregion
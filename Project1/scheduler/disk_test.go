@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_diskSchedules(t *testing.T) {
+	t.Parallel()
+	// The classic textbook queue: head at 53 on a 200-cylinder disk.
+	requests := []int64{98, 183, 37, 122, 14, 124, 65, 67}
+	tests := []struct {
+		name         string
+		up           bool
+		wantMovement map[string]int64
+		wantSequence map[string][]int64
+	}{
+		{
+			name: "sweeping up",
+			up:   true,
+			wantMovement: map[string]int64{
+				"FCFS": 640, "SSTF": 236, "SCAN": 331, "C-SCAN": 382, "LOOK": 299, "C-LOOK": 322,
+			},
+			wantSequence: map[string][]int64{
+				"SSTF":   {53, 65, 67, 37, 14, 98, 122, 124, 183},
+				"C-SCAN": {53, 65, 67, 98, 122, 124, 183, 199, 0, 14, 37},
+				"C-LOOK": {53, 65, 67, 98, 122, 124, 183, 14, 37},
+			},
+		},
+		{
+			name: "sweeping down",
+			wantMovement: map[string]int64{
+				"FCFS": 640, "SSTF": 236, "SCAN": 236, "C-SCAN": 386, "LOOK": 208, "C-LOOK": 326,
+			},
+			wantSequence: map[string][]int64{
+				"SCAN": {53, 37, 14, 0, 65, 67, 98, 122, 124, 183},
+				"LOOK": {53, 37, 14, 65, 67, 98, 122, 124, 183},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			for _, s := range diskSchedules(requests, 53, 200, tt.up) {
+				if want := tt.wantMovement[s.Title]; s.Movement != want {
+					t.Errorf("%s movement = %d, want %d (%v)", s.Title, s.Movement, want, s.Sequence)
+				}
+				if want, ok := tt.wantSequence[s.Title]; ok && !reflect.DeepEqual(s.Sequence, want) {
+					t.Errorf("%s sequence = %v, want %v", s.Title, s.Sequence, want)
+				}
+			}
+		})
+	}
+}
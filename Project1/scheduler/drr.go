@@ -0,0 +1,93 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+)
+
+//region Deficit round-robin
+
+// DRRPolicy is deficit round-robin, from fair queueing. A task is given
+// Quantum ticks of credit at the start of each of its turns, and runs
+// until its credit is spent. A task that leaves the CPU early, for I/O,
+// carries the credit it didn't use over to its next turn, where plain
+// round-robin would forfeit the rest of its quantum, so bursty tasks get
+// the same share of the CPU as tasks that never block. A finished task's
+// credit is dropped. Without I/O it schedules exactly like round-robin.
+type DRRPolicy struct {
+	Quantum int64
+	deficit map[*Task]int64
+	// turns counts each task's turns and carried is the most credit it
+	// carried over from a turn cut short.
+	turns   map[*Task]int
+	carried map[*Task]int64
+	tick    tickCharger
+}
+
+func (p *DRRPolicy) Pick(now int64, running *Task, ready []*Task) *Task {
+	if p.deficit == nil {
+		p.deficit, p.turns, p.carried = make(map[*Task]int64), make(map[*Task]int), make(map[*Task]int64)
+	}
+	if t := p.tick.due(now); t != nil {
+		p.deficit[t]--
+		if t != running {
+			if t.Remaining <= 0 {
+				p.deficit[t] = 0
+			} else if p.deficit[t] > p.carried[t] {
+				p.carried[t] = p.deficit[t]
+			}
+		}
+	}
+	pick := p.pick(running, ready)
+	p.tick.picked(now, pick)
+	return pick
+}
+
+func (p *DRRPolicy) pick(running *Task, ready []*Task) *Task {
+	if running != nil && p.deficit[running] > 0 {
+		return running
+	}
+	next := running
+	if len(ready) > 0 {
+		next = ready[0]
+	}
+	if next == nil {
+		return nil
+	}
+	p.deficit[next] += p.Quantum
+	p.turns[next]++
+	return next
+}
+
+// annotate adds each task's turns and the most credit it carried over.
+func (p *DRRPolicy) annotate(r *Report, tasks []*Task) {
+	turns, carried := Column{Header: "Turns"}, Column{Header: "Max carried"}
+	for _, t := range tasks {
+		turns.Values = append(turns.Values, fmt.Sprint(p.turns[t]))
+		carried.Values = append(carried.Values, fmt.Sprint(p.carried[t]))
+	}
+	r.Columns = append(r.Columns, turns, carried)
+}
+
+// DRRSchedule outputs the deficit round-robin schedule of processes as a
+// Gantt chart and a table of timing.
+func DRRSchedule(w io.Writer, title string, processes []Process, quantum int64, opts ...Option) {
+	outputReport(w, DRR(title, processes, quantum, opts...))
+}
+
+// DRR schedules processes deficit round-robin with quantum ticks of credit
+// per turn.
+func DRR(title string, processes []Process, quantum int64, opts ...Option) Report {
+	return simulate(title, processes, &DRRPolicy{Quantum: quantum}, opts...)
+}
+
+// drrReports runs deficit round-robin and plain round-robin with the same
+// quantum and options, such as random I/O, to compare them.
+func drrReports(processes []Process, quantum int64, opts ...Option) []Report {
+	return []Report{
+		DRR(fmt.Sprintf("Deficit round-robin, quantum %d", quantum), processes, quantum, opts...),
+		simulate(fmt.Sprintf("Round-robin, quantum %d", quantum), processes, RRPolicy{Quantum: quantum}, opts...),
+	}
+}
+
+//endregion
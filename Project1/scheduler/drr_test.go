@@ -0,0 +1,72 @@
+package scheduler
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDRR(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		quantum   int64
+		opts      []Option
+		// sameAsRR is whether the schedule must match plain round-robin's.
+		sameAsRR    bool
+		wantTurns   []string
+		wantCarried bool
+	}{
+		{
+			name:      "without I/O it is round-robin",
+			processes: []Process{{ProcessID: 1, BurstDuration: 7}, {ProcessID: 2, BurstDuration: 3}, {ProcessID: 3, ArrivalTime: 2, BurstDuration: 4}},
+			quantum:   2,
+			sameAsRR:  true,
+			wantTurns: []string{"4", "2", "2"},
+		},
+		{
+			name:        "I/O carries credit over",
+			processes:   []Process{{ProcessID: 1, BurstDuration: 40}, {ProcessID: 2, BurstDuration: 40}, {ProcessID: 3, BurstDuration: 40}},
+			quantum:     4,
+			opts:        []Option{WithRandomIO(0.3, 2, 7)},
+			wantCarried: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			reports := drrReports(tt.processes, tt.quantum, tt.opts...)
+			drr, rr := reports[0], reports[1]
+			if tt.sameAsRR && !reflect.DeepEqual(drr.Gantt, rr.Gantt) {
+				t.Errorf("DRR() Gantt = %v, want round-robin's %v", drr.Gantt, rr.Gantt)
+			}
+			if tt.wantTurns != nil && !reflect.DeepEqual(drr.Columns[0].Values, tt.wantTurns) {
+				t.Errorf("DRR() turns = %q, want %q", drr.Columns[0].Values, tt.wantTurns)
+			}
+			carried := false
+			for _, v := range drr.Columns[1].Values {
+				carried = carried || v != "0"
+			}
+			if carried != tt.wantCarried {
+				t.Errorf("DRR() carried = %q, want credit carried %v", drr.Columns[1].Values, tt.wantCarried)
+			}
+			if again := DRR("", tt.processes, tt.quantum, tt.opts...); !reflect.DeepEqual(again.Gantt, drr.Gantt) {
+				t.Errorf("DRR() is not reproducible: %v and %v", drr.Gantt, again.Gantt)
+			}
+		})
+	}
+}
+
+func TestDRRSchedule(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	DRRSchedule(&w, "Deficit round-robin", []Process{{ProcessID: 1, BurstDuration: 3}, {ProcessID: 2, BurstDuration: 3}}, 2)
+	for _, want := range []string{"|   1   |   2   |   1   |   2   |\n0\t2\t4\t5\t6", "MAX CARRIED"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("DRRSchedule() = %q, want it to contain %q", w.String(), want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+//region Earliest deadline first
+
+// parseDeadline parses the deadline column: an absolute time like "25", or
+// one relative to arrival like "+10", in the same units as the other times.
+// Empty means no deadline.
+func parseDeadline(s string, arrival, scale int64) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	relative := strings.HasPrefix(s, "+")
+	d, err := parseScaled(strings.TrimPrefix(s, "+"), scale)
+	if err != nil {
+		return 0, err
+	}
+	if relative {
+		d += arrival
+	}
+	if d <= arrival {
+		return 0, fmt.Errorf("%w: deadline %q is not after the arrival", ErrInvalidArgs, s)
+	}
+	return d, nil
+}
+
+// addDeadlineColumn adds each process's deadline, marking those it missed
+// and by how much, and notes how many missed and which.
+func addDeadlineColumn(r *Report, processes []Process) {
+	deadlines := make(map[int64]int64, len(processes))
+	for _, p := range processes {
+		deadlines[p.ProcessID] = p.Deadline
+	}
+	col := Column{Header: "Deadline"}
+	var missed []string
+	with := 0
+	for _, row := range r.Rows {
+		d := deadlines[row.ProcessID]
+		switch {
+		case d == 0:
+			col.Values = append(col.Values, "-")
+		case row.Exit > d:
+			with++
+			col.Values = append(col.Values, fmt.Sprintf("%d missed by %d", d, row.Exit-d))
+			missed = append(missed, fmt.Sprint(row.ProcessID))
+		default:
+			with++
+			col.Values = append(col.Values, fmt.Sprint(d))
+		}
+	}
+	col.Footer = fmt.Sprintf("Missed\n%d", len(missed))
+	r.Columns = append(r.Columns, col)
+	if len(missed) > 0 {
+		r.Notes = append(r.Notes, fmt.Sprintf("Deadline misses: %d of %d (%s)", len(missed), with, strings.Join(missed, ", ")))
+	} else {
+		r.Notes = append(r.Notes, fmt.Sprintf("Deadline misses: none of %d", with))
+	}
+}
+
+// EDFSchedule outputs the earliest-deadline-first schedule of processes as
+// a Gantt chart and a table of timing with their deadlines.
+func EDFSchedule(w io.Writer, title string, processes []Process) {
+	outputReport(w, EDF(title, processes))
+}
+
+// EDF schedules processes preemptively earliest deadline first, those
+// without a deadline only when no process with one is ready, and reports
+// the deadlines missed.
+func EDF(title string, processes []Process) Report {
+	r := simulate(title, processes, EDFPolicy{})
+	addDeadlineColumn(&r, processes)
+	return r
+}
+
+//endregion
@@ -0,0 +1,123 @@
+package scheduler
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseDeadline(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in      string
+		arrival int64
+		scale   int64
+		want    int64
+		wantErr error
+	}{
+		{in: "", arrival: 3, scale: 1, want: 0},
+		{in: "12", arrival: 3, scale: 1, want: 12},
+		{in: "+4", arrival: 3, scale: 1, want: 7},
+		{in: "+1.5", arrival: 30, scale: 10, want: 45},
+		{in: "3", arrival: 3, scale: 1, wantErr: ErrInvalidArgs},
+		{in: "+0", arrival: 3, scale: 1, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseDeadline(tt.in, tt.arrival, tt.scale)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseDeadline(%q) error = %v, want %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseDeadline(%q) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+	if _, err := parseDeadline("soon", 0, 1); err == nil {
+		t.Error("parseDeadline(soon) error = nil, want one")
+	}
+}
+
+func TestEDF(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		processes  []Process
+		wantGantt  []TimeSlice
+		wantColumn Column
+		wantNotes  []string
+	}{
+		{
+			name: "meets every deadline it can",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 4, Deadline: 10}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Deadline: 5},
+				{ProcessID: 3, ArrivalTime: 2, BurstDuration: 5}, {ProcessID: 4, ArrivalTime: 3, BurstDuration: 2, Deadline: 6},
+			},
+			wantGantt:  []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 4}, {PID: 4, Start: 4, Stop: 6}, {PID: 1, Start: 6, Stop: 9}, {PID: 3, Start: 9, Stop: 14}},
+			wantColumn: Column{Header: "Deadline", Values: []string{"10", "5", "-", "6"}, Footer: "Missed\n0"},
+			wantNotes:  []string{"Deadline misses: none of 3"},
+		},
+		{
+			name: "overload misses",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 3, Deadline: 4}, {ProcessID: 2, BurstDuration: 3, Deadline: 5},
+				{ProcessID: 3, BurstDuration: 3, Deadline: 6},
+			},
+			wantGantt:  []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 6}, {PID: 3, Start: 6, Stop: 9}},
+			wantColumn: Column{Header: "Deadline", Values: []string{"4", "5 missed by 1", "6 missed by 3"}, Footer: "Missed\n2"},
+			wantNotes:  []string{"Deadline misses: 2 of 3 (2, 3)"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := EDF("Earliest deadline first", tt.processes)
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("EDF() Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			if !reflect.DeepEqual(r.Columns, []Column{tt.wantColumn}) {
+				t.Errorf("EDF() columns = %+v, want %+v", r.Columns, tt.wantColumn)
+			}
+			if !reflect.DeepEqual(r.Notes, tt.wantNotes) {
+				t.Errorf("EDF() notes = %q, want %q", r.Notes, tt.wantNotes)
+			}
+		})
+	}
+}
+
+func TestEDFSchedule(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	EDFSchedule(&w, "Earliest deadline first", []Process{{ProcessID: 1, BurstDuration: 2, Deadline: 1}})
+	if want := "Deadline misses: 1 of 1 (1)\n"; !strings.Contains(w.String(), want) {
+		t.Errorf("EDFSchedule() = %q, want it to contain %q", w.String(), want)
+	}
+}
+
+func Test_loadProcessesDeadline(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader("1,5,2,0,,,,12\n2,5,3,0,,,,+4\n3,5,0,0,,,,\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []int64
+	for _, p := range processes {
+		got = append(got, p.Deadline)
+	}
+	if want := []int64{12, 7, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("loadProcesses() deadlines = %v, want %v", got, want)
+	}
+
+	var b bytes.Buffer
+	if err := outputProcessesCSV(&b, processes); err != nil {
+		t.Fatal(err)
+	}
+	if want := "1,5,2,0,100,batch,0,12\n2,5,3,0,100,batch,0,7\n3,5,0,0,100,batch,0,\n"; b.String() != want {
+		t.Errorf("outputProcessesCSV() = %q, want %q", b.String(), want)
+	}
+}
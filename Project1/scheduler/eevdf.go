@@ -0,0 +1,240 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+//region Earliest eligible virtual deadline first
+
+type (
+	// EEVDFPolicy is modelled on Linux's EEVDF scheduler, which replaced
+	// CFS. Each task accrues vruntime as under CFS, at a rate inversely
+	// proportional to its load weight, and asks for Slice ticks at a time.
+	// The queue's virtual time V is the weighted average vruntime of the
+	// runnable tasks, and a task's lag, how much CPU time it is owed, is its
+	// weight times how far its vruntime is behind V. Tasks with no negative
+	// lag are eligible, and the eligible task with the earliest virtual
+	// deadline, its vruntime when its request began plus the request scaled
+	// by its weight, runs. The running task keeps the CPU until its request
+	// is served unless a task arrives or wakes up with an earlier deadline.
+	// A task that blocks keeps its lag for when it wakes up, clamped to a
+	// request's worth; a new task starts with no lag and half a request.
+	EEVDFPolicy struct {
+		Slice    int64
+		entities map[*Task]*eevdfEntity
+		// sum and load are the weighted vruntime sum and total weight of
+		// the runnable tasks, whose average is V.
+		sum, load int64
+		// lastV is V when the queue last had tasks.
+		lastV       int64
+		tick        tickCharger
+		preemptions int
+	}
+
+	eevdfEntity struct {
+		vruntime, deadline int64
+		// request is how long the task's current request is, and used how
+		// much of it the task has run.
+		request, used int64
+		// queued reports whether the task is runnable, counted in V.
+		queued bool
+		// lag is the task's lag, in vruntime, when it last left the queue,
+		// and maxLag the most CPU time it has been owed or ahead by.
+		lag    int64
+		maxLag float64
+	}
+)
+
+// vslice is the virtual length of a request of ticks by t.
+func (p *EEVDFPolicy) vslice(t *Task, ticks int64) int64 {
+	return ticks * vruntimeScale * nice0Weight / t.loadWeight()
+}
+
+// avg is V, the weighted average vruntime of the runnable tasks.
+func (p *EEVDFPolicy) avg() int64 {
+	if p.load == 0 {
+		return p.lastV
+	}
+	return p.sum / p.load
+}
+
+// eligible reports whether e's lag is not negative: its vruntime is at most V.
+func (p *EEVDFPolicy) eligible(t *Task) bool {
+	return p.entities[t].vruntime*p.load <= p.sum
+}
+
+// lagTicks is t's lag in CPU time.
+func (p *EEVDFPolicy) lagTicks(t *Task) float64 {
+	return float64((p.avg()-p.entities[t].vruntime)*t.loadWeight()) / (vruntimeScale * nice0Weight)
+}
+
+func (p *EEVDFPolicy) enqueue(t *Task, e *eevdfEntity) {
+	e.queued = true
+	p.sum += e.vruntime * t.loadWeight()
+	p.load += t.loadWeight()
+}
+
+// dequeue takes t out of the queue, remembering its lag.
+func (p *EEVDFPolicy) dequeue(t *Task, e *eevdfEntity) {
+	e.lag = p.avg() - e.vruntime
+	if limit := p.vslice(t, p.Slice); e.lag > limit {
+		e.lag = limit
+	} else if e.lag < -limit {
+		e.lag = -limit
+	}
+	v := p.avg()
+	e.queued = false
+	p.sum -= e.vruntime * t.loadWeight()
+	p.load -= t.loadWeight()
+	if p.load == 0 {
+		p.lastV = v
+	}
+}
+
+// charge adds a tick run by t to its vruntime.
+func (p *EEVDFPolicy) charge(t *Task) {
+	e := p.entities[t]
+	d := p.vslice(t, 1)
+	e.vruntime += d
+	e.used++
+	if e.queued {
+		p.sum += d * t.loadWeight()
+	}
+}
+
+func (p *EEVDFPolicy) Pick(now int64, running *Task, ready []*Task) *Task {
+	if p.entities == nil {
+		p.entities = make(map[*Task]*eevdfEntity)
+	}
+	if t := p.tick.due(now); t != nil {
+		p.charge(t)
+	}
+	pick := p.pick(running, ready)
+	p.tick.picked(now, pick)
+	return pick
+}
+
+func (p *EEVDFPolicy) pick(running *Task, ready []*Task) *Task {
+	candidates := ready
+	if running != nil {
+		candidates = append([]*Task{running}, ready...)
+	}
+	runnable := make(map[*Task]bool, len(candidates))
+	for _, t := range candidates {
+		runnable[t] = true
+	}
+
+	// Tasks that finished or blocked leave the queue, and tasks that
+	// arrived or woke up join it where their lag puts them.
+	for t, e := range p.entities {
+		if e.queued && !runnable[t] {
+			p.dequeue(t, e)
+		}
+	}
+	joined := false
+	for _, t := range ready {
+		e, ok := p.entities[t]
+		if ok && e.queued {
+			continue
+		}
+		if !ok {
+			e = &eevdfEntity{}
+			p.entities[t] = e
+		}
+		e.vruntime, e.used, e.request = p.avg()-e.lag, 0, p.Slice
+		if !ok {
+			e.request = (p.Slice + 1) / 2
+		}
+		e.deadline = e.vruntime + p.vslice(t, e.request)
+		p.enqueue(t, e)
+		joined = true
+	}
+	for _, t := range candidates {
+		e := p.entities[t]
+		if lag := math.Abs(p.lagTicks(t)); lag > e.maxLag {
+			e.maxLag = lag
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	if running != nil {
+		e := p.entities[running]
+		if e.used >= e.request {
+			// Its request is served; the next begins.
+			e.used, e.request = 0, p.Slice
+			e.deadline = e.vruntime + p.vslice(running, p.Slice)
+		} else if !joined {
+			return running
+		}
+	}
+	// Ties go to the earliest in the ready queue, then the running task.
+	var pick *Task
+	for _, t := range append(ready[:len(ready):len(ready)], running) {
+		if t != nil && p.eligible(t) && (pick == nil || p.entities[t].deadline < p.entities[pick].deadline) {
+			pick = t
+		}
+	}
+	if pick == nil {
+		pick = minTask(candidates, func(a, b *Task) bool { return p.entities[a].vruntime < p.entities[b].vruntime })
+	}
+	if running != nil && pick != running {
+		p.preemptions++
+	}
+	return pick
+}
+
+// annotate adds each task's nice value, load weight, final vruntime in
+// ticks at nice 0, lag when it finished and the most it was owed or ahead
+// by, and notes the preemptions and how CFS would have done.
+func (p *EEVDFPolicy) annotate(r *Report, tasks []*Task) {
+	if p.entities == nil {
+		p.entities = make(map[*Task]*eevdfEntity)
+	}
+	if t := p.tick.flush(); t != nil {
+		p.charge(t)
+	}
+	nice, weight, vruntime := Column{Header: "Nice"}, Column{Header: "Weight"}, Column{Header: "vruntime"}
+	lag, maxLag := Column{Header: "Lag at exit"}, Column{Header: "Max lag"}
+	for _, t := range tasks {
+		e := p.entities[t]
+		if e == nil {
+			e = &eevdfEntity{}
+			p.entities[t] = e
+		}
+		if e.queued {
+			p.dequeue(t, e)
+		}
+		nice.Values = append(nice.Values, fmt.Sprint(t.Nice))
+		weight.Values = append(weight.Values, fmt.Sprint(t.loadWeight()))
+		vruntime.Values = append(vruntime.Values, strconv.FormatFloat(float64(e.vruntime)/vruntimeScale, 'f', 2, 64))
+		exit := float64(e.lag*t.loadWeight()) / (vruntimeScale * nice0Weight)
+		lag.Values = append(lag.Values, strconv.FormatFloat(exit, 'f', 2, 64))
+		maxLag.Values = append(maxLag.Values, strconv.FormatFloat(e.maxLag, 'f', 2, 64))
+	}
+	r.Columns = append(r.Columns, nice, weight, vruntime, lag, maxLag)
+	r.Notes = append(r.Notes, fmt.Sprintf("Base slice %d: %d preemptions", p.Slice, p.preemptions))
+}
+
+// EEVDFSchedule outputs the EEVDF schedule of processes as a Gantt chart
+// and a table of timing, compared with CFS with latency and granularity.
+func EEVDFSchedule(w io.Writer, title string, processes []Process, slice, latency, granularity int64) {
+	outputReport(w, EEVDF(title, processes, slice, latency, granularity))
+}
+
+// EEVDF schedules processes with earliest eligible virtual deadline first,
+// requesting slice ticks at a time and weighting them by their nice
+// values, and notes how CFS with latency and granularity compares.
+func EEVDF(title string, processes []Process, slice, latency, granularity int64) Report {
+	r := simulate(title, processes, &EEVDFPolicy{Slice: slice})
+	cfs := CFS("", processes, latency, granularity)
+	r.Notes = append(r.Notes, fmt.Sprintf("Against CFS, latency %d, granularity %d: average wait %.2f (CFS %.2f), context switches %d (CFS %d)",
+		latency, granularity, r.Wait, cfs.Wait, contextSwitches(r.Gantt), contextSwitches(cfs.Gantt)))
+	return r
+}
+
+//endregion
@@ -0,0 +1,95 @@
+package scheduler
+
+import (
+	"bytes"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestEEVDF(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		slice     int64
+		wantGantt []TimeSlice
+		wantNotes []string
+	}{
+		{
+			name:      "equal weights take turns a request at a time, new tasks half a request",
+			processes: []Process{{ProcessID: 1, BurstDuration: 6}, {ProcessID: 2, BurstDuration: 6}},
+			slice:     2,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 2}, {PID: 1, Start: 2, Stop: 4},
+				{PID: 2, Start: 4, Stop: 6}, {PID: 1, Start: 6, Stop: 8}, {PID: 2, Start: 8, Stop: 10},
+				{PID: 1, Start: 10, Stop: 11}, {PID: 2, Start: 11, Stop: 12},
+			},
+			wantNotes: []string{
+				"Base slice 2: 6 preemptions",
+				"Against CFS, latency 24, granularity 3: average wait 5.50 (CFS 3.00), context switches 7 (CFS 1)",
+			},
+		},
+		{
+			name:      "an arrival with an earlier deadline preempts",
+			processes: []Process{{ProcessID: 1, BurstDuration: 10, Nice: 5}, {ProcessID: 2, ArrivalTime: 3, BurstDuration: 2, Nice: -5}},
+			slice:     8,
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 5}, {PID: 1, Start: 5, Stop: 12}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := EEVDF("EEVDF", tt.processes, tt.slice, 24, 3)
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("EEVDF() Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			if tt.wantNotes != nil && !reflect.DeepEqual(r.Notes, tt.wantNotes) {
+				t.Errorf("EEVDF() notes = %q, want %q", r.Notes, tt.wantNotes)
+			}
+		})
+	}
+}
+
+func TestEEVDF_weights(t *testing.T) {
+	t.Parallel()
+	r := EEVDF("EEVDF", []Process{{ProcessID: 1, BurstDuration: 100}, {ProcessID: 2, BurstDuration: 100, Nice: 5}}, 3, 24, 3)
+	ran := make(map[int64]int64)
+	for _, s := range r.Gantt {
+		if s.Start < 80 {
+			stop := s.Stop
+			if stop > 80 {
+				stop = 80
+			}
+			ran[s.PID] += stop - s.Start
+		}
+	}
+	// Nice 0 weighs 1024 and nice 5 335, about 3 to 1.
+	if share := float64(ran[1]) / float64(ran[2]); share < 2.5 || share > 3.7 {
+		t.Errorf("in the first 80 ticks 1 ran %d and 2 ran %d, want about 3 to 1", ran[1], ran[2])
+	}
+	for i, c := range r.Columns {
+		if c.Header != "Max lag" {
+			continue
+		}
+		for _, v := range r.Columns[i].Values {
+			// Lag stays within about a request either way.
+			if lag, err := strconv.ParseFloat(v, 64); err != nil || lag > 4 {
+				t.Errorf("max lag %s, want at most a request and a tick", v)
+			}
+		}
+	}
+}
+
+func TestEEVDFSchedule(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	EEVDFSchedule(&w, "EEVDF, base slice 3", []Process{{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, BurstDuration: 1, Nice: -5}}, 3, 24, 3)
+	for _, want := range []string{"EEVDF, base slice 3", "LAG AT EXIT", "MAX LAG", "Against CFS, latency 24, granularity 3"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("EEVDFSchedule() = %q, want it to contain %q", w.String(), want)
+		}
+	}
+}
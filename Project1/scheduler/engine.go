@@ -0,0 +1,793 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//region Simulation engine
+
+type (
+	// Task is the state of a process while it is being simulated.
+	Task struct {
+		Process
+		// Remaining is the CPU time the task still needs.
+		Remaining int64 `json:"remaining"`
+		// Admitted is when the task entered the ready pool; later than its
+		// arrival only when admission is limited.
+		Admitted int64 `json:"admitted"`
+		// FirstRun is when the task was first dispatched, or -1.
+		FirstRun int64 `json:"first_run"`
+		// Exit is when the task completed.
+		Exit int64 `json:"exit"`
+		// Slice is how long the task has run since it was last dispatched.
+		Slice int64 `json:"slice"`
+		// Waited is the total time the task has spent ready but not running.
+		Waited int64 `json:"waited"`
+		// Queued is when the task last joined the ready queue.
+		Queued int64 `json:"queued"`
+		// Blocked is the total time the task has spent blocked on a Synchronizer.
+		Blocked int64 `json:"blocked"`
+		// IO is the total time the task has spent waiting for I/O.
+		IO int64 `json:"io,omitempty"`
+		// wake is when the task's current I/O completes.
+		wake int64
+	}
+
+	// Policy is a short-term scheduler: it decides which ready task runs next.
+	Policy interface {
+		// Pick returns the task to run for the tick starting at now. running
+		// is the task that ran the previous tick if it still has work to do;
+		// it is not in ready, which holds the other runnable tasks in the
+		// order they joined the ready queue. Returning a task other than
+		// running preempts it; returning nil leaves the CPU idle.
+		Pick(now int64, running *Task, ready []*Task) *Task
+	}
+
+	// Synchronizer is shared state tasks can block on, such as a semaphore.
+	Synchronizer interface {
+		// Acquire is called before t runs each tick; returning false blocks
+		// t until a later Release wakes it.
+		Acquire(t *Task) bool
+		// Release is called after t has run a tick and returns which of the
+		// blocked tasks to wake.
+		Release(t *Task, blocked []*Task) []*Task
+	}
+
+	// annotator is implemented by policies that add their own columns or
+	// notes to the report.
+	annotator interface {
+		annotate(r *Report, tasks []*Task)
+	}
+
+	// accounter is implemented by policies that need a periodic accounting
+	// event, such as replenishing credits, before the pick at every
+	// multiple of their period.
+	accounter interface {
+		period() int64
+		// account is passed the unfinished tasks that have arrived.
+		account(now int64, active []*Task)
+	}
+
+	// Option configures a simulation.
+	Option func(*engine)
+
+	engine struct {
+		policy Policy
+		// maxAdmitted is the degree of multiprogramming: how many unfinished
+		// tasks may be in the ready pool at once. Zero means unlimited.
+		maxAdmitted int
+		sync        Synchronizer
+		// stuck are the tasks still blocked when nothing else could run.
+		stuck []*Task
+		// slices is the Gantt chart of the last run.
+		slices []TimeSlice
+		// observers are called before every tick the run simulates.
+		observers []func(s *engineState)
+		// budgeted caps how often the running task may be preempted at
+		// maxPreemptions, unless it is negative; once they are used up,
+		// tasks run to completion.
+		budgeted       bool
+		maxPreemptions int
+		// preemptions and denied count the preemptions the last run made and refused.
+		preemptions, denied int
+		// ctx, if set, stops the run early once it is done.
+		ctx context.Context
+		// emit, if set, is called with every event of the run as it happens.
+		emit func(Event)
+		// hooks are the observers registered with WithObserver.
+		hooks []Observer
+		// aborted is the error an observer stopped the last run with.
+		aborted error
+		// clock paces the run; nil runs it as fast as possible.
+		clock Clock
+		// io, if set, interrupts running tasks with random I/O.
+		io *ioModel
+		// audit, if set, is told why every decision was made.
+		audit func(AuditEntry)
+		// switchCost is how many ticks of overhead a context switch from
+		// one task to another takes, and dispatchLatency how many every
+		// dispatch takes, even onto an idle CPU; switches and dispatches
+		// count how often the last run paid them.
+		switchCost, dispatchLatency int64
+		switches, dispatches        int
+		// queue follows the last run's events to record its ready queue.
+		queue *queueRecorder
+	}
+
+	// engineState is everything a run carries from one tick to the next.
+	engineState struct {
+		tasks    []*Task
+		arrived  []*Task
+		admitted []*Task
+		ready    []*Task
+		blocked  []*Task
+		// waiting are the tasks doing I/O, queued in the order it completes
+		// and then the order they started it.
+		waiting []*Task
+		running *Task
+		pool    int
+		done    int
+		now     int64
+		// nextAccount is when an accounter policy is next accounted.
+		nextAccount int64
+		// previous is the task that ran the last tick, nil if the CPU was
+		// idle, and switching how many ticks of overhead are left before
+		// running runs.
+		previous  *Task
+		switching int64
+	}
+)
+
+// WithMultiprogramming adds a long-term scheduler that admits arrivals to
+// the ready pool in arrival order, at most n unfinished tasks at a time.
+func WithMultiprogramming(n int) Option {
+	return func(e *engine) {
+		e.maxAdmitted = n
+	}
+}
+
+// WithSync makes tasks block and wake on s.
+func WithSync(s Synchronizer) Option {
+	return func(e *engine) {
+		e.sync = s
+	}
+}
+
+// simulate runs processes through policy one tick at a time and returns the resulting report.
+func simulate(title string, processes []Process, policy Policy, opts ...Option) Report {
+	e := &engine{policy: policy}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e.report(title, e.run(processes))
+}
+
+// report builds the report of a finished run.
+func (e *engine) report(title string, tasks []*Task) Report {
+	for _, t := range e.stuck {
+		tasks = removeTask(tasks, t)
+	}
+
+	r := taskReport(title, tasks, e.slices)
+	if e.queue != nil {
+		var end int64
+		for _, t := range tasks {
+			if t.Exit > end {
+				end = t.Exit
+			}
+		}
+		r.ReadyQueue = e.queue.until(end)
+	}
+	if e.maxAdmitted > 0 {
+		addAdmissionColumn(&r, tasks, e.maxAdmitted)
+	}
+	if e.sync != nil {
+		addBlockedColumn(&r, tasks, e.stuck)
+	}
+	if e.io != nil {
+		e.io.annotate(&r, tasks)
+	} else {
+		addCycleColumns(&r, tasks)
+	}
+	if e.aborted != nil {
+		r.Notes = append(r.Notes, fmt.Sprintf("Aborted at %v", e.aborted))
+	}
+	if e.budgeted {
+		addPreemptionNotes(&r, tasks, e.maxPreemptions, e.preemptions, e.denied)
+	}
+	if e.switchCost > 0 || e.dispatchLatency > 0 {
+		e.addOverheadNotes(&r)
+	}
+	if a, ok := e.policy.(annotator); ok {
+		a.annotate(&r, tasks)
+	}
+
+	return r
+}
+
+// newTasks returns a task per process, ordered by arrival and then input order.
+func newTasks(processes []Process) []*Task {
+	tasks := make([]*Task, len(processes))
+	for i, p := range processes {
+		tasks[i] = &Task{
+			Process:   p,
+			Remaining: p.BurstDuration,
+			FirstRun:  -1,
+		}
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].ArrivalTime < tasks[j].ArrivalTime
+	})
+
+	return tasks
+}
+
+// run simulates until every task completes, returning the tasks in arrival
+// order. The slices each task ran in are recorded in e.slices. If every
+// unfinished task ends up blocked, the run stops and they are left in e.stuck.
+func (e *engine) run(processes []Process) []*Task {
+	tasks := newTasks(processes)
+	e.slices, e.stuck = nil, nil
+	e.preemptions, e.denied = 0, 0
+	e.switches, e.dispatches = 0, 0
+	e.queue = newQueueRecorder()
+	e.aborted = nil
+	if e.io != nil {
+		e.io.reset()
+	}
+	return e.resume(&engineState{tasks: tasks, arrived: tasks})
+}
+
+// resume carries on the run in s until every task completes or is stuck.
+func (e *engine) resume(s *engineState) []*Task {
+	for s.done < len(s.tasks) {
+		if e.ctx != nil && e.ctx.Err() != nil {
+			break
+		}
+		if e.clock != nil {
+			ctx := e.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			if e.clock.Wait(ctx, s.now) != nil {
+				break
+			}
+		}
+		for _, observe := range e.observers {
+			observe(s)
+		}
+		if !e.step(s) {
+			break
+		}
+	}
+	return s.tasks
+}
+
+// step simulates the tick starting at s.now, or skips ahead to the next
+// arrival when nothing can run. It returns false if the run is stuck or an
+// observer aborted it.
+func (e *engine) step(s *engineState) bool {
+	for len(s.arrived) > 0 && s.arrived[0].ArrivalTime <= s.now {
+		e.event(ArriveEvent{Time: s.arrived[0].ArrivalTime, PID: s.arrived[0].ProcessID}, s.arrived[0])
+		s.admitted = append(s.admitted, s.arrived[0])
+		s.arrived = s.arrived[1:]
+	}
+	for len(s.admitted) > 0 && (e.maxAdmitted <= 0 || s.pool < e.maxAdmitted) {
+		t := s.admitted[0]
+		s.admitted = s.admitted[1:]
+		t.Admitted = s.now
+		t.Queued = s.now
+		if t.Remaining <= 0 {
+			t.Exit = s.now
+			s.done++
+			e.event(CompleteEvent{Time: s.now, PID: t.ProcessID}, t)
+			continue
+		}
+		s.pool++
+		s.ready = append(s.ready, t)
+	}
+
+	for len(s.waiting) > 0 && s.waiting[0].wake <= s.now {
+		t := s.waiting[0]
+		t.Queued = t.wake
+		s.ready = append(s.ready, t)
+		s.waiting = s.waiting[1:]
+		e.event(IOWakeEvent{Time: t.wake, PID: t.ProcessID}, t)
+	}
+
+	a, accounting := e.policy.(accounter)
+	if accounting && a.period() > 0 {
+		for ; s.nextAccount <= s.now; s.nextAccount += a.period() {
+			a.account(s.nextAccount, s.active())
+		}
+	}
+
+	if s.running == nil && len(s.ready) == 0 {
+		if len(s.arrived) == 0 && len(s.waiting) == 0 {
+			// Everything left is blocked and nothing can wake it.
+			e.stuck = s.blocked
+			return false
+		}
+		// Nothing to do until the next arrival or I/O completion.
+		next := int64(-1)
+		if len(s.arrived) > 0 {
+			next = s.arrived[0].ArrivalTime
+		}
+		if len(s.waiting) > 0 && (next < 0 || s.waiting[0].wake < next) {
+			next = s.waiting[0].wake
+		}
+		if accounting && a.period() > 0 && s.nextAccount < next {
+			// Account on time, with the tasks active then.
+			next = s.nextAccount
+		}
+		e.event(IdleEvent{Start: s.now, Stop: next}, nil)
+		s.now = next
+		s.previous = nil
+		return true
+	}
+
+	if s.switching > 0 {
+		// The context switch to s.running is still under way.
+		e.switchTick(s)
+		return true
+	}
+
+	// A task that has just been switched to runs at least a tick before the
+	// policy is asked again, or a quantum-based policy could switch away
+	// from it before it ever ran.
+	pick := s.running
+	if s.running == nil || s.running != s.previous || s.running.Slice > 0 {
+		pick = e.policy.Pick(s.now, s.running, s.ready)
+		e.auditDecision(s.now, s.running, s.ready, pick)
+	}
+	for pick != nil && e.sync != nil && !e.sync.Acquire(pick) {
+		if pick == s.running {
+			s.running = nil
+		} else {
+			s.ready = removeTask(s.ready, pick)
+		}
+		s.blocked = append(s.blocked, pick)
+		pick = nil
+		if s.running != nil || len(s.ready) > 0 {
+			pick = e.policy.Pick(s.now, s.running, s.ready)
+		}
+	}
+	if pick != s.running && s.running != nil {
+		if e.budgeted && e.maxPreemptions >= 0 && e.preemptions >= e.maxPreemptions {
+			pick = s.running
+			e.denied++
+		} else {
+			e.preemptions++
+		}
+	}
+	if pick != s.running {
+		if s.running != nil {
+			s.running.Queued = s.now
+			s.ready = append(s.ready, s.running)
+			e.event(PreemptEvent{Time: s.now, PID: s.running.ProcessID}, s.running)
+		}
+		if pick != nil {
+			s.ready = removeTask(s.ready, pick)
+			pick.Slice = 0
+			if pick.FirstRun < 0 {
+				pick.FirstRun = s.now
+			}
+			e.event(DispatchEvent{Time: s.now, PID: pick.ProcessID}, pick)
+		}
+		s.running = pick
+	}
+
+	if s.running != nil && s.running != s.previous {
+		if s.switching = e.overheadOf(s.previous); s.switching > 0 {
+			s.previous = s.running
+			e.switchTick(s)
+			return true
+		}
+	}
+
+	for _, h := range e.hooks {
+		if err := h.OnTick(s.now, s.running); err != nil {
+			e.aborted = fmt.Errorf("t=%d: %w", s.now, err)
+			return false
+		}
+	}
+
+	for _, t := range s.ready {
+		t.Waited++
+	}
+	for _, t := range s.blocked {
+		t.Blocked++
+	}
+	s.previous = s.running
+	if r := s.running; r != nil {
+		r.Remaining--
+		r.Slice++
+		e.record(r.ProcessID, s.now)
+		if e.sync != nil {
+			for _, t := range e.sync.Release(r, s.blocked) {
+				s.blocked = removeTask(s.blocked, t)
+				t.Queued = s.now + 1
+				s.ready = append(s.ready, t)
+			}
+		}
+		if r.Remaining <= 0 {
+			r.Exit = s.now + 1
+			s.running = nil
+			s.pool--
+			s.done++
+			e.event(CompleteEvent{Time: r.Exit, PID: r.ProcessID}, r)
+		} else if d := r.ioAfter(r.BurstDuration - r.Remaining); d > 0 {
+			e.startIO(s, r, d)
+		} else if e.io != nil {
+			if d := e.io.draw(); d > 0 {
+				e.startIO(s, r, d)
+			}
+		}
+	} else {
+		e.event(IdleEvent{Start: s.now, Stop: s.now + 1}, nil)
+	}
+	s.now++
+
+	return true
+}
+
+// startIO takes the running task t off the CPU for d ticks of I/O after the
+// tick starting at s.now, queueing it to wake when the I/O completes.
+func (e *engine) startIO(s *engineState, t *Task, d int64) {
+	t.IO += d
+	t.wake = s.now + 1 + d
+	i := sort.Search(len(s.waiting), func(i int) bool { return s.waiting[i].wake > t.wake })
+	s.waiting = append(s.waiting, nil)
+	copy(s.waiting[i+1:], s.waiting[i:])
+	s.waiting[i] = t
+	s.running = nil
+	e.event(IOStartEvent{Time: s.now + 1, PID: t.ProcessID, Stop: t.wake}, t)
+}
+
+// switchTick spends the tick starting at s.now on dispatching s.running:
+// the CPU is busy, but no task runs.
+func (e *engine) switchTick(s *engineState) {
+	s.switching--
+	for _, t := range s.ready {
+		t.Waited++
+	}
+	for _, t := range s.blocked {
+		t.Blocked++
+	}
+	if n := len(e.slices); n > 0 && e.slices[n-1].Overhead && e.slices[n-1].Stop == s.now {
+		e.slices[n-1].Stop++
+	} else {
+		e.slices = append(e.slices, TimeSlice{Start: s.now, Stop: s.now + 1, Overhead: true})
+	}
+	s.now++
+}
+
+// active returns the unfinished tasks that have arrived: running, ready,
+// doing I/O or blocked.
+func (s *engineState) active() []*Task {
+	var active []*Task
+	if s.running != nil {
+		active = append(active, s.running)
+	}
+	active = append(active, s.ready...)
+	active = append(active, s.waiting...)
+	return append(active, s.blocked...)
+}
+
+// event passes ev, which happened to t, to the run's listener and hooks.
+func (e *engine) event(ev Event, t *Task) {
+	if e.queue != nil {
+		e.queue.observe(ev)
+	}
+	if e.emit != nil {
+		e.emit(ev)
+	}
+	for _, h := range e.hooks {
+		switch ev.(type) {
+		case DispatchEvent:
+			h.OnDispatch(ev.At(), t)
+		case PreemptEvent:
+			h.OnPreempt(ev.At(), t)
+		case CompleteEvent:
+			h.OnComplete(ev.At(), t)
+		}
+	}
+}
+
+// record adds a tick of pid running at now to the Gantt chart, extending the
+// last slice when pid was already running.
+func (e *engine) record(pid, now int64) {
+	if n := len(e.slices); n > 0 && !e.slices[n-1].Overhead && e.slices[n-1].PID == pid && e.slices[n-1].Stop == now {
+		e.slices[n-1].Stop++
+		return
+	}
+	e.slices = append(e.slices, TimeSlice{PID: pid, Start: now, Stop: now + 1})
+}
+
+func removeTask(tasks []*Task, t *Task) []*Task {
+	for i := range tasks {
+		if tasks[i] == t {
+			return append(tasks[:i], tasks[i+1:]...)
+		}
+	}
+	return tasks
+}
+
+// taskReport builds the schedule table for completed tasks, in arrival order.
+// A task waits for as long as it isn't running, unless some task did I/O:
+// then waits are the time tasks spent in the ready queue, so that I/O
+// doesn't count as waiting.
+func taskReport(title string, tasks []*Task, gantt []TimeSlice) Report {
+	var (
+		totalWait       float64
+		totalTurnaround float64
+		lastCompletion  float64
+		rows            = make([]Row, len(tasks))
+		didIO           bool
+	)
+	for _, t := range tasks {
+		didIO = didIO || t.IO > 0
+	}
+	for i, t := range tasks {
+		turnaround := t.Exit - t.ArrivalTime
+		wait := turnaround - t.BurstDuration
+		if didIO {
+			wait = t.Waited
+		}
+		rows[i] = Row{
+			ProcessID:  t.ProcessID,
+			Priority:   t.Priority,
+			Burst:      t.BurstDuration,
+			Arrival:    t.ArrivalTime,
+			Deadline:   t.Deadline,
+			Wait:       wait,
+			Turnaround: turnaround,
+			Exit:       t.Exit,
+		}
+		totalWait += float64(rows[i].Wait)
+		totalTurnaround += float64(turnaround)
+		if float64(t.Exit) > lastCompletion {
+			lastCompletion = float64(t.Exit)
+		}
+	}
+
+	count := float64(len(tasks))
+	r := Report{
+		Title: title,
+		Gantt: gantt,
+		Rows:  rows,
+	}
+	if count > 0 {
+		r.Wait = totalWait / count
+		r.Turnaround = totalTurnaround / count
+	}
+	if lastCompletion > 0 {
+		r.Throughput = count / lastCompletion
+	}
+
+	return r
+}
+
+// addAdmissionColumn reports when each task was admitted and how much of the
+// average turnaround was spent waiting for admission.
+func addAdmissionColumn(r *Report, tasks []*Task, limit int) {
+	var delay, turnaround float64
+	col := Column{Header: "Admitted"}
+	for _, t := range tasks {
+		col.Values = append(col.Values, fmt.Sprint(t.Admitted))
+		delay += float64(t.Admitted - t.ArrivalTime)
+		turnaround += float64(t.Exit - t.ArrivalTime)
+	}
+	count := float64(len(tasks))
+	col.Footer = fmt.Sprintf("Delay\n%.2f", delay/count)
+	r.Columns = append(r.Columns, col)
+
+	share := 0.0
+	if turnaround > 0 {
+		share = 100 * delay / turnaround
+	}
+	r.Notes = append(r.Notes, fmt.Sprintf(
+		"Multiprogramming limit %d: admission delay averages %.2f, %.1f%% of turnaround",
+		limit, delay/count, share))
+}
+
+// addBlockedColumn reports how long each task spent blocked, and any tasks
+// that never finished because they stayed blocked.
+func addBlockedColumn(r *Report, tasks, stuck []*Task) {
+	var total float64
+	col := Column{Header: "Blocked"}
+	for _, t := range tasks {
+		col.Values = append(col.Values, fmt.Sprint(t.Blocked))
+		total += float64(t.Blocked)
+	}
+	if len(tasks) > 0 {
+		col.Footer = fmt.Sprintf("Average\n%.2f", total/float64(len(tasks)))
+	}
+	r.Columns = append(r.Columns, col)
+
+	if len(stuck) > 0 {
+		ids := make([]int64, len(stuck))
+		for i, t := range stuck {
+			ids[i] = t.ProcessID
+		}
+		r.Notes = append(r.Notes, fmt.Sprintf(
+			"Deadlock: processes %s are blocked with nothing left to wake them", formatIDs(ids)))
+	}
+}
+
+// responseColumn lists how long each task waited before it first ran, with the average as footer.
+func responseColumn(tasks []*Task) Column {
+	var total float64
+	col := Column{Header: "Response"}
+	for _, t := range tasks {
+		col.Values = append(col.Values, fmt.Sprint(t.FirstRun-t.ArrivalTime))
+		total += float64(t.FirstRun - t.ArrivalTime)
+	}
+	if len(tasks) > 0 {
+		col.Footer = fmt.Sprintf("Average\n%.2f", total/float64(len(tasks)))
+	}
+	return col
+}
+
+//endregion
+
+//region Policies
+
+type (
+	// FCFSPolicy runs tasks to completion in the order they became ready.
+	FCFSPolicy struct{}
+	// SJFPolicy runs the ready task with the shortest burst to completion.
+	SJFPolicy struct{}
+	// PriorityPolicy runs the ready task with the lowest priority number to completion.
+	PriorityPolicy struct{}
+	// RRPolicy gives each ready task up to Quantum ticks in turn.
+	RRPolicy struct {
+		Quantum int64
+	}
+)
+
+func (FCFSPolicy) Pick(_ int64, running *Task, ready []*Task) *Task {
+	if running != nil {
+		return running
+	}
+	return ready[0]
+}
+
+func (SJFPolicy) Pick(_ int64, running *Task, ready []*Task) *Task {
+	if running != nil {
+		return running
+	}
+	return minTask(ready, func(a, b *Task) bool { return a.BurstDuration < b.BurstDuration })
+}
+
+func (PriorityPolicy) Pick(_ int64, running *Task, ready []*Task) *Task {
+	if running != nil {
+		return running
+	}
+	return minTask(ready, func(a, b *Task) bool { return a.Priority < b.Priority })
+}
+
+func (p RRPolicy) Pick(_ int64, running *Task, ready []*Task) *Task {
+	if running != nil && (p.Quantum <= 0 || running.Slice%p.Quantum != 0 || len(ready) == 0) {
+		return running
+	}
+	if len(ready) == 0 {
+		return nil
+	}
+	return ready[0]
+}
+
+// classicPolicies are the engine's versions of the four algorithms every
+// run compares.
+func classicPolicies(quantum int64) []Policy {
+	return []Policy{FCFSPolicy{}, SJFPolicy{}, PriorityPolicy{}, RRPolicy{Quantum: quantum}}
+}
+
+// PolicyByName returns the policy called name (fcfs, sjf, priority,
+// preemptive-priority, srtf, hrrn, rr or priority-rr), giving the
+// round-robin ones quantum.
+func PolicyByName(name string, quantum int64) (Policy, error) {
+	switch strings.ToLower(name) {
+	case "fcfs":
+		return FCFSPolicy{}, nil
+	case "sjf":
+		return SJFPolicy{}, nil
+	case "priority":
+		return PriorityPolicy{}, nil
+	case "srtf":
+		return SRTFPolicy{}, nil
+	case "preemptive-priority":
+		return PreemptivePriorityPolicy{}, nil
+	case "hrrn":
+		return HRRNPolicy{}, nil
+	case "rr":
+		return RRPolicy{Quantum: quantum}, nil
+	case "priority-rr":
+		return PriorityRRPolicy{Quantum: quantum}, nil
+	}
+	return nil, fmt.Errorf("%w: unknown policy %q", ErrInvalidArgs, name)
+}
+
+// policyTitle names a policy the way the reports do.
+func policyTitle(p Policy) string {
+	switch p := p.(type) {
+	case FCFSPolicy:
+		return "First-come, first-serve"
+	case SJFPolicy:
+		return "Shortest-job-first"
+	case PriorityPolicy:
+		return "Priority"
+	case SRTFPolicy:
+		return "Shortest-remaining-time-first"
+	case PreemptivePriorityPolicy:
+		return "Preemptive priority"
+	case HRRNPolicy:
+		return "Highest response ratio next"
+	case RRPolicy:
+		return fmt.Sprintf("Round-robin, quantum %d", p.Quantum)
+	case PriorityRRPolicy:
+		return fmt.Sprintf("Priority with round-robin, quantum %d", p.Quantum)
+	case GenericPriorityScheduler:
+		return p.title()
+	case *MLFQPolicy:
+		return p.title()
+	case *MLQPolicy:
+		return p.title()
+	case FeedbackPolicy:
+		return p.title()
+	case *SRRPolicy:
+		return p.title()
+	case *RandomPolicy:
+		return fmt.Sprintf("Random dispatch, seed %d", p.Seed)
+	}
+	return fmt.Sprintf("%T", p)
+}
+
+// minTask returns the first task that no other task is less than.
+func minTask(tasks []*Task, less func(a, b *Task) bool) *Task {
+	if len(tasks) == 0 {
+		return nil
+	}
+	best := tasks[0]
+	for _, t := range tasks[1:] {
+		if less(t, best) {
+			best = t
+		}
+	}
+	return best
+}
+
+// tickCharger remembers the task a policy picked and when, so that the
+// policy can charge it for that tick at its next pick, even if it has since
+// finished or blocked: the engine only runs a picked task after Pick returns.
+type tickCharger struct {
+	last   *Task
+	lastAt int64
+}
+
+// picked records t as the task picked at now.
+func (c *tickCharger) picked(now int64, t *Task) {
+	c.last, c.lastAt = t, now
+}
+
+// due returns the task picked for a tick before now that hasn't been
+// charged yet, or nil, and forgets it.
+func (c *tickCharger) due(now int64) *Task {
+	if c.last == nil || now <= c.lastAt {
+		return nil
+	}
+	t := c.last
+	c.last = nil
+	return t
+}
+
+// flush returns the task picked for the last tick of the run, if it still
+// has to be charged, or nil.
+func (c *tickCharger) flush() *Task {
+	return c.due(c.lastAt + 1)
+}
+
+//endregion
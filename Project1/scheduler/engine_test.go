@@ -0,0 +1,99 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_simulate(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	tests := []struct {
+		name         string
+		policy       Policy
+		opts         []Option
+		wantGantt    []TimeSlice
+		wantWait     []int64
+		wantAdmitted []string
+	}{
+		{
+			name:      "FCFS",
+			policy:    FCFSPolicy{},
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 5, Stop: 14}, {PID: 3, Start: 14, Stop: 20}},
+			wantWait:  []int64{0, 2, 8},
+		},
+		{
+			name:   "RR quantum 4",
+			policy: RRPolicy{Quantum: 4},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 8}, {PID: 1, Start: 8, Stop: 9},
+				{PID: 3, Start: 9, Stop: 13}, {PID: 2, Start: 13, Stop: 17}, {PID: 3, Start: 17, Stop: 19},
+				{PID: 2, Start: 19, Stop: 20},
+			},
+			wantWait: []int64{4, 8, 7},
+		},
+		{
+			name:   "priority behind an admission limit of 1",
+			policy: PriorityPolicy{},
+			opts:   []Option{WithMultiprogramming(1)},
+			// Process 2 has the best priority but can't be admitted until process 1 finishes.
+			wantGantt:    []TimeSlice{{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 5, Stop: 14}, {PID: 3, Start: 14, Stop: 20}},
+			wantWait:     []int64{0, 2, 8},
+			wantAdmitted: []string{"0", "5", "14"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := simulate(tt.name, processes, tt.policy, tt.opts...)
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			var wait []int64
+			for _, row := range r.Rows {
+				wait = append(wait, row.Wait)
+			}
+			if !reflect.DeepEqual(wait, tt.wantWait) {
+				t.Errorf("wait = %v, want %v", wait, tt.wantWait)
+			}
+			var admitted []string
+			for _, c := range r.Columns {
+				if c.Header == "Admitted" {
+					admitted = c.Values
+				}
+			}
+			if !reflect.DeepEqual(admitted, tt.wantAdmitted) {
+				t.Errorf("admitted = %v, want %v", admitted, tt.wantAdmitted)
+			}
+		})
+	}
+}
+
+func Test_tickCharger(t *testing.T) {
+	t.Parallel()
+	a, b := &Task{}, &Task{}
+	var c tickCharger
+	if got := c.due(0); got != nil {
+		t.Errorf("due before any pick = %p, want nil", got)
+	}
+	c.picked(0, a)
+	c.picked(0, b) // picked again in the same tick: only b ran
+	if got := c.due(0); got != nil {
+		t.Errorf("due in the tick picked = %p, want nil", got)
+	}
+	if got := c.due(1); got != b {
+		t.Errorf("due(1) = %p, want %p", got, b)
+	}
+	if got := c.due(2); got != nil {
+		t.Errorf("due twice = %p, want nil", got)
+	}
+	c.picked(2, a)
+	if got := c.flush(); got != a {
+		t.Errorf("flush() = %p, want %p", got, a)
+	}
+}
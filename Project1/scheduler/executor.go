@@ -0,0 +1,335 @@
+//go:build unix
+
+package scheduler
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+//region Live executor
+
+// workerCommand is the real process standing in for a simulated one: a
+// busy loop that only stops when it is killed.
+var workerCommand = []string{"sh", "-c", "while :; do :; done"}
+
+type (
+	// executorConfig is how the exec subcommand runs its workers.
+	executorConfig struct {
+		Tick time.Duration
+		// CPUs, if set, are the CPUs every worker is pinned to.
+		CPUs []int
+		// Cgroup, if set, is a cgroup v2 directory to create and put the
+		// workers in, limited to CPUMax percent of one CPU if that is set.
+		Cgroup string
+		CPUMax int
+	}
+
+	// Divergence compares when the simulator predicted a process would
+	// complete with when its worker actually used up its burst.
+	Divergence struct {
+		PID       int64
+		Predicted int64
+		Measured  float64
+	}
+)
+
+// measuring reports whether workers run under real constraints, so that
+// they must actually use up their bursts and their completions are measured.
+func (c executorConfig) measuring() bool {
+	return len(c.CPUs) > 0 || c.Cgroup != ""
+}
+
+// runExec implements the exec subcommand:
+//
+//	exec [-tick 100ms] [-policy rr] [-quantum 10] [-cpus 0-1] [-cgroup dir [-cpu-max 50]] processes.csv
+//
+// It launches a real busy-looping process for every process of the
+// workload when it arrives, stopped, and enforces the simulated schedule
+// on them in real time with SIGCONT and SIGSTOP, killing each when its
+// burst is done, so the policy can be watched controlling real PIDs in
+// top. Interrupting the command kills every worker.
+//
+// With -cpus or -cgroup the workers are pinned or limited, and a worker
+// is only killed once it has used its burst of CPU time, however long
+// that takes; the measured completion times are then compared with the
+// simulated ones.
+func runExec(w io.Writer, args ...string) error {
+	fs := flag.NewFlagSet("exec", flag.ContinueOnError)
+	fs.SetOutput(w)
+	tick := fs.Duration("tick", 100*time.Millisecond, "real duration of one tick")
+	policyName := fs.String("policy", "rr", "policy to run: fcfs, sjf, priority, preemptive-priority, srtf, hrrn, rr or priority-rr")
+	quantum := fs.Int64("quantum", DefaultQuantum, "round-robin time quantum")
+	cpus := fs.String("cpus", "", "CPUs to pin the workers to, like \"0-1,3\"")
+	cgroup := fs.String("cgroup", "", "cgroup v2 directory to create and run the workers in, e.g. /sys/fs/cgroup/scheduler")
+	cpuMax := fs.Int("cpu-max", 0, "limit the -cgroup to this percentage of one CPU")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	if fs.NArg() != 1 || *tick <= 0 || *cpuMax < 0 || *cpuMax > 0 && *cgroup == "" {
+		return fmt.Errorf("%w: usage: exec [-tick 100ms] [-policy rr] [-quantum 10] [-cpus 0-1] [-cgroup dir [-cpu-max 50]] processes.csv", ErrInvalidArgs)
+	}
+	policy, err := PolicyByName(*policyName, *quantum)
+	if err != nil {
+		return err
+	}
+	cfg := executorConfig{Tick: *tick, Cgroup: *cgroup, CPUMax: *cpuMax}
+	if cfg.CPUs, err = parseCPUList(*cpus); err != nil {
+		return err
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("%v: error opening scheduling file", err)
+	}
+	defer func() { _ = f.Close() }()
+	workload, err := ReadWorkload(f)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	_, _ = fmt.Fprintf(w, "%s, one tick every %v\n", policyTitle(policy), *tick)
+	divergences, err := execute(ctx, w, workload, policy, cfg)
+	if err != nil {
+		return err
+	}
+	if cfg.measuring() {
+		outputDivergences(w, divergences)
+	}
+	return nil
+}
+
+// execute drives real workers through the schedule of policy over workload.
+// The simulation runs ahead and the events are acted on as the wall clock
+// reaches them. When measuring, it returns each process's predicted and
+// measured completion, in order of completion.
+func execute(ctx context.Context, w io.Writer, workload Workload, policy Policy, cfg executorConfig) ([]Divergence, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	events, err := SimulateStream(ctx, workload, policy)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Cgroup != "" {
+		if err := createCgroup(cfg.Cgroup, cfg.CPUMax); err != nil {
+			return nil, err
+		}
+		defer func() { _ = os.Remove(cfg.Cgroup) }()
+	}
+
+	bursts := make(map[int64]int64)
+	for _, p := range workload.Processes {
+		bursts[p.ProcessID] = p.BurstDuration
+	}
+	workers := make(map[int64]*exec.Cmd)
+	// due are the workers the simulation has completed, by predicted exit,
+	// that are still using up their bursts.
+	due := make(map[int64]int64)
+	defer func() {
+		for _, cmd := range workers {
+			_ = cmd.Process.Kill()
+			_ = cmd.Wait()
+		}
+	}()
+	send := func(pid int64, sig syscall.Signal) error {
+		cmd := workers[pid]
+		if cmd == nil {
+			return fmt.Errorf("no worker for process %d", pid)
+		}
+		return cmd.Process.Signal(sig)
+	}
+
+	clock := &WallClock{Tick: cfg.Tick}
+	var divergences []Divergence
+	// reap kills the due workers that have used up their bursts.
+	reap := func() error {
+		measured := float64(clock.originTick) + float64(time.Since(clock.origin))/float64(cfg.Tick)
+		for pid, predicted := range due {
+			cmd := workers[pid]
+			used, err := cpuTime(cmd.Process.Pid)
+			if err != nil {
+				return err
+			}
+			if used < time.Duration(bursts[pid])*cfg.Tick {
+				continue
+			}
+			_ = cmd.Process.Kill()
+			_ = cmd.Wait()
+			delete(workers, pid)
+			delete(due, pid)
+			divergences = append(divergences, Divergence{PID: pid, Predicted: predicted, Measured: measured})
+			_, _ = fmt.Fprintf(w, "t=%.1f: process %d (PID %d) has used its burst and is killed\n", measured, pid, cmd.Process.Pid)
+		}
+		return nil
+	}
+
+	for ev := range events {
+		if err := clock.Wait(ctx, ev.At()); err != nil {
+			return nil, err
+		}
+		if err := reap(); err != nil {
+			return nil, err
+		}
+		switch ev := ev.(type) {
+		case ArriveEvent:
+			cmd := exec.Command(workerCommand[0], workerCommand[1:]...)
+			if err := cmd.Start(); err != nil {
+				return nil, fmt.Errorf("starting worker for process %d: %w", ev.PID, err)
+			}
+			workers[ev.PID] = cmd
+			if err := cmd.Process.Signal(syscall.SIGSTOP); err != nil {
+				return nil, err
+			}
+			if err := constrainWorker(cmd.Process.Pid, cfg); err != nil {
+				return nil, err
+			}
+			_, _ = fmt.Fprintf(w, "t=%d: process %d arrives as PID %d, stopped\n", ev.Time, ev.PID, cmd.Process.Pid)
+		case DispatchEvent:
+			if err := send(ev.PID, syscall.SIGCONT); err != nil {
+				return nil, err
+			}
+			_, _ = fmt.Fprintf(w, "t=%d: process %d (PID %d) continued\n", ev.Time, ev.PID, workers[ev.PID].Process.Pid)
+		case PreemptEvent:
+			if err := send(ev.PID, syscall.SIGSTOP); err != nil {
+				return nil, err
+			}
+			_, _ = fmt.Fprintf(w, "t=%d: process %d (PID %d) stopped\n", ev.Time, ev.PID, workers[ev.PID].Process.Pid)
+		case CompleteEvent:
+			cmd := workers[ev.PID]
+			if cmd == nil {
+				return nil, fmt.Errorf("no worker for process %d", ev.PID)
+			}
+			if cfg.measuring() {
+				due[ev.PID] = ev.Time
+				_, _ = fmt.Fprintf(w, "t=%d: process %d (PID %d) completes in the simulation\n", ev.Time, ev.PID, cmd.Process.Pid)
+				if err := reap(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			_ = cmd.Process.Kill()
+			_ = cmd.Wait()
+			delete(workers, ev.PID)
+			_, _ = fmt.Fprintf(w, "t=%d: process %d (PID %d) completes and is killed\n", ev.Time, ev.PID, cmd.Process.Pid)
+		case IOStartEvent:
+			if err := send(ev.PID, syscall.SIGSTOP); err != nil {
+				return nil, err
+			}
+			_, _ = fmt.Fprintf(w, "t=%d: process %d (PID %d) stopped for I/O until t=%d\n", ev.Time, ev.PID, workers[ev.PID].Process.Pid, ev.Stop)
+		case IdleEvent:
+			_, _ = fmt.Fprintf(w, "t=%d: idle until t=%d\n", ev.Start, ev.Stop)
+		}
+	}
+
+	// Wait for the workers that fell behind the simulation.
+	poll := time.NewTicker(10 * time.Millisecond)
+	defer poll.Stop()
+	for len(due) > 0 {
+		select {
+		case <-poll.C:
+			if err := reap(); err != nil {
+				return nil, err
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return divergences, ctx.Err()
+}
+
+// constrainWorker pins the worker pid to cfg.CPUs and moves it into
+// cfg.Cgroup, if they are set.
+func constrainWorker(pid int, cfg executorConfig) error {
+	if len(cfg.CPUs) > 0 {
+		if err := pinToCPUs(pid, cfg.CPUs); err != nil {
+			return fmt.Errorf("pinning PID %d: %w", pid, err)
+		}
+	}
+	if cfg.Cgroup != "" {
+		if err := os.WriteFile(cfg.Cgroup+"/cgroup.procs", []byte(strconv.Itoa(pid)), 0o644); err != nil {
+			return fmt.Errorf("moving PID %d into %s: %w", pid, cfg.Cgroup, err)
+		}
+	}
+	return nil
+}
+
+// createCgroup creates the cgroup v2 directory dir, limited to cpuMax
+// percent of one CPU unless cpuMax is 0.
+func createCgroup(dir string, cpuMax int) error {
+	if err := os.Mkdir(dir, 0o755); err != nil && !os.IsExist(err) {
+		return fmt.Errorf("creating cgroup: %w", err)
+	}
+	if cpuMax == 0 {
+		return nil
+	}
+	const period = 100000
+	limit := fmt.Sprintf("%d %d", cpuMax*period/100, period)
+	if err := os.WriteFile(dir+"/cpu.max", []byte(limit), 0o644); err != nil {
+		return fmt.Errorf("limiting cgroup: %w", err)
+	}
+	return nil
+}
+
+// parseCPUList parses a CPU list in the kernel's format, like "0-2,4".
+func parseCPUList(list string) ([]int, error) {
+	var cpus []int
+	for _, f := range strings.Split(list, ",") {
+		if f = strings.TrimSpace(f); f == "" {
+			continue
+		}
+		bounds := strings.SplitN(f, "-", 2)
+		lo, err := strconv.Atoi(bounds[0])
+		hi := lo
+		if err == nil && len(bounds) == 2 {
+			hi, err = strconv.Atoi(bounds[1])
+		}
+		if err != nil || lo < 0 || hi < lo {
+			return nil, fmt.Errorf("%w: bad CPU range %q", ErrInvalidArgs, f)
+		}
+		for cpu := lo; cpu <= hi; cpu++ {
+			cpus = append(cpus, cpu)
+		}
+	}
+	sort.Ints(cpus)
+	return cpus, nil
+}
+
+// outputDivergences compares each process's simulated and measured
+// completion, in ticks.
+func outputDivergences(w io.Writer, divergences []Divergence) {
+	sort.Slice(divergences, func(i, j int) bool { return divergences[i].PID < divergences[j].PID })
+	_, _ = fmt.Fprintln(w, "Measured completion against the simulation")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "Predicted exit", "Measured exit", "Divergence"})
+	var total float64
+	for _, d := range divergences {
+		diff := d.Measured - float64(d.Predicted)
+		total += diff
+		table.Append([]string{
+			fmt.Sprint(d.PID),
+			fmt.Sprint(d.Predicted),
+			fmt.Sprintf("%.1f", d.Measured),
+			fmt.Sprintf("%+.1f", diff),
+		})
+	}
+	if len(divergences) > 0 {
+		table.SetFooter([]string{"", "", "Average", fmt.Sprintf("%+.1f", total/float64(len(divergences)))})
+	}
+	table.Render()
+}
+
+//endregion
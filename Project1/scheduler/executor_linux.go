@@ -0,0 +1,59 @@
+package scheduler
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+//region Linux process control
+
+// clockTicks is the unit of CPU times in /proc, USER_HZ, which is 100 on
+// every Linux architecture Go supports.
+const clockTicks = 100
+
+// pinToCPUs sets the CPU affinity of pid to cpus.
+func pinToCPUs(pid int, cpus []int) error {
+	var mask [1024 / 64]uint64
+	for _, cpu := range cpus {
+		if cpu >= len(mask)*64 {
+			return fmt.Errorf("%w: CPU %d is out of range", ErrInvalidArgs, cpu)
+		}
+		mask[cpu/64] |= 1 << (cpu % 64)
+	}
+	_, _, errno := syscall.RawSyscall(syscall.SYS_SCHED_SETAFFINITY,
+		uintptr(pid), uintptr(len(mask)*8), uintptr(unsafe.Pointer(&mask[0])))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// cpuTime returns the user and system CPU time pid has used.
+func cpuTime(pid int) (time.Duration, error) {
+	b, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return 0, err
+	}
+	// The command name can hold spaces, so count fields after its ")".
+	s := string(b)
+	fields := strings.Fields(s[strings.LastIndexByte(s, ')')+1:])
+	if len(fields) < 13 {
+		return 0, fmt.Errorf("short /proc/%d/stat", pid)
+	}
+	var total int64
+	for _, f := range fields[11:13] {
+		n, err := strconv.ParseInt(f, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("/proc/%d/stat: %w", pid, err)
+		}
+		total += n
+	}
+	return time.Duration(total) * time.Second / clockTicks, nil
+}
+
+//endregion
@@ -0,0 +1,43 @@
+package scheduler
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_execute_pinned(t *testing.T) {
+	t.Parallel()
+	workload, err := ReadWorkload(strings.NewReader("1,2,0,1\n2,1,0,1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	divergences, err := execute(context.Background(), io.Discard, workload, FCFSPolicy{},
+		executorConfig{Tick: 50 * time.Millisecond, CPUs: []int{0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(divergences) != 2 {
+		t.Fatalf("got %d measured completions, want 2", len(divergences))
+	}
+	for _, d := range divergences {
+		// A worker can't use its burst faster than the simulation allows,
+		// give or take the 10ms resolution of /proc CPU times.
+		if d.Measured < float64(d.Predicted)-0.5 {
+			t.Errorf("process %d measured at %.2f, before its predicted exit %d", d.PID, d.Measured, d.Predicted)
+		}
+	}
+}
+
+func Test_pinToCPUs(t *testing.T) {
+	t.Parallel()
+	if err := pinToCPUs(os.Getpid(), nil); err == nil {
+		t.Error("pinning to no CPUs succeeded")
+	}
+	if _, err := cpuTime(os.Getpid()); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !unix
+
+package scheduler
+
+import (
+	"fmt"
+	"io"
+)
+
+// runExec needs SIGSTOP and SIGCONT, which only Unix systems have.
+func runExec(io.Writer, ...string) error {
+	return fmt.Errorf("%w: exec needs a Unix system", ErrInvalidArgs)
+}
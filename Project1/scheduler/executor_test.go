@@ -0,0 +1,71 @@
+//go:build unix
+
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_execute(t *testing.T) {
+	t.Parallel()
+	workload, err := ReadWorkload(strings.NewReader("1,3,0,1\n2,1,1,1\n3,1,6,1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if _, err := execute(context.Background(), &out, workload, RRPolicy{Quantum: 2}, executorConfig{Tick: time.Millisecond}); err != nil {
+		t.Fatal(err)
+	}
+	got := regexp.MustCompile(`PID \d+`).ReplaceAllString(out.String(), "PID n")
+	want := `t=0: process 1 arrives as PID n, stopped
+t=0: process 1 (PID n) continued
+t=1: process 2 arrives as PID n, stopped
+t=2: process 1 (PID n) stopped
+t=2: process 2 (PID n) continued
+t=3: process 2 (PID n) completes and is killed
+t=3: process 1 (PID n) continued
+t=4: process 1 (PID n) completes and is killed
+t=4: idle until t=6
+t=6: process 3 arrives as PID n, stopped
+t=6: process 3 (PID n) continued
+t=7: process 3 (PID n) completes and is killed
+`
+	if got != want {
+		t.Errorf("execute() = %q, want %q", got, want)
+	}
+}
+
+func Test_parseCPUList(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		list    string
+		want    []int
+		wantErr bool
+	}{
+		{list: "", want: nil},
+		{list: "3", want: []int{3}},
+		{list: "4,0-2", want: []int{0, 1, 2, 4}},
+		{list: "2-1", wantErr: true},
+		{list: "a", wantErr: true},
+		{list: "-1", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.list, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseCPUList(tt.list)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseCPUList() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseCPUList() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
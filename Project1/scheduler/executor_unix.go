@@ -0,0 +1,18 @@
+//go:build unix && !linux
+
+package scheduler
+
+import (
+	"fmt"
+	"time"
+)
+
+// pinToCPUs needs sched_setaffinity, which only Linux has.
+func pinToCPUs(int, []int) error {
+	return fmt.Errorf("%w: pinning workers needs Linux", ErrInvalidArgs)
+}
+
+// cpuTime needs /proc, which only Linux has.
+func cpuTime(int) (time.Duration, error) {
+	return 0, fmt.Errorf("%w: measuring workers needs Linux", ErrInvalidArgs)
+}
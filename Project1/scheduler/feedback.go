@@ -0,0 +1,72 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+//region Feedback with doubling quanta
+
+// FeedbackPolicy is the classic feedback scheduler: a multilevel feedback
+// queue whose quantum doubles at each lower level, 1, 2, 4, 8 ticks from a
+// base of 1. A task that uses up its quantum drops a level, and the bottom
+// level runs round-robin with the longest quantum.
+type FeedbackPolicy struct {
+	*MLFQPolicy
+}
+
+// newFeedback returns a feedback scheduler of levels queues, the top one
+// with quantum base.
+func newFeedback(levels int, base int64) (FeedbackPolicy, error) {
+	if levels < 1 || base < 1 {
+		return FeedbackPolicy{}, fmt.Errorf("%w: feedback needs at least one level and a positive base quantum", ErrInvalidArgs)
+	}
+	c := MLFQConfig{}
+	for l, q := 0, base; l < levels; l, q = l+1, q*2 {
+		c.Quanta = append(c.Quanta, q)
+	}
+	return FeedbackPolicy{&MLFQPolicy{Config: c}}, nil
+}
+
+// annotate adds the level each task finished in to the MLFQ's columns.
+func (p FeedbackPolicy) annotate(r *Report, tasks []*Task) {
+	p.MLFQPolicy.annotate(r, tasks)
+	col := Column{Header: "Final level"}
+	for _, t := range tasks {
+		col.Values = append(col.Values, fmt.Sprint(p.level[t.ProcessID]))
+	}
+	r.Columns = append(r.Columns, col)
+}
+
+// title names the feedback scheduler after its quanta.
+func (p FeedbackPolicy) title() string {
+	quanta := make([]string, len(p.Config.Quanta))
+	for i, q := range p.Config.Quanta {
+		quanta[i] = fmt.Sprint(q)
+	}
+	return fmt.Sprintf("Feedback, quanta %s", strings.Join(quanta, "/"))
+}
+
+// FeedbackSchedule outputs the feedback schedule of processes as a Gantt
+// chart and a table of timing.
+func FeedbackSchedule(w io.Writer, title string, processes []Process, levels int, base int64) error {
+	r, err := Feedback(title, processes, levels, base)
+	if err != nil {
+		return err
+	}
+	outputReport(w, r)
+	return nil
+}
+
+// Feedback schedules processes with levels feedback queues, quanta doubling
+// down from base.
+func Feedback(title string, processes []Process, levels int, base int64) (Report, error) {
+	p, err := newFeedback(levels, base)
+	if err != nil {
+		return Report{}, err
+	}
+	return simulate(title, processes, p), nil
+}
+
+//endregion
@@ -0,0 +1,96 @@
+package scheduler
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFeedback(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		processes  []Process
+		levels     int
+		base       int64
+		wantGantt  []TimeSlice
+		wantLevels []string
+		wantErr    bool
+	}{
+		{
+			name: "quanta 1, 2, 4, 8",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 5}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 5},
+				{ProcessID: 3, ArrivalTime: 3, BurstDuration: 3}, {ProcessID: 4, ArrivalTime: 4, BurstDuration: 4},
+			},
+			levels: 4,
+			base:   1,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 2}, {PID: 1, Start: 2, Stop: 3},
+				{PID: 3, Start: 3, Stop: 4}, {PID: 4, Start: 4, Stop: 5}, {PID: 2, Start: 5, Stop: 7},
+				{PID: 1, Start: 7, Stop: 8}, {PID: 3, Start: 8, Stop: 10}, {PID: 4, Start: 10, Stop: 12},
+				{PID: 2, Start: 12, Stop: 14}, {PID: 1, Start: 14, Stop: 16}, {PID: 4, Start: 16, Stop: 17},
+			},
+			wantLevels: []string{"2", "2", "1", "2"},
+		},
+		{
+			name:       "the bottom level keeps the longest quantum",
+			processes:  []Process{{ProcessID: 1, BurstDuration: 20}, {ProcessID: 2, BurstDuration: 20}},
+			levels:     2,
+			base:       3,
+			wantGantt:  []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 6}, {PID: 1, Start: 6, Stop: 12}, {PID: 2, Start: 12, Stop: 18}, {PID: 1, Start: 18, Stop: 24}, {PID: 2, Start: 24, Stop: 30}, {PID: 1, Start: 30, Stop: 35}, {PID: 2, Start: 35, Stop: 40}},
+			wantLevels: []string{"1", "1"},
+		},
+		{name: "no levels", processes: []Process{{ProcessID: 1, BurstDuration: 1}}, base: 1, wantErr: true},
+		{name: "no quantum", processes: []Process{{ProcessID: 1, BurstDuration: 1}}, levels: 3, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r, err := Feedback("Feedback", tt.processes, tt.levels, tt.base)
+			if (err != nil) != tt.wantErr || err != nil && !errors.Is(err, ErrInvalidArgs) {
+				t.Fatalf("Feedback() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("Feedback() Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			final := r.Columns[len(r.Columns)-1]
+			if final.Header != "Final level" || !reflect.DeepEqual(final.Values, tt.wantLevels) {
+				t.Errorf("Feedback() last column = %v, want final levels %q", final, tt.wantLevels)
+			}
+		})
+	}
+}
+
+func TestFeedbackPolicy_title(t *testing.T) {
+	t.Parallel()
+	p, err := newFeedback(4, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := policyTitle(p), "Feedback, quanta 1/2/4/8"; got != want {
+		t.Errorf("policyTitle() = %q, want %q", got, want)
+	}
+}
+
+func TestFeedbackSchedule(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	if err := FeedbackSchedule(&w, "Feedback, quanta 1/2", []Process{{ProcessID: 1, BurstDuration: 4}}, 2, 1); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Feedback, quanta 1/2", "FINAL LEVEL", "Queue 1 (quantum 2) ran: 1 1-4"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("FeedbackSchedule() = %q, want it to contain %q", w.String(), want)
+		}
+	}
+	if err := FeedbackSchedule(&w, "Feedback", nil, 0, 1); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("FeedbackSchedule() with no levels error = %v, want ErrInvalidArgs", err)
+	}
+}
@@ -0,0 +1,260 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//region Gang scheduling
+
+// parseGroup parses the group column: a group ID, with empty or 0 meaning
+// the process is in no group.
+func parseGroup(s string) (int64, error) {
+	if s = strings.TrimSpace(s); s == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("%w: group must be a non-negative integer, got %q", ErrInvalidArgs, s)
+	}
+	return v, nil
+}
+
+// gang is a set of processes that only ever run together, each on a CPU
+// of its own: a group from the group column, or a process in no group on
+// its own.
+type gang struct {
+	group   int64
+	members []*Task
+	// ready is when the last member arrives and the gang can first run.
+	ready int64
+	// slice is how long the gang has held its CPUs since it was dispatched.
+	slice int64
+}
+
+// unfinished returns the members still to complete, each needing a CPU.
+func (g *gang) unfinished() []*Task {
+	var left []*Task
+	for _, t := range g.members {
+		if t.Remaining > 0 {
+			left = append(left, t)
+		}
+	}
+	return left
+}
+
+// name is how notes refer to the gang.
+func (g *gang) name() string {
+	if g.group == 0 {
+		return fmt.Sprintf("process %d", g.members[0].ProcessID)
+	}
+	return fmt.Sprintf("group %d", g.group)
+}
+
+// gangs splits tasks, in arrival order, into gangs ordered by when they
+// become ready.
+func gangs(tasks []*Task) []*gang {
+	var all []*gang
+	byGroup := make(map[int64]*gang)
+	for _, t := range tasks {
+		g := byGroup[t.Group]
+		if t.Group == 0 || g == nil {
+			g = &gang{group: t.Group}
+			all = append(all, g)
+			if t.Group != 0 {
+				byGroup[t.Group] = g
+			}
+		}
+		g.members = append(g.members, t)
+		if t.ArrivalTime > g.ready {
+			g.ready = t.ArrivalTime
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool { return all[i].ready < all[j].ready })
+	return all
+}
+
+// GangSchedule outputs the gang schedule of processes on cpus CPUs as a
+// Gantt chart with a lane per CPU and a table of timing.
+func GangSchedule(w io.Writer, title string, processes []Process, cpus int, quantum int64) error {
+	r, err := Gang(title, processes, cpus, quantum)
+	if err != nil {
+		return err
+	}
+	outputReport(w, r)
+	return nil
+}
+
+// Gang schedules processes on cpus CPUs, co-scheduling the processes of
+// each group: a group is dispatched only when there is a free CPU for
+// every one of its unfinished processes, and they then run in the same
+// ticks. Gangs take turns first-come, first-served, each holding its CPUs
+// for up to quantum ticks, or until it completes if quantum is 0; a gang
+// that doesn't fit is passed over for later ones that do. Free CPUs left
+// idle while a gang waits for enough of them are the cost of the gang
+// constraint, and the report counts them as fragmentation.
+func Gang(title string, processes []Process, cpus int, quantum int64) (Report, error) {
+	if cpus <= 0 || quantum < 0 {
+		return Report{}, fmt.Errorf("%w: gang scheduling needs a CPU and a non-negative quantum", ErrInvalidArgs)
+	}
+	if hasBurstCycles(processes) {
+		return Report{}, fmt.Errorf("%w: gang scheduling doesn't model I/O, so processes can't have burst cycles", ErrInvalidArgs)
+	}
+	tasks := newTasks(processes)
+	pending := gangs(tasks)
+	for _, g := range pending {
+		if len(g.members) > cpus {
+			return Report{}, fmt.Errorf("%w: %s has %d processes but there are only %d CPUs", ErrInvalidArgs, g.name(), len(g.members), cpus)
+		}
+	}
+
+	var (
+		queue, running []*gang
+		// owner is the task on each CPU, nil when it is free.
+		owner = make([]*Task, cpus)
+		gantt []TimeSlice
+		// last is the index in gantt of each task's latest slice.
+		last                   = make(map[*Task]int)
+		held                   = make(map[*Task]int64)
+		busy, fragmented, done int64
+		now                    int64
+	)
+	for _, t := range tasks {
+		if t.Remaining <= 0 {
+			t.Exit = t.ArrivalTime
+			done++
+		}
+	}
+	for done < int64(len(tasks)) {
+		for len(pending) > 0 && pending[0].ready <= now {
+			if len(pending[0].unfinished()) > 0 {
+				queue = append(queue, pending[0])
+			}
+			pending = pending[1:]
+		}
+		// A gang whose quantum is up gives back its CPUs and goes to the
+		// back of the queue.
+		for i := 0; i < len(running); i++ {
+			if g := running[i]; quantum > 0 && g.slice >= quantum {
+				for cpu, t := range owner {
+					for _, m := range g.members {
+						if t == m {
+							owner[cpu] = nil
+						}
+					}
+				}
+				running = append(running[:i], running[i+1:]...)
+				queue = append(queue, g)
+				i--
+			}
+		}
+		free := 0
+		for _, t := range owner {
+			if t == nil {
+				free++
+			}
+		}
+		for i := 0; i < len(queue) && free > 0; i++ {
+			g := queue[i]
+			left := g.unfinished()
+			if len(left) > free {
+				continue
+			}
+			cpu := 0
+			for _, t := range left {
+				for owner[cpu] != nil {
+					cpu++
+				}
+				owner[cpu] = t
+				if t.FirstRun < 0 {
+					t.FirstRun = now
+				}
+			}
+			free -= len(left)
+			g.slice = 0
+			running = append(running, g)
+			queue = append(queue[:i], queue[i+1:]...)
+			i--
+		}
+
+		if len(running) == 0 {
+			// Nothing to do until the next gang is ready.
+			now = pending[0].ready
+			continue
+		}
+		for _, g := range queue {
+			for _, t := range g.unfinished() {
+				t.Waited++
+				if free > 0 {
+					held[t]++
+				}
+			}
+		}
+		if len(queue) > 0 {
+			fragmented += int64(free)
+		}
+		for cpu, t := range owner {
+			if t == nil {
+				continue
+			}
+			busy++
+			t.Remaining--
+			if i, ok := last[t]; ok && gantt[i].CPU == cpu && gantt[i].Stop == now {
+				gantt[i].Stop++
+			} else {
+				last[t] = len(gantt)
+				gantt = append(gantt, TimeSlice{PID: t.ProcessID, Start: now, Stop: now + 1, CPU: cpu})
+			}
+			if t.Remaining <= 0 {
+				t.Exit = now + 1
+				owner[cpu] = nil
+				done++
+			}
+		}
+		for i := 0; i < len(running); i++ {
+			running[i].slice++
+			if len(running[i].unfinished()) == 0 {
+				running = append(running[:i], running[i+1:]...)
+				i--
+			}
+		}
+		now++
+	}
+
+	r := taskReport(title, tasks, gantt)
+	group, heldCol := Column{Header: "Group"}, Column{Header: "Held"}
+	var total int64
+	for _, t := range tasks {
+		g := "-"
+		if t.Group != 0 {
+			g = fmt.Sprint(t.Group)
+		}
+		group.Values = append(group.Values, g)
+		heldCol.Values = append(heldCol.Values, fmt.Sprint(held[t]))
+		total += held[t]
+	}
+	heldCol.Footer = fmt.Sprintf("Total\n%d", total)
+	r.Columns = append(r.Columns, group, heldCol)
+
+	var span int64
+	for _, t := range tasks {
+		if t.Exit > span {
+			span = t.Exit
+		}
+	}
+	slots := span * int64(cpus)
+	idle := slots - busy
+	share := 0.0
+	if slots > 0 {
+		share = 100 * float64(idle) / float64(slots)
+	}
+	r.Notes = append(r.Notes, fmt.Sprintf(
+		"%d CPUs, quantum %d: %d of %d CPU slots idle (%.1f%%), %d of them fragmentation, free while a gang waited for enough CPUs",
+		cpus, quantum, idle, slots, share, fragmented))
+	return r, nil
+}
+
+//endregion
@@ -0,0 +1,145 @@
+package scheduler
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGang(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		cpus      int
+		quantum   int64
+		wantGantt []TimeSlice
+		wantHeld  []string
+		wantNote  string
+		wantErr   bool
+	}{
+		{
+			name: "a group runs on every CPU at once",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 3, Group: 1}, {ProcessID: 2, BurstDuration: 3, Group: 1},
+				{ProcessID: 3, BurstDuration: 2},
+			},
+			cpus: 2,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 0, Stop: 3, CPU: 1}, {PID: 3, Start: 3, Stop: 5},
+			},
+			wantHeld: []string{"0", "0", "0"},
+			wantNote: "2 CPUs, quantum 0: 2 of 10 CPU slots idle (20.0%), 0 of them fragmentation, free while a gang waited for enough CPUs",
+		},
+		{
+			name: "free CPUs idle while a gang waits for enough of them",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 4},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Group: 2}, {ProcessID: 3, ArrivalTime: 1, BurstDuration: 2, Group: 2},
+				{ProcessID: 4, ArrivalTime: 1, BurstDuration: 2, Group: 2},
+			},
+			cpus: 3,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 6}, {PID: 3, Start: 4, Stop: 6, CPU: 1},
+				{PID: 4, Start: 4, Stop: 6, CPU: 2},
+			},
+			wantHeld: []string{"0", "3", "3", "3"},
+			wantNote: "3 CPUs, quantum 0: 8 of 18 CPU slots idle (44.4%), 6 of them fragmentation, free while a gang waited for enough CPUs",
+		},
+		{
+			name: "gangs take turns a quantum at a time",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 4, Group: 1}, {ProcessID: 2, BurstDuration: 4, Group: 1},
+				{ProcessID: 3, BurstDuration: 2}, {ProcessID: 4, BurstDuration: 2},
+			},
+			cpus:    2,
+			quantum: 2,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 0, Stop: 2, CPU: 1}, {PID: 3, Start: 2, Stop: 4},
+				{PID: 4, Start: 2, Stop: 4, CPU: 1}, {PID: 1, Start: 4, Stop: 6}, {PID: 2, Start: 4, Stop: 6, CPU: 1},
+			},
+			wantHeld: []string{"0", "0", "0", "0"},
+			wantNote: "2 CPUs, quantum 2: 0 of 12 CPU slots idle (0.0%), 0 of them fragmentation, free while a gang waited for enough CPUs",
+		},
+		{
+			name: "a group larger than the machine",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 1, Group: 1}, {ProcessID: 2, BurstDuration: 1, Group: 1},
+			},
+			cpus:    1,
+			wantErr: true,
+		},
+		{name: "no CPUs", processes: []Process{{ProcessID: 1, BurstDuration: 1}}, wantErr: true},
+		{
+			name:      "burst cycles",
+			processes: []Process{{ProcessID: 1, BurstDuration: 2, Bursts: []int64{1, 1, 1}}},
+			cpus:      1,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r, err := Gang("Gang", tt.processes, tt.cpus, tt.quantum)
+			if (err != nil) != tt.wantErr || err != nil && !errors.Is(err, ErrInvalidArgs) {
+				t.Fatalf("Gang() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("Gang() Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			if held := r.Columns[1]; !reflect.DeepEqual(held.Values, tt.wantHeld) {
+				t.Errorf("Gang() held = %q, want %q", held.Values, tt.wantHeld)
+			}
+			if !reflect.DeepEqual(r.Notes, []string{tt.wantNote}) {
+				t.Errorf("Gang() notes = %q, want %q", r.Notes, tt.wantNote)
+			}
+		})
+	}
+}
+
+func TestGangSchedule(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	processes := []Process{{ProcessID: 1, BurstDuration: 2, Group: 1}, {ProcessID: 2, BurstDuration: 2, Group: 1}}
+	if err := GangSchedule(&w, "Gang scheduling, 2 CPUs", processes, 2, 0); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"CPU 0", "CPU 1"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("GangSchedule() = %q, want a lane for %s", w.String(), want)
+		}
+	}
+}
+
+func Test_loadProcessesGroup(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader("1,5,0,0,,,,,2\n2,5,0,0,,,,,2\n3,5,0,0,,,,,\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []int64
+	for _, p := range processes {
+		got = append(got, p.Group)
+	}
+	if want := []int64{2, 2, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("loadProcesses() groups = %v, want %v", got, want)
+	}
+
+	var b bytes.Buffer
+	if err := outputProcessesCSV(&b, processes); err != nil {
+		t.Fatal(err)
+	}
+	if want := "1,5,0,0,100,batch,0,,2\n2,5,0,0,100,batch,0,,2\n3,5,0,0,100,batch,0,,0\n"; b.String() != want {
+		t.Errorf("outputProcessesCSV() = %q, want %q", b.String(), want)
+	}
+
+	if _, err := loadProcesses(strings.NewReader("1,5,0,0,,,,,-1\n")); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("loadProcesses(group -1) error = %v, want ErrInvalidArgs", err)
+	}
+}
@@ -0,0 +1,48 @@
+package scheduler
+
+//region Comparator policies
+
+type (
+	// ProcessState is what a comparator sees of a task: a copy of its state
+	// at the tick being scheduled.
+	ProcessState struct {
+		Task
+		// Now is the tick being scheduled.
+		Now int64
+	}
+
+	// GenericPriorityScheduler runs the task that Less orders first, so a
+	// selection policy can be written as a comparator and still get the
+	// engine's queueing, preemption and metrics. Tasks Less leaves
+	// unordered run in the order they joined the ready queue. A preemptive
+	// scheduler also compares the running task with the ready ones each
+	// tick, and switches only when a ready task is strictly first.
+	GenericPriorityScheduler struct {
+		// Name titles the reports; it defaults to "Generic priority".
+		Name       string
+		Less       func(a, b ProcessState) bool
+		Preemptive bool
+	}
+)
+
+func (g GenericPriorityScheduler) Pick(now int64, running *Task, ready []*Task) *Task {
+	if running != nil && !g.Preemptive {
+		return running
+	}
+	candidates := ready
+	if running != nil {
+		candidates = append([]*Task{running}, ready...)
+	}
+	return minTask(candidates, func(a, b *Task) bool {
+		return g.Less(ProcessState{Task: *a, Now: now}, ProcessState{Task: *b, Now: now})
+	})
+}
+
+func (g GenericPriorityScheduler) title() string {
+	if g.Name != "" {
+		return g.Name
+	}
+	return "Generic priority"
+}
+
+//endregion
@@ -0,0 +1,71 @@
+package scheduler
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_GenericPriorityScheduler(t *testing.T) {
+	t.Parallel()
+	w, err := ReadWorkload(strings.NewReader("1,6,0,3\n2,3,1,1\n3,1,2,2\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	shortestRemaining := func(a, b ProcessState) bool { return a.Remaining < b.Remaining }
+	tests := []struct {
+		name      string
+		scheduler GenericPriorityScheduler
+		wantTitle string
+		wantGantt []TimeSlice
+	}{
+		{
+			name:      "shortest remaining, preemptive",
+			scheduler: GenericPriorityScheduler{Name: "SRTF", Less: shortestRemaining, Preemptive: true},
+			wantTitle: "SRTF",
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 2}, {PID: 3, Start: 2, Stop: 3},
+				{PID: 2, Start: 3, Stop: 5}, {PID: 1, Start: 5, Stop: 10},
+			},
+		},
+		{
+			name:      "shortest remaining, non-preemptive",
+			scheduler: GenericPriorityScheduler{Less: shortestRemaining},
+			wantTitle: "Generic priority",
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 6}, {PID: 3, Start: 6, Stop: 7}, {PID: 2, Start: 7, Stop: 10}},
+		},
+		{
+			name: "highest response ratio",
+			scheduler: GenericPriorityScheduler{Less: func(a, b ProcessState) bool {
+				ra := float64(a.Now-a.ArrivalTime) / float64(a.BurstDuration)
+				rb := float64(b.Now-b.ArrivalTime) / float64(b.BurstDuration)
+				return ra > rb
+			}},
+			wantTitle: "Generic priority",
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 6}, {PID: 3, Start: 6, Stop: 7}, {PID: 2, Start: 7, Stop: 10}},
+		},
+		{
+			name:      "unordered",
+			scheduler: GenericPriorityScheduler{Less: func(a, b ProcessState) bool { return false }, Preemptive: true},
+			wantTitle: "Generic priority",
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 6}, {PID: 2, Start: 6, Stop: 9}, {PID: 3, Start: 9, Stop: 10}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			res, err := Simulate(context.Background(), w, tt.scheduler)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Title != tt.wantTitle {
+				t.Errorf("Title = %q, want %q", res.Title, tt.wantTitle)
+			}
+			if !reflect.DeepEqual(res.Gantt, tt.wantGantt) {
+				t.Errorf("Gantt = %v, want %v", res.Gantt, tt.wantGantt)
+			}
+		})
+	}
+}
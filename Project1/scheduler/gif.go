@@ -0,0 +1,314 @@
+package scheduler
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+//region GIF export
+
+const (
+	gifLaneHeight  = 18
+	gifLabelWidth  = 40
+	gifAxisHeight  = 20
+	gifMargin      = 6
+	gifMaxWidth    = 720
+	gifMaxFrames   = 240
+	gifFrameDelay  = 25  // hundredths of a second between frames
+	gifFinalDelay  = 300 // hold the finished chart before looping
+	gifGlyphScale  = 2
+	gifGlyphWidth  = 3
+	gifGlyphHeight = 5
+)
+
+// Palette indexes; process colors start at gifProcessColor.
+const (
+	gifBackground = iota
+	gifInk
+	gifReady
+	gifGrid
+	gifProcessColor
+)
+
+var gifPalette = color.Palette{
+	color.RGBA{0xff, 0xff, 0xff, 0xff}, // background
+	color.RGBA{0x20, 0x20, 0x20, 0xff}, // ink: labels, cursor, completion marks
+	color.RGBA{0xdd, 0xdd, 0xdd, 0xff}, // ready but not running
+	color.RGBA{0xaa, 0xaa, 0xaa, 0xff}, // grid
+	color.RGBA{0x1f, 0x77, 0xb4, 0xff},
+	color.RGBA{0xff, 0x7f, 0x0e, 0xff},
+	color.RGBA{0x2c, 0xa0, 0x2c, 0xff},
+	color.RGBA{0xd6, 0x27, 0x28, 0xff},
+	color.RGBA{0x94, 0x67, 0xbd, 0xff},
+	color.RGBA{0x8c, 0x56, 0x4b, 0xff},
+	color.RGBA{0xe3, 0x77, 0xc2, 0xff},
+	color.RGBA{0x7f, 0x7f, 0x7f, 0xff},
+	color.RGBA{0xbc, 0xbd, 0x22, 0xff},
+	color.RGBA{0x17, 0xbe, 0xcf, 0xff},
+}
+
+// gifDigits is a 3x5 bitmap font for the digits, minus sign and the C that
+// labels CPU lanes, one string per row.
+var gifDigits = map[rune][gifGlyphHeight]string{
+	'C': {"###", "#..", "#..", "#..", "###"},
+	'0': {"###", "#.#", "#.#", "#.#", "###"},
+	'1': {".#.", "##.", ".#.", ".#.", "###"},
+	'2': {"###", "..#", "###", "#..", "###"},
+	'3': {"###", "..#", "###", "..#", "###"},
+	'4': {"#.#", "#.#", "###", "..#", "..#"},
+	'5': {"###", "#..", "###", "..#", "###"},
+	'6': {"###", "#..", "###", "#.#", "###"},
+	'7': {"###", "..#", "..#", "..#", "..#"},
+	'8': {"###", "#.#", "###", "#.#", "###"},
+	'9': {"###", "#.#", "###", "..#", "###"},
+	'-': {"...", "...", "###", "...", "..."},
+}
+
+// writeGIFs writes one animated GIF per report into dir, naming each file after the report title.
+func writeGIFs(dir string, processes []Process, reports []Report) error {
+	for _, r := range reports {
+		name := filepath.Join(dir, slugify(r.Title)+".gif")
+		f, err := os.Create(name)
+		if err != nil {
+			return fmt.Errorf("%w: creating GIF", err)
+		}
+		if err := outputGIF(f, processes, r.Gantt); err != nil {
+			_ = f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("%w: closing GIF", err)
+		}
+	}
+
+	return nil
+}
+
+// outputGIF renders the construction of a Gantt chart as an animated GIF.
+// Each process gets a lane; a frame is drawn per time unit (or per few
+// units for long schedules, which are also squeezed to gifMaxWidth pixels)
+// showing which processes have arrived, which one is running, where it was
+// preempted, and when each one completes. A run on several CPUs adds a lane
+// per CPU, labelled C0, C1 and so on, and marks slices whose process last
+// ran on another CPU with a notch at their start.
+func outputGIF(w io.Writer, processes []Process, gantt []TimeSlice) error {
+	if len(gantt) == 0 {
+		return fmt.Errorf("%w: nothing to render", ErrInvalidArgs)
+	}
+
+	lanes, arrival := gifLanes(processes, gantt)
+	cpus := cpuCount(gantt)
+	if cpus == 1 {
+		cpus = 0
+	}
+	migrated := migrations(gantt)
+	completion := make(map[int64]int64)
+	origin, end := gantt[0].Start, gantt[0].Stop
+	for _, s := range gantt {
+		if s.Start < origin {
+			origin = s.Start
+		}
+		if s.Stop > end {
+			end = s.Stop
+		}
+		if !s.Overhead && s.Stop > completion[s.PID] {
+			completion[s.PID] = s.Stop
+		}
+	}
+	if origin > 0 {
+		origin = 0
+	}
+	span := end - origin
+	if span <= 0 {
+		return fmt.Errorf("%w: empty schedule", ErrInvalidArgs)
+	}
+
+	// Up to 24 pixels a tick for short schedules, and several ticks a
+	// pixel for ones longer than gifMaxWidth.
+	width := int64(gifMaxWidth)
+	if unit := gifMaxWidth / span; unit >= 1 {
+		if unit > 24 {
+			unit = 24
+		}
+		width = span * unit
+	}
+	step := (span + gifMaxFrames - 1) / gifMaxFrames
+
+	bounds := image.Rect(0, 0,
+		gifLabelWidth+int(width)+2*gifMargin+gifLabelWidth,
+		(len(lanes)+cpus)*gifLaneHeight+gifAxisHeight+2*gifMargin)
+	x := func(t int64) int { return gifLabelWidth + gifMargin + int((t-origin)*width/span) }
+	laneY := func(i int) int { return gifMargin + i*gifLaneHeight }
+
+	anim := &gif.GIF{}
+	for now := origin; ; now += step {
+		if now > end {
+			now = end
+		}
+		img := image.NewPaletted(bounds, gifPalette)
+		fill(img, img.Bounds(), gifBackground)
+
+		for i, pid := range lanes {
+			y := laneY(i)
+			drawNumber(img, gifMargin, y+4, pid, gifInk)
+
+			// Arrived and not yet finished: shade the lane as ready.
+			if a, ok := arrival[pid]; ok && a < now {
+				stop := now
+				if c := completion[pid]; c < stop {
+					stop = c
+				}
+				if stop > a {
+					fill(img, image.Rect(x(a), y+4, x(stop), y+gifLaneHeight-4), gifReady)
+				}
+			}
+		}
+
+		for cpu := 0; cpu < cpus; cpu++ {
+			drawText(img, gifMargin, laneY(len(lanes)+cpu)+4, fmt.Sprintf("C%d", cpu), gifInk)
+		}
+
+		for j, s := range gantt {
+			if s.Start >= now || s.Overhead {
+				continue
+			}
+			stop := s.Stop
+			if stop > now {
+				stop = now
+			}
+			i := laneIndex(lanes, s.PID)
+			c := uint8(gifProcessColor + i%(len(gifPalette)-gifProcessColor))
+			// Keep slices shorter than a pixel visible.
+			x0, x1 := x(s.Start), x(stop)
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+			fill(img, image.Rect(x0, laneY(i)+2, x1, laneY(i)+gifLaneHeight-2), c)
+			if cpus > 0 {
+				cpuY := laneY(len(lanes) + s.CPU)
+				fill(img, image.Rect(x0, cpuY+2, x1, cpuY+gifLaneHeight-2), c)
+				if migrated[j] {
+					fill(img, image.Rect(x0, laneY(i), x0+3, laneY(i)+4), gifInk)
+					fill(img, image.Rect(x0, cpuY, x0+3, cpuY+4), gifInk)
+				}
+			}
+			// A slice that ended before completion was preempted; mark the break.
+			if s.Stop <= now && s.Stop < completion[s.PID] {
+				fill(img, image.Rect(x(s.Stop)-1, laneY(i)+2, x(s.Stop), laneY(i)+gifLaneHeight-2), gifGrid)
+			}
+		}
+
+		for i, pid := range lanes {
+			if c := completion[pid]; c <= now {
+				fill(img, image.Rect(x(c), laneY(i), x(c)+2, laneY(i)+gifLaneHeight), gifInk)
+			}
+		}
+
+		axis := laneY(len(lanes)+cpus) + 2
+		fill(img, image.Rect(x(origin), axis, x(end)+1, axis+1), gifGrid)
+		fill(img, image.Rect(x(now), gifMargin, x(now)+1, axis+4), gifInk)
+		drawNumber(img, x(now)+2, axis+4, now, gifInk)
+
+		delay := gifFrameDelay
+		if now == end {
+			delay = gifFinalDelay
+		}
+		anim.Image = append(anim.Image, img)
+		anim.Delay = append(anim.Delay, delay)
+		if now == end {
+			break
+		}
+	}
+
+	if err := gif.EncodeAll(w, anim); err != nil {
+		return fmt.Errorf("%w: encoding GIF", err)
+	}
+
+	return nil
+}
+
+// gifLanes returns the PIDs to draw, in ascending order, and their arrival
+// times. Context switches belong to no process and get no lane.
+func gifLanes(processes []Process, gantt []TimeSlice) ([]int64, map[int64]int64) {
+	arrival := make(map[int64]int64, len(processes))
+	for _, p := range processes {
+		arrival[p.ProcessID] = p.ArrivalTime
+	}
+	seen := make(map[int64]bool)
+	lanes := make([]int64, 0, len(arrival))
+	for _, s := range gantt {
+		if !s.Overhead && !seen[s.PID] {
+			seen[s.PID] = true
+			lanes = append(lanes, s.PID)
+		}
+	}
+	sort.Slice(lanes, func(i, j int) bool { return lanes[i] < lanes[j] })
+
+	return lanes, arrival
+}
+
+func laneIndex(lanes []int64, pid int64) int {
+	for i := range lanes {
+		if lanes[i] == pid {
+			return i
+		}
+	}
+	return -1
+}
+
+func fill(img *image.Paletted, r image.Rectangle, c uint8) {
+	r = r.Intersect(img.Bounds())
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			img.SetColorIndex(x, y, c)
+		}
+	}
+}
+
+func drawNumber(img *image.Paletted, x, y int, n int64, c uint8) {
+	drawText(img, x, y, fmt.Sprint(n), c)
+}
+
+// drawText draws s in the gifDigits font, skipping characters it lacks.
+func drawText(img *image.Paletted, x, y int, s string, c uint8) {
+	for _, r := range s {
+		glyph := gifDigits[r]
+		for row := range glyph {
+			for col, bit := range glyph[row] {
+				if bit != '#' {
+					continue
+				}
+				px, py := x+col*gifGlyphScale, y+row*gifGlyphScale
+				fill(img, image.Rect(px, py, px+gifGlyphScale, py+gifGlyphScale), c)
+			}
+		}
+		x += (gifGlyphWidth + 1) * gifGlyphScale
+	}
+}
+
+// slugify turns a chart title into a file-name friendly string.
+func slugify(title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+			dash = false
+		case !dash && b.Len() > 0:
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+//endregion
@@ -0,0 +1,138 @@
+package scheduler
+
+import (
+	"bytes"
+	"errors"
+	"image/gif"
+	"reflect"
+	"testing"
+)
+
+func Test_outputGIF(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	tests := []struct {
+		name       string
+		gantt      []TimeSlice
+		wantFrames int
+		wantWidth  int
+		wantHeight int
+		wantErr    error
+	}{
+		{
+			name:    "empty gantt",
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name: "frame per time unit",
+			gantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 5},
+				{PID: 2, Start: 5, Stop: 14},
+				{PID: 3, Start: 14, Stop: 20},
+			},
+			wantFrames: 21,
+			wantWidth:  2*gifLabelWidth + 2*gifMargin + 20*24,
+			wantHeight: 3*gifLaneHeight + gifAxisHeight + 2*gifMargin,
+		},
+		{
+			name: "lane per CPU",
+			gantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 5},
+				{PID: 2, Start: 3, Stop: 12, CPU: 1},
+				{PID: 1, Start: 12, Stop: 14, CPU: 1},
+			},
+			wantFrames: 15,
+			wantWidth:  2*gifLabelWidth + 2*gifMargin + 14*24,
+			wantHeight: 4*gifLaneHeight + gifAxisHeight + 2*gifMargin,
+		},
+		{
+			name: "long schedule",
+			gantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 50000},
+				{PID: 2, Start: 50000, Stop: 100000},
+				{PID: 3, Start: 100000, Stop: 100003},
+			},
+			wantFrames: gifMaxFrames + 1,
+			wantWidth:  2*gifLabelWidth + 2*gifMargin + gifMaxWidth,
+			wantHeight: 3*gifLaneHeight + gifAxisHeight + 2*gifMargin,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			err := outputGIF(&w, processes, tt.gantt)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			g, err := gif.DecodeAll(&w)
+			if err != nil {
+				t.Fatalf("decoding GIF: %v", err)
+			}
+			if got := len(g.Image); got != tt.wantFrames {
+				t.Errorf("frames = %d, want %d", got, tt.wantFrames)
+			}
+			if got := g.Config.Width; got != tt.wantWidth {
+				t.Errorf("width = %d, want %d", got, tt.wantWidth)
+			}
+			if got := g.Config.Height; got != tt.wantHeight {
+				t.Errorf("height = %d, want %d", got, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func Test_outputGIF_migration(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 5},
+		{PID: 2, Start: 3, Stop: 12, CPU: 1},
+		{PID: 1, Start: 12, Stop: 14, CPU: 1},
+	}
+	var w bytes.Buffer
+	if err := outputGIF(&w, nil, gantt); err != nil {
+		t.Fatal(err)
+	}
+	g, err := gif.DecodeAll(&w)
+	if err != nil {
+		t.Fatalf("decoding GIF: %v", err)
+	}
+	last := g.Image[len(g.Image)-1]
+	x := func(t int) int { return gifLabelWidth + gifMargin + t*24 }
+	laneY := func(i int) int { return gifMargin + i*gifLaneHeight }
+	tests := []struct {
+		name string
+		x, y int
+		want uint8
+	}{
+		{name: "process 1 moved to CPU 1", x: x(12), y: laneY(0) + 1, want: gifInk},
+		{name: "on CPU 1's lane", x: x(12), y: laneY(3) + 1, want: gifInk},
+		{name: "process 2 didn't move", x: x(3), y: laneY(1) + 1, want: gifBackground},
+	}
+	for _, tt := range tests {
+		if got := last.ColorIndexAt(tt.x, tt.y); got != tt.want {
+			t.Errorf("%s: color at (%d, %d) = %d, want %d", tt.name, tt.x, tt.y, got, tt.want)
+		}
+	}
+}
+
+func Test_gifLanes(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{
+		{PID: 2, Start: 0, Stop: 3},
+		{Start: 3, Stop: 4, Overhead: true},
+		{PID: 1, Start: 4, Stop: 6},
+	}
+	lanes, _ := gifLanes(nil, gantt)
+	if want := []int64{1, 2}; !reflect.DeepEqual(lanes, want) {
+		t.Errorf("gifLanes() = %v, want %v", lanes, want)
+	}
+}
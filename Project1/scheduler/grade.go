@@ -0,0 +1,224 @@
+package scheduler
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+//region Grading
+
+// gradeCheck is a single metric compared between the expected and submitted results.
+type gradeCheck struct {
+	Algorithm string
+	What      string
+	Got, Want float64
+	Tolerance float64
+	Missing   bool
+}
+
+func (c gradeCheck) passed() bool {
+	return !c.Missing && math.Abs(c.Got-c.Want) <= c.Tolerance
+}
+
+// runGrade implements the grade subcommand:
+//
+//	grade -expected results.json student_output.json
+//	grade -expected results.json student_schedule.csv
+//
+// The submission is either a JSON results file (as written by -json) or a CSV
+// of the student's schedule, read by readScheduleCSV. Every per-process wait,
+// turnaround and exit time, and every footer average, is checked against the
+// expected results and a score breakdown per algorithm is written to w.
+func runGrade(w io.Writer, args ...string) error {
+	fs := flag.NewFlagSet("grade", flag.ContinueOnError)
+	fs.SetOutput(w)
+	expectedPath := fs.String("expected", "", "JSON results to grade against")
+	tolerance := fs.Float64("tolerance", 0, "allowed difference for per-process times")
+	avgTolerance := fs.Float64("avg-tolerance", 0.01, "allowed difference for averages and throughput")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	if *expectedPath == "" || fs.NArg() != 1 {
+		return fmt.Errorf("%w: usage: grade -expected results.json <submission.json|schedule.csv>", ErrInvalidArgs)
+	}
+
+	expected, err := readReports(*expectedPath)
+	if err != nil {
+		return err
+	}
+	submitted, err := loadSubmission(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	checks := gradeReports(expected, submitted, *tolerance, *avgTolerance)
+	outputGrade(w, expected, checks)
+
+	return nil
+}
+
+// loadSubmission reads submitted results, either JSON reports or a CSV schedule.
+func loadSubmission(path string) ([]Report, error) {
+	if !strings.EqualFold(filepath.Ext(path), ".csv") {
+		return readReports(path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("%v: error opening submission", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	return readScheduleCSV(f)
+}
+
+// readScheduleCSV reads a schedule worked out by hand, one
+// algorithm,pid,wait,turnaround,exit row per process, after an optional
+// header. The rows of each algorithm make up a report whose averages and
+// throughput are computed from them, as the schedulers compute theirs.
+func readScheduleCSV(r io.Reader) ([]Report, error) {
+	in := csv.NewReader(r)
+	in.FieldsPerRecord = 5
+	in.TrimLeadingSpace = true
+	records, err := in.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading schedule: %v", ErrInvalidArgs, err)
+	}
+	if len(records) > 0 && strings.EqualFold(records[0][0], "algorithm") {
+		records = records[1:]
+	}
+
+	var reports []Report
+	index := make(map[string]int)
+	for line, rec := range records {
+		var nums [4]int64
+		for i, f := range rec[1:] {
+			if nums[i], err = strconv.ParseInt(f, 10, 64); err != nil {
+				return nil, fmt.Errorf("%w: schedule row %d: %v", ErrInvalidArgs, line+1, err)
+			}
+		}
+		i, ok := index[rec[0]]
+		if !ok {
+			i = len(reports)
+			index[rec[0]] = i
+			reports = append(reports, Report{Title: rec[0]})
+		}
+		reports[i].Rows = append(reports[i].Rows, Row{ProcessID: nums[0], Wait: nums[1], Turnaround: nums[2], Exit: nums[3]})
+	}
+
+	for i := range reports {
+		var wait, turnaround float64
+		var last int64
+		for _, row := range reports[i].Rows {
+			wait += float64(row.Wait)
+			turnaround += float64(row.Turnaround)
+			if row.Exit > last {
+				last = row.Exit
+			}
+		}
+		count := float64(len(reports[i].Rows))
+		reports[i].Wait = wait / count
+		reports[i].Turnaround = turnaround / count
+		if last > 0 {
+			reports[i].Throughput = count / float64(last)
+		}
+	}
+	return reports, nil
+}
+
+// gradeReports compares each expected report against the submitted report with the same title.
+func gradeReports(expected, submitted []Report, tolerance, avgTolerance float64) []gradeCheck {
+	var checks []gradeCheck
+	for _, want := range expected {
+		got, found := findReport(submitted, want.Title)
+		check := func(what string, g, w, tol float64, missing bool) {
+			checks = append(checks, gradeCheck{
+				Algorithm: want.Title,
+				What:      what,
+				Got:       g,
+				Want:      w,
+				Tolerance: tol,
+				Missing:   missing || !found,
+			})
+		}
+
+		for _, row := range want.Rows {
+			i := rowIndex(got.Rows, row.ProcessID)
+			var r Row
+			if i >= 0 {
+				r = got.Rows[i]
+			}
+			prefix := fmt.Sprintf("process %d ", row.ProcessID)
+			check(prefix+"wait", float64(r.Wait), float64(row.Wait), tolerance, i < 0)
+			check(prefix+"turnaround", float64(r.Turnaround), float64(row.Turnaround), tolerance, i < 0)
+			check(prefix+"exit", float64(r.Exit), float64(row.Exit), tolerance, i < 0)
+		}
+		check("average wait", got.Wait, want.Wait, avgTolerance, false)
+		check("average turnaround", got.Turnaround, want.Turnaround, avgTolerance, false)
+		check("throughput", got.Throughput, want.Throughput, avgTolerance, false)
+	}
+
+	return checks
+}
+
+// findReport finds a report by title, ignoring case and punctuation.
+func findReport(reports []Report, title string) (Report, bool) {
+	for _, r := range reports {
+		if slugify(r.Title) == slugify(title) {
+			return r, true
+		}
+	}
+	return Report{}, false
+}
+
+func outputGrade(w io.Writer, expected []Report, checks []gradeCheck) {
+	_, _ = fmt.Fprintln(w, "Grade")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Checks", "Passed", "Score"})
+
+	var total float64
+	for _, r := range expected {
+		var count, passed int
+		for _, c := range checks {
+			if c.Algorithm != r.Title {
+				continue
+			}
+			count++
+			if c.passed() {
+				passed++
+			}
+		}
+		score := 100.0
+		if count > 0 {
+			score = 100 * float64(passed) / float64(count)
+		}
+		total += score
+		table.Append([]string{r.Title, fmt.Sprint(count), fmt.Sprint(passed), fmt.Sprintf("%.1f%%", score)})
+	}
+	if len(expected) > 0 {
+		total /= float64(len(expected))
+	}
+	table.SetFooter([]string{"", "", "Total", fmt.Sprintf("%.1f%%", total)})
+	table.Render()
+
+	for _, c := range checks {
+		switch {
+		case c.passed():
+		case c.Missing:
+			_, _ = fmt.Fprintf(w, "%s: %s missing\n", c.Algorithm, c.What)
+		default:
+			_, _ = fmt.Fprintf(w, "%s: %s = %g, want %g (±%g)\n", c.Algorithm, c.What, c.Got, c.Want, c.Tolerance)
+		}
+	}
+}
+
+//endregion
@@ -0,0 +1,122 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_gradeReports(t *testing.T) {
+	t.Parallel()
+	expected := []Report{{
+		Title: "First-come, first-serve",
+		Rows: []Row{
+			{ProcessID: 1, Wait: 0, Turnaround: 5, Exit: 5},
+			{ProcessID: 2, Wait: 2, Turnaround: 11, Exit: 14},
+		},
+		Wait:       1,
+		Turnaround: 8,
+		Throughput: 0.14,
+	}}
+	tests := []struct {
+		name       string
+		submitted  []Report
+		tolerance  float64
+		wantPassed int
+	}{
+		{
+			name:       "identical",
+			submitted:  expected,
+			wantPassed: 9,
+		},
+		{
+			name: "title matched loosely, one wait off",
+			submitted: []Report{{
+				Title: "first come first serve",
+				Rows: []Row{
+					{ProcessID: 1, Wait: 0, Turnaround: 5, Exit: 5},
+					{ProcessID: 2, Wait: 3, Turnaround: 11, Exit: 14},
+				},
+				Wait:       1,
+				Turnaround: 8,
+				Throughput: 0.14,
+			}},
+			wantPassed: 8,
+		},
+		{
+			name: "within tolerance",
+			submitted: []Report{{
+				Title: "First-come, first-serve",
+				Rows: []Row{
+					{ProcessID: 1, Wait: 1, Turnaround: 6, Exit: 6},
+					{ProcessID: 2, Wait: 2, Turnaround: 11, Exit: 14},
+				},
+				Wait:       1,
+				Turnaround: 8,
+				Throughput: 0.14,
+			}},
+			tolerance:  1,
+			wantPassed: 9,
+		},
+		{
+			name:       "algorithm missing",
+			submitted:  []Report{{Title: "Round-robin"}},
+			wantPassed: 0,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			checks := gradeReports(expected, tt.submitted, tt.tolerance, 0.01)
+			if len(checks) != 9 {
+				t.Fatalf("checks = %d, want 9", len(checks))
+			}
+			var passed int
+			for _, c := range checks {
+				if c.passed() {
+					passed++
+				}
+			}
+			if passed != tt.wantPassed {
+				t.Errorf("passed = %d, want %d", passed, tt.wantPassed)
+			}
+		})
+	}
+}
+
+func Test_readScheduleCSV(t *testing.T) {
+	t.Parallel()
+	in := `algorithm,pid,wait,turnaround,exit
+First-come first-serve,1,0,5,5
+First-come first-serve,2,2,11,14
+Round-robin,1,4,9,9
+`
+	want := []Report{
+		{
+			Title:      "First-come first-serve",
+			Rows:       []Row{{ProcessID: 1, Turnaround: 5, Exit: 5}, {ProcessID: 2, Wait: 2, Turnaround: 11, Exit: 14}},
+			Wait:       1,
+			Turnaround: 8,
+			Throughput: 2.0 / 14,
+		},
+		{
+			Title:      "Round-robin",
+			Rows:       []Row{{ProcessID: 1, Wait: 4, Turnaround: 9, Exit: 9}},
+			Wait:       4,
+			Turnaround: 9,
+			Throughput: 1.0 / 9,
+		},
+	}
+	got, err := readScheduleCSV(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readScheduleCSV() = %+v, want %+v", got, want)
+	}
+	if _, err := readScheduleCSV(strings.NewReader("FCFS,1,x,5,5\n")); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("bad number: error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
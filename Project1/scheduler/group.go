@@ -0,0 +1,294 @@
+package scheduler
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+//region Hierarchical fairness groups
+
+type (
+	// Group is a cgroup-like node of the fairness hierarchy. Its weight is
+	// its CPU share relative to its siblings, which are the other groups and
+	// processes directly in its parent.
+	Group struct {
+		// Path is the group's slash separated name, e.g. "web/api"; the root is "".
+		Path     string
+		Weight   int64
+		PIDs     map[int64]bool
+		Children []*Group
+	}
+
+	// GroupPolicy shares the CPU fairly down the group hierarchy: starting
+	// at the root, it descends into the child group or process with the
+	// lowest virtual time (ticks run scaled by defaultWeight/weight) among
+	// those with something runnable, so every level gets its weighted share
+	// of what its parent gets. A group or process that becomes runnable
+	// starts no lower than its busy siblings, so it can't claim the CPU for
+	// the time it was idle.
+	GroupPolicy struct {
+		Root *Group
+		// nodes are the groups and, once they arrive, the tasks, by key.
+		nodes map[interface{}]*groupNode
+	}
+
+	groupNode struct {
+		weight   int64
+		parent   *groupNode
+		children []*groupNode
+		task     *Task
+		group    *Group
+		vtime    float64
+		ran      int64
+		// active is whether the node had a runnable task at the last pick.
+		active bool
+	}
+)
+
+// groupOf returns the deepest group pid is placed in, or g itself.
+func (g *Group) groupOf(pid int64) *Group {
+	for _, c := range g.Children {
+		if found := c.groupOf(pid); found != c || c.PIDs[pid] {
+			return found
+		}
+	}
+	return g
+}
+
+// flatten returns the weights that give tasks, competing flat for the CPU,
+// the shares the hierarchy under g entitles them to. A task's share is its
+// weight's part of the weights of its siblings, counting only groups with
+// one of tasks in them, times its group's share of its parent, and so on up
+// to g. Group weights are in process weight units, a group of
+// defaultWeight weighing unit against weight. Without groups every task
+// keeps its own weight.
+func (g *Group) flatten(tasks []*Task, weight func(*Task) int64, unit int64) map[*Task]int64 {
+	direct := make(map[*Group][]*Task)
+	busy := make(map[*Group]bool)
+	var total int64
+	for _, t := range tasks {
+		in := g.groupOf(t.ProcessID)
+		direct[in] = append(direct[in], t)
+		total += weight(t)
+	}
+	var mark func(n *Group) bool
+	mark = func(n *Group) bool {
+		busy[n] = len(direct[n]) > 0
+		for _, c := range n.Children {
+			if mark(c) {
+				busy[n] = true
+			}
+		}
+		return busy[n]
+	}
+	mark(g)
+
+	weights := make(map[*Task]int64, len(tasks))
+	var walk func(n *Group, share float64)
+	walk = func(n *Group, share float64) {
+		groupWeight := func(c *Group) float64 { return float64(c.Weight) * float64(unit) / defaultWeight }
+		var siblings float64
+		for _, t := range direct[n] {
+			siblings += float64(weight(t))
+		}
+		for _, c := range n.Children {
+			if busy[c] {
+				siblings += groupWeight(c)
+			}
+		}
+		for _, t := range direct[n] {
+			w := int64(math.Round(share * float64(weight(t)) / siblings * float64(total)))
+			if w < 1 {
+				w = 1
+			}
+			weights[t] = w
+		}
+		for _, c := range n.Children {
+			if busy[c] {
+				walk(c, share*groupWeight(c)/siblings)
+			}
+		}
+	}
+	walk(g, 1)
+	return weights
+}
+
+func (p *GroupPolicy) node(key interface{}) *groupNode {
+	if p.nodes == nil {
+		p.nodes = make(map[interface{}]*groupNode)
+		var add func(g *Group, parent *groupNode)
+		add = func(g *Group, parent *groupNode) {
+			n := &groupNode{weight: g.Weight, parent: parent, group: g}
+			if parent != nil {
+				parent.children = append(parent.children, n)
+			}
+			p.nodes[g] = n
+			for _, c := range g.Children {
+				add(c, n)
+			}
+		}
+		add(p.Root, nil)
+	}
+	return p.nodes[key]
+}
+
+func (p *GroupPolicy) Pick(_ int64, running *Task, ready []*Task) *Task {
+	root := p.node(p.Root)
+	runnable := ready
+	if running != nil {
+		runnable = append([]*Task{running}, ready...)
+	}
+	if len(runnable) == 0 {
+		return nil
+	}
+
+	busy := make(map[*groupNode]bool)
+	for _, t := range runnable {
+		n := p.nodes[t]
+		if n == nil {
+			parent := p.node(p.Root.groupOf(t.ProcessID))
+			n = &groupNode{weight: t.weight(), parent: parent, task: t}
+			parent.children = append(parent.children, n)
+			p.nodes[t] = n
+		}
+		for ; n != nil; n = n.parent {
+			busy[n] = true
+		}
+	}
+	var onPath map[*groupNode]bool
+	if running != nil {
+		onPath = make(map[*groupNode]bool)
+		for n := p.nodes[running]; n != nil; n = n.parent {
+			onPath[n] = true
+		}
+	}
+
+	n := root
+	for n.task == nil {
+		// Nodes that just became runnable catch up with their busy siblings.
+		floor := math.Inf(1)
+		for _, c := range n.children {
+			if busy[c] && c.active {
+				floor = math.Min(floor, c.vtime)
+			}
+		}
+		var next *groupNode
+		for _, c := range n.children {
+			if !busy[c] {
+				continue
+			}
+			if !c.active && !math.IsInf(floor, 1) && c.vtime < floor {
+				c.vtime = floor
+			}
+			if next == nil || c.vtime < next.vtime || c.vtime == next.vtime && onPath[c] {
+				next = c
+			}
+		}
+		n = next
+	}
+	for _, c := range p.nodes {
+		c.active = busy[c]
+	}
+
+	for c := n; c != nil; c = c.parent {
+		c.ran++
+		c.vtime += float64(defaultWeight) / float64(c.weight)
+	}
+	return n.task
+}
+
+// annotate adds each task's group and response time, and compares every
+// group's configured share of its parent with the share it got. The two
+// only match while all the siblings have work to do.
+func (p *GroupPolicy) annotate(r *Report, tasks []*Task) {
+	r.Columns = append(r.Columns, groupColumn(p.Root, tasks), responseColumn(tasks))
+
+	var walk func(n *groupNode)
+	walk = func(n *groupNode) {
+		var siblings int64
+		for _, c := range n.children {
+			siblings += c.weight
+		}
+		for _, c := range n.children {
+			if c.group == nil {
+				continue
+			}
+			achieved := 0.0
+			if n.ran > 0 {
+				achieved = 100 * float64(c.ran) / float64(n.ran)
+			}
+			parent := n.group.Path
+			if parent == "" {
+				parent = "/"
+			}
+			r.Notes = append(r.Notes, fmt.Sprintf("Group %s: weight %d, configured %.1f%% of %s, achieved %.1f%%",
+				c.group.Path, c.weight, 100*float64(c.weight)/float64(siblings), parent, achieved))
+			walk(c)
+		}
+	}
+	walk(p.node(p.Root))
+}
+
+// groupColumn lists the group under root each task is in.
+func groupColumn(root *Group, tasks []*Task) Column {
+	col := Column{Header: "Group"}
+	for _, t := range tasks {
+		path := root.groupOf(t.ProcessID).Path
+		if path == "" {
+			path = "/"
+		}
+		col.Values = append(col.Values, path)
+	}
+	return col
+}
+
+// parseGroups parses a semicolon separated list of groups like
+// "web=300:1,2;web/api=200:4;db=100:3": each a slash separated path, its
+// weight in the same units as process weights, and optionally the IDs of
+// the processes directly in it. A group's parent must come before it.
+// Processes in no group are directly in the root.
+func parseGroups(spec string) (*Group, error) {
+	root := &Group{Weight: defaultWeight}
+	byPath := map[string]*Group{"": root}
+	for _, f := range strings.Split(spec, ";") {
+		if f = strings.TrimSpace(f); f == "" {
+			continue
+		}
+		kv := strings.SplitN(f, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("%w: want <path>=<weight>[:<pids>], got %q", ErrInvalidArgs, f)
+		}
+		path := strings.Trim(strings.TrimSpace(kv[0]), "/")
+		if path == "" || byPath[path] != nil {
+			return nil, fmt.Errorf("%w: group %q is empty or repeated", ErrInvalidArgs, kv[0])
+		}
+		parentPath := ""
+		if i := strings.LastIndex(path, "/"); i >= 0 {
+			parentPath = path[:i]
+		}
+		parent := byPath[parentPath]
+		if parent == nil {
+			return nil, fmt.Errorf("%w: group %q comes before its parent %q", ErrInvalidArgs, path, parentPath)
+		}
+
+		rest := strings.SplitN(kv[1], ":", 2)
+		weight, err := strconv.ParseInt(strings.TrimSpace(rest[0]), 10, 64)
+		if err != nil || weight <= 0 {
+			return nil, fmt.Errorf("%w: group %q needs a positive weight", ErrInvalidArgs, path)
+		}
+		g := &Group{Path: path, Weight: weight, PIDs: make(map[int64]bool)}
+		if len(rest) == 2 {
+			if g.PIDs, err = parsePIDs(rest[1]); err != nil {
+				return nil, err
+			}
+		}
+		parent.Children = append(parent.Children, g)
+		byPath[path] = g
+	}
+
+	return root, nil
+}
+
+//endregion
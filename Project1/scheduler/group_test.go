@@ -0,0 +1,169 @@
+package scheduler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseGroups(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		spec    string
+		want    map[int64]string // group path of each process
+		wantErr bool
+	}{
+		{
+			name: "nested",
+			spec: "web=300:1,2;web/api=200:4;db=100:3",
+			want: map[int64]string{1: "web", 2: "web", 3: "db", 4: "web/api", 5: ""},
+		},
+		{name: "parent missing", spec: "web/api=200:4", wantErr: true},
+		{name: "repeated", spec: "db=1;db=2", wantErr: true},
+		{name: "bad weight", spec: "db=0:3", wantErr: true},
+		{name: "no weight", spec: "db", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root, err := parseGroups(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseGroups() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got := make(map[int64]string)
+			for pid := range tt.want {
+				got[pid] = root.groupOf(pid).Path
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("groups = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_GroupPolicy(t *testing.T) {
+	t.Parallel()
+	// Each process needs exactly its group's share of the 50 ticks, so all
+	// the groups stay busy until the end.
+	processes, err := loadProcesses(strings.NewReader("1,15,0\n2,15,0\n3,10,0\n4,10,0\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		spec      string
+		wantNotes []string
+	}{
+		{
+			name: "nested",
+			spec: "a=300:1;a/x=100:2;b=100:3",
+			wantNotes: []string{
+				"Group a: weight 300, configured 60.0% of /, achieved 60.0%",
+				"Group a/x: weight 100, configured 50.0% of a, achieved 50.0%",
+				"Group b: weight 100, configured 20.0% of /, achieved 20.0%",
+			},
+		},
+		{
+			name: "flat",
+			spec: "a=300:1,2;b=100:3",
+			wantNotes: []string{
+				"Group a: weight 300, configured 60.0% of /, achieved 60.0%",
+				"Group b: weight 100, configured 20.0% of /, achieved 20.0%",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			root, err := parseGroups(tt.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := simulate(tt.name, processes, &GroupPolicy{Root: root})
+			if got := r.makespan(); got != 50 {
+				t.Errorf("makespan = %d, want 50", got)
+			}
+			if !reflect.DeepEqual(r.Notes, tt.wantNotes) {
+				t.Errorf("notes = %q, want %q", r.Notes, tt.wantNotes)
+			}
+		})
+	}
+}
+
+func Test_Group_flatten(t *testing.T) {
+	t.Parallel()
+	root, err := parseGroups("a=100:1,2,3;b=100:4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tasks := make([]*Task, 5)
+	for i := range tasks {
+		tasks[i] = &Task{Process: Process{ProcessID: int64(i + 1)}}
+	}
+	tests := []struct {
+		name  string
+		tasks []*Task
+		want  []int64
+	}{
+		// Groups a and b and process 5 in the root each get a third;
+		// a splits its third three ways.
+		{name: "all runnable", tasks: tasks, want: []int64{56, 56, 56, 167, 167}},
+		// With b idle, a and process 5 share the CPU.
+		{name: "group idle", tasks: []*Task{tasks[0], tasks[4]}, want: []int64{100, 100}},
+		// Without groups, every task keeps its weight.
+		{name: "one group", tasks: tasks[:3], want: []int64{100, 100, 100}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			weights := root.flatten(tt.tasks, (*Task).weight, defaultWeight)
+			var got []int64
+			for _, task := range tt.tasks {
+				got = append(got, weights[task])
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("flatten() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_groupedPolicies(t *testing.T) {
+	t.Parallel()
+	root, err := parseGroups("a=100:1,2,3;b=100:4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 12},
+		{ProcessID: 2, BurstDuration: 12},
+		{ProcessID: 3, BurstDuration: 12},
+		{ProcessID: 4, BurstDuration: 12},
+	}
+	tests := []struct {
+		name   string
+		policy Policy
+	}{
+		{name: "stride", policy: &StridePolicy{Quantum: 1, Groups: root}},
+		{name: "cfs", policy: &CFSPolicy{Latency: 24, Granularity: 3, Groups: root}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			// Group b's one process gets half the CPU, not a quarter, so it
+			// finishes its 12 ticks by 24.
+			r := simulate(tt.name, processes, tt.policy)
+			if exit := r.Rows[3].Exit; exit != 24 {
+				t.Errorf("process 4 exits at %d, want 24", exit)
+			}
+		})
+	}
+}
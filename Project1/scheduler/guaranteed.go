@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+//region Guaranteed scheduling
+
+// GuaranteedPolicy is guaranteed, or fair-share, scheduling: with n tasks
+// runnable, each is entitled to 1/n of the CPU, and every tick a task is
+// runnable adds its share of that tick to its entitlement. Every tick the
+// task with the lowest ratio of CPU time consumed to CPU time entitled to
+// runs, the running task keeping the CPU on ties, so the task furthest
+// behind its share catches up first.
+type GuaranteedPolicy struct {
+	consumed map[*Task]int64
+	entitled map[*Task]float64
+}
+
+// ratio is how much of its entitlement t has consumed, 0 before it is
+// entitled to anything.
+func (p *GuaranteedPolicy) ratio(t *Task) float64 {
+	if p.entitled[t] == 0 {
+		return 0
+	}
+	return float64(p.consumed[t]) / p.entitled[t]
+}
+
+func (p *GuaranteedPolicy) Pick(_ int64, running *Task, ready []*Task) *Task {
+	if p.consumed == nil {
+		p.consumed, p.entitled = make(map[*Task]int64), make(map[*Task]float64)
+	}
+	candidates := ready
+	if running != nil {
+		candidates = append([]*Task{running}, ready...)
+	}
+	pick := minTask(candidates, func(a, b *Task) bool { return p.ratio(a) < p.ratio(b) })
+	if pick == nil {
+		return nil
+	}
+	for _, t := range candidates {
+		p.entitled[t] += 1 / float64(len(candidates))
+	}
+	p.consumed[pick]++
+	return pick
+}
+
+// annotate adds each task's consumed and entitled CPU time and the ratio of
+// the two it finished with.
+func (p *GuaranteedPolicy) annotate(r *Report, tasks []*Task) {
+	consumed, entitled, ratio := Column{Header: "Consumed"}, Column{Header: "Entitled"}, Column{Header: "Achieved/entitled"}
+	for _, t := range tasks {
+		consumed.Values = append(consumed.Values, fmt.Sprint(p.consumed[t]))
+		entitled.Values = append(entitled.Values, strconv.FormatFloat(p.entitled[t], 'f', 2, 64))
+		ratio.Values = append(ratio.Values, strconv.FormatFloat(p.ratio(t), 'f', 2, 64))
+	}
+	r.Columns = append(r.Columns, consumed, entitled, ratio)
+}
+
+// GuaranteedSchedule outputs the guaranteed schedule of processes as a
+// Gantt chart and a table of timing.
+func GuaranteedSchedule(w io.Writer, title string, processes []Process) {
+	outputReport(w, Guaranteed(title, processes))
+}
+
+// Guaranteed schedules processes so each gets an equal share of the CPU
+// while it is runnable.
+func Guaranteed(title string, processes []Process) Report {
+	return simulate(title, processes, &GuaranteedPolicy{})
+}
+
+//endregion
@@ -0,0 +1,67 @@
+package scheduler
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGuaranteed(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		wantGantt []TimeSlice
+		// wantColumns are the consumed, entitled and ratio columns.
+		wantColumns [][]string
+	}{
+		{
+			name:      "ties keep the running task",
+			processes: []Process{{ProcessID: 1, BurstDuration: 3}, {ProcessID: 2, BurstDuration: 3}},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 3}, {PID: 1, Start: 3, Stop: 5}, {PID: 2, Start: 5, Stop: 6},
+			},
+			// 2 runs alone, and is entitled to the whole CPU, once 1 is done.
+			wantColumns: [][]string{{"3", "3"}, {"2.50", "3.50"}, {"1.20", "0.86"}},
+		},
+		{
+			name: "a late arrival catches up",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 6}, {ProcessID: 2, ArrivalTime: 4, BurstDuration: 3},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 5}, {PID: 1, Start: 5, Stop: 7}, {PID: 2, Start: 7, Stop: 9},
+			},
+			wantColumns: [][]string{{"6", "3"}, {"5.50", "3.50"}, {"1.09", "0.86"}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := Guaranteed("Guaranteed scheduling", tt.processes)
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("Guaranteed() Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			var columns [][]string
+			for _, c := range r.Columns {
+				columns = append(columns, c.Values)
+			}
+			if !reflect.DeepEqual(columns, tt.wantColumns) {
+				t.Errorf("Guaranteed() columns = %q, want %q", columns, tt.wantColumns)
+			}
+		})
+	}
+}
+
+func TestGuaranteedSchedule(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	GuaranteedSchedule(&w, "Guaranteed scheduling", []Process{{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, BurstDuration: 1}})
+	for _, want := range []string{"Guaranteed scheduling", "ACHIEVED/ENTITLED"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("GuaranteedSchedule() = %q, want it to contain %q", w.String(), want)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+//region CPU heatmap
+
+// heatmapMaxColumns caps how many time buckets a heatmap is split into.
+const heatmapMaxColumns = 60
+
+var heatmapShades = []rune(" ░▒▓█")
+
+// cpuUtilization splits the schedule into equal time buckets of width ticks
+// and returns, per CPU, the fraction of each bucket the CPU was busy running
+// a process. Context switches are not counted as busy.
+func cpuUtilization(gantt []TimeSlice) (util [][]float64, width int64) {
+	var end int64
+	for _, s := range gantt {
+		if s.Stop > end {
+			end = s.Stop
+		}
+	}
+	width = (end + heatmapMaxColumns - 1) / heatmapMaxColumns
+	if width < 1 {
+		width = 1
+	}
+	buckets := int((end + width - 1) / width)
+
+	util = make([][]float64, cpuCount(gantt))
+	for c := range util {
+		util[c] = make([]float64, buckets)
+	}
+	for _, s := range gantt {
+		if s.Overhead {
+			continue
+		}
+		for t := s.Start; t < s.Stop; t++ {
+			if t >= 0 {
+				util[s.CPU][t/width]++
+			}
+		}
+	}
+	for c := range util {
+		for b := range util[c] {
+			span := width
+			if last := end - int64(b)*width; last < span {
+				span = last
+			}
+			util[c][b] /= float64(span)
+		}
+	}
+
+	return util, width
+}
+
+// heatmapReports picks the reports worth a heatmap: the multi-core ones, as a
+// single CPU's row only repeats its Gantt chart, or every report when the run
+// has no multi-core ones.
+func heatmapReports(reports []Report) []Report {
+	var multicore []Report
+	for _, r := range reports {
+		if cpuCount(r.Gantt) > 1 {
+			multicore = append(multicore, r)
+		}
+	}
+	if len(multicore) == 0 {
+		return reports
+	}
+	return multicore
+}
+
+// outputHeatmap draws a row per CPU and a column per time bucket, shaded by how busy the CPU was.
+func outputHeatmap(w io.Writer, r Report) {
+	util, width := cpuUtilization(r.Gantt)
+	_, _ = fmt.Fprintf(w, "CPU utilization: %s (%d ticks per column, ░▒▓█ = up to 25/50/75/100%%)\n", r.Title, width)
+	for c, row := range util {
+		var b strings.Builder
+		for _, u := range row {
+			shade := int(u*float64(len(heatmapShades)-1) + 0.999)
+			if shade >= len(heatmapShades) {
+				shade = len(heatmapShades) - 1
+			}
+			b.WriteRune(heatmapShades[shade])
+		}
+		_, _ = fmt.Fprintf(w, "CPU %-3d|%s|\n", c, b.String())
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+//endregion
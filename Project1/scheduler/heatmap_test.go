@@ -0,0 +1,70 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_cpuUtilization(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		gantt []TimeSlice
+		want  [][]float64
+	}{
+		{
+			name: "two CPUs",
+			gantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2, CPU: 0},
+				{PID: 2, Start: 1, Stop: 3, CPU: 1},
+			},
+			want: [][]float64{{1, 1, 0}, {0, 1, 1}},
+		},
+		{
+			name: "context switch is idle",
+			gantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1},
+				{Start: 1, Stop: 2, Overhead: true},
+				{PID: 2, Start: 2, Stop: 3},
+			},
+			want: [][]float64{{1, 0, 1}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			util, width := cpuUtilization(tt.gantt)
+			if width != 1 || !reflect.DeepEqual(util, tt.want) {
+				t.Errorf("cpuUtilization() = %v, %d, want %v, 1", util, width, tt.want)
+			}
+		})
+	}
+}
+
+func Test_heatmapReports(t *testing.T) {
+	t.Parallel()
+	single := Report{Title: "FCFS", Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}}}
+	multi := Report{Title: "FCFS (2 CPUs)", Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 0, Stop: 1, CPU: 1}}}
+	tests := []struct {
+		name    string
+		reports []Report
+		want    []string
+	}{
+		{name: "single CPU only", reports: []Report{single}, want: []string{"FCFS"}},
+		{name: "multi-core only", reports: []Report{single, multi}, want: []string{"FCFS (2 CPUs)"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got []string
+			for _, r := range heatmapReports(tt.reports) {
+				got = append(got, r.Title)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("heatmapReports() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+//region Waiting time histograms
+
+// histogramBuckets is how many buckets the waiting times are split into.
+// All algorithms share the same buckets so their histograms line up.
+const histogramBuckets = 10
+
+// histogramBarWidth is the length of the longest bar.
+const histogramBarWidth = 40
+
+// Histogram counts an algorithm's waiting times in equal-width buckets:
+// bucket i holds waits from i*BucketWidth up to (i+1)*BucketWidth.
+type Histogram struct {
+	Title       string `json:"title"`
+	BucketWidth int64  `json:"bucket_width"`
+	Counts      []int  `json:"counts"`
+}
+
+// waitHistograms buckets each report's waiting times.
+func waitHistograms(reports []Report) []Histogram {
+	var longest int64
+	for _, r := range reports {
+		for _, row := range r.Rows {
+			if row.Wait > longest {
+				longest = row.Wait
+			}
+		}
+	}
+	width := (longest + histogramBuckets) / histogramBuckets
+
+	histograms := make([]Histogram, len(reports))
+	for i, r := range reports {
+		h := Histogram{Title: r.Title, BucketWidth: width, Counts: make([]int, histogramBuckets)}
+		for _, row := range r.Rows {
+			b := row.Wait / width
+			if b < 0 {
+				b = 0
+			}
+			h.Counts[b]++
+		}
+		// Drop empty buckets past the last used one.
+		n := len(h.Counts)
+		for n > 1 && h.Counts[n-1] == 0 {
+			n--
+		}
+		h.Counts = h.Counts[:n]
+		histograms[i] = h
+	}
+
+	return histograms
+}
+
+func outputHistogram(w io.Writer, h Histogram) {
+	_, _ = fmt.Fprintf(w, "Waiting times: %s\n", h.Title)
+	peak := 0
+	for _, c := range h.Counts {
+		if c > peak {
+			peak = c
+		}
+	}
+	label := len(fmt.Sprint(int64(len(h.Counts)) * h.BucketWidth))
+	for i, c := range h.Counts {
+		bar := 0
+		if peak > 0 {
+			bar = c * histogramBarWidth / peak
+		}
+		if c > 0 && bar == 0 {
+			bar = 1
+		}
+		from := int64(i) * h.BucketWidth
+		_, _ = fmt.Fprintf(w, "%*d-%-*d | %s %d\n", label, from, label, from+h.BucketWidth-1, strings.Repeat("#", bar), c)
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+//endregion
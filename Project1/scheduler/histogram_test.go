@@ -0,0 +1,30 @@
+package scheduler
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func Test_waitHistograms(t *testing.T) {
+	t.Parallel()
+	reports := []Report{
+		{Title: "A", Rows: []Row{{Wait: 0}, {Wait: 3}, {Wait: 19}}},
+		{Title: "B", Rows: []Row{{Wait: 1}, {Wait: 2}, {Wait: 2}}},
+	}
+	got := waitHistograms(reports)
+	want := []Histogram{
+		{Title: "A", BucketWidth: 2, Counts: []int{1, 1, 0, 0, 0, 0, 0, 0, 0, 1}},
+		{Title: "B", BucketWidth: 2, Counts: []int{1, 2}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("waitHistograms() = %v, want %v", got, want)
+	}
+
+	var w bytes.Buffer
+	outputHistogram(&w, got[1])
+	wantOut := "Waiting times: B\n0-1 | #################### 1\n2-3 | ######################################## 2\n\n"
+	if w.String() != wantOut {
+		t.Errorf("outputHistogram() = %q, want %q", w.String(), wantOut)
+	}
+}
@@ -0,0 +1,261 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+)
+
+//region Burst history
+
+// The ways a burst is predicted from a process's history.
+const (
+	// historyAverage predicts the mean of every burst seen.
+	historyAverage = "average"
+	// historyExponential predicts the exponential average τ(n+1) = α·t(n) + (1-α)·τ(n).
+	historyExponential = "exponential"
+)
+
+type (
+	// BurstHistory is what earlier runs learned about each process, by ID.
+	BurstHistory map[int64]*BurstRecord
+
+	// BurstRecord is one process's history. Runs counts the CPU bursts
+	// seen: one a run, or one per CPU burst of a burst cycle.
+	BurstRecord struct {
+		Runs     int     `json:"runs"`
+		Average  float64 `json:"average"`
+		Estimate float64 `json:"estimate"`
+		Last     int64   `json:"last"`
+	}
+
+	// PredictedBurstPolicy is shortest job first on predicted rather than
+	// actual bursts, the way a real scheduler has to work. Preemptive, it is
+	// shortest remaining time first: the running task's prediction less what
+	// it has run is compared with the ready tasks' predictions every tick.
+	// A task with a burst cycle is predicted one CPU burst at a time, each
+	// burst it finishes being learned, with Alpha, before the next.
+	PredictedBurstPolicy struct {
+		// Start is each process's history before the run.
+		Start      map[int64]BurstRecord
+		Mode       string
+		Alpha      float64
+		Preemptive bool
+	}
+)
+
+// loadBurstHistory reads the history in path; a missing file is an empty history.
+func loadBurstHistory(path string) (BurstHistory, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return BurstHistory{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading burst history", err)
+	}
+	h := BurstHistory{}
+	if err := json.Unmarshal(b, &h); err != nil {
+		return nil, fmt.Errorf("%w: decoding %s", err, path)
+	}
+	return h, nil
+}
+
+// cpuBursts returns p's CPU bursts: those of its burst cycle, or its one burst.
+func cpuBursts(p Process) []int64 {
+	if len(p.Bursts) == 0 {
+		return []int64{p.BurstDuration}
+	}
+	bursts := make([]int64, 0, len(p.Bursts)/2+1)
+	for i := 0; i < len(p.Bursts); i += 2 {
+		bursts = append(bursts, p.Bursts[i])
+	}
+	return bursts
+}
+
+// prediction is the burst rec predicts next.
+func (rec BurstRecord) prediction(mode string) float64 {
+	if mode == historyExponential {
+		return rec.Estimate
+	}
+	return rec.Average
+}
+
+// add learns burst, weighting it by alpha in the exponential estimate. The
+// first burst of a record with no estimate yet becomes its estimate.
+func (rec *BurstRecord) add(burst int64, alpha float64) {
+	if rec.Runs == 0 && rec.Estimate == 0 {
+		rec.Estimate = float64(burst)
+	} else {
+		rec.Estimate = alpha*float64(burst) + (1-alpha)*rec.Estimate
+	}
+	rec.Runs++
+	rec.Average += (float64(burst) - rec.Average) / float64(rec.Runs)
+	rec.Last = burst
+}
+
+// start returns every process's record before the run. A process with no
+// history starts from tau0 or, if tau0 is 0, the mean prediction of those
+// with one, or 1 if no process has any, so unknown processes are neither
+// favored nor starved.
+func (h BurstHistory) start(processes []Process, mode string, tau0 float64) map[int64]BurstRecord {
+	records := make(map[int64]BurstRecord)
+	var known float64
+	for _, p := range processes {
+		if rec := h[p.ProcessID]; rec != nil && rec.Runs > 0 {
+			records[p.ProcessID] = *rec
+			known += rec.prediction(mode)
+		}
+	}
+	fallback := tau0
+	if fallback <= 0 {
+		fallback = 1
+		if len(records) > 0 {
+			fallback = known / float64(len(records))
+		}
+	}
+	for _, p := range processes {
+		if _, ok := records[p.ProcessID]; !ok {
+			records[p.ProcessID] = BurstRecord{Average: fallback, Estimate: fallback}
+		}
+	}
+	return records
+}
+
+// learn adds the CPU bursts of processes to the history, weighting the
+// latest burst by alpha in the exponential estimate. A new process's first
+// estimate is tau0 or, if tau0 is 0, its first burst.
+func (h BurstHistory) learn(processes []Process, alpha, tau0 float64) {
+	for _, p := range processes {
+		rec := h[p.ProcessID]
+		if rec == nil {
+			rec = &BurstRecord{Estimate: tau0}
+			h[p.ProcessID] = rec
+		}
+		for _, b := range cpuBursts(p) {
+			rec.add(b, alpha)
+		}
+	}
+}
+
+// predictions returns the prediction of each CPU burst of process, each
+// made once the bursts before it had been learned.
+func (p PredictedBurstPolicy) predictions(process Process) []float64 {
+	rec := p.Start[process.ProcessID]
+	bursts := cpuBursts(process)
+	taus := make([]float64, len(bursts))
+	for i, b := range bursts {
+		taus[i] = rec.prediction(p.Mode)
+		rec.add(b, p.Alpha)
+	}
+	return taus
+}
+
+// remaining is the prediction of t's current CPU burst less what it has
+// run of it.
+func (p PredictedBurstPolicy) remaining(t *Task) float64 {
+	taus, bursts := p.predictions(t.Process), cpuBursts(t.Process)
+	done, k := t.BurstDuration-t.Remaining, 0
+	for k < len(bursts)-1 && done >= bursts[k] {
+		done -= bursts[k]
+		k++
+	}
+	return math.Max(taus[k]-float64(done), 0)
+}
+
+func (p PredictedBurstPolicy) Pick(_ int64, running *Task, ready []*Task) *Task {
+	if running != nil && !p.Preemptive {
+		return running
+	}
+	candidates := ready
+	if running != nil {
+		candidates = append([]*Task{running}, ready...)
+	}
+	remaining := make(map[*Task]float64, len(candidates))
+	for _, t := range candidates {
+		remaining[t] = p.remaining(t)
+	}
+	return minTask(candidates, func(a, b *Task) bool { return remaining[a] < remaining[b] })
+}
+
+// annotate adds each task's predicted bursts and their errors, over when
+// positive, and the mean prediction error over every burst.
+func (p PredictedBurstPolicy) annotate(r *Report, tasks []*Task) {
+	col, errs := Column{Header: "Predicted"}, Column{Header: "Error"}
+	var totalError float64
+	var count int
+	for _, t := range tasks {
+		taus, bursts := p.predictions(t.Process), cpuBursts(t.Process)
+		predicted, e := make([]string, len(taus)), make([]string, len(taus))
+		for i, tau := range taus {
+			predicted[i] = fmt.Sprintf("%.1f", tau)
+			e[i] = fmt.Sprintf("%.1f", tau-float64(bursts[i]))
+			totalError += math.Abs(tau - float64(bursts[i]))
+		}
+		count += len(taus)
+		col.Values = append(col.Values, strings.Join(predicted, " "))
+		errs.Values = append(errs.Values, strings.Join(e, " "))
+	}
+	r.Columns = append(r.Columns, col, errs)
+	if count > 0 {
+		r.Notes = append(r.Notes, fmt.Sprintf("Mean absolute prediction error: %.2f", totalError/float64(count)))
+	}
+}
+
+// historyReports runs SJF and SRTF on bursts predicted from the history in
+// path, starting processes it doesn't know from tau0, notes how their
+// waits compare with knowing the actual bursts, then adds this workload's
+// bursts to the history and saves it. With no path, every process starts
+// unknown and only what the run itself learns, burst by burst of a burst
+// cycle, informs the predictions, so runs are reproducible.
+func historyReports(processes []Process, path, mode string, alpha, tau0 float64) ([]Report, error) {
+	mode = strings.ToLower(mode)
+	if mode != historyAverage && mode != historyExponential {
+		return nil, fmt.Errorf("%w: history mode must be %s or %s", ErrInvalidArgs, historyAverage, historyExponential)
+	}
+	if alpha < 0 || alpha > 1 {
+		return nil, fmt.Errorf("%w: alpha must be between 0 and 1", ErrInvalidArgs)
+	}
+	if tau0 < 0 {
+		return nil, fmt.Errorf("%w: τ0 must not be negative", ErrInvalidArgs)
+	}
+	h := BurstHistory{}
+	if path != "" {
+		var err error
+		if h, err = loadBurstHistory(path); err != nil {
+			return nil, err
+		}
+	}
+
+	start := h.start(processes, mode, tau0)
+	how := mode
+	if mode == historyExponential {
+		how = fmt.Sprintf("exponential, α %g", alpha)
+	}
+	if tau0 > 0 {
+		how += fmt.Sprintf(", τ0 %g", tau0)
+	}
+	reports := []Report{
+		simulate(fmt.Sprintf("Shortest-job-first on predicted bursts (%s)", how), processes,
+			PredictedBurstPolicy{Start: start, Mode: mode, Alpha: alpha}),
+		simulate(fmt.Sprintf("Shortest-remaining-time-first on predicted bursts (%s)", how), processes,
+			PredictedBurstPolicy{Start: start, Mode: mode, Alpha: alpha, Preemptive: true}),
+	}
+	for i, known := range []Policy{SJFPolicy{}, SRTFPolicy{}} {
+		oracle := simulate("", processes, known)
+		reports[i].Notes = append(reports[i].Notes, fmt.Sprintf("Average wait %.2f, %.2f knowing the actual bursts", reports[i].Wait, oracle.Wait))
+	}
+
+	if path == "" {
+		return reports, nil
+	}
+	h.learn(processes, alpha, tau0)
+	if err := writeJSON(path, h); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+//endregion
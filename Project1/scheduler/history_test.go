@@ -0,0 +1,183 @@
+package scheduler
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_BurstHistory(t *testing.T) {
+	t.Parallel()
+	runs := [][]Process{
+		{{ProcessID: 1, BurstDuration: 8}, {ProcessID: 2, BurstDuration: 2}},
+		{{ProcessID: 1, BurstDuration: 4}, {ProcessID: 2, BurstDuration: 6}},
+	}
+	h := BurstHistory{}
+	for _, run := range runs {
+		h.learn(run, 0.75, 0)
+	}
+	tests := []struct {
+		name string
+		mode string
+		tau0 float64
+		want map[int64]float64
+	}{
+		{name: "average", mode: historyAverage, want: map[int64]float64{1: 6, 2: 4, 3: 5}},
+		{name: "exponential", mode: historyExponential, want: map[int64]float64{1: 5, 2: 5, 3: 5}},
+		{name: "unknown from τ0", mode: historyExponential, tau0: 10, want: map[int64]float64{1: 5, 2: 5, 3: 10}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := make(map[int64]float64)
+			for id, rec := range h.start([]Process{{ProcessID: 1}, {ProcessID: 2}, {ProcessID: 3}}, tt.mode, tt.tau0) {
+				got[id] = rec.prediction(tt.mode)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("start() predicts %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_BurstHistory_learn(t *testing.T) {
+	t.Parallel()
+	h := BurstHistory{}
+	for _, burst := range []int64{8, 4, 10} {
+		h.learn([]Process{{ProcessID: 1, BurstDuration: burst}}, 0.5, 0)
+	}
+	want := BurstRecord{Runs: 3, Average: 22.0 / 3, Estimate: 8, Last: 10}
+	if *h[1] != want {
+		t.Errorf("after three runs, process 1 has %+v, want %+v", *h[1], want)
+	}
+
+	// A burst cycle is learned a CPU burst at a time, here from τ0 10.
+	h = BurstHistory{}
+	h.learn([]Process{{ProcessID: 1, BurstDuration: 23, Bursts: []int64{6, 1, 4, 2, 13}}}, 0.5, 10)
+	mean := 5.0 // after 6 and 4
+	mean += (13 - mean) / 3
+	want = BurstRecord{Runs: 3, Average: mean, Estimate: 9.5, Last: 13}
+	if *h[1] != want {
+		t.Errorf("after a burst cycle, process 1 has %+v, want %+v", *h[1], want)
+	}
+}
+
+func Test_PredictedBurstPolicy_predictions(t *testing.T) {
+	t.Parallel()
+	cycle := Process{ProcessID: 1, BurstDuration: 23, Bursts: []int64{6, 1, 4, 2, 13}}
+	p := PredictedBurstPolicy{
+		Start: BurstHistory{}.start([]Process{cycle}, historyExponential, 10),
+		Mode:  historyExponential,
+		Alpha: 0.5,
+	}
+	// The textbook sequence: τ0 10, then each burst learned before the next.
+	if got, want := p.predictions(cycle), []float64{10, 8, 6}; !reflect.DeepEqual(got, want) {
+		t.Errorf("predictions() = %v, want %v", got, want)
+	}
+}
+
+func Test_historyReports(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "history.json")
+	first, err := loadProcesses(strings.NewReader("1,2,0,1\n2,8,0,1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	swapped, err := loadProcesses(strings.NewReader("1,8,0,1\n2,2,0,1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		workload  []Process
+		wantGantt []TimeSlice
+	}{
+		// Nothing is known yet, so both are predicted alike and run in order.
+		{workload: first, wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 10}}},
+		// Process 1 was short last time, so it runs first again though it is now long.
+		{workload: swapped, wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 8}, {PID: 2, Start: 8, Stop: 10}}},
+		// The estimates have moved halfway: 5 each, so it's back to input order.
+		{workload: swapped, wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 8}, {PID: 2, Start: 8, Stop: 10}}},
+		// 6.5 against 3.5: process 2 has been learned to be the short one.
+		{workload: swapped, wantGantt: []TimeSlice{{PID: 2, Start: 0, Stop: 2}, {PID: 1, Start: 2, Stop: 10}}},
+	}
+	// The runs share the history file, so they run in order.
+	for i, tt := range tests {
+		reports, err := historyReports(tt.workload, path, historyExponential, 0.5, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(reports) != 2 {
+			t.Fatalf("run %d: got %d reports, want 2", i, len(reports))
+		}
+		if !reflect.DeepEqual(reports[0].Gantt, tt.wantGantt) {
+			t.Errorf("run %d: Gantt = %v, want %v", i, reports[0].Gantt, tt.wantGantt)
+		}
+	}
+
+	if _, err := historyReports(first, path, "median", 0.5, 0); err == nil {
+		t.Error("unknown mode accepted")
+	}
+	if _, err := historyReports(first, path, historyExponential, 0.5, -1); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("negative τ0: error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
+
+func Test_historyReportsBurstCycle(t *testing.T) {
+	t.Parallel()
+	// Process 1 is predicted 3 like process 2 at first, but its first burst
+	// of 1 brings its second down to 2, so SJF runs it ahead of process 3.
+	// With no history yet, a history file makes no difference.
+	processes, err := loadProcesses(strings.NewReader("1,\"cpu:1,io:1,cpu:4\",0,1\n2,3,0,1\n3,3,1,1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{filepath.Join(t.TempDir(), "history.json"), ""} {
+		testHistoryBurstCycle(t, processes, path)
+	}
+}
+
+func testHistoryBurstCycle(t *testing.T, processes []Process, path string) {
+	t.Helper()
+	reports, err := historyReports(processes, path, historyExponential, 0.5, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sjf := reports[0]
+	if want := []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 4}, {PID: 1, Start: 4, Stop: 8}, {PID: 3, Start: 8, Stop: 11}}; !reflect.DeepEqual(sjf.Gantt, want) {
+		t.Errorf("SJF Gantt = %v, want %v", sjf.Gantt, want)
+	}
+	columns := make(map[string][]string)
+	for _, c := range sjf.Columns {
+		columns[c.Header] = c.Values
+	}
+	if want := []string{"3.0 2.0", "3.0", "3.0"}; !reflect.DeepEqual(columns["Predicted"], want) {
+		t.Errorf("predicted = %q, want %q", columns["Predicted"], want)
+	}
+	if want := []string{"2.0 -2.0", "0.0", "0.0"}; !reflect.DeepEqual(columns["Error"], want) {
+		t.Errorf("errors = %q, want %q", columns["Error"], want)
+	}
+	if want := "Mean absolute prediction error: 1.00"; !strings.Contains(strings.Join(sjf.Notes, "\n"), want) {
+		t.Errorf("notes = %q, want %q", sjf.Notes, want)
+	}
+}
+
+func Test_historyReports_withoutFile(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader("1,8,0,1\n2,2,0,1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Nothing carries over between runs, so each one predicts the same.
+	for i := 0; i < 3; i++ {
+		reports, err := historyReports(processes, "", historyExponential, 0.5, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []TimeSlice{{PID: 1, Start: 0, Stop: 8}, {PID: 2, Start: 8, Stop: 10}}; !reflect.DeepEqual(reports[0].Gantt, want) {
+			t.Errorf("run %d: Gantt = %v, want %v", i, reports[0].Gantt, want)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+//region Highest response ratio next
+
+// HRRNPolicy runs, whenever the CPU is free, the ready task with the
+// highest response ratio (wait + burst) / burst, then lets it finish. Short
+// jobs go first as under SJF, but a long job's ratio grows while it waits,
+// so it cannot starve. Ties go to the earliest in the ready queue.
+type HRRNPolicy struct{}
+
+func (HRRNPolicy) Pick(_ int64, running *Task, ready []*Task) *Task {
+	if running != nil {
+		return running
+	}
+	return minTask(ready, func(a, b *Task) bool { return responseRatio(a) > responseRatio(b) })
+}
+
+// responseRatio is the ratio HRRN compares t on.
+func responseRatio(t *Task) float64 {
+	return float64(t.Waited+t.BurstDuration) / float64(t.BurstDuration)
+}
+
+// annotate notes, for every dispatch with more than one task waiting, the
+// response ratios the chosen task won with.
+func (HRRNPolicy) annotate(r *Report, tasks []*Task) {
+	order := append([]*Task(nil), tasks...)
+	sort.SliceStable(order, func(i, j int) bool { return order[i].FirstRun < order[j].FirstRun })
+
+	var dispatches []string
+	for _, pick := range order {
+		at := pick.FirstRun
+		ratio := func(t *Task) float64 {
+			return float64(at-t.ArrivalTime+t.BurstDuration) / float64(t.BurstDuration)
+		}
+		var waiting []*Task
+		for _, t := range order {
+			if t != pick && t.ArrivalTime <= at && t.FirstRun > at {
+				waiting = append(waiting, t)
+			}
+		}
+		sort.SliceStable(waiting, func(i, j int) bool { return ratio(waiting[i]) > ratio(waiting[j]) })
+		others := make([]string, len(waiting))
+		for i, t := range waiting {
+			others[i] = fmt.Sprintf("%d (%.2f)", t.ProcessID, ratio(t))
+		}
+		if len(others) > 0 {
+			dispatches = append(dispatches, fmt.Sprintf("t=%d: %d (%.2f) over %s", at, pick.ProcessID, ratio(pick), strings.Join(others, ", ")))
+		}
+	}
+	if len(dispatches) > 0 {
+		r.Notes = append(r.Notes, "Response ratios at dispatch: "+strings.Join(dispatches, "; "))
+	}
+}
+
+// HRRNSchedule outputs the highest-response-ratio-next schedule of
+// processes as a Gantt chart and a table of timing.
+func HRRNSchedule(w io.Writer, title string, processes []Process) {
+	outputReport(w, HRRN(title, processes))
+}
+
+// HRRN schedules processes highest response ratio next. Unlike SJF it
+// only chooses among the processes that have arrived when the CPU frees up.
+func HRRN(title string, processes []Process) Report {
+	return simulate(title, processes, HRRNPolicy{})
+}
+
+//endregion
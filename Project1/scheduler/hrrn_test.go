@@ -0,0 +1,81 @@
+package scheduler
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHRRN(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		wantGantt []TimeSlice
+		wantWait  []int64
+		wantNotes []string
+	}{
+		{
+			name: "textbook workload",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 3}, {ProcessID: 2, ArrivalTime: 2, BurstDuration: 6},
+				{ProcessID: 3, ArrivalTime: 4, BurstDuration: 4}, {ProcessID: 4, ArrivalTime: 6, BurstDuration: 5},
+				{ProcessID: 5, ArrivalTime: 8, BurstDuration: 2},
+			},
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 9}, {PID: 3, Start: 9, Stop: 13}, {PID: 5, Start: 13, Stop: 15}, {PID: 4, Start: 15, Stop: 20}},
+			wantWait:  []int64{0, 1, 5, 9, 5},
+			wantNotes: []string{"Response ratios at dispatch: t=9: 3 (2.25) over 4 (1.60), 5 (1.50); t=13: 5 (3.50) over 4 (2.40)"},
+		},
+		{
+			name: "only arrived processes are considered",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 4}, {ProcessID: 2, ArrivalTime: 5, BurstDuration: 1},
+				{ProcessID: 3, ArrivalTime: 1, BurstDuration: 8},
+			},
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 3, Start: 4, Stop: 12}, {PID: 2, Start: 12, Stop: 13}},
+			wantWait:  []int64{0, 3, 7},
+		},
+		{
+			name: "waiting raises a long job above a short one",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 10}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 4},
+				{ProcessID: 3, ArrivalTime: 9, BurstDuration: 2},
+			},
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 10}, {PID: 2, Start: 10, Stop: 14}, {PID: 3, Start: 14, Stop: 16}},
+			wantWait:  []int64{0, 9, 5},
+			wantNotes: []string{"Response ratios at dispatch: t=10: 2 (3.25) over 3 (1.50)"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := HRRN("Highest response ratio next", tt.processes)
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("HRRN() Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			var waits []int64
+			for _, row := range r.Rows {
+				waits = append(waits, row.Wait)
+			}
+			if !reflect.DeepEqual(waits, tt.wantWait) {
+				t.Errorf("HRRN() waits = %v, want %v", waits, tt.wantWait)
+			}
+			if !reflect.DeepEqual(r.Notes, tt.wantNotes) {
+				t.Errorf("HRRN() notes = %q, want %q", r.Notes, tt.wantNotes)
+			}
+		})
+	}
+}
+
+func TestHRRNSchedule(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	HRRNSchedule(&w, "Highest response ratio next", []Process{{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, BurstDuration: 1}})
+	for _, want := range []string{"Highest response ratio next", "Response ratios at dispatch: t=0: 1 (1.00) over 2 (1.00)"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("HRRNSchedule() = %q, want it to contain %q", w.String(), want)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package scheduler
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//region Human-readable times
+
+// timeUnits are the units human times are printed in, largest first.
+var timeUnits = []struct {
+	Name string
+	Size time.Duration
+}{
+	{"h", time.Hour},
+	{"min", time.Minute},
+	{"s", time.Second},
+	{"ms", time.Millisecond},
+	{"µs", time.Microsecond},
+	{"ns", time.Nanosecond},
+}
+
+// parseUnit parses a workload's time unit: the name of one of timeUnits,
+// like "ms", or a duration like "10ms" or "2.5s".
+func parseUnit(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	for _, u := range timeUnits {
+		if s == u.Name {
+			return u.Size, nil
+		}
+	}
+	if s == "us" {
+		return time.Microsecond, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("%w: the time unit must be a unit like ms or a positive duration like 10ms, got %q", ErrInvalidArgs, s)
+	}
+	return d, nil
+}
+
+// tickDuration is how long a tick lasts with unit the workload's time unit
+// and scale ticks to the unit, or 0 if the workload gives no unit.
+func tickDuration(unit string, scale int64) (time.Duration, error) {
+	if unit == "" {
+		return 0, nil
+	}
+	d, err := parseUnit(unit)
+	if err != nil {
+		return 0, err
+	}
+	if scale > 1 {
+		d /= time.Duration(scale)
+	}
+	return d, nil
+}
+
+// formatTicks formats a time in ticks: raw, or with -human in the largest
+// unit it is at least one of, such as "12.5 s" for 12500 ticks of 1ms.
+func (o renderer) formatTicks(ticks int64) string {
+	if o.tick <= 0 {
+		return fmt.Sprint(ticks)
+	}
+	return o.catalog.point(formatDuration(float64(ticks) * float64(o.tick)))
+}
+
+// formatAverageTicks formats an average time in ticks, to two decimals
+// when raw.
+func (o renderer) formatAverageTicks(ticks float64) string {
+	if o.tick <= 0 {
+		return o.catalog.float(ticks, 2)
+	}
+	return o.catalog.point(formatDuration(ticks * float64(o.tick)))
+}
+
+// formatThroughput formats processes completed per tick: raw, or with
+// -human per second.
+func (o renderer) formatThroughput(perTick float64) string {
+	if o.tick <= 0 {
+		return o.catalog.float(perTick, 2) + o.catalog.msg("per_tick")
+	}
+	return o.catalog.point(significant(perTick/o.tick.Seconds())) + "/s"
+}
+
+// formatDuration formats ns nanoseconds to three significant digits in
+// the largest unit it is at least one of.
+func formatDuration(ns float64) string {
+	if ns == 0 {
+		return "0"
+	}
+	for _, u := range timeUnits {
+		if math.Abs(ns) >= float64(u.Size) || u.Size == time.Nanosecond {
+			return significant(ns/float64(u.Size)) + " " + u.Name
+		}
+	}
+	return ""
+}
+
+// significant formats v to three significant digits, without trailing
+// zeros.
+func significant(v float64) string {
+	s := strconv.FormatFloat(v, 'f', -1, 64)
+	if v != 0 {
+		digits := 2 - int(math.Floor(math.Log10(math.Abs(v))))
+		if digits < 0 {
+			digits = 0
+		}
+		s = strconv.FormatFloat(v, 'f', digits, 64)
+		if strings.Contains(s, ".") {
+			s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+		}
+	}
+	return s
+}
+
+//endregion
@@ -0,0 +1,104 @@
+package scheduler
+
+import (
+	"errors"
+	"flag"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_formatDuration(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		ns   float64
+		want string
+	}{
+		{ns: 0, want: "0"},
+		{ns: 12500 * float64(time.Millisecond), want: "12.5 s"},
+		{ns: 2 * float64(time.Millisecond), want: "2 ms"},
+		{ns: 90 * float64(time.Second), want: "1.5 min"},
+		{ns: 26.666666 * float64(time.Second), want: "26.7 s"},
+		{ns: 1234 * float64(time.Hour), want: "1234 h"},
+		{ns: 999, want: "999 ns"},
+		{ns: 0.5, want: "0.5 ns"},
+		{ns: 1500, want: "1.5 µs"},
+	}
+	for _, tt := range tests {
+		if got := formatDuration(tt.ns); got != tt.want {
+			t.Errorf("formatDuration(%v) = %q, want %q", tt.ns, got, tt.want)
+		}
+	}
+}
+
+func Test_outputReport_human(t *testing.T) {
+	t.Parallel()
+	o := renderer{tick: time.Millisecond}
+	if got := o.formatTicks(12500); got != "12.5 s" {
+		t.Errorf("formatTicks(12500) = %q, want 12.5 s", got)
+	}
+	if got := o.formatThroughput(0.5); got != "500/s" {
+		t.Errorf("formatThroughput(0.5) = %q, want 500/s", got)
+	}
+
+	var b strings.Builder
+	o.outputReport(&b, simulate("FCFS", []Process{{ProcessID: 1, BurstDuration: 3000}, {ProcessID: 2, BurstDuration: 1500}}, FCFSPolicy{}))
+	for _, want := range []string{"0\t3 s\t4.5 s", "| 1.5 s ", "AVERAGE", " 1.5 s  |   3.75 s", "0.444/s", "Makespan: 4.5 s\n"} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("outputReport() = %q, want it to contain %q", b.String(), want)
+		}
+	}
+
+	if got := (renderer{}).formatTicks(12500); got != "12500" {
+		t.Errorf("formatTicks(12500) without -human = %q, want 12500", got)
+	}
+}
+
+func Test_tickDuration(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		unit    string
+		scale   int64
+		want    time.Duration
+		wantErr bool
+	}{
+		{unit: "", scale: 4, want: 0},
+		{unit: "ms", scale: 1, want: time.Millisecond},
+		{unit: "ms", scale: 10, want: 100 * time.Microsecond},
+		{unit: "10us", scale: 0, want: 10 * time.Microsecond},
+		{unit: "min", scale: 1, want: time.Minute},
+		{unit: "fortnight", wantErr: true},
+		{unit: "-1ms", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.unit, func(t *testing.T) {
+			t.Parallel()
+			got, err := tickDuration(tt.unit, tt.scale)
+			if (err != nil) != tt.wantErr || err != nil && !errors.Is(err, ErrInvalidArgs) {
+				t.Fatalf("tickDuration() error = %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("tickDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_tickDuration_metadata(t *testing.T) {
+	t.Parallel()
+	// A workload in ms with times to a tenth has ticks of 100µs.
+	_, metadata, err := loadWorkload(strings.NewReader("#unit=ms\n1,2.5,0,1\n2,1,0.5,1\n"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	unit := fs.String("unit", "", "")
+	scale := fs.Int64("scale", 0, "")
+	if err := applyMetadata(fs, metadata); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := tickDuration(*unit, *scale); err != nil || got != 100*time.Microsecond {
+		t.Errorf("tickDuration(%q, %d) = %v, %v, want 100µs", *unit, *scale, got, err)
+	}
+}
@@ -0,0 +1,106 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+//region Arrival jitter
+
+// jitterArrivals returns a copy of processes with each arrival moved by a
+// uniformly random amount in [-bound, bound], never before tick 0, and
+// reordered by the new arrivals since the schedulers expect them in order.
+func jitterArrivals(processes []Process, bound int64, rng *rand.Rand) []Process {
+	jittered := append([]Process(nil), processes...)
+	for i := range jittered {
+		jittered[i].ArrivalTime += rng.Int63n(2*bound+1) - bound
+		if jittered[i].ArrivalTime < 0 {
+			jittered[i].ArrivalTime = 0
+		}
+	}
+	sort.SliceStable(jittered, func(i, j int) bool { return jittered[i].ArrivalTime < jittered[j].ArrivalTime })
+	return jittered
+}
+
+// JitterSpread is how one algorithm's averages varied across jittered runs.
+type JitterSpread struct {
+	Title                        string
+	Wait, WaitStdDev             float64
+	Turnaround, TurnaroundStdDev float64
+	MinWait, MaxWait             float64
+}
+
+// jitterSpreads runs each policy on runs jittered copies of processes,
+// seeded seed, seed+1, ..., and summarizes each algorithm's average wait
+// and turnaround across them.
+func jitterSpreads(processes []Process, bound int64, runs int, seed, quantum int64) []JitterSpread {
+	var (
+		spreads     []JitterSpread
+		waits, tats [][]float64
+	)
+	for run := 0; run < runs; run++ {
+		rng := rand.New(rand.NewSource(seed + int64(run)))
+		jittered := jitterArrivals(processes, bound, rng)
+		for i, policy := range classicPolicies(quantum) {
+			r := simulate(policyTitle(policy), jittered, policy)
+			if run == 0 {
+				spreads = append(spreads, JitterSpread{Title: r.Title})
+				waits, tats = append(waits, nil), append(tats, nil)
+			}
+			waits[i] = append(waits[i], r.Wait)
+			tats[i] = append(tats[i], r.Turnaround)
+		}
+	}
+	for i := range spreads {
+		spreads[i].Wait, spreads[i].WaitStdDev = meanStdDev(waits[i])
+		spreads[i].Turnaround, spreads[i].TurnaroundStdDev = meanStdDev(tats[i])
+		spreads[i].MinWait, spreads[i].MaxWait = math.Inf(1), math.Inf(-1)
+		for _, w := range waits[i] {
+			spreads[i].MinWait = math.Min(spreads[i].MinWait, w)
+			spreads[i].MaxWait = math.Max(spreads[i].MaxWait, w)
+		}
+	}
+	return spreads
+}
+
+// meanStdDev is the mean and population standard deviation of xs.
+func meanStdDev(xs []float64) (float64, float64) {
+	if len(xs) == 0 {
+		return 0, 0
+	}
+	var sum, sq float64
+	for _, x := range xs {
+		sum += x
+	}
+	mean := sum / float64(len(xs))
+	for _, x := range xs {
+		sq += (x - mean) * (x - mean)
+	}
+	return mean, math.Sqrt(sq / float64(len(xs)))
+}
+
+// outputJitterSpreads prints how robust each algorithm is to arrival noise.
+func outputJitterSpreads(w io.Writer, spreads []JitterSpread, bound int64, runs int, seed int64) {
+	_, _ = fmt.Fprintf(w, "Arrival jitter of ±%d over %d runs (seeds %d to %d)\n", bound, runs, seed, seed+int64(runs)-1)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Average wait", "Std dev", "Min", "Max", "Average turnaround", "Std dev"})
+	for _, s := range spreads {
+		table.Append([]string{
+			s.Title,
+			fmt.Sprintf("%.2f", s.Wait),
+			fmt.Sprintf("%.2f", s.WaitStdDev),
+			fmt.Sprintf("%.2f", s.MinWait),
+			fmt.Sprintf("%.2f", s.MaxWait),
+			fmt.Sprintf("%.2f", s.Turnaround),
+			fmt.Sprintf("%.2f", s.TurnaroundStdDev),
+		})
+	}
+	table.Render()
+}
+
+//endregion
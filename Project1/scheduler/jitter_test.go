@@ -0,0 +1,67 @@
+package scheduler
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func Test_jitterArrivals(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, ArrivalTime: 0}, {ProcessID: 2, ArrivalTime: 5}, {ProcessID: 3, ArrivalTime: 100}}
+	tests := []struct {
+		name  string
+		bound int64
+	}{
+		{name: "none", bound: 0},
+		{name: "small", bound: 2},
+		{name: "large", bound: 50},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := jitterArrivals(processes, tt.bound, rand.New(rand.NewSource(3)))
+			again := jitterArrivals(processes, tt.bound, rand.New(rand.NewSource(3)))
+			for i, p := range got {
+				original := processes[p.ProcessID-1].ArrivalTime
+				if p.ArrivalTime < 0 || math.Abs(float64(p.ArrivalTime-original)) > float64(tt.bound) {
+					t.Errorf("process %d arrives at %d, more than %d from %d", p.ProcessID, p.ArrivalTime, tt.bound, original)
+				}
+				if i > 0 && p.ArrivalTime < got[i-1].ArrivalTime {
+					t.Errorf("arrivals out of order: %v", got)
+				}
+				if !reflect.DeepEqual(p, again[i]) {
+					t.Errorf("same seed gave %v and %v", p, again[i])
+				}
+			}
+			if processes[1].ArrivalTime != 5 {
+				t.Errorf("jitterArrivals modified its input")
+			}
+		})
+	}
+}
+
+func Test_meanStdDev(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		xs             []float64
+		wantMean, want float64
+	}{
+		{name: "empty"},
+		{name: "constant", xs: []float64{3, 3, 3}, wantMean: 3},
+		{name: "spread", xs: []float64{2, 4, 4, 4, 5, 5, 7, 9}, wantMean: 5, want: 2},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mean, sd := meanStdDev(tt.xs)
+			if mean != tt.wantMean || sd != tt.want {
+				t.Errorf("meanStdDev() = %v, %v, want %v, %v", mean, sd, tt.wantMean, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+//region JSON output
+
+// writeReports writes reports to the file at path as an indented JSON array.
+func writeReports(path string, reports []Report) error {
+	return writeJSON(path, reports)
+}
+
+// writeJSON writes v to the file at path as indented JSON.
+func writeJSON(path string, v interface{}) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("%w: creating JSON file", err)
+	}
+	if err := outputJSON(f, v); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("%w: closing JSON file", err)
+	}
+
+	return nil
+}
+
+func outputJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("%w: encoding JSON", err)
+	}
+
+	return nil
+}
+
+// readReports reads a JSON array of reports as written by writeReports.
+func readReports(path string) ([]Report, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading JSON file", err)
+	}
+	var reports []Report
+	if err := json.Unmarshal(b, &reports); err != nil {
+		return nil, fmt.Errorf("%w: decoding %s", err, path)
+	}
+
+	return reports, nil
+}
+
+//endregion
@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+)
+
+//region Least laxity first
+
+// LLFPolicy is preemptive least laxity first: every tick the ready task
+// with the least laxity, the slack between its deadline and the earliest it
+// could finish, runs. A waiting task's laxity shrinks every tick while the
+// running task's stays the same, so tasks with close laxities take turns
+// tick by tick. Ties keep the running task. Tasks without a deadline run
+// only when no task with one is ready.
+type LLFPolicy struct{}
+
+// laxity is how long t could still wait at now and meet its deadline.
+func laxity(now int64, t *Task) int64 {
+	return t.Deadline - now - t.Remaining
+}
+
+func (LLFPolicy) Pick(now int64, running *Task, ready []*Task) *Task {
+	less := func(a, b *Task) bool {
+		if a.Deadline == 0 || b.Deadline == 0 {
+			return b.Deadline == 0 && a.Deadline != 0
+		}
+		return laxity(now, a) < laxity(now, b)
+	}
+	best := minTask(ready, less)
+	if running != nil && (best == nil || !less(best, running)) {
+		return running
+	}
+	return best
+}
+
+// annotate adds each task's deadline and the misses, and compares the
+// context switches with earliest deadline first, which LLF's turn taking
+// adds to.
+func (LLFPolicy) annotate(r *Report, tasks []*Task) {
+	processes := make([]Process, len(tasks))
+	for i, t := range tasks {
+		processes[i] = t.Process
+	}
+	addDeadlineColumn(r, processes)
+	r.Notes = append(r.Notes, fmt.Sprintf("Context switches: %d (%d under earliest deadline first)",
+		contextSwitches(r.Gantt), contextSwitches(simulate("", processes, EDFPolicy{}).Gantt)))
+}
+
+// LLFSchedule outputs the least-laxity-first schedule of processes as a
+// Gantt chart and a table of timing with their deadlines.
+func LLFSchedule(w io.Writer, title string, processes []Process) {
+	outputReport(w, LLF(title, processes))
+}
+
+// LLF schedules processes least laxity first, recomputing every ready
+// process's laxity each tick.
+func LLF(title string, processes []Process) Report {
+	return simulate(title, processes, LLFPolicy{})
+}
+
+//endregion
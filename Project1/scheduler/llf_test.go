@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLLF(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		wantGantt []TimeSlice
+		wantNotes []string
+	}{
+		{
+			name: "equal laxities take turns",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 4, Deadline: 10}, {ProcessID: 2, BurstDuration: 4, Deadline: 10},
+				{ProcessID: 3, ArrivalTime: 1, BurstDuration: 2, Deadline: 6}, {ProcessID: 4, ArrivalTime: 2, BurstDuration: 3},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1}, {PID: 3, Start: 1, Stop: 3}, {PID: 2, Start: 3, Stop: 5}, {PID: 1, Start: 5, Stop: 7},
+				{PID: 2, Start: 7, Stop: 9}, {PID: 1, Start: 9, Stop: 10}, {PID: 4, Start: 10, Stop: 13},
+			},
+			wantNotes: []string{"Deadline misses: none of 3", "Context switches: 6 (4 under earliest deadline first)"},
+		},
+		{
+			name: "the most urgent runs first, but an overload misses",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 2, Deadline: 6}, {ProcessID: 2, BurstDuration: 6, Deadline: 7},
+			},
+			wantGantt: []TimeSlice{{PID: 2, Start: 0, Stop: 4}, {PID: 1, Start: 4, Stop: 6}, {PID: 2, Start: 6, Stop: 8}},
+			wantNotes: []string{"Deadline misses: 1 of 2 (2)", "Context switches: 2 (1 under earliest deadline first)"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := LLF("Least laxity first", tt.processes)
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("LLF() Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			if !reflect.DeepEqual(r.Notes, tt.wantNotes) {
+				t.Errorf("LLF() notes = %q, want %q", r.Notes, tt.wantNotes)
+			}
+		})
+	}
+}
+
+func TestLLFSchedule(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	LLFSchedule(&w, "Least laxity first", []Process{{ProcessID: 1, BurstDuration: 2, Deadline: 5}, {ProcessID: 2, BurstDuration: 1}})
+	for _, want := range []string{"DEADLINE", "Context switches: 1 (1 under earliest deadline first)"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("LLFSchedule() = %q, want it to contain %q", w.String(), want)
+		}
+	}
+}
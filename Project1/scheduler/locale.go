@@ -0,0 +1,632 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//region Localized output
+
+// Catalog is the text of the schedule reports in one language: messages
+// by key, some of them format strings, and the decimal separator numbers
+// are printed with. Text translates the column headers, footer labels and
+// notes policies add to reports, keyed by their English text or by the
+// format string they were printed with, such as "Preemptions: %d".
+type Catalog struct {
+	Decimal  string            `json:"decimal,omitempty"`
+	Messages map[string]string `json:"messages"`
+	Text     map[string]string `json:"text,omitempty"`
+}
+
+// catalogs are the built-in languages, by locale. English is complete; the
+// others fall back to it for any key they lack.
+var catalogs = map[string]Catalog{
+	"en": {Decimal: ".", Messages: map[string]string{
+		"gantt":          "Gantt schedule",
+		"cpu":            "CPU %d",
+		"schedule":       "Schedule table",
+		"id":             "ID",
+		"priority":       "Priority",
+		"burst":          "Burst",
+		"arrival":        "Arrival",
+		"wait":           "Wait",
+		"turnaround":     "Turnaround",
+		"exit":           "Exit",
+		"response_ratio": "Response ratio",
+		"average":        "Average",
+		"throughput":     "Throughput",
+		"per_tick":       "/t",
+		"makespan":       "Makespan: %s",
+		"ready_queue":    "Ready queue (max %d): |%s|",
+	}},
+	"de": {Decimal: ",", Messages: map[string]string{
+		"gantt":          "Gantt-Diagramm",
+		"schedule":       "Ablauftabelle",
+		"priority":       "Priorität",
+		"burst":          "Rechenzeit",
+		"arrival":        "Ankunft",
+		"wait":           "Wartezeit",
+		"turnaround":     "Verweilzeit",
+		"exit":           "Ende",
+		"response_ratio": "Antwortverhältnis",
+		"average":        "Mittelwert",
+		"throughput":     "Durchsatz",
+		"makespan":       "Gesamtdauer: %s",
+		"ready_queue":    "Bereit-Warteschlange (max. %d): |%s|",
+	}, Text: textDE},
+	"es": {Decimal: ",", Messages: map[string]string{
+		"gantt":          "Diagrama de Gantt",
+		"schedule":       "Tabla de planificación",
+		"priority":       "Prioridad",
+		"burst":          "Ráfaga",
+		"arrival":        "Llegada",
+		"wait":           "Espera",
+		"turnaround":     "Retorno",
+		"exit":           "Salida",
+		"response_ratio": "Razón de respuesta",
+		"average":        "Promedio",
+		"throughput":     "Rendimiento",
+		"makespan":       "Duración total: %s",
+		"ready_queue":    "Cola de listos (máx. %d): |%s|",
+	}, Text: textES},
+	"fr": {Decimal: ",", Messages: map[string]string{
+		"gantt":          "Diagramme de Gantt",
+		"schedule":       "Table d'ordonnancement",
+		"priority":       "Priorité",
+		"burst":          "Durée",
+		"arrival":        "Arrivée",
+		"wait":           "Attente",
+		"turnaround":     "Temps de séjour",
+		"exit":           "Fin",
+		"response_ratio": "Ratio de réponse",
+		"average":        "Moyenne",
+		"throughput":     "Débit",
+		"makespan":       "Durée totale : %s",
+		"ready_queue":    "File des prêts (max. %d) : |%s|",
+	}, Text: textFR},
+}
+
+var textDE = map[string]string{
+	"Accepted":           "Angenommen",
+	"Achieved/entitled":  "Erreicht/zustehend",
+	"Admitted":           "Zugelassen",
+	"Affinity":           "Affinität",
+	"Aged priority":      "Gealterte Priorität",
+	"Blocked":            "Blockiert",
+	"Boosts":             "Anhebungen",
+	"CPU share":          "CPU-Anteil",
+	"CPU time":           "CPU-Zeit",
+	"Class":              "Klasse",
+	"Consumed":           "Verbraucht",
+	"Credits earned":     "Erhaltene Credits",
+	"Deadline":           "Frist",
+	"Draws won":          "Gewonnene Ziehungen",
+	"Dynamic":            "Dynamisch",
+	"Entitled":           "Zustehend",
+	"Entitled share":     "Zustehender Anteil",
+	"Error":              "Fehler",
+	"Expired":            "Abgelaufen",
+	"Final credit":       "Endguthaben",
+	"Final level":        "Endstufe",
+	"Group":              "Gruppe",
+	"Held":               "Gehalten",
+	"I/O":                "E/A",
+	"Interactive":        "Interaktiv",
+	"Lag at exit":        "Lag am Ende",
+	"Max carried":        "Max. Übertrag",
+	"Max lag":            "Max. Lag",
+	"Migrations":         "Migrationen",
+	"Penalty":            "Strafe",
+	"Predicted":          "Vorhergesagt",
+	"Preempted":          "Verdrängt",
+	"Queue":              "Warteschlange",
+	"Queues":             "Warteschlangen",
+	"Ran":                "Lief",
+	"Ran OVER":           "Lief in OVER",
+	"Requeued":           "Wiedereingereiht",
+	"Response":           "Antwortzeit",
+	"Static":             "Statisch",
+	"Stride":             "Schrittweite",
+	"Task":               "Aufgabe",
+	"Tickets":            "Lose",
+	"Timeslice":          "Zeitscheibe",
+	"Turns":              "Runden",
+	"Wait without aging": "Wartezeit ohne Alterung",
+	"Waited new":         "Wartezeit neu",
+	"Weight":             "Gewicht",
+
+	"Average": "Mittelwert",
+	"Delay":   "Verzögerung",
+	"Draws":   "Ziehungen",
+	"Missed":  "Verpasst",
+	"Total":   "Gesamt",
+
+	"%d CPUs, quantum %d: %d of %d CPU slots idle (%.1f%%), %d of them fragmentation, free while a gang waited for enough CPUs": "%d CPUs, Quantum %d: %d von %d CPU-Slots frei (%.1f%%), davon %d durch Fragmentierung, frei während eine Gang auf genug CPUs wartete",
+	"%d items left in the buffer":                          "%d Elemente im Puffer verblieben",
+	"%d resource requests deferred to keep the state safe": "%d Ressourcenanforderungen zurückgestellt, um den Zustand sicher zu halten",
+	"Aborted at %v": "Abgebrochen bei %v",
+	"Active and expired arrays swapped at %s":                                                                             "Aktives und abgelaufenes Array getauscht bei %s",
+	"Against CFS, latency %d, granularity %d: average wait %.2f (CFS %.2f), context switches %d (CFS %d)":                 "Gegenüber CFS, Latenz %d, Granularität %d: mittlere Wartezeit %.2f (CFS %.2f), Kontextwechsel %d (CFS %d)",
+	"Against random dispatch: %s":                                                                                         "Gegenüber zufälliger Zuteilung: %s",
+	"Aperiodic: %d jobs, average response %.2f, average turnaround %.2f":                                                  "Aperiodisch: %d Aufträge, mittlere Antwortzeit %.2f, mittlere Verweilzeit %.2f",
+	"Average wait %.2f, %.2f knowing the actual bursts":                                                                   "Mittlere Wartezeit %.2f, %.2f bei bekannten tatsächlichen Bursts",
+	"Background: average turnaround %.2f (%.2f unthrottled)":                                                              "Hintergrund: mittlere Verweilzeit %.2f (%.2f ungedrosselt)",
+	"Base slice %d: %d preemptions":                                                                                       "Basiszeitscheibe %d: %d Verdrängungen",
+	"Batch: %d processes, throughput %.2f/t":                                                                              "Batch: %d Prozesse, Durchsatz %.2f/t",
+	"Burst cycles: %d I/O bursts taking %d ticks; CPU utilization %.1f%%":                                                 "Burst-Zyklen: %d E/A-Bursts mit %d Ticks; CPU-Auslastung %.1f%%",
+	"Context switches: %d (%d under earliest deadline first)":                                                             "Kontextwechsel: %d (%d bei Earliest Deadline First)",
+	"Credits after accounting: %s":                                                                                        "Credits nach der Abrechnung: %s",
+	"Deadline misses: %d of %d (%s)":                                                                                      "Fristverletzungen: %d von %d (%s)",
+	"Deadline misses: none of %d":                                                                                         "Fristverletzungen: keine von %d",
+	"Deadlock: processes %s are blocked with nothing left to wake them":                                                   "Verklemmung: Prozesse %s sind blockiert, und nichts kann sie mehr wecken",
+	"Dispatch latency %d: %d dispatches took %d ticks, %.1f%% of the makespan":                                            "Zuteilungslatenz %d: %d Zuteilungen dauerten %d Ticks, %.1f%% der Gesamtdauer",
+	"Dispatched from the (N)ew or (A)ccepted queue:\n%s":                                                                  "Zugeteilt aus der (N)euen oder (A)ngenommenen Warteschlange:\n%s",
+	"Effective CPU utilization: %.1f%%":                                                                                   "Effektive CPU-Auslastung: %.1f%%",
+	"Effective priority over time: %s":                                                                                    "Effektive Priorität im Zeitverlauf: %s",
+	"Fairness (Jain's index of weighted slowdown, 1 is perfectly fair): %.3f":                                             "Fairness (Jain-Index der gewichteten Verlangsamung, 1 ist vollkommen fair): %.3f",
+	"Foreground: average response %.2f (%.2f unthrottled)":                                                                "Vordergrund: mittlere Antwortzeit %.2f (%.2f ungedrosselt)",
+	"Group %s: weight %d, configured %.1f%% of %s, achieved %.1f%%":                                                       "Gruppe %s: Gewicht %d, konfiguriert %.1f%% von %s, erreicht %.1f%%",
+	"Interactive: %d processes, average response %.2f":                                                                    "Interaktiv: %d Prozesse, mittlere Antwortzeit %.2f",
+	"Mean absolute prediction error: %.2f":                                                                                "Mittlerer absoluter Vorhersagefehler: %.2f",
+	"Migration penalty %d: %d dispatches on another CPU cost %d ticks, %s of the CPU time":                                "Migrationsstrafe %d: %d Zuteilungen auf einer anderen CPU kosteten %d Ticks, %s der CPU-Zeit",
+	"Multiprogramming limit %d: admission delay averages %.2f, %.1f%% of turnaround":                                      "Multiprogramming-Grenze %d: Zulassungsverzögerung im Mittel %.2f, %.1f%% der Verweilzeit",
+	"None would have starved without aging (waiting over %d)":                                                             "Ohne Alterung wäre keiner verhungert (Wartezeit über %d)",
+	"Partition %s (budget %d%%): ran %d ticks, %.1f%% of CPU time, %d of them borrowed from idle partitions":              "Partition %s (Budget %d%%): lief %d Ticks, %.1f%% der CPU-Zeit, davon %d von freien Partitionen geliehen",
+	"Placed first-fit by utilization: %s; load imbalance %.1f%%":                                                          "First-Fit nach Auslastung verteilt: %s; Lastungleichgewicht %.1f%%",
+	"Preemption points: %s":                                                                                               "Verdrängungspunkte: %s",
+	"Preemptions: %d":                                                                                                     "Verdrängungen: %d",
+	"Preemptions: %d of %d allowed, %d more refused":                                                                      "Verdrängungen: %d von %d erlaubt, %d weitere abgelehnt",
+	"Queue %d (%s) ran: %s":                                                                                               "Warteschlange %d (%s) lief: %s",
+	"Queue %d (%s, %s): %d processes, average wait %.2f, average response %.2f":                                           "Warteschlange %d (%s, %s): %d Prozesse, mittlere Wartezeit %.2f, mittlere Antwortzeit %.2f",
+	"Queue transitions: %s":                                                                                               "Warteschlangenwechsel: %s",
+	"RT interference: %d normal processes wait %.2f on average (%.2f without the %d realtime ones), response %.2f (%.2f)": "Echtzeit-Störung: %d normale Prozesse warten im Mittel %.2f (%.2f ohne die %d Echtzeitprozesse), Antwortzeit %.2f (%.2f)",
+	"Random I/O: %d requests (probability %g per tick, mean %g ticks, seed %d)":                                           "Zufällige E/A: %d Anforderungen (Wahrscheinlichkeit %g je Tick, Mittel %g Ticks, Seed %d)",
+	"Response ratios at dispatch: %s":                                                                                     "Antwortverhältnisse bei Zuteilung: %s",
+	"Round-robin within %s":                                                                                               "Round-Robin innerhalb %s",
+	"Speedup %.2f over 1 CPU (makespan %d), efficiency %.1f%%":                                                            "Beschleunigung %.2f gegenüber 1 CPU (Gesamtdauer %d), Effizienz %.1f%%",
+	"Speeds %s, %s first: energy %.2f, a busy tick at speed s costing s³":                                                 "Geschwindigkeiten %s, %s zuerst: Energie %.2f, ein belegter Tick bei Geschwindigkeit s kostet s³",
+	"Steals from queues of at least %d: %s":                                                                               "Übernahmen aus Warteschlangen ab %d: %s",
+	"Switch cost %d: %d context switches took %d ticks, %.1f%% of the makespan":                                           "Wechselkosten %d: %d Kontextwechsel dauerten %d Ticks, %.1f%% der Gesamtdauer",
+	"Target latency %d, minimum granularity %d: %d preemptions":                                                           "Ziellatenz %d, minimale Granularität %d: %d Verdrängungen",
+	"Ticks delayed only by affinity, waiting while the idle CPUs were ones it may not use: %s":                            "Nur durch Affinität verzögerte Ticks, wartend, während nur unerlaubte CPUs frei waren: %s",
+	"Utilization: %s (average %s)":                                                                                        "Auslastung: %s (Mittel %s)",
+	"Would have starved without aging (waiting over %d): %s":                                                              "Ohne Alterung verhungert (Wartezeit über %d): %s",
+	"t=%d: budget exhausted, refilled to %d, deadline postponed to %d":                                                    "t=%d: Budget erschöpft, aufgefüllt auf %d, Frist verschoben auf %d",
+	"t=%d: budget refilled to %d, deadline %d":                                                                            "t=%d: Budget aufgefüllt auf %d, Frist %d",
+	"t=%d: work arrived, budget %d, deadline %d":                                                                          "t=%d: Arbeit eingetroffen, Budget %d, Frist %d",
+}
+
+var textES = map[string]string{
+	"Accepted":           "Aceptado",
+	"Achieved/entitled":  "Logrado/asignado",
+	"Admitted":           "Admitido",
+	"Affinity":           "Afinidad",
+	"Aged priority":      "Prioridad envejecida",
+	"Blocked":            "Bloqueado",
+	"Boosts":             "Impulsos",
+	"CPU share":          "Cuota de CPU",
+	"CPU time":           "Tiempo de CPU",
+	"Class":              "Clase",
+	"Consumed":           "Consumido",
+	"Credits earned":     "Créditos ganados",
+	"Deadline":           "Plazo",
+	"Draws won":          "Sorteos ganados",
+	"Dynamic":            "Dinámica",
+	"Entitled":           "Asignado",
+	"Entitled share":     "Cuota asignada",
+	"Expired":            "Expirado",
+	"Final credit":       "Crédito final",
+	"Final level":        "Nivel final",
+	"Group":              "Grupo",
+	"Held":               "Retenidas",
+	"I/O":                "E/S",
+	"Interactive":        "Interactivo",
+	"Lag at exit":        "Retraso al salir",
+	"Max carried":        "Máx. acumulado",
+	"Max lag":            "Retraso máx.",
+	"Migrations":         "Migraciones",
+	"Partition":          "Partición",
+	"Penalty":            "Penalización",
+	"Predicted":          "Previsto",
+	"Preempted":          "Expropiado",
+	"Quantum":            "Cuanto",
+	"Queue":              "Cola",
+	"Queues":             "Colas",
+	"Ran":                "Ejecutó",
+	"Ran OVER":           "Ejecutó en OVER",
+	"Requeued":           "Reencolado",
+	"Response":           "Respuesta",
+	"Static":             "Estática",
+	"Stride":             "Paso",
+	"Task":               "Tarea",
+	"Tickets":            "Boletos",
+	"Timeslice":          "Porción de tiempo",
+	"Turns":              "Turnos",
+	"Wait without aging": "Espera sin envejecimiento",
+	"Waited new":         "Espera como nuevo",
+	"Weight":             "Peso",
+
+	"Average": "Promedio",
+	"Delay":   "Retraso",
+	"Draws":   "Sorteos",
+	"Missed":  "Incumplidos",
+
+	"%d CPUs, quantum %d: %d of %d CPU slots idle (%.1f%%), %d of them fragmentation, free while a gang waited for enough CPUs": "%d CPUs, cuanto %d: %d de %d ranuras de CPU ociosas (%.1f%%), %d de ellas por fragmentación, libres mientras una banda esperaba suficientes CPUs",
+	"%d items left in the buffer":                          "%d elementos quedan en el búfer",
+	"%d resource requests deferred to keep the state safe": "%d solicitudes de recursos aplazadas para mantener el estado seguro",
+	"Aborted at %v": "Abortado en %v",
+	"Active and expired arrays swapped at %s":                                                                             "Arreglos activo y expirado intercambiados en %s",
+	"Against CFS, latency %d, granularity %d: average wait %.2f (CFS %.2f), context switches %d (CFS %d)":                 "Frente a CFS, latencia %d, granularidad %d: espera media %.2f (CFS %.2f), cambios de contexto %d (CFS %d)",
+	"Against random dispatch: %s":                                                                                         "Frente al despacho aleatorio: %s",
+	"Aperiodic: %d jobs, average response %.2f, average turnaround %.2f":                                                  "Aperiódicos: %d trabajos, respuesta media %.2f, retorno medio %.2f",
+	"Average wait %.2f, %.2f knowing the actual bursts":                                                                   "Espera media %.2f, %.2f conociendo las ráfagas reales",
+	"Background: average turnaround %.2f (%.2f unthrottled)":                                                              "Segundo plano: retorno medio %.2f (%.2f sin limitar)",
+	"Base slice %d: %d preemptions":                                                                                       "Porción base %d: %d expropiaciones",
+	"Batch: %d processes, throughput %.2f/t":                                                                              "Por lotes: %d procesos, rendimiento %.2f/t",
+	"Burst cycles: %d I/O bursts taking %d ticks; CPU utilization %.1f%%":                                                 "Ciclos de ráfagas: %d ráfagas de E/S de %d ticks; uso de CPU %.1f%%",
+	"Context switches: %d (%d under earliest deadline first)":                                                             "Cambios de contexto: %d (%d con el plazo más próximo primero)",
+	"Credits after accounting: %s":                                                                                        "Créditos tras la contabilidad: %s",
+	"Deadline misses: %d of %d (%s)":                                                                                      "Plazos incumplidos: %d de %d (%s)",
+	"Deadline misses: none of %d":                                                                                         "Plazos incumplidos: ninguno de %d",
+	"Deadlock: processes %s are blocked with nothing left to wake them":                                                   "Interbloqueo: los procesos %s están bloqueados sin nada que pueda despertarlos",
+	"Dispatch latency %d: %d dispatches took %d ticks, %.1f%% of the makespan":                                            "Latencia de despacho %d: %d despachos tomaron %d ticks, %.1f%% de la duración total",
+	"Dispatched from the (N)ew or (A)ccepted queue:\n%s":                                                                  "Despachado desde la cola de (N)uevos o (A)ceptados:\n%s",
+	"Effective CPU utilization: %.1f%%":                                                                                   "Utilización efectiva de CPU: %.1f%%",
+	"Effective priority over time: %s":                                                                                    "Prioridad efectiva en el tiempo: %s",
+	"Fairness (Jain's index of weighted slowdown, 1 is perfectly fair): %.3f":                                             "Equidad (índice de Jain de la ralentización ponderada, 1 es totalmente equitativo): %.3f",
+	"Foreground: average response %.2f (%.2f unthrottled)":                                                                "Primer plano: respuesta media %.2f (%.2f sin limitar)",
+	"Group %s: weight %d, configured %.1f%% of %s, achieved %.1f%%":                                                       "Grupo %s: peso %d, configurado %.1f%% de %s, logrado %.1f%%",
+	"Interactive: %d processes, average response %.2f":                                                                    "Interactivos: %d procesos, respuesta media %.2f",
+	"Mean absolute prediction error: %.2f":                                                                                "Error absoluto medio de predicción: %.2f",
+	"Migration penalty %d: %d dispatches on another CPU cost %d ticks, %s of the CPU time":                                "Penalización por migración %d: %d despachos en otra CPU costaron %d ticks, %s del tiempo de CPU",
+	"Multiprogramming limit %d: admission delay averages %.2f, %.1f%% of turnaround":                                      "Límite de multiprogramación %d: el retraso de admisión promedia %.2f, %.1f%% del retorno",
+	"None would have starved without aging (waiting over %d)":                                                             "Ninguno habría sufrido inanición sin envejecimiento (esperando más de %d)",
+	"Partition %s (budget %d%%): ran %d ticks, %.1f%% of CPU time, %d of them borrowed from idle partitions":              "Partición %s (presupuesto %d%%): ejecutó %d ticks, %.1f%% del tiempo de CPU, %d de ellos prestados de particiones ociosas",
+	"Placed first-fit by utilization: %s; load imbalance %.1f%%":                                                          "Colocados por primer ajuste según utilización: %s; desequilibrio de carga %.1f%%",
+	"Preemption points: %s":                                                                                               "Puntos de expropiación: %s",
+	"Preemptions: %d":                                                                                                     "Expropiaciones: %d",
+	"Preemptions: %d of %d allowed, %d more refused":                                                                      "Expropiaciones: %d de %d permitidas, %d más rechazadas",
+	"Queue %d (%s) ran: %s":                                                                                               "Cola %d (%s) ejecutó: %s",
+	"Queue %d (%s, %s): %d processes, average wait %.2f, average response %.2f":                                           "Cola %d (%s, %s): %d procesos, espera media %.2f, respuesta media %.2f",
+	"Queue transitions: %s":                                                                                               "Transiciones de cola: %s",
+	"RT interference: %d normal processes wait %.2f on average (%.2f without the %d realtime ones), response %.2f (%.2f)": "Interferencia de tiempo real: %d procesos normales esperan %.2f de media (%.2f sin los %d de tiempo real), respuesta %.2f (%.2f)",
+	"Random I/O: %d requests (probability %g per tick, mean %g ticks, seed %d)":                                           "E/S aleatoria: %d solicitudes (probabilidad %g por tick, media %g ticks, semilla %d)",
+	"Response ratios at dispatch: %s":                                                                                     "Razones de respuesta al despachar: %s",
+	"Round-robin within %s":                                                                                               "Turno rotatorio dentro de %s",
+	"Speedup %.2f over 1 CPU (makespan %d), efficiency %.1f%%":                                                            "Aceleración %.2f sobre 1 CPU (duración total %d), eficiencia %.1f%%",
+	"Speeds %s, %s first: energy %.2f, a busy tick at speed s costing s³":                                                 "Velocidades %s, %s primero: energía %.2f, un tick ocupado a velocidad s cuesta s³",
+	"Steals from queues of at least %d: %s":                                                                               "Robos de colas de al menos %d: %s",
+	"Switch cost %d: %d context switches took %d ticks, %.1f%% of the makespan":                                           "Coste de cambio %d: %d cambios de contexto tomaron %d ticks, %.1f%% de la duración total",
+	"Target latency %d, minimum granularity %d: %d preemptions":                                                           "Latencia objetivo %d, granularidad mínima %d: %d expropiaciones",
+	"Ticks delayed only by affinity, waiting while the idle CPUs were ones it may not use: %s":                            "Ticks retrasados solo por afinidad, esperando mientras las CPU libres eran de uso no permitido: %s",
+	"Utilization: %s (average %s)":                                                                                        "Utilización: %s (media %s)",
+	"Would have starved without aging (waiting over %d): %s":                                                              "Habrían sufrido inanición sin envejecimiento (esperando más de %d): %s",
+	"t=%d: budget exhausted, refilled to %d, deadline postponed to %d":                                                    "t=%d: presupuesto agotado, repuesto a %d, plazo aplazado a %d",
+	"t=%d: budget refilled to %d, deadline %d":                                                                            "t=%d: presupuesto repuesto a %d, plazo %d",
+	"t=%d: work arrived, budget %d, deadline %d":                                                                          "t=%d: llegó trabajo, presupuesto %d, plazo %d",
+}
+
+var textFR = map[string]string{
+	"Accepted":           "Accepté",
+	"Achieved/entitled":  "Obtenu/dû",
+	"Admitted":           "Admis",
+	"Affinity":           "Affinité",
+	"Aged priority":      "Priorité vieillie",
+	"Blocked":            "Bloqué",
+	"Boosts":             "Relances",
+	"CPU share":          "Part du CPU",
+	"CPU time":           "Temps CPU",
+	"Class":              "Classe",
+	"Consumed":           "Consommé",
+	"Credits earned":     "Crédits gagnés",
+	"Deadline":           "Échéance",
+	"Draws won":          "Tirages gagnés",
+	"Dynamic":            "Dynamique",
+	"Entitled":           "Dû",
+	"Entitled share":     "Part due",
+	"Error":              "Erreur",
+	"Expired":            "Expiré",
+	"Final credit":       "Crédit final",
+	"Final level":        "Niveau final",
+	"Group":              "Groupe",
+	"Held":               "Tenus",
+	"I/O":                "E/S",
+	"Interactive":        "Interactif",
+	"Lag at exit":        "Retard à la fin",
+	"Max carried":        "Report max.",
+	"Max lag":            "Retard max.",
+	"Penalty":            "Pénalité",
+	"Predicted":          "Prévu",
+	"Preempted":          "Préempté",
+	"Queue":              "File",
+	"Queues":             "Files",
+	"Ran":                "Exécuté",
+	"Ran OVER":           "Exécuté en OVER",
+	"Requeued":           "Remis en file",
+	"Response":           "Réponse",
+	"Static":             "Statique",
+	"Stride":             "Pas",
+	"Task":               "Tâche",
+	"Timeslice":          "Tranche de temps",
+	"Turns":              "Tours",
+	"Wait without aging": "Attente sans vieillissement",
+	"Waited new":         "Attente nouveau",
+	"Weight":             "Poids",
+
+	"Average": "Moyenne",
+	"Delay":   "Délai",
+	"Draws":   "Tirages",
+	"Missed":  "Manquées",
+
+	"%d CPUs, quantum %d: %d of %d CPU slots idle (%.1f%%), %d of them fragmentation, free while a gang waited for enough CPUs": "%d CPU, quantum %d : %d sur %d créneaux CPU inactifs (%.1f%%), dont %d par fragmentation, libres pendant qu'un gang attendait assez de CPU",
+	"%d items left in the buffer":                          "%d éléments restés dans le tampon",
+	"%d resource requests deferred to keep the state safe": "%d demandes de ressources différées pour garder l'état sûr",
+	"Aborted at %v": "Interrompu à %v",
+	"Active and expired arrays swapped at %s":                                                                             "Tableaux actif et expiré échangés à %s",
+	"Against CFS, latency %d, granularity %d: average wait %.2f (CFS %.2f), context switches %d (CFS %d)":                 "Face à CFS, latence %d, granularité %d : attente moyenne %.2f (CFS %.2f), changements de contexte %d (CFS %d)",
+	"Against random dispatch: %s":                                                                                         "Face à l'attribution aléatoire : %s",
+	"Aperiodic: %d jobs, average response %.2f, average turnaround %.2f":                                                  "Apériodiques : %d travaux, réponse moyenne %.2f, séjour moyen %.2f",
+	"Average wait %.2f, %.2f knowing the actual bursts":                                                                   "Attente moyenne %.2f, %.2f en connaissant les rafales réelles",
+	"Background: average turnaround %.2f (%.2f unthrottled)":                                                              "Arrière-plan : séjour moyen %.2f (%.2f sans bridage)",
+	"Base slice %d: %d preemptions":                                                                                       "Tranche de base %d : %d préemptions",
+	"Batch: %d processes, throughput %.2f/t":                                                                              "Par lots : %d processus, débit %.2f/t",
+	"Burst cycles: %d I/O bursts taking %d ticks; CPU utilization %.1f%%":                                                 "Cycles de rafales : %d rafales d'E/S de %d ticks ; utilisation du CPU %.1f%%",
+	"Context switches: %d (%d under earliest deadline first)":                                                             "Changements de contexte : %d (%d avec l'échéance la plus proche d'abord)",
+	"Credits after accounting: %s":                                                                                        "Crédits après comptabilisation : %s",
+	"Deadline misses: %d of %d (%s)":                                                                                      "Échéances manquées : %d sur %d (%s)",
+	"Deadline misses: none of %d":                                                                                         "Échéances manquées : aucune sur %d",
+	"Deadlock: processes %s are blocked with nothing left to wake them":                                                   "Interblocage : les processus %s sont bloqués sans plus rien pour les réveiller",
+	"Dispatch latency %d: %d dispatches took %d ticks, %.1f%% of the makespan":                                            "Latence d'attribution %d : %d attributions ont pris %d ticks, %.1f%% de la durée totale",
+	"Dispatched from the (N)ew or (A)ccepted queue:\n%s":                                                                  "Attribué depuis la file des (N)ouveaux ou des (A)cceptés :\n%s",
+	"Effective CPU utilization: %.1f%%":                                                                                   "Utilisation effective du CPU : %.1f%%",
+	"Effective priority over time: %s":                                                                                    "Priorité effective au fil du temps : %s",
+	"Fairness (Jain's index of weighted slowdown, 1 is perfectly fair): %.3f":                                             "Équité (indice de Jain du ralentissement pondéré, 1 est parfaitement équitable) : %.3f",
+	"Foreground: average response %.2f (%.2f unthrottled)":                                                                "Premier plan : réponse moyenne %.2f (%.2f sans bridage)",
+	"Group %s: weight %d, configured %.1f%% of %s, achieved %.1f%%":                                                       "Groupe %s : poids %d, configuré %.1f%% de %s, obtenu %.1f%%",
+	"Interactive: %d processes, average response %.2f":                                                                    "Interactifs : %d processus, réponse moyenne %.2f",
+	"Mean absolute prediction error: %.2f":                                                                                "Erreur absolue moyenne de prédiction : %.2f",
+	"Migration penalty %d: %d dispatches on another CPU cost %d ticks, %s of the CPU time":                                "Pénalité de migration %d : %d attributions sur un autre CPU ont coûté %d ticks, %s du temps CPU",
+	"Multiprogramming limit %d: admission delay averages %.2f, %.1f%% of turnaround":                                      "Limite de multiprogrammation %d : délai d'admission moyen %.2f, %.1f%% du temps de séjour",
+	"None would have starved without aging (waiting over %d)":                                                             "Aucun n'aurait subi de famine sans vieillissement (attente de plus de %d)",
+	"Partition %s (budget %d%%): ran %d ticks, %.1f%% of CPU time, %d of them borrowed from idle partitions":              "Partition %s (budget %d%%) : a exécuté %d ticks, %.1f%% du temps CPU, dont %d empruntés aux partitions inactives",
+	"Placed first-fit by utilization: %s; load imbalance %.1f%%":                                                          "Placés en first-fit selon l'utilisation : %s ; déséquilibre de charge %.1f%%",
+	"Preemption points: %s":                                                                                               "Points de préemption : %s",
+	"Preemptions: %d":                                                                                                     "Préemptions : %d",
+	"Preemptions: %d of %d allowed, %d more refused":                                                                      "Préemptions : %d sur %d autorisées, %d de plus refusées",
+	"Queue %d (%s) ran: %s":                                                                                               "File %d (%s) a exécuté : %s",
+	"Queue %d (%s, %s): %d processes, average wait %.2f, average response %.2f":                                           "File %d (%s, %s) : %d processus, attente moyenne %.2f, réponse moyenne %.2f",
+	"Queue transitions: %s":                                                                                               "Transitions de file : %s",
+	"RT interference: %d normal processes wait %.2f on average (%.2f without the %d realtime ones), response %.2f (%.2f)": "Interférence temps réel : %d processus normaux attendent %.2f en moyenne (%.2f sans les %d temps réel), réponse %.2f (%.2f)",
+	"Random I/O: %d requests (probability %g per tick, mean %g ticks, seed %d)":                                           "E/S aléatoires : %d requêtes (probabilité %g par tick, moyenne %g ticks, graine %d)",
+	"Response ratios at dispatch: %s":                                                                                     "Ratios de réponse à l'attribution : %s",
+	"Round-robin within %s":                                                                                               "Tourniquet au sein de %s",
+	"Speedup %.2f over 1 CPU (makespan %d), efficiency %.1f%%":                                                            "Accélération %.2f par rapport à 1 CPU (durée totale %d), efficacité %.1f%%",
+	"Speeds %s, %s first: energy %.2f, a busy tick at speed s costing s³":                                                 "Vitesses %s, %s d'abord : énergie %.2f, un tick occupé à la vitesse s coûtant s³",
+	"Steals from queues of at least %d: %s":                                                                               "Vols dans les files d'au moins %d : %s",
+	"Switch cost %d: %d context switches took %d ticks, %.1f%% of the makespan":                                           "Coût de commutation %d : %d changements de contexte ont pris %d ticks, %.1f%% de la durée totale",
+	"Target latency %d, minimum granularity %d: %d preemptions":                                                           "Latence cible %d, granularité minimale %d : %d préemptions",
+	"Ticks delayed only by affinity, waiting while the idle CPUs were ones it may not use: %s":                            "Ticks retardés uniquement par l'affinité, en attente alors que les CPU libres lui étaient interdits : %s",
+	"Utilization: %s (average %s)":                                                                                        "Utilisation : %s (moyenne %s)",
+	"Would have starved without aging (waiting over %d): %s":                                                              "Auraient subi une famine sans vieillissement (attente de plus de %d) : %s",
+	"t=%d: budget exhausted, refilled to %d, deadline postponed to %d":                                                    "t=%d : budget épuisé, rechargé à %d, échéance repoussée à %d",
+	"t=%d: budget refilled to %d, deadline %d":                                                                            "t=%d : budget rechargé à %d, échéance %d",
+	"t=%d: work arrived, budget %d, deadline %d":                                                                          "t=%d : travail arrivé, budget %d, échéance %d",
+}
+
+// localeFromEnv is the language of the usual locale environment
+// variables, such as "de" for LANG=de_DE.UTF-8, or "" if none is set.
+func localeFromEnv() string {
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		v := os.Getenv(name)
+		if v == "" || v == "C" || v == "POSIX" {
+			continue
+		}
+		if i := strings.IndexAny(v, "_.@"); i >= 0 {
+			v = v[:i]
+		}
+		return strings.ToLower(v)
+	}
+	return ""
+}
+
+// loadCatalog returns the built-in catalog for locale, overridden by the
+// messages and decimal separator of the JSON catalog file at path, if
+// given, and then by decimal, if given. An unknown locale is an error
+// unless the file supplies its messages.
+func loadCatalog(locale, path, decimal string) (Catalog, error) {
+	c, ok := catalogs[locale]
+	if !ok && path == "" {
+		names := make([]string, 0, len(catalogs))
+		for name := range catalogs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return Catalog{}, fmt.Errorf("%w: no messages for locale %q (have %s)", ErrInvalidArgs, locale, strings.Join(names, ", "))
+	}
+	merged := Catalog{Decimal: c.Decimal, Messages: make(map[string]string), Text: make(map[string]string)}
+	for k, v := range c.Messages {
+		merged.Messages[k] = v
+	}
+	for k, v := range c.Text {
+		merged.Text[k] = v
+	}
+
+	if path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return Catalog{}, fmt.Errorf("%w: reading message catalog", err)
+		}
+		var custom Catalog
+		if err := json.Unmarshal(b, &custom); err != nil {
+			return Catalog{}, fmt.Errorf("%w: parsing message catalog %s", err, path)
+		}
+		for k, v := range custom.Messages {
+			if _, ok := catalogs["en"].Messages[k]; !ok {
+				return Catalog{}, fmt.Errorf("%w: %s: unknown message %q", ErrInvalidArgs, path, k)
+			}
+			merged.Messages[k] = v
+		}
+		for k, v := range custom.Text {
+			if _, want := formatParts(k); !sameVerbs(want, v) {
+				return Catalog{}, fmt.Errorf("%w: %s: %q must print the values of %q in order", ErrInvalidArgs, path, v, k)
+			}
+			merged.Text[k] = v
+		}
+		if custom.Decimal != "" {
+			merged.Decimal = custom.Decimal
+		}
+	}
+	if decimal != "" {
+		merged.Decimal = decimal
+	}
+	return merged, nil
+}
+
+// msg returns the message for key, in English if the catalog lacks it.
+func (c Catalog) msg(key string) string {
+	if m, ok := c.Messages[key]; ok {
+		return m
+	}
+	return catalogs["en"].Messages[key]
+}
+
+// float formats v with prec decimals and the catalog's decimal separator.
+func (c Catalog) float(v float64, prec int) string {
+	return c.point(strconv.FormatFloat(v, 'f', prec, 64))
+}
+
+// point replaces the first decimal point in s with the catalog's decimal
+// separator.
+func (c Catalog) point(s string) string {
+	if c.Decimal != "" && c.Decimal != "." {
+		s = strings.Replace(s, ".", c.Decimal, 1)
+	}
+	return s
+}
+
+// number puts s in the catalog's decimal separator if it is a decimal
+// number, such as "1.50" or "12.5%", and returns it as it is otherwise.
+func (c Catalog) number(s string) string {
+	if !strings.Contains(s, ".") {
+		return s
+	}
+	if _, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64); err != nil {
+		return s
+	}
+	return c.point(s)
+}
+
+// text translates s, a column header, footer label or note, or returns it
+// as it is if the catalog has none for it. A translation keyed by the
+// format s was printed with gets the values s printed, in order, with
+// decimals in the catalog's separator. The longest matching format wins.
+func (c Catalog) text(s string) string {
+	if t, ok := c.Text[s]; ok {
+		return t
+	}
+	formats := make([]string, 0, len(c.Text))
+	for format := range c.Text {
+		if strings.Contains(format, "%") {
+			formats = append(formats, format)
+		}
+	}
+	sort.Slice(formats, func(i, j int) bool {
+		if len(formats[i]) != len(formats[j]) {
+			return len(formats[i]) > len(formats[j])
+		}
+		return formats[i] < formats[j]
+	})
+	for _, format := range formats {
+		args, ok := scanFormat(format, s)
+		if !ok {
+			continue
+		}
+		literals, _ := formatParts(c.Text[format])
+		if len(literals) != len(args)+1 {
+			return s
+		}
+		var b strings.Builder
+		b.WriteString(literals[0])
+		for i, arg := range args {
+			b.WriteString(c.number(arg))
+			b.WriteString(literals[i+1])
+		}
+		return b.String()
+	}
+	return s
+}
+
+// formatParts splits a format string into the literal text around its
+// verbs, one more than there are verbs, and the verbs, such as "%.2f".
+// "%%" is a literal "%".
+func formatParts(format string) (literals, verbs []string) {
+	var lit []byte
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			lit = append(lit, format[i])
+			continue
+		}
+		if i+1 < len(format) && format[i+1] == '%' {
+			lit = append(lit, '%')
+			i++
+			continue
+		}
+		start := i
+		for i++; i < len(format) && strings.IndexByte("+-# 0123456789.", format[i]) >= 0; i++ {
+		}
+		end := i + 1
+		if end > len(format) {
+			end = len(format)
+		}
+		literals = append(literals, string(lit))
+		verbs = append(verbs, format[start:end])
+		lit = nil
+	}
+	return append(literals, string(lit)), verbs
+}
+
+// sameVerbs reports whether format has exactly the verbs want, in order.
+func sameVerbs(want []string, format string) bool {
+	_, verbs := formatParts(format)
+	if len(verbs) != len(want) {
+		return false
+	}
+	for i := range verbs {
+		if verbs[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// scanFormat returns the values s printed through format, as text, if s
+// can have been printed by it.
+func scanFormat(format, s string) ([]string, bool) {
+	literals, _ := formatParts(format)
+	if !strings.HasPrefix(s, literals[0]) {
+		return nil, false
+	}
+	s = s[len(literals[0]):]
+	var args []string
+	for i, lit := range literals[1:] {
+		if i == len(literals)-2 {
+			if !strings.HasSuffix(s, lit) {
+				return nil, false
+			}
+			return append(args, s[:len(s)-len(lit)]), true
+		}
+		end := strings.Index(s, lit)
+		if lit == "" || end < 0 {
+			return nil, false
+		}
+		args = append(args, s[:end])
+		s = s[end+len(lit):]
+	}
+	return nil, s == ""
+}
+
+//endregion
@@ -0,0 +1,192 @@
+package scheduler
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_loadCatalog(t *testing.T) {
+	t.Parallel()
+	custom := filepath.Join(t.TempDir(), "messages.json")
+	if err := os.WriteFile(custom, []byte(`{"decimal": "·", "messages": {"wait": "Espera (ticks)"}, "text": {"Preemptions: %d": "Expropiadas: %d"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	unknown := filepath.Join(t.TempDir(), "unknown.json")
+	if err := os.WriteFile(unknown, []byte(`{"messages": {"colour": "Farbe"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	verbs := filepath.Join(t.TempDir(), "verbs.json")
+	if err := os.WriteFile(verbs, []byte(`{"text": {"Preemptions: %d": "Verdrängungen"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name        string
+		locale      string
+		path        string
+		decimal     string
+		wantWait    string
+		wantExit    string
+		wantText    string
+		wantDecimal string
+		wantErr     bool
+	}{
+		{name: "english", locale: "en", wantWait: "Wait", wantExit: "Exit", wantText: "Preemptions: 2", wantDecimal: "."},
+		{name: "german", locale: "de", wantWait: "Wartezeit", wantExit: "Ende", wantText: "Verdrängungen: 2", wantDecimal: ","},
+		{name: "decimal override", locale: "de", decimal: ".", wantWait: "Wartezeit", wantExit: "Ende", wantText: "Verdrängungen: 2", wantDecimal: "."},
+		{name: "catalog file", locale: "es", path: custom, wantWait: "Espera (ticks)", wantExit: "Salida", wantText: "Expropiadas: 2", wantDecimal: "·"},
+		{name: "catalog file for a new locale", locale: "pt", path: custom, wantWait: "Espera (ticks)", wantExit: "Exit", wantText: "Expropiadas: 2", wantDecimal: "·"},
+		{name: "unknown locale", locale: "pt", wantErr: true},
+		{name: "unknown message", locale: "en", path: unknown, wantErr: true},
+		{name: "text dropping a value", locale: "en", path: verbs, wantErr: true},
+		{name: "missing file", locale: "en", path: filepath.Join(t.TempDir(), "none.json"), wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c, err := loadCatalog(tt.locale, tt.path, tt.decimal)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadCatalog() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := c.msg("wait"); got != tt.wantWait {
+				t.Errorf("msg(wait) = %q, want %q", got, tt.wantWait)
+			}
+			if got := c.msg("exit"); got != tt.wantExit {
+				t.Errorf("msg(exit) = %q, want %q", got, tt.wantExit)
+			}
+			if got := c.text("Preemptions: 2"); got != tt.wantText {
+				t.Errorf("text() = %q, want %q", got, tt.wantText)
+			}
+			if c.Decimal != tt.wantDecimal {
+				t.Errorf("Decimal = %q, want %q", c.Decimal, tt.wantDecimal)
+			}
+		})
+	}
+
+	if _, err := loadCatalog("pt", "", ""); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("loadCatalog(pt) error = %v, want ErrInvalidArgs", err)
+	}
+}
+
+func TestCatalog_float(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		decimal string
+		v       float64
+		want    string
+	}{
+		{decimal: ".", v: 3.14159, want: "3.14"},
+		{decimal: "", v: 3.14159, want: "3.14"},
+		{decimal: ",", v: -1234.5, want: "-1234,50"},
+		{decimal: ",", v: 2, want: "2,00"},
+	}
+	for _, tt := range tests {
+		if got := (Catalog{Decimal: tt.decimal}).float(tt.v, 2); got != tt.want {
+			t.Errorf("float(%v) with %q = %q, want %q", tt.v, tt.decimal, got, tt.want)
+		}
+	}
+}
+
+func Test_localeFromEnv(t *testing.T) {
+	tests := []struct {
+		all, messages, lang string
+		want                string
+	}{
+		{lang: "de_DE.UTF-8", want: "de"},
+		{messages: "fr_FR", lang: "de_DE.UTF-8", want: "fr"},
+		{all: "C", lang: "es_ES@euro", want: "es"},
+		{want: ""},
+	}
+	for _, tt := range tests {
+		t.Setenv("LC_ALL", tt.all)
+		t.Setenv("LC_MESSAGES", tt.messages)
+		t.Setenv("LANG", tt.lang)
+		if got := localeFromEnv(); got != tt.want {
+			t.Errorf("localeFromEnv() with LC_ALL=%q LC_MESSAGES=%q LANG=%q = %q, want %q", tt.all, tt.messages, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestCatalog_text(t *testing.T) {
+	t.Parallel()
+	c := Catalog{Decimal: ",", Text: map[string]string{
+		"Total":                              "Gesamt",
+		"Preemptions: %d":                    "Verdrängungen: %d",
+		"Preemptions: %d of %d allowed":      "Verdrängungen: %d von %d erlaubt",
+		"Effective CPU utilization: %.1f%%":  "Effektive CPU-Auslastung: %.1f%%",
+		"Utilization: %s (average %s)":       "Auslastung: %s (Mittel %s)",
+		"Dispatched from the queue:\n%s":     "Zugeteilt aus der Warteschlange:\n%s",
+		"Queue %d (%s) ran: %s":              "Warteschlange %d (%s) lief: %s",
+		"Mean absolute prediction error: %d": "Mittlerer Fehler: %d %d",
+	}}
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{s: "Total", want: "Gesamt"},
+		{s: "Preemptions: 3", want: "Verdrängungen: 3"},
+		{s: "Preemptions: 3 of 5 allowed", want: "Verdrängungen: 3 von 5 erlaubt"},
+		{s: "Effective CPU utilization: 87.5%", want: "Effektive CPU-Auslastung: 87,5%"},
+		{s: "Utilization: CPU 0 50.0% (average 62.5%)", want: "Auslastung: CPU 0 50.0% (Mittel 62,5%)"},
+		{s: "Dispatched from the queue:\n|1|2|", want: "Zugeteilt aus der Warteschlange:\n|1|2|"},
+		{s: "Queue 0 (quantum 2) ran: 1 0-2, 2 2-4", want: "Warteschlange 0 (quantum 2) lief: 1 0-2, 2 2-4"},
+		{s: "Queue 0 ran: 1 0-2", want: "Queue 0 ran: 1 0-2"},
+		{s: "Preempted", want: "Preempted"},
+		// A translation that doesn't print the same values is ignored.
+		{s: "Mean absolute prediction error: 2", want: "Mean absolute prediction error: 2"},
+	}
+	for _, tt := range tests {
+		if got := c.text(tt.s); got != tt.want {
+			t.Errorf("text(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+// Test_catalogs_text checks that every built-in translation prints the
+// values of the text it translates, in the same order.
+func Test_catalogs_text(t *testing.T) {
+	t.Parallel()
+	for locale, c := range catalogs {
+		for k, v := range c.Text {
+			if _, want := formatParts(k); !sameVerbs(want, v) {
+				t.Errorf("%s: %q doesn't print the values of %q", locale, v, k)
+			}
+		}
+	}
+}
+
+func Test_outputReport_localized(t *testing.T) {
+	t.Parallel()
+	fr, err := loadCatalog("fr", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b strings.Builder
+	renderer{catalog: fr}.outputReport(&b, simulate("FCFS", []Process{{ProcessID: 1, BurstDuration: 3}, {ProcessID: 2, BurstDuration: 3}}, FCFSPolicy{}))
+	for _, want := range []string{"Diagramme de Gantt\n", "TEMPS DE SÉJOUR", "MOYENNE", "1,50", "Durée totale : 6\n", "File des prêts (max. 1) : |"} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("outputReport() = %q, want it to contain %q", b.String(), want)
+		}
+	}
+
+	de, err := loadCatalog("de", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Reset()
+	renderer{catalog: de}.outputReport(&b, PreemptivePriority("Preemptive priority", []Process{
+		{ProcessID: 1, BurstDuration: 3, Priority: 2},
+		{ProcessID: 2, BurstDuration: 1, ArrivalTime: 1, Priority: 1},
+	}))
+	for _, want := range []string{"VERDRÄNGT", "GESAMT", "Verdrängungspunkte: t=1: 2 preempts 1\n"} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("outputReport() = %q, want it to contain %q", b.String(), want)
+		}
+	}
+}
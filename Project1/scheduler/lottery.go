@@ -0,0 +1,87 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+)
+
+//region Lottery scheduling
+
+// LotteryPolicy holds a lottery every Quantum ticks among the running and
+// ready tasks, each holding as many tickets as its weight, and runs the
+// winner. A task's chance of winning is its share of the tickets, so over
+// many draws it gets that share of the CPU. Without a quantum a lottery is
+// only held when the CPU frees up. Runs with the same Seed draw the same
+// winners.
+type LotteryPolicy struct {
+	Quantum int64
+	Seed    int64
+	rng     *rand.Rand
+	// won counts the draws each task won; draws counts those with more
+	// than one task in them.
+	won   map[int64]int
+	draws int
+}
+
+func (p *LotteryPolicy) Pick(_ int64, running *Task, ready []*Task) *Task {
+	if running != nil && (len(ready) == 0 || p.Quantum <= 0 || running.Slice%p.Quantum != 0) {
+		return running
+	}
+	if len(ready) == 0 {
+		return nil
+	}
+	if p.rng == nil {
+		p.rng = rand.New(rand.NewSource(p.Seed))
+		p.won = make(map[int64]int)
+	}
+
+	candidates := ready
+	if running != nil {
+		candidates = append([]*Task{running}, ready...)
+	}
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+	var total int64
+	for _, t := range candidates {
+		total += t.weight()
+	}
+	ticket := p.rng.Int63n(total)
+	winner := candidates[len(candidates)-1]
+	for _, t := range candidates {
+		if ticket -= t.weight(); ticket < 0 {
+			winner = t
+			break
+		}
+	}
+	p.draws++
+	p.won[winner.ProcessID]++
+	return winner
+}
+
+// annotate adds each task's tickets, the draws it won and its CPU share
+// against the share its tickets entitle it to.
+func (p *LotteryPolicy) annotate(r *Report, tasks []*Task) {
+	tickets, won := Column{Header: "Tickets"}, Column{Header: "Draws won"}
+	for _, t := range tasks {
+		tickets.Values = append(tickets.Values, fmt.Sprint(t.weight()))
+		won.Values = append(won.Values, fmt.Sprint(p.won[t.ProcessID]))
+	}
+	won.Footer = fmt.Sprintf("Draws\n%d", p.draws)
+	r.Columns = append(append(r.Columns, tickets, won), shareColumns(tasks)...)
+}
+
+// LotterySchedule outputs the lottery schedule of processes as a Gantt
+// chart and a table of timing.
+func LotterySchedule(w io.Writer, title string, processes []Process, quantum, seed int64) {
+	outputReport(w, Lottery(title, processes, quantum, seed))
+}
+
+// Lottery schedules processes by lottery, drawing a winner every quantum
+// ticks with tickets from the weight column and random numbers from seed.
+func Lottery(title string, processes []Process, quantum, seed int64) Report {
+	return simulate(title, processes, &LotteryPolicy{Quantum: quantum, Seed: seed})
+}
+
+//endregion
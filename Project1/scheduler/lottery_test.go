@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLottery(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		quantum   int64
+		// Process 1 must get between minShare and maxShare of the first
+		// window ticks.
+		window    int64
+		minShare  float64
+		maxShare  float64
+		wantDraws string
+	}{
+		{
+			name:      "tickets set the share",
+			processes: []Process{{ProcessID: 1, BurstDuration: 2000, Weight: 900}, {ProcessID: 2, BurstDuration: 2000, Weight: 100}},
+			quantum:   1,
+			window:    1000,
+			minShare:  0.85,
+			maxShare:  0.95,
+		},
+		{
+			name:      "equal tickets share equally",
+			processes: []Process{{ProcessID: 1, BurstDuration: 2000}, {ProcessID: 2, BurstDuration: 2000}},
+			quantum:   5,
+			window:    2000,
+			minShare:  0.4,
+			maxShare:  0.6,
+		},
+		{
+			name:      "a lone task draws nothing",
+			processes: []Process{{ProcessID: 1, BurstDuration: 5}, {ProcessID: 2, ArrivalTime: 5, BurstDuration: 5}},
+			quantum:   1,
+			window:    5,
+			minShare:  1,
+			maxShare:  1,
+			wantDraws: "Draws\n0",
+		},
+		{
+			name:      "without a quantum draws only when the CPU frees up",
+			processes: []Process{{ProcessID: 1, BurstDuration: 5}, {ProcessID: 2, BurstDuration: 5}, {ProcessID: 3, BurstDuration: 5}},
+			window:    15,
+			minShare:  1.0 / 3,
+			maxShare:  1.0 / 3,
+			wantDraws: "Draws\n2",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := Lottery("Lottery", tt.processes, tt.quantum, 42)
+			if again := Lottery("Lottery", tt.processes, tt.quantum, 42); !reflect.DeepEqual(r.Gantt, again.Gantt) {
+				t.Fatalf("Lottery() with the same seed differs: %v and %v", r.Gantt, again.Gantt)
+			}
+			var ran int64
+			for _, s := range r.Gantt {
+				stop := s.Stop
+				if stop > tt.window {
+					stop = tt.window
+				}
+				if s.PID == 1 && s.Start < stop {
+					ran += stop - s.Start
+				}
+			}
+			if share := float64(ran) / float64(tt.window); share < tt.minShare-1e-9 || share > tt.maxShare+1e-9 {
+				t.Errorf("Lottery() gave process 1 %.3f of the first %d ticks, want %.3f to %.3f", share, tt.window, tt.minShare, tt.maxShare)
+			}
+			if tt.wantDraws != "" && r.Columns[1].Footer != tt.wantDraws {
+				t.Errorf("Lottery() draws = %q, want %q", r.Columns[1].Footer, tt.wantDraws)
+			}
+		})
+	}
+}
+
+func TestLotterySchedule(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	LotterySchedule(&w, "Lottery", []Process{{ProcessID: 1, BurstDuration: 3, Weight: 30}, {ProcessID: 2, BurstDuration: 3}}, 1, 1)
+	for _, want := range []string{"TICKETS", "DRAWS WON", "|  1 |        0 |     3 |       0 |"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("LotterySchedule() = %q, want it to contain %q", w.String(), want)
+		}
+	}
+}
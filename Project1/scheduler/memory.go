@@ -0,0 +1,258 @@
+package scheduler
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+//region Contiguous memory allocation
+
+type (
+	// MemoryRequest allocates Size units to ID, or frees ID when Free is set.
+	MemoryRequest struct {
+		Free bool  `json:"free"`
+		ID   int64 `json:"id"`
+		Size int64 `json:"size,omitempty"`
+	}
+	// MemoryStep is the state of memory after one request.
+	MemoryStep struct {
+		Request MemoryRequest `json:"request"`
+		// Address is where the block was placed; -1 if it didn't fit.
+		Address int64 `json:"address"`
+		Holes   int   `json:"holes"`
+		Free    int64 `json:"free"`
+		Largest int64 `json:"largest_hole"`
+		// Fragmentation is the share of free memory outside the largest
+		// hole: 0 when all free memory is contiguous.
+		Fragmentation float64 `json:"fragmentation"`
+	}
+	// MemoryResult is how a placement strategy handled a request stream.
+	MemoryResult struct {
+		Title  string       `json:"title"`
+		Steps  []MemoryStep `json:"steps"`
+		Failed int          `json:"failed"`
+	}
+	// memoryBlock is an allocated or free range of memory.
+	memoryBlock struct {
+		start, size int64
+		id          int64 // -1 when free
+	}
+)
+
+// runMemory implements the memory subcommand:
+//
+//	memory -size 1000 [-json results.json] requests.csv
+//
+// Each line of requests.csv is either "alloc,<id>,<size>" or "free,<id>".
+// First-fit, best-fit and worst-fit placement are simulated and the
+// external fragmentation after every request is written to w.
+func runMemory(w io.Writer, args ...string) error {
+	fs := flag.NewFlagSet("memory", flag.ContinueOnError)
+	fs.SetOutput(w)
+	size := fs.Int64("size", 1000, "total memory size")
+	jsonPath := fs.String("json", "", "file to write the results to as JSON")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	if fs.NArg() != 1 || *size <= 0 {
+		return fmt.Errorf("%w: usage: memory -size N [-json file] requests.csv", ErrInvalidArgs)
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("%v: error opening requests file", err)
+	}
+	defer func() { _ = f.Close() }()
+	requests, err := loadMemoryRequests(f)
+	if err != nil {
+		return err
+	}
+
+	results := allocations(requests, *size)
+	for _, r := range results {
+		outputMemory(w, r)
+	}
+	outputMemorySummary(w, results)
+	if *jsonPath != "" {
+		return writeJSON(*jsonPath, results)
+	}
+	return nil
+}
+
+func loadMemoryRequests(r io.Reader) ([]MemoryRequest, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading CSV", err)
+	}
+
+	requests := make([]MemoryRequest, 0, len(rows))
+	for i, row := range rows {
+		var req MemoryRequest
+		switch op := strings.ToLower(row[0]); {
+		case op == "alloc" && len(row) == 3:
+			size, err := strconv.ParseInt(row[2], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d", err, i+1)
+			}
+			if size <= 0 {
+				return nil, fmt.Errorf("%w: line %d: size must be positive", ErrInvalidArgs, i+1)
+			}
+			req.Size = size
+		case op == "free" && len(row) == 2:
+			req.Free = true
+		default:
+			return nil, fmt.Errorf("%w: line %d: want alloc,<id>,<size> or free,<id>", ErrInvalidArgs, i+1)
+		}
+		id, err := strconv.ParseInt(row[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d", err, i+1)
+		}
+		req.ID = id
+		requests = append(requests, req)
+	}
+	return requests, nil
+}
+
+// allocations runs every placement strategy over requests.
+func allocations(requests []MemoryRequest, size int64) []MemoryResult {
+	return []MemoryResult{
+		allocate("First-fit", requests, size, func(hole, best memoryBlock) bool { return false }),
+		allocate("Best-fit", requests, size, func(hole, best memoryBlock) bool { return hole.size < best.size }),
+		allocate("Worst-fit", requests, size, func(hole, best memoryBlock) bool { return hole.size > best.size }),
+	}
+}
+
+// allocate places each request in the first hole that fits unless a later
+// fitting hole is better, and coalesces holes when blocks are freed.
+func allocate(title string, requests []MemoryRequest, size int64, better func(hole, best memoryBlock) bool) MemoryResult {
+	blocks := []memoryBlock{{start: 0, size: size, id: -1}}
+	r := MemoryResult{Title: title}
+	for _, req := range requests {
+		step := MemoryStep{Request: req, Address: -1}
+		if req.Free {
+			for i := range blocks {
+				if blocks[i].id == req.ID {
+					blocks[i].id = -1
+					step.Address = blocks[i].start
+				}
+			}
+			blocks = coalesce(blocks)
+		} else {
+			best := -1
+			for i, b := range blocks {
+				if b.id == -1 && b.size >= req.Size && (best < 0 || better(b, blocks[best])) {
+					best = i
+				}
+			}
+			if best < 0 {
+				r.Failed++
+			} else {
+				hole := blocks[best]
+				step.Address = hole.start
+				placed := memoryBlock{start: hole.start, size: req.Size, id: req.ID}
+				rest := memoryBlock{start: hole.start + req.Size, size: hole.size - req.Size, id: -1}
+				tail := append([]memoryBlock{placed}, blocks[best+1:]...)
+				if rest.size > 0 {
+					tail = append([]memoryBlock{placed, rest}, blocks[best+1:]...)
+				}
+				blocks = append(blocks[:best:best], tail...)
+			}
+		}
+
+		for _, b := range blocks {
+			if b.id != -1 {
+				continue
+			}
+			step.Holes++
+			step.Free += b.size
+			if b.size > step.Largest {
+				step.Largest = b.size
+			}
+		}
+		if step.Free > 0 {
+			step.Fragmentation = 1 - float64(step.Largest)/float64(step.Free)
+		}
+		r.Steps = append(r.Steps, step)
+	}
+	return r
+}
+
+func coalesce(blocks []memoryBlock) []memoryBlock {
+	out := blocks[:0]
+	for _, b := range blocks {
+		if n := len(out); n > 0 && b.id == -1 && out[n-1].id == -1 {
+			out[n-1].size += b.size
+			continue
+		}
+		out = append(out, b)
+	}
+	return out
+}
+
+func outputMemory(w io.Writer, r MemoryResult) {
+	outputTitle(w, r.Title)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Step", "Request", "Address", "Holes", "Free", "Largest hole", "Fragmentation"})
+	for i, s := range r.Steps {
+		req := fmt.Sprintf("alloc %d (%d)", s.Request.ID, s.Request.Size)
+		if s.Request.Free {
+			req = fmt.Sprintf("free %d", s.Request.ID)
+		}
+		addr := fmt.Sprint(s.Address)
+		if s.Address < 0 {
+			addr = "failed"
+			if s.Request.Free {
+				addr = "not allocated"
+			}
+		}
+		table.Append([]string{
+			fmt.Sprint(i + 1), req, addr,
+			fmt.Sprint(s.Holes), fmt.Sprint(s.Free), fmt.Sprint(s.Largest),
+			fmt.Sprintf("%.1f%%", 100*s.Fragmentation),
+		})
+	}
+	table.SetFooter([]string{"", "", fmt.Sprintf("Failed\n%d", r.Failed), "", "", "",
+		fmt.Sprintf("Average\n%.1f%%", 100*averageFragmentation(r))})
+	table.Render()
+}
+
+func averageFragmentation(r MemoryResult) float64 {
+	if len(r.Steps) == 0 {
+		return 0
+	}
+	var total float64
+	for _, s := range r.Steps {
+		total += s.Fragmentation
+	}
+	return total / float64(len(r.Steps))
+}
+
+func outputMemorySummary(w io.Writer, results []MemoryResult) {
+	_, _ = fmt.Fprintln(w, "Allocation summary")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Strategy", "Failed allocations", "Average fragmentation", "Final fragmentation"})
+	for _, r := range results {
+		final := 0.0
+		if n := len(r.Steps); n > 0 {
+			final = r.Steps[n-1].Fragmentation
+		}
+		table.Append([]string{
+			r.Title, fmt.Sprint(r.Failed),
+			fmt.Sprintf("%.1f%%", 100*averageFragmentation(r)),
+			fmt.Sprintf("%.1f%%", 100*final),
+		})
+	}
+	table.Render()
+}
+
+//endregion
@@ -0,0 +1,56 @@
+package scheduler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_allocations(t *testing.T) {
+	t.Parallel()
+	requests, err := loadMemoryRequests(strings.NewReader(`alloc,1,100
+alloc,2,300
+alloc,3,100
+alloc,4,200
+free,1
+free,3
+alloc,5,80
+alloc,6,250`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Memory of 850 leaves a hole of 150 at 700 after the four allocations;
+	// freeing 1 and 3 opens two holes of 100 at 0 and 400.
+	tests := []struct {
+		title       string
+		wantAddress []int64
+		wantFailed  int
+	}{
+		{title: "First-fit", wantAddress: []int64{0, 100, 400, 500, 0, 400, 0, -1}, wantFailed: 1},
+		{title: "Best-fit", wantAddress: []int64{0, 100, 400, 500, 0, 400, 0, -1}, wantFailed: 1},
+		{title: "Worst-fit", wantAddress: []int64{0, 100, 400, 500, 0, 400, 700, -1}, wantFailed: 1},
+	}
+	results := allocations(requests, 850)
+	for i, tt := range tests {
+		r := results[i]
+		if r.Title != tt.title {
+			t.Fatalf("result %d = %s, want %s", i, r.Title, tt.title)
+		}
+		var addresses []int64
+		for _, s := range r.Steps {
+			addresses = append(addresses, s.Address)
+		}
+		if !reflect.DeepEqual(addresses, tt.wantAddress) {
+			t.Errorf("%s addresses = %v, want %v", tt.title, addresses, tt.wantAddress)
+		}
+		if r.Failed != tt.wantFailed {
+			t.Errorf("%s failed = %d, want %d", tt.title, r.Failed, tt.wantFailed)
+		}
+	}
+
+	// After first-fit places 5 at 0, holes are 20 at 80, 100 at 400 and 150 at 700.
+	step := results[0].Steps[6]
+	if step.Holes != 3 || step.Free != 270 || step.Largest != 150 {
+		t.Errorf("first-fit step 7 = %+v", step)
+	}
+}
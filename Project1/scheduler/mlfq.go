@@ -0,0 +1,224 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//region Multilevel feedback queue
+
+type (
+	// MLFQConfig configures a multilevel feedback queue. Queue 0 is the
+	// highest priority.
+	MLFQConfig struct {
+		// Quanta is each queue's time slice; 0 runs the queue first-come,
+		// first-served. There are as many queues as quanta.
+		Quanta []int64
+		// Allotments is how much CPU time a task may use in each queue,
+		// over any number of slices, before it is demoted to the next.
+		// Missing or 0 entries default to the queue's quantum; a queue
+		// without a quantum or allotment never demotes.
+		Allotments []int64
+		// Boost moves every task back to queue 0 every Boost ticks, so
+		// long-running tasks demoted to the bottom don't starve; 0 never
+		// boosts.
+		Boost int64
+	}
+
+	// MLFQPolicy schedules the highest non-empty queue round-robin with
+	// its quantum, demoting tasks that use up their allotment and
+	// preempting the running task when one is ready in a higher queue.
+	MLFQPolicy struct {
+		Config MLFQConfig
+		// level is each task's queue, used is the CPU time it has had in
+		// that queue and slice how long it has run since it was dispatched
+		// or moved.
+		level map[int64]int
+		used  map[int64]int64
+		slice map[int64]int64
+		// runs are the ticks run, merged into slices of one task in one
+		// queue; transitions are the demotions and boosts.
+		runs        []mlfqRun
+		transitions []string
+		lastBoost   int64
+	}
+
+	// mlfqRun is a stretch of ticks a task ran in one queue.
+	mlfqRun struct {
+		TimeSlice
+		Level int
+	}
+)
+
+// parseMLFQ parses comma separated per-queue quanta like "8,16,0" and,
+// optionally, allotments like "16,32".
+func parseMLFQ(quanta, allotments string, boost int64) (MLFQConfig, error) {
+	c := MLFQConfig{Boost: boost}
+	var err error
+	if c.Quanta, err = parseTicksList(quanta); err != nil {
+		return MLFQConfig{}, err
+	}
+	if c.Allotments, err = parseTicksList(allotments); err != nil {
+		return MLFQConfig{}, err
+	}
+	if len(c.Quanta) == 0 {
+		return MLFQConfig{}, fmt.Errorf("%w: MLFQ needs at least one queue", ErrInvalidArgs)
+	}
+	if len(c.Allotments) > len(c.Quanta) {
+		return MLFQConfig{}, fmt.Errorf("%w: %d allotments for %d queues", ErrInvalidArgs, len(c.Allotments), len(c.Quanta))
+	}
+	if boost < 0 {
+		return MLFQConfig{}, fmt.Errorf("%w: boost period must not be negative, got %d", ErrInvalidArgs, boost)
+	}
+	return c, nil
+}
+
+// parseTicksList parses a comma separated list of non-negative tick counts.
+func parseTicksList(list string) ([]int64, error) {
+	var ticks []int64
+	for _, f := range strings.Split(list, ",") {
+		if f = strings.TrimSpace(f); f == "" {
+			continue
+		}
+		v, err := strconv.ParseInt(f, 10, 64)
+		if err != nil || v < 0 {
+			return nil, fmt.Errorf("%w: bad tick count %q", ErrInvalidArgs, f)
+		}
+		ticks = append(ticks, v)
+	}
+	return ticks, nil
+}
+
+// allotment is how long a task may run in queue level before demotion, 0
+// for forever.
+func (c MLFQConfig) allotment(level int) int64 {
+	if level < len(c.Allotments) && c.Allotments[level] > 0 {
+		return c.Allotments[level]
+	}
+	return c.Quanta[level]
+}
+
+func (p *MLFQPolicy) Pick(now int64, running *Task, ready []*Task) *Task {
+	if p.level == nil {
+		p.level, p.used, p.slice = make(map[int64]int), make(map[int64]int64), make(map[int64]int64)
+	}
+	c := p.Config
+
+	// A demoted task goes to the back of its new queue.
+	demoted := false
+	if running != nil {
+		p.used[running.ProcessID]++
+		p.slice[running.ProcessID]++
+		l := p.level[running.ProcessID]
+		if a := c.allotment(l); a > 0 && p.used[running.ProcessID] >= a && l+1 < len(c.Quanta) {
+			p.level[running.ProcessID] = l + 1
+			p.used[running.ProcessID], p.slice[running.ProcessID] = 0, 0
+			p.transitions = append(p.transitions, fmt.Sprintf("t=%d: %d demoted to queue %d", now, running.ProcessID, l+1))
+			demoted = true
+		}
+	}
+	if c.Boost > 0 && now-p.lastBoost >= c.Boost {
+		p.lastBoost = now - now%c.Boost
+		boosted := false
+		for _, t := range append([]*Task{running}, ready...) {
+			if t == nil {
+				continue
+			}
+			if p.level[t.ProcessID] > 0 {
+				boosted = true
+			}
+			p.level[t.ProcessID], p.used[t.ProcessID] = 0, 0
+		}
+		if boosted {
+			p.transitions = append(p.transitions, fmt.Sprintf("t=%d: boost, every task back to queue 0", p.lastBoost))
+		}
+	}
+
+	// The highest queue with a ready task, and its first task in the
+	// order they joined the ready queue.
+	var head *Task
+	for _, t := range ready {
+		if head == nil || p.level[t.ProcessID] < p.level[head.ProcessID] {
+			head = t
+		}
+	}
+	pick := head
+	if running != nil {
+		l := p.level[running.ProcessID]
+		q := c.Quanta[l]
+		expired := q > 0 && p.slice[running.ProcessID] >= q || demoted
+		if head == nil || p.level[head.ProcessID] > l || p.level[head.ProcessID] == l && !expired {
+			pick = running
+		}
+	}
+	if pick == nil {
+		return nil
+	}
+
+	if pick != running {
+		if running != nil {
+			p.slice[running.ProcessID] = 0
+		}
+		p.slice[pick.ProcessID] = 0
+	}
+	l := p.level[pick.ProcessID]
+	if n := len(p.runs); n > 0 && p.runs[n-1].PID == pick.ProcessID && p.runs[n-1].Level == l && p.runs[n-1].Stop == now {
+		p.runs[n-1].Stop = now + 1
+	} else {
+		p.runs = append(p.runs, mlfqRun{TimeSlice: TimeSlice{PID: pick.ProcessID, Start: now, Stop: now + 1}, Level: l})
+	}
+	return pick
+}
+
+// annotate adds the queues each task ran in, lists the slices each queue
+// ran, and the demotions and boosts.
+func (p *MLFQPolicy) annotate(r *Report, tasks []*Task) {
+	path := make(map[int64][]string)
+	for _, run := range p.runs {
+		levels := path[run.PID]
+		if l := fmt.Sprint(run.Level); len(levels) == 0 || levels[len(levels)-1] != l {
+			path[run.PID] = append(levels, l)
+		}
+	}
+	col := Column{Header: "Queues"}
+	for _, t := range tasks {
+		col.Values = append(col.Values, strings.Join(path[t.ProcessID], "→"))
+	}
+	r.Columns = append(r.Columns, col)
+
+	for l, q := range p.Config.Quanta {
+		var lane []string
+		for _, run := range p.runs {
+			if run.Level == l {
+				lane = append(lane, fmt.Sprintf("%d %d-%d", run.PID, run.Start, run.Stop))
+			}
+		}
+		quantum := "FCFS"
+		if q > 0 {
+			quantum = fmt.Sprintf("quantum %d", q)
+		}
+		r.Notes = append(r.Notes, fmt.Sprintf("Queue %d (%s) ran: %s", l, quantum, strings.Join(lane, ", ")))
+	}
+	if len(p.transitions) > 0 {
+		r.Notes = append(r.Notes, "Queue transitions: "+strings.Join(p.transitions, ", "))
+	}
+}
+
+// title names the MLFQ after its queues.
+func (p *MLFQPolicy) title() string {
+	quanta := make([]string, len(p.Config.Quanta))
+	for i, q := range p.Config.Quanta {
+		quanta[i] = fmt.Sprint(q)
+		if q == 0 {
+			quanta[i] = "FCFS"
+		}
+	}
+	title := fmt.Sprintf("MLFQ, quanta %s", strings.Join(quanta, "/"))
+	if p.Config.Boost > 0 {
+		title += fmt.Sprintf(", boost every %d", p.Config.Boost)
+	}
+	return title
+}
+
+//endregion
@@ -0,0 +1,130 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseMLFQ(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		quanta     string
+		allotments string
+		boost      int64
+		want       MLFQConfig
+		wantErr    error
+	}{
+		{name: "quanta", quanta: "8, 16,0", want: MLFQConfig{Quanta: []int64{8, 16, 0}}},
+		{name: "allotments and boost", quanta: "2,4,0", allotments: "4", boost: 50, want: MLFQConfig{Quanta: []int64{2, 4, 0}, Allotments: []int64{4}, Boost: 50}},
+		{name: "no queues", quanta: " ", wantErr: ErrInvalidArgs},
+		{name: "bad quantum", quanta: "2,x", wantErr: ErrInvalidArgs},
+		{name: "negative quantum", quanta: "-2", wantErr: ErrInvalidArgs},
+		{name: "too many allotments", quanta: "2", allotments: "4,8", wantErr: ErrInvalidArgs},
+		{name: "negative boost", quanta: "2", boost: -1, wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseMLFQ(tt.quanta, tt.allotments, tt.boost)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseMLFQ() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseMLFQ() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMLFQ(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		config     MLFQConfig
+		processes  []Process
+		wantTitle  string
+		wantGantt  []TimeSlice
+		wantQueues []string
+		wantNotes  []string
+	}{
+		{
+			name:       "demotes after a quantum",
+			config:     MLFQConfig{Quanta: []int64{1, 0}},
+			processes:  []Process{{ProcessID: 1, BurstDuration: 3}, {ProcessID: 2, BurstDuration: 2}},
+			wantTitle:  "MLFQ, quanta 1/FCFS",
+			wantGantt:  []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 2}, {PID: 1, Start: 2, Stop: 4}, {PID: 2, Start: 4, Stop: 5}},
+			wantQueues: []string{"0→1", "0→1"},
+			wantNotes: []string{
+				"Queue 0 (quantum 1) ran: 1 0-1, 2 1-2",
+				"Queue 1 (FCFS) ran: 1 2-4, 2 4-5",
+				"Queue transitions: t=1: 1 demoted to queue 1, t=2: 2 demoted to queue 1",
+			},
+		},
+		{
+			name:      "allotment spans slices",
+			config:    MLFQConfig{Quanta: []int64{1, 0}, Allotments: []int64{2}},
+			processes: []Process{{ProcessID: 1, BurstDuration: 3}, {ProcessID: 2, BurstDuration: 3}},
+			wantTitle: "MLFQ, quanta 1/FCFS",
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 2}, {PID: 1, Start: 2, Stop: 3},
+				{PID: 2, Start: 3, Stop: 4}, {PID: 1, Start: 4, Stop: 5}, {PID: 2, Start: 5, Stop: 6},
+			},
+			wantQueues: []string{"0→1", "0→1"},
+			wantNotes: []string{
+				"Queue 0 (quantum 1) ran: 1 0-1, 2 1-2, 1 2-3, 2 3-4",
+				"Queue 1 (FCFS) ran: 1 4-5, 2 5-6",
+				"Queue transitions: t=3: 1 demoted to queue 1, t=4: 2 demoted to queue 1",
+			},
+		},
+		{
+			name:       "arrival preempts a lower queue",
+			config:     MLFQConfig{Quanta: []int64{2, 0}},
+			processes:  []Process{{ProcessID: 1, BurstDuration: 5}, {ProcessID: 2, ArrivalTime: 3, BurstDuration: 1}},
+			wantTitle:  "MLFQ, quanta 2/FCFS",
+			wantGantt:  []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 4}, {PID: 1, Start: 4, Stop: 6}},
+			wantQueues: []string{"0→1", "0"},
+			wantNotes: []string{
+				"Queue 0 (quantum 2) ran: 1 0-2, 2 3-4",
+				"Queue 1 (FCFS) ran: 1 2-3, 1 4-6",
+				"Queue transitions: t=2: 1 demoted to queue 1",
+			},
+		},
+		{
+			name:       "boost",
+			config:     MLFQConfig{Quanta: []int64{1, 0}, Boost: 4},
+			processes:  []Process{{ProcessID: 1, BurstDuration: 6}},
+			wantTitle:  "MLFQ, quanta 1/FCFS, boost every 4",
+			wantGantt:  []TimeSlice{{PID: 1, Start: 0, Stop: 6}},
+			wantQueues: []string{"0→1→0→1"},
+			wantNotes: []string{
+				"Queue 0 (quantum 1) ran: 1 0-1, 1 4-5",
+				"Queue 1 (FCFS) ran: 1 1-4, 1 5-6",
+				"Queue transitions: t=1: 1 demoted to queue 1, t=4: boost, every task back to queue 0, t=5: 1 demoted to queue 1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p := &MLFQPolicy{Config: tt.config}
+			if got := p.title(); got != tt.wantTitle {
+				t.Errorf("title() = %q, want %q", got, tt.wantTitle)
+			}
+			r := simulate(p.title(), tt.processes, p)
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("MLFQ Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			want := []Column{{Header: "Queues", Values: tt.wantQueues}}
+			if !reflect.DeepEqual(r.Columns, want) {
+				t.Errorf("MLFQ columns = %+v, want %+v", r.Columns, want)
+			}
+			if !reflect.DeepEqual(r.Notes, tt.wantNotes) {
+				t.Errorf("MLFQ notes = %q, want %q", r.Notes, tt.wantNotes)
+			}
+		})
+	}
+}
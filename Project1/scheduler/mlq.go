@@ -0,0 +1,174 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//region Multilevel queue
+
+// defaultMLQ is the multilevel queue -mlq runs when given "default":
+// system processes first-come, first-served, then interactive ones
+// round-robin with -quantum, then batch ones first-come, first-served.
+const defaultMLQ = "system=fcfs,interactive=rr,batch=fcfs"
+
+type (
+	// MLQPolicy partitions tasks into fixed queues by class. Queues are
+	// strictly prioritized: a queue runs only while every queue above it is
+	// empty, and a task arriving in a higher queue preempts the running
+	// one. Within a queue tasks are scheduled by the queue's own policy,
+	// and a preempted task resumes before any other of its queue.
+	MLQPolicy struct {
+		Queues []mlqQueue
+		// resume is the preempted task of each queue.
+		resume map[int]*Task
+	}
+
+	// mlqQueue is one queue of a multilevel queue: the class of its tasks,
+	// and its policy as named in the spec.
+	mlqQueue struct {
+		Class  string
+		Name   string
+		Policy Policy
+	}
+)
+
+// parseMLQ parses a multilevel queue spec like
+// "system=fcfs,interactive=rr:4,batch=fcfs", highest queue first. Each
+// queue takes a class's processes and schedules them with a policy
+// PolicyByName knows; rr takes its quantum after a colon, defaulting to
+// quantum.
+func parseMLQ(spec string, quantum int64) (*MLQPolicy, error) {
+	if strings.TrimSpace(spec) == "default" {
+		spec = defaultMLQ
+	}
+	p := &MLQPolicy{}
+	seen := make(map[string]bool)
+	for _, f := range strings.Split(spec, ",") {
+		if f = strings.TrimSpace(f); f == "" {
+			continue
+		}
+		kv := strings.SplitN(f, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("%w: want <class>=<policy>, got %q", ErrInvalidArgs, f)
+		}
+		var probe Process
+		if err := probe.setClass(kv[0]); err != nil {
+			return nil, err
+		}
+		class := probe.class()
+		if seen[class] {
+			return nil, fmt.Errorf("%w: class %q has two queues", ErrInvalidArgs, class)
+		}
+		seen[class] = true
+
+		name := strings.ToLower(strings.TrimSpace(kv[1]))
+		q := quantum
+		if i := strings.Index(name, ":"); i >= 0 {
+			v, err := strconv.ParseInt(name[i+1:], 10, 64)
+			if err != nil || v <= 0 || name[:i] != "rr" {
+				return nil, fmt.Errorf("%w: bad queue policy %q", ErrInvalidArgs, kv[1])
+			}
+			name, q = name[:i], v
+		}
+		policy, err := PolicyByName(name, q)
+		if err != nil {
+			return nil, err
+		}
+		if name == "rr" {
+			name = fmt.Sprintf("rr:%d", q)
+		}
+		p.Queues = append(p.Queues, mlqQueue{Class: class, Name: name, Policy: policy})
+	}
+	if len(p.Queues) == 0 {
+		return nil, fmt.Errorf("%w: the multilevel queue needs at least one queue", ErrInvalidArgs)
+	}
+	return p, nil
+}
+
+// queue is the index of t's queue. Classes without a queue of their own
+// share the lowest one.
+func (p *MLQPolicy) queue(t *Task) int {
+	class := t.class()
+	for i, q := range p.Queues {
+		if q.Class == class {
+			return i
+		}
+	}
+	return len(p.Queues) - 1
+}
+
+func (p *MLQPolicy) Pick(now int64, running *Task, ready []*Task) *Task {
+	if p.resume == nil {
+		p.resume = make(map[int]*Task)
+	}
+	queues := make([][]*Task, len(p.Queues))
+	highest := len(p.Queues)
+	for _, t := range ready {
+		l := p.queue(t)
+		queues[l] = append(queues[l], t)
+		if l < highest {
+			highest = l
+		}
+	}
+
+	if running != nil {
+		l := p.queue(running)
+		if highest >= l {
+			return p.Queues[l].Policy.Pick(now, running, queues[l])
+		}
+		p.resume[l] = running
+	}
+	if highest == len(p.Queues) {
+		return nil
+	}
+	if t := p.resume[highest]; t != nil {
+		delete(p.resume, highest)
+		for _, r := range queues[highest] {
+			if r == t {
+				return t
+			}
+		}
+	}
+	return p.Queues[highest].Policy.Pick(now, nil, queues[highest])
+}
+
+// annotate adds each task's queue and response time, and the average wait
+// and response of each queue.
+func (p *MLQPolicy) annotate(r *Report, tasks []*Task) {
+	col := Column{Header: "Queue"}
+	type totals struct {
+		n              int
+		wait, response int64
+	}
+	sums := make([]totals, len(p.Queues))
+	for _, t := range tasks {
+		l := p.queue(t)
+		col.Values = append(col.Values, fmt.Sprintf("%d (%s)", l, t.class()))
+		sums[l].n++
+		sums[l].wait += t.Exit - t.ArrivalTime - t.BurstDuration
+		sums[l].response += t.FirstRun - t.ArrivalTime
+	}
+	r.Columns = append(r.Columns, col, responseColumn(tasks))
+
+	for l, q := range p.Queues {
+		if sums[l].n == 0 {
+			continue
+		}
+		n := float64(sums[l].n)
+		r.Notes = append(r.Notes, fmt.Sprintf("Queue %d (%s, %s): %d processes, average wait %.2f, average response %.2f",
+			l, q.Class, q.Name, sums[l].n, float64(sums[l].wait)/n, float64(sums[l].response)/n))
+	}
+}
+
+// title names the multilevel queue after its queues, highest first.
+func (p *MLQPolicy) title() string {
+	queues := make([]string, len(p.Queues))
+	for i, q := range p.Queues {
+		queues[i] = q.Class + " " + q.Name
+	}
+	return "Multilevel queue, " + strings.Join(queues, " > ")
+}
+
+//endregion
@@ -0,0 +1,107 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseMLQ(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		spec      string
+		wantTitle string
+		wantErr   error
+	}{
+		{name: "default", spec: "default", wantTitle: "Multilevel queue, system fcfs > interactive rr:3 > batch fcfs"},
+		{name: "own quantum", spec: "interactive=RR:2, batch=sjf", wantTitle: "Multilevel queue, interactive rr:2 > batch sjf"},
+		{name: "realtime class", spec: "rt-fifo=priority,batch=fcfs", wantTitle: "Multilevel queue, fifo priority > batch fcfs"},
+		{name: "no queues", spec: " ", wantErr: ErrInvalidArgs},
+		{name: "missing policy", spec: "batch", wantErr: ErrInvalidArgs},
+		{name: "unknown class", spec: "kernel=fcfs", wantErr: ErrInvalidArgs},
+		{name: "unknown policy", spec: "batch=lifo", wantErr: ErrInvalidArgs},
+		{name: "quantum on fcfs", spec: "batch=fcfs:2", wantErr: ErrInvalidArgs},
+		{name: "class twice", spec: "b=fcfs,batch=sjf", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p, err := parseMLQ(tt.spec, 3)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseMLQ(%q) error = %v, want %v", tt.spec, err, tt.wantErr)
+			}
+			if err == nil && p.title() != tt.wantTitle {
+				t.Errorf("parseMLQ(%q) title = %q, want %q", tt.spec, p.title(), tt.wantTitle)
+			}
+		})
+	}
+}
+
+func TestMLQ(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		spec      string
+		processes []Process
+		wantGantt []TimeSlice
+		wantQueue []string
+		wantNotes []string
+	}{
+		{
+			name: "higher queue preempts and the preempted task resumes",
+			spec: "system=fcfs,interactive=rr:2,batch=fcfs",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 8}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 4, Interactive: true},
+				{ProcessID: 3, ArrivalTime: 2, BurstDuration: 3, System: true}, {ProcessID: 4, ArrivalTime: 3, BurstDuration: 4, Interactive: true},
+				{ProcessID: 5, ArrivalTime: 9, BurstDuration: 2},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 2}, {PID: 3, Start: 2, Stop: 5}, {PID: 2, Start: 5, Stop: 7},
+				{PID: 4, Start: 7, Stop: 9}, {PID: 2, Start: 9, Stop: 10}, {PID: 4, Start: 10, Stop: 12}, {PID: 1, Start: 12, Stop: 19},
+				{PID: 5, Start: 19, Stop: 21},
+			},
+			wantQueue: []string{"2 (batch)", "1 (interactive)", "0 (system)", "1 (interactive)", "2 (batch)"},
+			wantNotes: []string{
+				"Queue 0 (system, fcfs): 1 processes, average wait 0.00, average response 0.00",
+				"Queue 1 (interactive, rr:2): 2 processes, average wait 5.00, average response 2.00",
+				"Queue 2 (batch, fcfs): 2 processes, average wait 10.50, average response 5.00",
+			},
+		},
+		{
+			name: "classes without a queue share the lowest",
+			spec: "interactive=fcfs,batch=sjf",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, System: true},
+				{ProcessID: 3, ArrivalTime: 1, BurstDuration: 1}, {ProcessID: 4, ArrivalTime: 1, BurstDuration: 2, Interactive: true},
+			},
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 4, Start: 1, Stop: 3}, {PID: 1, Start: 3, Stop: 4}, {PID: 3, Start: 4, Stop: 5}, {PID: 2, Start: 5, Stop: 8}},
+			wantQueue: []string{"1 (batch)", "1 (system)", "1 (batch)", "0 (interactive)"},
+			wantNotes: []string{
+				"Queue 0 (interactive, fcfs): 1 processes, average wait 0.00, average response 0.00",
+				"Queue 1 (batch, sjf): 3 processes, average wait 3.00, average response 2.33",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p, err := parseMLQ(tt.spec, 4)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := simulate(p.title(), tt.processes, p)
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("MLQ Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			if len(r.Columns) == 0 || !reflect.DeepEqual(r.Columns[0].Values, tt.wantQueue) {
+				t.Errorf("MLQ columns = %+v, want queues %q", r.Columns, tt.wantQueue)
+			}
+			if !reflect.DeepEqual(r.Notes, tt.wantNotes) {
+				t.Errorf("MLQ notes = %q, want %q", r.Notes, tt.wantNotes)
+			}
+		})
+	}
+}
@@ -0,0 +1,636 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//region Multi-core simulation
+
+// speedScale is how many units of work a speed 1 CPU does per tick, so
+// CPU speeds can be given to hundredths and work stays an integer.
+const speedScale = 100
+
+type (
+	// multicore is a machine with several CPUs. Each tick every CPU asks
+	// the policy for its next task, so the policy's Pick is called once per
+	// CPU: running is the task that ran on that CPU the previous tick, and
+	// ready holds the tasks not on any CPU that it may run. Globally
+	// scheduled, the CPUs share one ready queue, and a task preempted on one
+	// CPU rejoins it where any CPU may pick it up. Partitioned, each task is
+	// placed on a CPU before the run and only ever runs there, unless work
+	// stealing lets an idle CPU take it from another's queue. Either way, a
+	// task only runs on the CPUs its affinity allows.
+	multicore struct {
+		policy Policy
+		cpus   int
+		// partitioned places each task on a CPU of its own, first-fit by
+		// utilization; placed is the placement, load the CPU time placed on
+		// each CPU and home the CPU whose queue each task is in now.
+		partitioned bool
+		placed      map[*Task]int
+		home        map[*Task]int
+		load        []int64
+		// stealing lets a partitioned CPU with nothing to run take a task
+		// from the longest queue holding at least threshold waiting tasks;
+		// steals describes each one.
+		stealing  bool
+		threshold int
+		steals    []string
+		// speeds is each CPU's speed in work units per tick, speedScale
+		// for all of them if nil. A task's burst is work, the ticks it takes
+		// at speed 1: left is how much each task has still to do, and
+		// Remaining the ticks that takes at speed 1, rounded up.
+		speeds []int64
+		left   map[*Task]int64
+		// energyAware prefers the most energy-efficient CPUs, the slowest,
+		// over the fastest when dispatching and placing tasks; energy is
+		// what the run used.
+		energyAware bool
+		energy      float64
+		// penalty is how many ticks a task dispatched on another CPU than
+		// the one it last ran on spends warming that CPU's cache before it
+		// gets any work done; warming is how many each task has still to
+		// spend, penalized how many it spent and moves how often a penalty
+		// was due.
+		penalty   int64
+		warming   map[*Task]int64
+		penalized map[*Task]int64
+		moves     int
+		// slices is the Gantt chart of the last run, with a lane per CPU.
+		slices []TimeSlice
+		// last is the index in slices of each task's latest slice.
+		last map[*Task]int
+		// busy is how many ticks each CPU ran a task, and cpuTime how many
+		// each task ran.
+		busy    []int64
+		cpuTime map[*Task]int64
+		// pinned is how many ticks each task waited while a CPU was idle
+		// that only its affinity kept it off.
+		pinned map[*Task]int64
+		// ran is the CPUs each task ran on, in the order it first ran on
+		// them, and migrations how often it moved between CPUs: globally,
+		// dispatched on another CPU than the one it last ran on, and with
+		// work stealing, stolen.
+		ran        map[*Task][]int
+		migrations map[*Task]int
+	}
+
+	// CoreOption configures a multi-core simulation.
+	CoreOption func(*multicore)
+)
+
+// WithPartitioning gives each CPU a ready queue of its own, placing every
+// task on one before the run.
+func WithPartitioning() CoreOption {
+	return func(m *multicore) {
+		m.partitioned = true
+	}
+}
+
+// WithStealing partitions the CPUs as WithPartitioning does, and lets a CPU
+// with nothing to run steal a task from the longest queue, provided at
+// least threshold tasks are waiting in it.
+func WithStealing(threshold int) CoreOption {
+	return func(m *multicore) {
+		m.partitioned, m.stealing, m.threshold = true, true, threshold
+	}
+}
+
+// WithSpeeds sets each CPU's speed, in hundredths of a speed 1 CPU.
+func WithSpeeds(speeds []int64) CoreOption {
+	return func(m *multicore) {
+		m.speeds = speeds
+	}
+}
+
+// WithEnergyAware places and dispatches tasks on the most energy-efficient
+// CPUs first, rather than the fastest.
+func WithEnergyAware() CoreOption {
+	return func(m *multicore) {
+		m.energyAware = true
+	}
+}
+
+// WithMigrationPenalty makes a task dispatched on another CPU than the one
+// it last ran on spend ticks ticks there before it does any work.
+func WithMigrationPenalty(ticks int64) CoreOption {
+	return func(m *multicore) {
+		m.penalty = ticks
+	}
+}
+
+// parseSpeeds parses comma separated CPU speeds relative to 1, like
+// "1,1,0.5,0.5", into hundredths.
+func parseSpeeds(list string) ([]int64, error) {
+	var speeds []int64
+	for _, f := range strings.Split(list, ",") {
+		v, err := parseScaled(f, speedScale)
+		if err != nil || v <= 0 {
+			return nil, fmt.Errorf("%w: CPU speed must be positive, in hundredths at most, got %q", ErrInvalidArgs, f)
+		}
+		speeds = append(speeds, v)
+	}
+	return speeds, nil
+}
+
+// simulateCores runs processes through policy on cpus CPUs, sharing a ready
+// queue unless partitioned, and returns the resulting report. The CPUs run
+// each task's burst straight through: they don't model I/O, so burst
+// cycles, or any of the engine's Options.
+func simulateCores(title string, processes []Process, policy Policy, cpus int, opts ...CoreOption) Report {
+	m := &multicore{policy: policy, cpus: cpus}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m.report(title, m.run(processes))
+}
+
+// multicoreReports runs first-come, first-serve, shortest-job-first,
+// priority and round-robin on cpus CPUs in each of the comma separated
+// modes: global, partitioned, or stealing, partitioned with work stealing
+// at threshold. Every run also gets opts, and notes its speedup and
+// efficiency over the same policy on one CPU.
+func multicoreReports(processes []Process, cpus int, quantum int64, modes string, threshold int, opts ...CoreOption) ([]Report, error) {
+	if hasBurstCycles(processes) {
+		return nil, fmt.Errorf("%w: multi-core runs don't model I/O, so processes can't have burst cycles", ErrInvalidArgs)
+	}
+	single := make(map[string]int64)
+	for _, p := range classicPolicies(quantum) {
+		single[policyTitle(p)] = simulate("", processes, p).makespan()
+	}
+	var reports []Report
+	for _, mode := range strings.Split(modes, ",") {
+		var modeOpts []CoreOption
+		switch mode = strings.ToLower(strings.TrimSpace(mode)); mode {
+		case "global":
+		case "partitioned":
+			modeOpts = append(modeOpts, WithPartitioning())
+		case "stealing":
+			if threshold < 1 {
+				return nil, fmt.Errorf("%w: the steal threshold must be at least 1, got %d", ErrInvalidArgs, threshold)
+			}
+			modeOpts = append(modeOpts, WithStealing(threshold))
+		default:
+			return nil, fmt.Errorf("%w: CPU mode must be global, partitioned or stealing, got %q", ErrInvalidArgs, mode)
+		}
+		modeOpts = append(modeOpts, opts...)
+		probe := &multicore{cpus: cpus}
+		for _, opt := range modeOpts {
+			opt(probe)
+		}
+		for _, p := range processes {
+			if p.affinityCPUs(cpus) == "" {
+				return nil, fmt.Errorf("%w: process %d's affinity allows none of the %d CPUs", ErrInvalidArgs, p.ProcessID, cpus)
+			}
+		}
+		for _, p := range classicPolicies(quantum) {
+			r := simulateCores(fmt.Sprintf("%s (%s)", policyTitle(p), probe.describe()), processes, p, cpus, modeOpts...)
+			r.Notes = append(r.Notes, speedupNote(single[policyTitle(p)], r.makespan(), cpus))
+			reports = append(reports, r)
+		}
+	}
+	return reports, nil
+}
+
+// describe names the machine and how it schedules, for report titles.
+func (m *multicore) describe() string {
+	d := fmt.Sprintf("%d CPUs", m.cpus)
+	switch {
+	case m.stealing:
+		d += ", work stealing"
+	case m.partitioned:
+		d += ", partitioned"
+	}
+	if m.energyAware {
+		d += ", energy-aware"
+	}
+	if m.penalty > 0 {
+		d += fmt.Sprintf(", migration penalty %d", m.penalty)
+	}
+	return d
+}
+
+// speed is how much work cpu does per tick.
+func (m *multicore) speed(cpu int) int64 {
+	if m.speeds == nil {
+		return speedScale
+	}
+	return m.speeds[cpu]
+}
+
+// order returns the CPUs in the order they take work: the fastest first,
+// or when energy-aware the most efficient. A busy tick costs the cube of
+// the CPU's speed, so the energy a unit of work takes grows with the
+// square of the speed and the slowest CPUs are the most efficient.
+func (m *multicore) order() []int {
+	order := make([]int, m.cpus)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		if m.energyAware {
+			return m.speed(order[a]) < m.speed(order[b])
+		}
+		return m.speed(order[a]) > m.speed(order[b])
+	})
+	return order
+}
+
+// place assigns each task to a CPU first-fit by utilization, in arrival
+// order. A task's utilization is its burst over a CPU's fair share of all
+// the bursts, in proportion to its speed, so a perfectly balanced CPU is
+// at 1: each task goes on the first CPU, in the order CPUs take work, it
+// fits on without passing 1, or the least utilized one if it fits on none,
+// of the CPUs its affinity allows.
+func (m *multicore) place(tasks []*Task) {
+	m.placed, m.home, m.load = make(map[*Task]int), make(map[*Task]int), make([]int64, m.cpus)
+	var total int64
+	for _, t := range tasks {
+		total += t.BurstDuration
+	}
+	order := m.order()
+	for _, t := range tasks {
+		home := -1
+		for _, cpu := range order {
+			if t.allowed(cpu) && m.utilization(m.load[cpu]+t.BurstDuration, cpu, total) <= 1 {
+				home = cpu
+				break
+			}
+		}
+		if home < 0 {
+			for _, cpu := range order {
+				if t.allowed(cpu) && (home < 0 || m.utilization(m.load[cpu], cpu, total) < m.utilization(m.load[home], home, total)) {
+					home = cpu
+				}
+			}
+		}
+		m.placed[t], m.home[t] = home, home
+		m.load[home] += t.BurstDuration
+	}
+}
+
+// utilization is load on cpu over its fair share of total.
+func (m *multicore) utilization(load int64, cpu int, total int64) float64 {
+	var speeds int64
+	for c := 0; c < m.cpus; c++ {
+		speeds += m.speed(c)
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(load*speeds) / float64(total*m.speed(cpu))
+}
+
+// eligible returns the ready tasks cpu may run: those whose affinity
+// allows it, and when partitioned only those placed on it.
+func (m *multicore) eligible(cpu int, ready []*Task) []*Task {
+	var mine []*Task
+	for _, t := range ready {
+		if m.partitioned && m.home[t] == cpu || !m.partitioned && t.allowed(cpu) {
+			mine = append(mine, t)
+		}
+	}
+	return mine
+}
+
+// steal has each CPU with nothing to run take the task queued last on the
+// CPU with the most waiting tasks, if there are at least the threshold,
+// skipping tasks whose affinity doesn't allow the thief.
+func (m *multicore) steal(now int64, running, ready []*Task) {
+	for cpu, r := range running {
+		if r != nil || len(m.eligible(cpu, ready)) > 0 {
+			continue
+		}
+		victim, most := -1, 0
+		for other := range running {
+			if n := len(m.eligible(other, ready)); n > most {
+				victim, most = other, n
+			}
+		}
+		if victim < 0 || most < m.threshold {
+			continue
+		}
+		var t *Task
+		for _, q := range m.eligible(victim, ready) {
+			if q.allowed(cpu) {
+				t = q
+			}
+		}
+		if t == nil {
+			continue
+		}
+		m.home[t] = cpu
+		m.migrations[t]++
+		m.steals = append(m.steals, fmt.Sprintf("t=%d: %d from CPU %d to CPU %d", now, t.ProcessID, victim, cpu))
+	}
+}
+
+// run simulates until every task completes, returning the tasks in arrival
+// order.
+func (m *multicore) run(processes []Process) []*Task {
+	tasks := newTasks(processes)
+	m.slices, m.last = nil, make(map[*Task]int)
+	m.busy, m.ran, m.migrations = make([]int64, m.cpus), make(map[*Task][]int), make(map[*Task]int)
+	m.cpuTime, m.left, m.pinned = make(map[*Task]int64), make(map[*Task]int64), make(map[*Task]int64)
+	m.steals, m.energy = nil, 0
+	m.warming, m.penalized, m.moves = make(map[*Task]int64), make(map[*Task]int64), 0
+	for _, t := range tasks {
+		m.left[t] = t.BurstDuration * speedScale
+	}
+	order := m.order()
+	if m.partitioned {
+		m.place(tasks)
+	}
+
+	var (
+		arrived = tasks
+		ready   []*Task
+		running = make([]*Task, m.cpus)
+		done    int
+		now     int64
+	)
+	for done < len(tasks) {
+		for len(arrived) > 0 && arrived[0].ArrivalTime <= now {
+			t := arrived[0]
+			arrived = arrived[1:]
+			t.Admitted, t.Queued = now, now
+			if t.Remaining <= 0 {
+				t.Exit = now
+				done++
+				continue
+			}
+			ready = append(ready, t)
+		}
+
+		idle := len(ready) == 0
+		for _, t := range running {
+			if t != nil {
+				idle = false
+			}
+		}
+		if idle {
+			// Nothing to do until the next arrival.
+			now = arrived[0].ArrivalTime
+			continue
+		}
+
+		if m.stealing {
+			m.steal(now, running, ready)
+		}
+		for _, cpu := range order {
+			r := running[cpu]
+			if r != nil && r.Slice == 0 {
+				// Still warming up after a migration.
+				continue
+			}
+			mine := m.eligible(cpu, ready)
+			if r == nil && len(mine) == 0 {
+				continue
+			}
+			pick := m.policy.Pick(now, r, mine)
+			if pick == r {
+				continue
+			}
+			if r != nil {
+				r.Queued = now
+				ready = append(ready, r)
+			}
+			if pick != nil {
+				ready = removeTask(ready, pick)
+				pick.Slice = 0
+				if pick.FirstRun < 0 {
+					pick.FirstRun = now
+				}
+				if i, ok := m.last[pick]; ok && m.slices[i].CPU != cpu {
+					m.warming[pick] += m.penalty
+					m.moves++
+				}
+			}
+			running[cpu] = pick
+		}
+
+		for _, t := range ready {
+			t.Waited++
+			if !m.partitioned && m.pinnedOff(t, running) {
+				m.pinned[t]++
+			}
+		}
+		for cpu, t := range running {
+			if t == nil {
+				continue
+			}
+			// Warming up doesn't count against the task's slice, and it
+			// can't be preempted meanwhile, so a quantum shorter than the
+			// penalty can't keep it from ever running.
+			if m.warming[t] > 0 {
+				m.warming[t]--
+				m.penalized[t]++
+			} else {
+				m.left[t] -= m.speed(cpu)
+				t.Remaining = (m.left[t] + speedScale - 1) / speedScale
+				t.Slice++
+			}
+			m.record(t, cpu, now)
+			if m.left[t] <= 0 {
+				t.Remaining = 0
+				t.Exit = now + 1
+				running[cpu] = nil
+				done++
+			}
+		}
+		now++
+	}
+	return tasks
+}
+
+// pinnedOff reports whether some CPU is idle but t's affinity allows none
+// of the idle ones.
+func (m *multicore) pinnedOff(t *Task, running []*Task) bool {
+	idle := false
+	for cpu, r := range running {
+		if r == nil {
+			if t.allowed(cpu) {
+				return false
+			}
+			idle = true
+		}
+	}
+	return idle
+}
+
+// record adds a tick of t running on cpu at now to the Gantt chart,
+// extending t's last slice when it was already running there.
+func (m *multicore) record(t *Task, cpu int, now int64) {
+	m.busy[cpu]++
+	m.cpuTime[t]++
+	speed := float64(m.speed(cpu)) / speedScale
+	m.energy += speed * speed * speed
+	if i, ok := m.last[t]; ok && m.slices[i].CPU == cpu && m.slices[i].Stop == now {
+		m.slices[i].Stop++
+		return
+	}
+	if i, ok := m.last[t]; ok && m.slices[i].CPU != cpu && !m.partitioned {
+		m.migrations[t]++
+	}
+	m.last[t] = len(m.slices)
+	m.slices = append(m.slices, TimeSlice{PID: t.ProcessID, Start: now, Stop: now + 1, CPU: cpu})
+	cpus := m.ran[t]
+	for _, c := range cpus {
+		if c == cpu {
+			return
+		}
+	}
+	m.ran[t] = append(cpus, cpu)
+}
+
+// report builds the report of a finished run, adding the CPUs each task
+// ran on and noting each CPU's utilization over the makespan. Globally
+// scheduled or with work stealing, it counts each task's migrations;
+// partitioned, it notes the placement and how unevenly it loaded the CPUs,
+// and any steals.
+func (m *multicore) report(title string, tasks []*Task) Report {
+	r := taskReport(title, tasks, m.slices)
+	col, migrations := Column{Header: "CPUs"}, Column{Header: "Migrations"}
+	var moves int
+	for _, t := range tasks {
+		cpus := make([]string, len(m.ran[t]))
+		for i, c := range m.ran[t] {
+			cpus[i] = fmt.Sprint(c)
+		}
+		col.Values = append(col.Values, strings.Join(cpus, ","))
+		migrations.Values = append(migrations.Values, fmt.Sprint(m.migrations[t]))
+		moves += m.migrations[t]
+	}
+	migrations.Footer = fmt.Sprintf("Total\n%d", moves)
+	r.Columns = append(r.Columns, col)
+	if !m.partitioned || m.stealing {
+		r.Columns = append(r.Columns, migrations)
+	}
+	if m.speeds != nil {
+		ran := Column{Header: "CPU time"}
+		for _, t := range tasks {
+			ran.Values = append(ran.Values, fmt.Sprint(m.cpuTime[t]))
+		}
+		r.Columns = append(r.Columns, ran)
+	}
+
+	span := r.makespan()
+	var total int64
+	util := make([]string, m.cpus)
+	for cpu, busy := range m.busy {
+		total += busy
+		util[cpu] = fmt.Sprintf("CPU %d %s", cpu, percentOf(busy, span))
+	}
+	r.Notes = append(r.Notes, fmt.Sprintf("Utilization: %s (average %s)",
+		strings.Join(util, ", "), percentOf(total, span*int64(m.cpus))))
+	if m.penalty > 0 {
+		penalty := Column{Header: "Penalty"}
+		var total int64
+		for _, t := range tasks {
+			penalty.Values = append(penalty.Values, fmt.Sprint(m.penalized[t]))
+			total += m.penalized[t]
+		}
+		penalty.Footer = fmt.Sprintf("Total\n%d", total)
+		r.Columns = append(r.Columns, penalty)
+		r.Notes = append(r.Notes, fmt.Sprintf("Migration penalty %d: %d dispatches on another CPU cost %d ticks, %s of the CPU time",
+			m.penalty, m.moves, total, percentOf(total, sumTicks(m.busy))))
+	}
+	if m.partitioned {
+		r.Notes = append(r.Notes, m.placementNote(tasks))
+	}
+	if m.stealing {
+		steals := "none"
+		if len(m.steals) > 0 {
+			steals = strings.Join(m.steals, ", ")
+		}
+		r.Notes = append(r.Notes, fmt.Sprintf("Steals from queues of at least %d: %s", m.threshold, steals))
+	}
+	if m.speeds != nil || m.energyAware {
+		speeds := make([]string, m.cpus)
+		for cpu := range speeds {
+			speeds[cpu] = fmt.Sprintf("CPU %d %.2f", cpu, float64(m.speed(cpu))/speedScale)
+		}
+		first := "fastest"
+		if m.energyAware {
+			first = "most efficient"
+		}
+		r.Notes = append(r.Notes, fmt.Sprintf("Speeds %s, %s first: energy %.2f, a busy tick at speed s costing s³",
+			strings.Join(speeds, ", "), first, m.energy))
+	}
+	if m.pinning(tasks) {
+		affinity := Column{Header: "Affinity"}
+		var delayed []string
+		for _, t := range tasks {
+			affinity.Values = append(affinity.Values, t.affinityCPUs(m.cpus))
+			if m.pinned[t] > 0 {
+				delayed = append(delayed, fmt.Sprintf("%d for %d", t.ProcessID, m.pinned[t]))
+			}
+		}
+		r.Columns = append(r.Columns, affinity)
+		if len(delayed) > 0 {
+			r.Notes = append(r.Notes, "Ticks delayed only by affinity, waiting while the idle CPUs were ones it may not use: "+strings.Join(delayed, ", "))
+		}
+	}
+	return r
+}
+
+// pinning reports whether any task's affinity keeps it off some CPU.
+func (m *multicore) pinning(tasks []*Task) bool {
+	for _, t := range tasks {
+		if t.affinityCPUs(m.cpus) != "any" {
+			return true
+		}
+	}
+	return false
+}
+
+// placementNote lists the tasks placed on each CPU and its utilization,
+// and the load imbalance: how far the most utilized CPU is over its fair
+// share.
+func (m *multicore) placementNote(tasks []*Task) string {
+	var total int64
+	for _, load := range m.load {
+		total += load
+	}
+	busiest := 0.0
+	cpus := make([]string, m.cpus)
+	for cpu, load := range m.load {
+		var ids []int64
+		for _, t := range tasks {
+			if m.placed[t] == cpu {
+				ids = append(ids, t.ProcessID)
+			}
+		}
+		u := m.utilization(load, cpu, total)
+		if u > busiest {
+			busiest = u
+		}
+		cpus[cpu] = fmt.Sprintf("CPU %d %.2f (%s)", cpu, u, formatIDs(ids))
+	}
+	imbalance := 0.0
+	if total > 0 {
+		imbalance = 100 * (busiest - 1)
+	}
+	return fmt.Sprintf("Placed first-fit by utilization: %s; load imbalance %.1f%%", strings.Join(cpus, ", "), imbalance)
+}
+
+// sumTicks adds up ticks.
+func sumTicks(ticks []int64) int64 {
+	var sum int64
+	for _, t := range ticks {
+		sum += t
+	}
+	return sum
+}
+
+// percentOf formats part as a percentage of whole.
+func percentOf(part, whole int64) string {
+	if whole <= 0 {
+		return "0.0%"
+	}
+	return fmt.Sprintf("%.1f%%", 100*float64(part)/float64(whole))
+}
+
+//endregion
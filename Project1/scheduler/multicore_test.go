@@ -0,0 +1,378 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func Test_simulateCores(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		processes      []Process
+		policy         Policy
+		cpus           int
+		wantGantt      []TimeSlice
+		wantCPUs       []string
+		wantMigrations []string
+		wantNote       string
+	}{
+		{
+			name: "first-come, first-serve fills both CPUs",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 3}, {ProcessID: 2, BurstDuration: 2}, {ProcessID: 3, BurstDuration: 2},
+			},
+			policy: FCFSPolicy{},
+			cpus:   2,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 0, Stop: 2, CPU: 1}, {PID: 3, Start: 2, Stop: 4, CPU: 1},
+			},
+			wantCPUs:       []string{"0", "1", "1"},
+			wantMigrations: []string{"0", "0", "0"},
+			wantNote:       "Utilization: CPU 0 75.0%, CPU 1 100.0% (average 87.5%)",
+		},
+		{
+			name: "round-robin moves preempted processes between CPUs",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, BurstDuration: 2}, {ProcessID: 3, BurstDuration: 2},
+			},
+			policy: RRPolicy{Quantum: 1},
+			cpus:   2,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 0, Stop: 1, CPU: 1}, {PID: 3, Start: 1, Stop: 2},
+				{PID: 1, Start: 1, Stop: 2, CPU: 1}, {PID: 2, Start: 2, Stop: 3}, {PID: 3, Start: 2, Stop: 3, CPU: 1},
+			},
+			wantCPUs:       []string{"0,1", "1,0", "0,1"},
+			wantMigrations: []string{"1", "1", "1"},
+			wantNote:       "Utilization: CPU 0 100.0%, CPU 1 100.0% (average 100.0%)",
+		},
+		{
+			name:           "idle CPUs wait for the next arrival",
+			processes:      []Process{{ProcessID: 1, BurstDuration: 1}, {ProcessID: 2, ArrivalTime: 3, BurstDuration: 1}},
+			policy:         SJFPolicy{},
+			cpus:           3,
+			wantGantt:      []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 3, Stop: 4}},
+			wantCPUs:       []string{"0", "0"},
+			wantMigrations: []string{"0", "0"},
+			wantNote:       "Utilization: CPU 0 50.0%, CPU 1 0.0%, CPU 2 0.0% (average 16.7%)",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := simulateCores("", tt.processes, tt.policy, tt.cpus)
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("simulateCores() Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			if got := r.Columns[0].Values; !reflect.DeepEqual(got, tt.wantCPUs) {
+				t.Errorf("simulateCores() CPUs = %q, want %q", got, tt.wantCPUs)
+			}
+			if got := r.Columns[1].Values; !reflect.DeepEqual(got, tt.wantMigrations) {
+				t.Errorf("simulateCores() migrations = %q, want %q", got, tt.wantMigrations)
+			}
+			if !reflect.DeepEqual(r.Notes, []string{tt.wantNote}) {
+				t.Errorf("simulateCores() notes = %q, want %q", r.Notes, tt.wantNote)
+			}
+		})
+	}
+}
+
+func Test_simulateCoresOneCPU(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 5, Priority: 2}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1, Priority: 3}, {ProcessID: 4, ArrivalTime: 9, BurstDuration: 2},
+	}
+	for _, p := range classicPolicies(2) {
+		want := simulate("", processes, p)
+		got := simulateCores("", processes, p, 1)
+		if !reflect.DeepEqual(got.Gantt, want.Gantt) || !reflect.DeepEqual(got.Rows, want.Rows) {
+			t.Errorf("simulateCores(%s, 1 CPU) = %v, want %v as simulate", policyTitle(p), got.Gantt, want.Gantt)
+		}
+	}
+}
+
+func Test_simulateCoresPartitioned(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		policy    Policy
+		cpus      int
+		wantGantt []TimeSlice
+		wantNote  string
+	}{
+		{
+			name: "first-fit fills a CPU before the next",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 4}, {ProcessID: 2, BurstDuration: 2}, {ProcessID: 3, BurstDuration: 2},
+				{ProcessID: 4, BurstDuration: 4},
+			},
+			policy: FCFSPolicy{},
+			cpus:   2,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 4}, {PID: 3, Start: 0, Stop: 2, CPU: 1}, {PID: 4, Start: 2, Stop: 6, CPU: 1},
+				{PID: 2, Start: 4, Stop: 6},
+			},
+			wantNote: "Placed first-fit by utilization: CPU 0 1.00 (<1, 2>), CPU 1 1.00 (<3, 4>); load imbalance 0.0%",
+		},
+		{
+			name: "a process that fits nowhere goes on the least loaded CPU",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 3}, {ProcessID: 2, BurstDuration: 3}, {ProcessID: 3, BurstDuration: 2},
+			},
+			policy: RRPolicy{Quantum: 1},
+			cpus:   2,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 0, Stop: 3, CPU: 1}, {PID: 3, Start: 1, Stop: 2},
+				{PID: 1, Start: 2, Stop: 3}, {PID: 3, Start: 3, Stop: 4}, {PID: 1, Start: 4, Stop: 5},
+			},
+			wantNote: "Placed first-fit by utilization: CPU 0 1.25 (<1, 3>), CPU 1 0.75 (<2>); load imbalance 25.0%",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := simulateCores("", tt.processes, tt.policy, tt.cpus, WithPartitioning())
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("simulateCores() Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			if len(r.Columns) != 1 {
+				t.Errorf("simulateCores() columns = %v, want only the CPUs", r.Columns)
+			}
+			if got := r.Notes[len(r.Notes)-1]; got != tt.wantNote {
+				t.Errorf("simulateCores() last note = %q, want %q", got, tt.wantNote)
+			}
+		})
+	}
+}
+
+func Test_simulateCoresStealing(t *testing.T) {
+	t.Parallel()
+	// 1 and 2 are placed on CPU 0 and 3 and 4 on CPU 1, which has nothing
+	// to run from 1 until 4 arrives at 3.
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 4}, {ProcessID: 2, BurstDuration: 1}, {ProcessID: 3, BurstDuration: 1},
+		{ProcessID: 4, ArrivalTime: 3, BurstDuration: 4},
+	}
+	tests := []struct {
+		name           string
+		threshold      int
+		wantGantt      []TimeSlice
+		wantMigrations []string
+		wantNote       string
+	}{
+		{
+			name:      "an idle CPU steals a waiting process",
+			threshold: 1,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 4}, {PID: 3, Start: 0, Stop: 1, CPU: 1}, {PID: 2, Start: 1, Stop: 2, CPU: 1},
+				{PID: 4, Start: 3, Stop: 7, CPU: 1},
+			},
+			wantMigrations: []string{"0", "1", "0", "0"},
+			wantNote:       "Steals from queues of at least 1: t=1: 2 from CPU 0 to CPU 1",
+		},
+		{
+			name:      "queues shorter than the threshold are left alone",
+			threshold: 2,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 4}, {PID: 3, Start: 0, Stop: 1, CPU: 1}, {PID: 4, Start: 3, Stop: 7, CPU: 1},
+				{PID: 2, Start: 4, Stop: 5},
+			},
+			wantMigrations: []string{"0", "0", "0", "0"},
+			wantNote:       "Steals from queues of at least 2: none",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := simulateCores("", processes, FCFSPolicy{}, 2, WithStealing(tt.threshold))
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("simulateCores() Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			if got := r.Columns[1]; got.Header != "Migrations" || !reflect.DeepEqual(got.Values, tt.wantMigrations) {
+				t.Errorf("simulateCores() second column = %v, want migrations %q", got, tt.wantMigrations)
+			}
+			if got := r.Notes[len(r.Notes)-1]; got != tt.wantNote {
+				t.Errorf("simulateCores() last note = %q, want %q", got, tt.wantNote)
+			}
+		})
+	}
+}
+
+func Test_multicoreReports(t *testing.T) {
+	t.Parallel()
+	reports, err := multicoreReports([]Process{{ProcessID: 1, BurstDuration: 2}}, 2, 4, "global, partitioned,stealing", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, r := range reports {
+		got = append(got, r.Title)
+	}
+	want := []string{
+		"First-come, first-serve (2 CPUs)", "Shortest-job-first (2 CPUs)", "Priority (2 CPUs)", "Round-robin, quantum 4 (2 CPUs)",
+		"First-come, first-serve (2 CPUs, partitioned)", "Shortest-job-first (2 CPUs, partitioned)", "Priority (2 CPUs, partitioned)",
+		"Round-robin, quantum 4 (2 CPUs, partitioned)",
+		"First-come, first-serve (2 CPUs, work stealing)", "Shortest-job-first (2 CPUs, work stealing)",
+		"Priority (2 CPUs, work stealing)", "Round-robin, quantum 4 (2 CPUs, work stealing)",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("multicoreReports() titles = %q, want %q", got, want)
+	}
+	// One process can't use the second CPU, so it is no faster on two.
+	if want := "Speedup 1.00 over 1 CPU (makespan 2), efficiency 50.0%"; reports[0].Notes[len(reports[0].Notes)-1] != want {
+		t.Errorf("multicoreReports() last note = %q, want %q", reports[0].Notes[len(reports[0].Notes)-1], want)
+	}
+	reports, err = multicoreReports([]Process{{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, BurstDuration: 2}}, 2, 4, "global", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Speedup 2.00 over 1 CPU (makespan 4), efficiency 100.0%"; reports[0].Notes[len(reports[0].Notes)-1] != want {
+		t.Errorf("multicoreReports() last note = %q, want %q", reports[0].Notes[len(reports[0].Notes)-1], want)
+	}
+	cycles := []Process{{ProcessID: 1, BurstDuration: 9, Bursts: []int64{5, 3, 4}}}
+	if _, err := multicoreReports(cycles, 2, 4, "global", 1); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("multicoreReports(burst cycles) error = %v, want ErrInvalidArgs", err)
+	}
+	if _, err := multicoreReports(nil, 2, 4, "clustered", 1); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("multicoreReports(clustered) error = %v, want ErrInvalidArgs", err)
+	}
+	if _, err := multicoreReports(nil, 2, 4, "stealing", 0); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("multicoreReports(stealing, threshold 0) error = %v, want ErrInvalidArgs", err)
+	}
+}
+
+func Test_simulateCoresSpeeds(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		opts      []CoreOption
+		wantGantt []TimeSlice
+		wantTime  []string
+		wantNotes []string
+	}{
+		{
+			name:      "the fastest CPU takes work first",
+			processes: []Process{{ProcessID: 1, BurstDuration: 2}},
+			opts:      []CoreOption{WithSpeeds([]int64{50, 100})},
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2, CPU: 1}},
+			wantTime:  []string{"2"},
+			wantNotes: []string{
+				"Utilization: CPU 0 0.0%, CPU 1 100.0% (average 50.0%)",
+				"Speeds CPU 0 0.50, CPU 1 1.00, fastest first: energy 2.00, a busy tick at speed s costing s³",
+			},
+		},
+		{
+			name:      "energy-aware runs it slower on the LITTLE CPU for less energy",
+			processes: []Process{{ProcessID: 1, BurstDuration: 2}},
+			opts:      []CoreOption{WithSpeeds([]int64{50, 100}), WithEnergyAware()},
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 4}},
+			wantTime:  []string{"4"},
+			wantNotes: []string{
+				"Utilization: CPU 0 100.0%, CPU 1 0.0% (average 50.0%)",
+				"Speeds CPU 0 0.50, CPU 1 1.00, most efficient first: energy 0.50, a busy tick at speed s costing s³",
+			},
+		},
+		{
+			name:      "partitioned, a slower CPU's fair share is smaller",
+			processes: []Process{{ProcessID: 1, BurstDuration: 4}, {ProcessID: 2, BurstDuration: 2}},
+			opts:      []CoreOption{WithSpeeds([]int64{100, 50}), WithPartitioning()},
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 0, Stop: 4, CPU: 1}},
+			wantTime:  []string{"4", "4"},
+			wantNotes: []string{
+				"Utilization: CPU 0 100.0%, CPU 1 100.0% (average 100.0%)",
+				"Placed first-fit by utilization: CPU 0 1.00 (<1>), CPU 1 1.00 (<2>); load imbalance 0.0%",
+				"Speeds CPU 0 1.00, CPU 1 0.50, fastest first: energy 4.50, a busy tick at speed s costing s³",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := simulateCores("", tt.processes, FCFSPolicy{}, 2, tt.opts...)
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("simulateCores() Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			if got := r.Columns[len(r.Columns)-1]; got.Header != "CPU time" || !reflect.DeepEqual(got.Values, tt.wantTime) {
+				t.Errorf("simulateCores() last column = %v, want CPU time %q", got, tt.wantTime)
+			}
+			if !reflect.DeepEqual(r.Notes, tt.wantNotes) {
+				t.Errorf("simulateCores() notes = %q, want %q", r.Notes, tt.wantNotes)
+			}
+		})
+	}
+}
+
+func Test_parseSpeeds(t *testing.T) {
+	t.Parallel()
+	got, err := parseSpeeds("1, 1,0.5,0.25")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int64{100, 100, 50, 25}; !reflect.DeepEqual(got, want) {
+		t.Errorf("parseSpeeds() = %v, want %v", got, want)
+	}
+	for _, bad := range []string{"1,0", "fast", "0.125", "1,,1"} {
+		if _, err := parseSpeeds(bad); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("parseSpeeds(%q) error = %v, want ErrInvalidArgs", bad, err)
+		}
+	}
+}
+
+func Test_simulateCoresMigrationPenalty(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, BurstDuration: 2}, {ProcessID: 3, BurstDuration: 2},
+	}
+	tests := []struct {
+		name        string
+		penalty     int64
+		wantGantt   []TimeSlice
+		wantPenalty []string
+		wantNote    string
+	}{
+		{
+			name:    "every migration warms the new CPU first",
+			penalty: 1,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 0, Stop: 1, CPU: 1}, {PID: 3, Start: 1, Stop: 2},
+				{PID: 1, Start: 1, Stop: 3, CPU: 1}, {PID: 2, Start: 2, Stop: 4}, {PID: 3, Start: 3, Stop: 5, CPU: 1},
+			},
+			wantPenalty: []string{"1", "1", "1"},
+			wantNote:    "Migration penalty 1: 3 dispatches on another CPU cost 3 ticks, 33.3% of the CPU time",
+		},
+		{
+			name:    "a penalty longer than the quantum can't be preempted",
+			penalty: 3,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 0, Stop: 1, CPU: 1}, {PID: 3, Start: 1, Stop: 2},
+				{PID: 1, Start: 1, Stop: 5, CPU: 1}, {PID: 2, Start: 2, Stop: 6}, {PID: 3, Start: 5, Stop: 9, CPU: 1},
+			},
+			wantPenalty: []string{"3", "3", "3"},
+			wantNote:    "Migration penalty 3: 3 dispatches on another CPU cost 9 ticks, 60.0% of the CPU time",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := simulateCores("", processes, RRPolicy{Quantum: 1}, 2, WithMigrationPenalty(tt.penalty))
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("simulateCores() Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			if got := r.Columns[2]; got.Header != "Penalty" || !reflect.DeepEqual(got.Values, tt.wantPenalty) {
+				t.Errorf("simulateCores() third column = %v, want penalties %q", got, tt.wantPenalty)
+			}
+			if got := r.Notes[1]; got != tt.wantNote {
+				t.Errorf("simulateCores() second note = %q, want %q", got, tt.wantNote)
+			}
+		})
+	}
+}
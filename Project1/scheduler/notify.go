@@ -0,0 +1,84 @@
+package scheduler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+//region Completion notification
+
+// notifyTimeout bounds how long a webhook may take to accept the summary.
+const notifyTimeout = 10 * time.Second
+
+// notifyCompletion announces that a run finished by POSTing the summary as
+// JSON to url and/or writing it to the marker file doneFile. Either may be
+// empty. Both are attempted even if the first fails.
+func notifyCompletion(url, doneFile string, s Summary) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%w: encoding summary", err)
+	}
+
+	var errs []error
+	if url != "" {
+		if err := postSummary(url, b); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if doneFile != "" {
+		if err := writeAtomic(doneFile, b, "completion marker"); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errs[0]
+	}
+
+	return nil
+}
+
+func postSummary(url string, b []byte) error {
+	client := &http.Client{Timeout: notifyTimeout}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("%w: notifying webhook", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%w: webhook responded %s: %s", ErrInvalidArgs, resp.Status, body)
+	}
+
+	return nil
+}
+
+// writeAtomic writes b to path through a temporary file and a rename, so a
+// reader never sees a partial file. what names the file in errors.
+func writeAtomic(path string, b []byte, what string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return fmt.Errorf("%w: writing %s", err, what)
+	}
+	if _, err := tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("%w: writing %s", err, what)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("%w: writing %s", err, what)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("%w: writing %s", err, what)
+	}
+
+	return nil
+}
+
+//endregion
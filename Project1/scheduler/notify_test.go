@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func Test_notifyCompletion(t *testing.T) {
+	t.Parallel()
+	started := time.Date(2023, 3, 1, 22, 0, 0, 0, time.UTC)
+	summary := summarize("overnight.csv", started, started.Add(8*time.Hour), []Report{
+		{Title: "Round-robin", Rows: []Row{{ProcessID: 1}}, Wait: 3, Turnaround: 7, Throughput: 0.25},
+	})
+
+	received := make(chan Summary, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var s Summary
+		if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received <- s
+	}))
+	t.Cleanup(srv.Close)
+	done := filepath.Join(t.TempDir(), "run.done")
+
+	if err := notifyCompletion(srv.URL, done, summary); err != nil {
+		t.Fatal(err)
+	}
+
+	got := <-received
+	if got.Workload != "overnight.csv" || len(got.Algorithms) != 1 || got.Algorithms[0].AverageWait != 3 {
+		t.Errorf("webhook received %+v", got)
+	}
+	b, err := os.ReadFile(done)
+	if err != nil {
+		t.Fatalf("marker file not written: %v", err)
+	}
+	var marker Summary
+	if err := json.Unmarshal(b, &marker); err != nil || !marker.Finished.Equal(started.Add(8*time.Hour)) {
+		t.Errorf("marker = %s (%v)", b, err)
+	}
+}
+
+func Test_notifyCompletion_webhookError(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(srv.Close)
+
+	if err := notifyCompletion(srv.URL, "", Summary{}); err == nil {
+		t.Error("want an error when the webhook fails")
+	}
+}
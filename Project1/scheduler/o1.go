@@ -0,0 +1,274 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"math/bits"
+	"strings"
+)
+
+//region O(1) scheduler
+
+// The O(1) scheduler's constants, from Linux 2.6, with a tick of 10ms.
+const (
+	o1Levels = 140
+	// o1MaxSleepAvg is the most sleep, in ticks, a task's average counts.
+	o1MaxSleepAvg = 100
+	// o1MaxBonus is the spread of the interactivity bonus, -5 to +5.
+	o1MaxBonus = 10
+	// o1InteractiveDelta is how much bonus a nice 0 task needs to count as
+	// interactive.
+	o1InteractiveDelta = 2
+	// o1StarvationLimit is how long, in ticks per runnable task, the
+	// expired array may wait before interactive tasks are expired too.
+	o1StarvationLimit = o1MaxSleepAvg
+)
+
+type (
+	// O1Policy is modelled on the Linux 2.6 O(1) scheduler. Runnable tasks
+	// are kept in two priority arrays, active and expired, each a queue per
+	// priority level with a bitmap of the non-empty ones, so the next task is
+	// found by the first set bit whatever the number of tasks. The highest
+	// priority active task runs, preempting a lower priority one, until its
+	// timeslice, which is longer the lower its nice value, runs out. It then
+	// gets a fresh timeslice in the expired array, unless it is interactive
+	// and the expired array isn't starving, in which case it goes to the back
+	// of its queue in the active array. When the active array empties the
+	// arrays are swapped. A task's dynamic priority is its static one, from
+	// its nice value, improved by a bonus of up to 5 for sleeping, on I/O,
+	// and worsened by up to 5 for running.
+	O1Policy struct {
+		active, expired *o1Array
+		tasks           map[*Task]*o1Task
+		// expiredSince is when the first task went into the expired array
+		// since the last swap, or -1.
+		expiredSince int64
+		tick         tickCharger
+		switches     []string
+	}
+
+	// o1Array is a priority array: a FIFO queue per priority level, and a
+	// bitmap of which queues have tasks.
+	o1Array struct {
+		bitmap [(o1Levels + 63) / 64]uint64
+		queues [o1Levels][]*Task
+	}
+
+	// o1Task is the O(1) scheduler's state of a task.
+	o1Task struct {
+		prio     int
+		sleepAvg int64
+		slice    int64
+		// array is the array the task is queued in, nil when it isn't
+		// runnable.
+		array *o1Array
+		// io is the task's I/O time when it was last runnable.
+		io int64
+		// expirations and requeues count the timeslices that ran out with
+		// the task sent to the expired array and kept in the active one.
+		expirations, requeues int
+	}
+)
+
+// enqueue adds t to the back of the queue of prio.
+func (a *o1Array) enqueue(t *Task, prio int) {
+	a.queues[prio] = append(a.queues[prio], t)
+	a.bitmap[prio/64] |= 1 << (prio % 64)
+}
+
+// remove takes t out of the queue of prio.
+func (a *o1Array) remove(t *Task, prio int) {
+	a.queues[prio] = removeTask(a.queues[prio], t)
+	if len(a.queues[prio]) == 0 {
+		a.bitmap[prio/64] &^= 1 << (prio % 64)
+	}
+}
+
+// first returns the highest priority, lowest numbered, non-empty level.
+func (a *o1Array) first() (int, bool) {
+	for i, word := range a.bitmap {
+		if word != 0 {
+			return i*64 + bits.TrailingZeros64(word), true
+		}
+	}
+	return 0, false
+}
+
+// o1StaticPrio is the O(1) static priority of a nice value, 100 to 139.
+func o1StaticPrio(t *Task) int {
+	nice := t.Nice
+	if nice < -20 {
+		nice = -20
+	} else if nice > 19 {
+		nice = 19
+	}
+	return 120 + int(nice)
+}
+
+// o1Timeslice is the timeslice of a static priority in ticks: 800ms at
+// nice -20, 100ms at nice 0 and 5ms, rounded up to a tick, at nice 19.
+func o1Timeslice(static int) int64 {
+	ms := int64(o1Levels-static) * 5
+	if static < 120 {
+		ms = int64(o1Levels-static) * 20
+	}
+	if ms < 10 {
+		return 1
+	}
+	return ms / 10
+}
+
+// o1Bonus is the interactivity bonus of a task with sleepAvg, -5 to +5.
+func o1Bonus(sleepAvg int64) int {
+	return int(sleepAvg*o1MaxBonus/o1MaxSleepAvg) - o1MaxBonus/2
+}
+
+// effectivePrio is t's dynamic priority.
+func (p *O1Policy) effectivePrio(t *Task) int {
+	prio := o1StaticPrio(t) - o1Bonus(p.tasks[t].sleepAvg)
+	if prio < 100 {
+		return 100
+	} else if prio > o1Levels-1 {
+		return o1Levels - 1
+	}
+	return prio
+}
+
+// interactive reports whether t has enough bonus for its nice value to
+// stay in the active array when its timeslice runs out.
+func (p *O1Policy) interactive(t *Task) bool {
+	return o1Bonus(p.tasks[t].sleepAvg) >= int(t.Nice)*o1MaxBonus/40+o1InteractiveDelta
+}
+
+// starving reports whether the expired array has waited too long for the
+// runnable tasks.
+func (p *O1Policy) starving(now int64, runnable int) bool {
+	return p.expiredSince >= 0 && now-p.expiredSince >= o1StarvationLimit*int64(runnable)
+}
+
+func (p *O1Policy) Pick(now int64, running *Task, ready []*Task) *Task {
+	if p.tasks == nil {
+		p.active, p.expired, p.tasks, p.expiredSince = &o1Array{}, &o1Array{}, make(map[*Task]*o1Task), -1
+	}
+	runnable := make(map[*Task]bool, len(ready)+1)
+	for _, t := range ready {
+		runnable[t] = true
+	}
+	if running != nil {
+		runnable[running] = true
+	}
+
+	// Charge the last tick, and expire or requeue the running task if its
+	// timeslice has run out.
+	if t := p.tick.due(now); t != nil {
+		s := p.tasks[t]
+		s.slice--
+		if s.sleepAvg > 0 {
+			s.sleepAvg--
+		}
+		if runnable[t] && s.slice <= 0 {
+			s.array.remove(t, s.prio)
+			s.prio, s.slice = p.effectivePrio(t), o1Timeslice(o1StaticPrio(t))
+			if p.interactive(t) && !p.starving(now, len(runnable)) {
+				s.requeues++
+				s.array = p.active
+			} else {
+				s.expirations++
+				if p.expiredSince < 0 {
+					p.expiredSince = now
+				}
+				s.array = p.expired
+			}
+			s.array.enqueue(t, s.prio)
+		}
+	}
+
+	// Tasks that finished or blocked leave the arrays, keeping what is left
+	// of their timeslice, and tasks that arrived or woke join the active
+	// array with credit for the time they slept.
+	for t, s := range p.tasks {
+		if s.array != nil && !runnable[t] {
+			s.array.remove(t, s.prio)
+			s.array = nil
+		}
+	}
+	for _, t := range ready {
+		s := p.tasks[t]
+		if s == nil {
+			s = &o1Task{sleepAvg: o1MaxSleepAvg / 2, slice: o1Timeslice(o1StaticPrio(t))}
+			p.tasks[t] = s
+		}
+		if s.array != nil {
+			continue
+		}
+		if s.sleepAvg += t.IO - s.io; s.sleepAvg > o1MaxSleepAvg {
+			s.sleepAvg = o1MaxSleepAvg
+		}
+		if s.slice <= 0 {
+			s.slice = o1Timeslice(o1StaticPrio(t))
+		}
+		s.prio, s.array = p.effectivePrio(t), p.active
+		s.array.enqueue(t, s.prio)
+	}
+	for t := range runnable {
+		p.tasks[t].io = t.IO
+	}
+
+	prio, ok := p.active.first()
+	if !ok {
+		if _, ok = p.expired.first(); ok {
+			p.active, p.expired, p.expiredSince = p.expired, p.active, -1
+			p.switches = append(p.switches, fmt.Sprintf("t=%d", now))
+			prio, _ = p.active.first()
+		}
+	}
+	var pick *Task
+	if ok {
+		pick = p.active.queues[prio][0]
+	}
+	p.tick.picked(now, pick)
+	return pick
+}
+
+// annotate adds each task's static and final dynamic priority, timeslice,
+// whether it ended interactive, and how often its timeslice ran out into
+// each array, and lists when the arrays were swapped.
+func (p *O1Policy) annotate(r *Report, tasks []*Task) {
+	static, dynamic, slice := Column{Header: "Static"}, Column{Header: "Dynamic"}, Column{Header: "Timeslice"}
+	interactive, expired, requeued := Column{Header: "Interactive"}, Column{Header: "Expired"}, Column{Header: "Requeued"}
+	for _, t := range tasks {
+		s := p.tasks[t]
+		if s == nil {
+			s = &o1Task{sleepAvg: o1MaxSleepAvg / 2}
+			p.tasks[t] = s
+		}
+		static.Values = append(static.Values, fmt.Sprint(o1StaticPrio(t)))
+		dynamic.Values = append(dynamic.Values, fmt.Sprint(p.effectivePrio(t)))
+		slice.Values = append(slice.Values, fmt.Sprint(o1Timeslice(o1StaticPrio(t))))
+		verdict := "no"
+		if p.interactive(t) {
+			verdict = "yes"
+		}
+		interactive.Values = append(interactive.Values, verdict)
+		expired.Values = append(expired.Values, fmt.Sprint(s.expirations))
+		requeued.Values = append(requeued.Values, fmt.Sprint(s.requeues))
+	}
+	r.Columns = append(r.Columns, static, dynamic, slice, interactive, expired, requeued)
+	if len(p.switches) > 0 {
+		r.Notes = append(r.Notes, "Active and expired arrays swapped at "+strings.Join(p.switches, ", "))
+	}
+}
+
+// O1Schedule outputs the O(1) scheduler's schedule of processes as a Gantt
+// chart and a table of timing.
+func O1Schedule(w io.Writer, title string, processes []Process, opts ...Option) {
+	outputReport(w, O1(title, processes, opts...))
+}
+
+// O1 schedules processes with the O(1) scheduler, timeslices and static
+// priorities following the nice column.
+func O1(title string, processes []Process, opts ...Option) Report {
+	return simulate(title, processes, &O1Policy{}, opts...)
+}
+
+//endregion
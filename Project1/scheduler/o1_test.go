@@ -0,0 +1,138 @@
+package scheduler
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestO1(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		wantGantt []TimeSlice
+		// wantColumns are the static, dynamic, timeslice, interactive,
+		// expired and requeued columns.
+		wantColumns [][]string
+		wantNotes   []string
+	}{
+		{
+			name: "nice sets priority and timeslice",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 30}, {ProcessID: 2, BurstDuration: 30, Nice: 10},
+				{ProcessID: 3, ArrivalTime: 2, BurstDuration: 8, Nice: -5},
+			},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2}, {PID: 3, Start: 2, Stop: 10}, {PID: 1, Start: 10, Stop: 18},
+				{PID: 2, Start: 18, Stop: 23}, {PID: 1, Start: 23, Stop: 33}, {PID: 2, Start: 33, Stop: 38},
+				{PID: 1, Start: 38, Stop: 48}, {PID: 2, Start: 48, Stop: 68},
+			},
+			wantColumns: [][]string{
+				{"120", "130", "115"}, {"123", "133", "116"}, {"10", "5", "50"},
+				{"no", "no", "no"}, {"2", "5", "0"}, {"0", "0", "0"},
+			},
+			wantNotes: []string{"Active and expired arrays swapped at t=23, t=38, t=53, t=58, t=63"},
+		},
+		{
+			name:      "a lone task keeps expiring into a swap",
+			processes: []Process{{ProcessID: 1, BurstDuration: 25}},
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 25}},
+			wantColumns: [][]string{
+				{"120"}, {"123"}, {"10"}, {"no"}, {"2"}, {"0"},
+			},
+			wantNotes: []string{"Active and expired arrays swapped at t=10, t=20"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := O1("O(1) scheduler", tt.processes)
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("O1() Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			var columns [][]string
+			for _, c := range r.Columns {
+				columns = append(columns, c.Values)
+			}
+			if !reflect.DeepEqual(columns, tt.wantColumns) {
+				t.Errorf("O1() columns = %q, want %q", columns, tt.wantColumns)
+			}
+			if !reflect.DeepEqual(r.Notes, tt.wantNotes) {
+				t.Errorf("O1() notes = %q, want %q", r.Notes, tt.wantNotes)
+			}
+		})
+	}
+}
+
+func TestO1Policy_interactive(t *testing.T) {
+	t.Parallel()
+	p := &O1Policy{}
+	a, b := &Task{Process: Process{ProcessID: 1}}, &Task{Process: Process{ProcessID: 2}}
+	if got := p.Pick(0, nil, []*Task{a, b}); got != a {
+		t.Fatalf("Pick() = %v, want 1", got)
+	}
+	// 1 blocks for I/O for 50 ticks, then wakes with a bonus and preempts 2.
+	if got := p.Pick(1, nil, []*Task{b}); got != b {
+		t.Fatalf("Pick() = %v, want 2", got)
+	}
+	a.IO = 50
+	if got := p.Pick(2, b, []*Task{a}); got != a {
+		t.Fatalf("Pick() on waking = %v, want 1 preempting", got)
+	}
+	if prio := p.effectivePrio(a); prio != 116 || !p.interactive(a) {
+		t.Fatalf("after sleeping, 1 has priority %d and interactive %v, want 116 and true", prio, p.interactive(a))
+	}
+	// Its timeslice runs out, but being interactive it stays active.
+	for now := int64(3); now < 12; now++ {
+		if got := p.Pick(now, a, []*Task{b}); got != a {
+			t.Fatalf("Pick() at %d = %v, want 1", now, got)
+		}
+	}
+	if s := p.tasks[a]; s.requeues != 1 || s.expirations != 0 || s.array != p.active {
+		t.Errorf("1 requeued %d and expired %d times, want requeued once into the active array", s.requeues, s.expirations)
+	}
+}
+
+func Test_o1Array(t *testing.T) {
+	t.Parallel()
+	var a o1Array
+	x, y, z := &Task{Process: Process{ProcessID: 1}}, &Task{Process: Process{ProcessID: 2}}, &Task{Process: Process{ProcessID: 3}}
+	a.enqueue(x, 139)
+	a.enqueue(y, 64)
+	a.enqueue(z, 64)
+	if prio, ok := a.first(); !ok || prio != 64 || a.queues[64][0] != y {
+		t.Errorf("first() = %d, %v, want 64 headed by 2", prio, ok)
+	}
+	a.remove(y, 64)
+	a.remove(z, 64)
+	if prio, ok := a.first(); !ok || prio != 139 {
+		t.Errorf("first() = %d, %v, want 139", prio, ok)
+	}
+	a.remove(x, 139)
+	if _, ok := a.first(); ok || a.bitmap != [3]uint64{} {
+		t.Errorf("first() of an empty array found a level, bitmap %v", a.bitmap)
+	}
+}
+
+func Test_o1Timeslice(t *testing.T) {
+	t.Parallel()
+	for static, want := range map[int]int64{100: 80, 115: 50, 120: 10, 130: 5, 139: 1} {
+		if got := o1Timeslice(static); got != want {
+			t.Errorf("o1Timeslice(%d) = %d, want %d", static, got, want)
+		}
+	}
+}
+
+func TestO1Schedule(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	O1Schedule(&w, "O(1) scheduler", []Process{{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, BurstDuration: 1}}, WithRandomIO(0.5, 2, 1))
+	for _, want := range []string{"O(1) scheduler", "TIMESLICE", "INTERACTIVE"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("O1Schedule() = %q, want it to contain %q", w.String(), want)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package scheduler
+
+//region Observers
+
+// Observer is told about a simulation as it runs, to collect custom
+// metrics or stop the run early. The tasks passed in are the engine's own
+// and must not be changed.
+type Observer interface {
+	// OnDispatch is called when t gets the CPU at now.
+	OnDispatch(now int64, t *Task)
+	// OnPreempt is called when t loses the CPU at now before finishing.
+	OnPreempt(now int64, t *Task)
+	// OnComplete is called when t finishes at now.
+	OnComplete(now int64, t *Task)
+	// OnTick is called before the tick starting at now is run, with the
+	// task about to run it, or nil if the CPU is idle. Returning an error
+	// aborts the run with it.
+	OnTick(now int64, running *Task) error
+}
+
+// NopObserver does nothing; embed it to implement only some of Observer.
+type NopObserver struct{}
+
+func (NopObserver) OnDispatch(int64, *Task)   {}
+func (NopObserver) OnPreempt(int64, *Task)    {}
+func (NopObserver) OnComplete(int64, *Task)   {}
+func (NopObserver) OnTick(int64, *Task) error { return nil }
+
+// WithObserver registers o with the simulation. Observers are called in
+// the order they were registered.
+func WithObserver(o Observer) Option {
+	return func(e *engine) {
+		e.hooks = append(e.hooks, o)
+	}
+}
+
+//endregion
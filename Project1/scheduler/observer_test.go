@@ -0,0 +1,91 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// logObserver records every call, and fails the tick at abortAt if set.
+type logObserver struct {
+	log     []string
+	abortAt int64
+}
+
+func (o *logObserver) OnDispatch(now int64, t *Task) {
+	o.log = append(o.log, fmt.Sprintf("%d dispatch %d", now, t.ProcessID))
+}
+
+func (o *logObserver) OnPreempt(now int64, t *Task) {
+	o.log = append(o.log, fmt.Sprintf("%d preempt %d", now, t.ProcessID))
+}
+
+func (o *logObserver) OnComplete(now int64, t *Task) {
+	o.log = append(o.log, fmt.Sprintf("%d complete %d", now, t.ProcessID))
+}
+
+func (o *logObserver) OnTick(now int64, running *Task) error {
+	if o.abortAt > 0 && now == o.abortAt {
+		return errors.New("too slow")
+	}
+	return nil
+}
+
+// dispatchCounter only counts dispatches.
+type dispatchCounter struct {
+	NopObserver
+	n int
+}
+
+func (c *dispatchCounter) OnDispatch(int64, *Task) { c.n++ }
+
+func Test_WithObserver(t *testing.T) {
+	t.Parallel()
+	w, err := ReadWorkload(strings.NewReader("1,3,0,1\n2,1,1,1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name         string
+		abortAt      int64
+		wantLog      []string
+		wantErr      string
+		wantDispatch int
+	}{
+		{
+			name: "full run",
+			wantLog: []string{
+				"0 dispatch 1", "2 preempt 1", "2 dispatch 2", "3 complete 2", "3 dispatch 1", "4 complete 1",
+			},
+			wantDispatch: 3,
+		},
+		{
+			name:         "aborted",
+			abortAt:      3,
+			wantLog:      []string{"0 dispatch 1", "2 preempt 1", "2 dispatch 2", "3 complete 2", "3 dispatch 1"},
+			wantErr:      "t=3: too slow",
+			wantDispatch: 3,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			o := &logObserver{abortAt: tt.abortAt}
+			c := &dispatchCounter{}
+			_, err := Simulate(context.Background(), w, RRPolicy{Quantum: 2}, WithObserver(o), WithObserver(c))
+			if (err != nil || tt.wantErr != "") && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("Simulate() error = %v, want %q", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(o.log, tt.wantLog) {
+				t.Errorf("log = %q, want %q", o.log, tt.wantLog)
+			}
+			if c.n != tt.wantDispatch {
+				t.Errorf("dispatches = %d, want %d", c.n, tt.wantDispatch)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package scheduler
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+//region OpenTelemetry export
+
+// otlpServiceName is the service the exported spans are reported under.
+const otlpServiceName = "process-scheduler"
+
+// The subset of the OTLP/JSON trace encoding we produce.
+type (
+	otlpTraces struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+	otlpResource struct {
+		Attributes []otlpAttribute `json:"attributes"`
+	}
+	otlpScopeSpans struct {
+		Scope struct {
+			Name string `json:"name"`
+		} `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	otlpSpan struct {
+		TraceID           string          `json:"traceId"`
+		SpanID            string          `json:"spanId"`
+		ParentSpanID      string          `json:"parentSpanId,omitempty"`
+		Name              string          `json:"name"`
+		Kind              int             `json:"kind"`
+		StartTimeUnixNano string          `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+		Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	}
+	otlpAttribute struct {
+		Key   string    `json:"key"`
+		Value otlpValue `json:"value"`
+	}
+	otlpValue struct {
+		StringValue *string `json:"stringValue,omitempty"`
+		IntValue    *string `json:"intValue,omitempty"`
+		BoolValue   *bool   `json:"boolValue,omitempty"`
+	}
+)
+
+// otlpSpanKindInternal is SPAN_KIND_INTERNAL.
+const otlpSpanKindInternal = 1
+
+func otlpString(key, v string) otlpAttribute {
+	return otlpAttribute{Key: key, Value: otlpValue{StringValue: &v}}
+}
+
+func otlpInt(key string, v int64) otlpAttribute {
+	s := fmt.Sprint(v)
+	return otlpAttribute{Key: key, Value: otlpValue{IntValue: &s}}
+}
+
+func otlpBool(key string, v bool) otlpAttribute {
+	return otlpAttribute{Key: key, Value: otlpValue{BoolValue: &v}}
+}
+
+// exportOTLP writes reports as OTLP/JSON traces to dest, which is either a
+// file or an http(s) URL of a collector's traces endpoint
+// (e.g. http://localhost:4318/v1/traces). Tick 0 is mapped to base and every
+// tick lasts tick.
+func exportOTLP(dest string, reports []Report, base time.Time, tick time.Duration) error {
+	traces := buildOTLPTraces(reports, base, tick, randomID)
+	b, err := json.Marshal(traces)
+	if err != nil {
+		return fmt.Errorf("%w: encoding OTLP", err)
+	}
+
+	if strings.HasPrefix(dest, "http://") || strings.HasPrefix(dest, "https://") {
+		client := &http.Client{Timeout: 10 * time.Second}
+		resp, err := client.Post(dest, "application/json", bytes.NewReader(b))
+		if err != nil {
+			return fmt.Errorf("%w: sending OTLP", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode/100 != 2 {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+			return fmt.Errorf("%w: collector responded %s: %s", ErrInvalidArgs, resp.Status, body)
+		}
+		return nil
+	}
+
+	if err := os.WriteFile(dest, b, 0o644); err != nil {
+		return fmt.Errorf("%w: writing OTLP", err)
+	}
+	return nil
+}
+
+// buildOTLPTraces maps the whole workload to one trace with a root span per
+// algorithm and a child span per Gantt slice. On several CPUs, each slice
+// names its CPU in scheduler.cpu, and scheduler.migrated marks slices whose
+// process last ran on another CPU.
+func buildOTLPTraces(reports []Report, base time.Time, tick time.Duration, newID func(bytes int) string) otlpTraces {
+	at := func(t int64) string {
+		return fmt.Sprint(base.Add(time.Duration(t) * tick).UnixNano())
+	}
+
+	traceID := newID(16)
+	scope := otlpScopeSpans{}
+	scope.Scope.Name = otlpServiceName
+	for _, r := range reports {
+		if len(r.Gantt) == 0 {
+			continue
+		}
+		start, end := r.Gantt[0].Start, r.Gantt[0].Stop
+		for _, s := range r.Gantt {
+			if s.Start < start {
+				start = s.Start
+			}
+			if s.Stop > end {
+				end = s.Stop
+			}
+		}
+
+		root := otlpSpan{
+			TraceID:           traceID,
+			SpanID:            newID(8),
+			Name:              r.Title,
+			Kind:              otlpSpanKindInternal,
+			StartTimeUnixNano: at(start),
+			EndTimeUnixNano:   at(end),
+			Attributes: []otlpAttribute{
+				otlpString("scheduler.algorithm", r.Title),
+				otlpInt("scheduler.processes", int64(len(r.Rows))),
+			},
+		}
+		cpus := cpuCount(r.Gantt)
+		if cpus > 1 {
+			root.Attributes = append(root.Attributes, otlpInt("scheduler.cpus", int64(cpus)))
+		}
+		scope.Spans = append(scope.Spans, root)
+		migrated := migrations(r.Gantt)
+		for i, s := range r.Gantt {
+			name := fmt.Sprintf("PID %d", s.PID)
+			attributes := []otlpAttribute{
+				otlpString("scheduler.algorithm", r.Title),
+				otlpInt("process.pid", s.PID),
+			}
+			if cpus > 1 {
+				attributes = append(attributes, otlpInt("scheduler.cpu", int64(s.CPU)))
+			}
+			if migrated[i] {
+				attributes = append(attributes, otlpBool("scheduler.migrated", true))
+			}
+			if s.Overhead {
+				// A context switch runs no process.
+				name = "Context switch"
+				attributes = []otlpAttribute{
+					otlpString("scheduler.algorithm", r.Title),
+					otlpBool("scheduler.overhead", true),
+				}
+			}
+			scope.Spans = append(scope.Spans, otlpSpan{
+				TraceID:           traceID,
+				SpanID:            newID(8),
+				ParentSpanID:      root.SpanID,
+				Name:              name,
+				Kind:              otlpSpanKindInternal,
+				StartTimeUnixNano: at(s.Start),
+				EndTimeUnixNano:   at(s.Stop),
+				Attributes: append(attributes,
+					otlpInt("scheduler.start_tick", s.Start),
+					otlpInt("scheduler.stop_tick", s.Stop),
+				),
+			})
+		}
+	}
+
+	return otlpTraces{ResourceSpans: []otlpResourceSpans{{
+		Resource:   otlpResource{Attributes: []otlpAttribute{otlpString("service.name", otlpServiceName)}},
+		ScopeSpans: []otlpScopeSpans{scope},
+	}}}
+}
+
+// randomID returns a random hex-encoded trace or span ID of the given length in bytes.
+func randomID(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+//endregion
@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func Test_buildOTLPTraces(t *testing.T) {
+	t.Parallel()
+	var next int
+	newID := func(n int) string {
+		next++
+		return fmt.Sprintf("%0*d", n*2, next)
+	}
+	reports := []Report{
+		{
+			Title: "Round-robin",
+			Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 1, Start: 4, Stop: 5}},
+			Rows:  []Row{{ProcessID: 1}, {ProcessID: 2}},
+		},
+		{Title: "Empty"},
+	}
+	base := time.Unix(100, 0)
+
+	got := buildOTLPTraces(reports, base, time.Second, newID)
+	spans := got.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 4 {
+		t.Fatalf("spans = %d, want a root and 3 slices", len(spans))
+	}
+	root := spans[0]
+	if root.Name != "Round-robin" || root.StartTimeUnixNano != "100000000000" || root.EndTimeUnixNano != "105000000000" {
+		t.Errorf("root span = %+v", root)
+	}
+	for _, s := range spans[1:] {
+		if s.TraceID != root.TraceID || s.ParentSpanID != root.SpanID {
+			t.Errorf("slice span %s not a child of the root: %+v", s.Name, s)
+		}
+	}
+	if last := spans[3]; last.Name != "PID 1" || last.StartTimeUnixNano != "104000000000" {
+		t.Errorf("last slice = %+v", last)
+	}
+}
+
+func Test_buildOTLPTraces_cpus(t *testing.T) {
+	t.Parallel()
+	reports := []Report{{
+		Title: "Round-robin on 2 CPUs",
+		Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 0, Stop: 2, CPU: 1}, {PID: 1, Start: 2, Stop: 3, CPU: 1}},
+		Rows:  []Row{{ProcessID: 1}, {ProcessID: 2}},
+	}}
+	got := buildOTLPTraces(reports, time.Unix(0, 0), time.Second, func(n int) string { return "id" })
+	spans := got.ResourceSpans[0].ScopeSpans[0].Spans
+	attribute := func(s otlpSpan, key string) string {
+		for _, a := range s.Attributes {
+			if a.Key != key {
+				continue
+			}
+			switch {
+			case a.Value.IntValue != nil:
+				return *a.Value.IntValue
+			case a.Value.BoolValue != nil:
+				return fmt.Sprint(*a.Value.BoolValue)
+			}
+		}
+		return ""
+	}
+	if got := attribute(spans[0], "scheduler.cpus"); got != "2" {
+		t.Errorf("root scheduler.cpus = %q, want 2", got)
+	}
+	for i, want := range []struct{ cpu, migrated string }{{"0", ""}, {"1", ""}, {"1", "true"}} {
+		s := spans[i+1]
+		if cpu, migrated := attribute(s, "scheduler.cpu"), attribute(s, "scheduler.migrated"); cpu != want.cpu || migrated != want.migrated {
+			t.Errorf("slice %d: scheduler.cpu %q, scheduler.migrated %q, want %q and %q", i, cpu, migrated, want.cpu, want.migrated)
+		}
+	}
+}
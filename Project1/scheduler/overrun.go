@@ -0,0 +1,166 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+//region Overrun handling
+
+// The ways of handling a job that runs past its task's WCET.
+const (
+	// overrunAbort kills the job when it reaches its WCET; it counts as a miss.
+	overrunAbort = "abort"
+	// overrunDemote lets the job finish, but only when no job within its
+	// WCET is ready.
+	overrunDemote = "demote"
+	// overrunSkip lets the job finish at its priority and drops its task's
+	// next release to make up for it.
+	overrunSkip = "skip"
+)
+
+var overrunModes = []string{overrunAbort, overrunDemote, overrunSkip}
+
+// OverrunResult is how a task set fared under one overrun handling mode.
+type OverrunResult struct {
+	Mode                       string
+	Overruns, Aborted, Skipped int
+	Missed                     int
+}
+
+// drawOverruns returns how long each job really needs, by job ID: its WCET
+// or, with probability prob, factor times that rounded up.
+func drawOverruns(jobs []Process, prob, factor float64, rng *rand.Rand) map[int64]int64 {
+	actual := make(map[int64]int64, len(jobs))
+	for _, j := range jobs {
+		actual[j.ProcessID] = j.BurstDuration
+		if rng.Float64() < prob {
+			actual[j.ProcessID] = int64(math.Ceil(float64(j.BurstDuration) * factor))
+		}
+	}
+	return actual
+}
+
+// applyOverrun returns the jobs as they run under mode, given their actual
+// execution times, with the IDs of the jobs that were aborted and the
+// number of releases that were skipped.
+func applyOverrun(jobs []Process, owner map[int64]PeriodicTask, actual map[int64]int64, mode string) ([]Process, map[int64]bool, int) {
+	var (
+		run      []Process
+		aborted  = make(map[int64]bool)
+		skipped  int
+		skipNext = make(map[int64]bool)
+	)
+	for _, j := range jobs {
+		task := owner[j.ProcessID].ID
+		overran := actual[j.ProcessID] > j.BurstDuration
+		switch mode {
+		case overrunAbort:
+			if overran {
+				aborted[j.ProcessID] = true
+			}
+		case overrunSkip:
+			if skipNext[task] {
+				skipNext[task] = false
+				skipped++
+				continue
+			}
+			skipNext[task] = overran
+			j.BurstDuration = actual[j.ProcessID]
+		default:
+			j.BurstDuration = actual[j.ProcessID]
+		}
+		run = append(run, j)
+	}
+	return run, aborted, skipped
+}
+
+// DemotePolicy runs jobs under Inner until they have used their budget,
+// after which they only run when no job within its budget is ready.
+type DemotePolicy struct {
+	Inner Policy
+	// Budget is each job's WCET, by job ID.
+	Budget map[int64]int64
+}
+
+func (p DemotePolicy) overran(t *Task) bool {
+	return t.BurstDuration-t.Remaining >= p.Budget[t.ProcessID]
+}
+
+func (p DemotePolicy) Pick(now int64, running *Task, ready []*Task) *Task {
+	var normal, demoted []*Task
+	for _, t := range ready {
+		if p.overran(t) {
+			demoted = append(demoted, t)
+		} else {
+			normal = append(normal, t)
+		}
+	}
+	if running != nil && p.overran(running) {
+		if len(normal) > 0 {
+			return p.Inner.Pick(now, nil, normal)
+		}
+		return running
+	}
+	if running != nil || len(normal) > 0 {
+		return p.Inner.Pick(now, running, normal)
+	}
+	if len(demoted) > 0 {
+		return demoted[0]
+	}
+	return nil
+}
+
+// compareOverruns simulates the jobs under every overrun handling mode.
+func compareOverruns(jobs []Process, owner map[int64]PeriodicTask, actual map[int64]int64, policy Policy) []OverrunResult {
+	overruns := 0
+	budget := make(map[int64]int64, len(jobs))
+	for _, j := range jobs {
+		budget[j.ProcessID] = j.BurstDuration
+		if actual[j.ProcessID] > j.BurstDuration {
+			overruns++
+		}
+	}
+
+	var results []OverrunResult
+	for _, mode := range overrunModes {
+		run, aborted, skipped := applyOverrun(jobs, owner, actual, mode)
+		p := policy
+		if mode == overrunDemote {
+			p = DemotePolicy{Inner: policy, Budget: budget}
+		}
+		r := simulate(mode, run, p)
+		result := OverrunResult{Mode: mode, Overruns: overruns, Aborted: len(aborted), Skipped: skipped}
+		for _, row := range r.Rows {
+			if aborted[row.ProcessID] || row.Exit > row.Arrival+owner[row.ProcessID].Deadline {
+				result.Missed++
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func outputOverruns(w io.Writer, results []OverrunResult) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Overrun handling", "Overrunning jobs", "Aborted", "Skipped releases", "Deadline misses"})
+	best := 0
+	for i, r := range results {
+		table.Append([]string{
+			r.Mode, fmt.Sprint(r.Overruns), fmt.Sprint(r.Aborted), fmt.Sprint(r.Skipped), fmt.Sprint(r.Missed),
+		})
+		if r.Missed < results[best].Missed {
+			best = i
+		}
+	}
+	table.Render()
+	if len(results) > 0 {
+		_, _ = fmt.Fprintf(w, "Fewest deadline misses: %s\n", results[best].Mode)
+	}
+}
+
+//endregion
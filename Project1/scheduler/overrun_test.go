@@ -0,0 +1,67 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_applyOverrun(t *testing.T) {
+	t.Parallel()
+	tasks := []PeriodicTask{{ID: 1, Period: 4, WCET: 1, Deadline: 4}, {ID: 2, Period: 8, WCET: 2, Deadline: 8}}
+	jobs, owner := releaseJobs(tasks, 16, false, 0, nil)
+	// Jobs 1, 3, 4, 6 are task 1's; 2 and 5 task 2's. Job 1 and job 2 overrun.
+	actual := map[int64]int64{1: 2, 2: 3, 3: 1, 4: 1, 5: 2, 6: 1}
+	tests := []struct {
+		mode        string
+		wantBursts  map[int64]int64
+		wantAborted map[int64]bool
+		wantSkipped int
+	}{
+		{
+			mode:        overrunAbort,
+			wantBursts:  map[int64]int64{1: 1, 2: 2, 3: 1, 4: 1, 5: 2, 6: 1},
+			wantAborted: map[int64]bool{1: true, 2: true},
+		},
+		{
+			mode:        overrunDemote,
+			wantBursts:  map[int64]int64{1: 2, 2: 3, 3: 1, 4: 1, 5: 2, 6: 1},
+			wantAborted: map[int64]bool{},
+		},
+		{
+			mode:        overrunSkip,
+			wantBursts:  map[int64]int64{1: 2, 2: 3, 4: 1, 6: 1},
+			wantAborted: map[int64]bool{},
+			wantSkipped: 2,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.mode, func(t *testing.T) {
+			t.Parallel()
+			run, aborted, skipped := applyOverrun(jobs, owner, actual, tt.mode)
+			bursts := make(map[int64]int64)
+			for _, j := range run {
+				bursts[j.ProcessID] = j.BurstDuration
+			}
+			if !reflect.DeepEqual(bursts, tt.wantBursts) || !reflect.DeepEqual(aborted, tt.wantAborted) || skipped != tt.wantSkipped {
+				t.Errorf("applyOverrun() = %v, %v, %d, want %v, %v, %d",
+					bursts, aborted, skipped, tt.wantBursts, tt.wantAborted, tt.wantSkipped)
+			}
+		})
+	}
+}
+
+func Test_DemotePolicy(t *testing.T) {
+	t.Parallel()
+	// Process 1 overruns its budget of 2 and yields to process 2, which is
+	// less urgent but still within its budget.
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 5, Priority: 1},
+		{ProcessID: 2, BurstDuration: 2, ArrivalTime: 1, Priority: 2},
+	}
+	p := DemotePolicy{Inner: FixedPriorityPolicy{}, Budget: map[int64]int64{1: 2, 2: 2}}
+	want := []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 1, Start: 4, Stop: 7}}
+	if got := simulate("", processes, p).Gantt; !reflect.DeepEqual(got, want) {
+		t.Errorf("Gantt = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,251 @@
+package scheduler
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+//region Page replacement
+
+type (
+	// PagingStep is the state of memory after one page reference.
+	PagingStep struct {
+		Page int64 `json:"page"`
+		// Frames holds the page in each frame; -1 marks an empty frame.
+		Frames []int64 `json:"frames"`
+		Fault  bool    `json:"fault"`
+		// Evicted is the page replaced on this step, or -1.
+		Evicted int64 `json:"evicted"`
+	}
+	// PagingResult is how a replacement algorithm handled a reference string.
+	PagingResult struct {
+		Title  string       `json:"title"`
+		Steps  []PagingStep `json:"steps"`
+		Faults int          `json:"faults"`
+	}
+)
+
+// runPaging implements the paging subcommand:
+//
+//	paging -frames 3 [-json results.json] references.csv
+//
+// references.csv is the page reference string, comma separated on one or
+// more lines. Each algorithm's frame table and fault count is written to w.
+func runPaging(w io.Writer, args ...string) error {
+	fs := flag.NewFlagSet("paging", flag.ContinueOnError)
+	fs.SetOutput(w)
+	frames := fs.Int("frames", 3, "number of page frames")
+	jsonPath := fs.String("json", "", "file to write the results to as JSON")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	if fs.NArg() != 1 || *frames < 1 {
+		return fmt.Errorf("%w: usage: paging -frames N [-json file] references.csv", ErrInvalidArgs)
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("%v: error opening reference string", err)
+	}
+	defer func() { _ = f.Close() }()
+	// A reference string has the same shape as a list of disk requests.
+	refs, err := loadDiskRequests(f)
+	if err != nil {
+		return err
+	}
+
+	results := pageReplacements(refs, *frames)
+	for _, r := range results {
+		outputPaging(w, r)
+	}
+	outputPagingSummary(w, results)
+	if *jsonPath != "" {
+		return writeJSON(*jsonPath, results)
+	}
+	return nil
+}
+
+// pageReplacements runs every page replacement algorithm over refs.
+func pageReplacements(refs []int64, frames int) []PagingResult {
+	return []PagingResult{
+		replacePages("FIFO", refs, frames, victimFIFO),
+		replacePages("LRU", refs, frames, victimLRU),
+		replaceClock(refs, frames),
+		replacePages("Optimal", refs, frames, victimOptimal),
+	}
+}
+
+// pageFrames tracks, per frame, which page it holds and when that page was loaded and last used.
+type pageFrames struct {
+	page   []int64
+	loaded []int
+	used   []int
+}
+
+// victimFunc picks the frame to replace at step i of refs when every frame is full.
+type victimFunc func(m *pageFrames, refs []int64, i int) int
+
+func replacePages(title string, refs []int64, frames int, victim victimFunc) PagingResult {
+	m := &pageFrames{
+		page:   make([]int64, frames),
+		loaded: make([]int, frames),
+		used:   make([]int, frames),
+	}
+	for f := range m.page {
+		m.page[f] = -1
+	}
+
+	r := PagingResult{Title: title}
+	for i, p := range refs {
+		step := PagingStep{Page: p, Evicted: -1}
+		f := indexOf(m.page, p)
+		if f < 0 {
+			step.Fault = true
+			r.Faults++
+			if f = indexOf(m.page, -1); f < 0 {
+				f = victim(m, refs, i)
+				step.Evicted = m.page[f]
+			}
+			m.page[f] = p
+			m.loaded[f] = i
+		}
+		m.used[f] = i
+		step.Frames = append([]int64(nil), m.page...)
+		r.Steps = append(r.Steps, step)
+	}
+	return r
+}
+
+func victimFIFO(m *pageFrames, _ []int64, _ int) int {
+	return argMin(m.loaded)
+}
+
+func victimLRU(m *pageFrames, _ []int64, _ int) int {
+	return argMin(m.used)
+}
+
+// victimOptimal replaces the page used furthest in the future, or never again.
+func victimOptimal(m *pageFrames, refs []int64, i int) int {
+	best, bestNext := 0, -1
+	for f, p := range m.page {
+		next := len(refs)
+		for j := i + 1; j < len(refs); j++ {
+			if refs[j] == p {
+				next = j
+				break
+			}
+		}
+		if next > bestNext {
+			best, bestNext = f, next
+		}
+	}
+	return best
+}
+
+// replaceClock is the second-chance algorithm: the hand skips, and clears
+// the reference bit of, recently used pages.
+func replaceClock(refs []int64, frames int) PagingResult {
+	var (
+		page = make([]int64, frames)
+		ref  = make([]bool, frames)
+		hand int
+		r    = PagingResult{Title: "Clock"}
+	)
+	for f := range page {
+		page[f] = -1
+	}
+	for _, p := range refs {
+		step := PagingStep{Page: p, Evicted: -1}
+		if f := indexOf(page, p); f >= 0 {
+			ref[f] = true
+		} else {
+			step.Fault = true
+			r.Faults++
+			for page[hand] != -1 && ref[hand] {
+				ref[hand] = false
+				hand = (hand + 1) % frames
+			}
+			step.Evicted = page[hand]
+			page[hand] = p
+			ref[hand] = true
+			hand = (hand + 1) % frames
+		}
+		step.Frames = append([]int64(nil), page...)
+		r.Steps = append(r.Steps, step)
+	}
+	return r
+}
+
+func indexOf(values []int64, v int64) int {
+	for i := range values {
+		if values[i] == v {
+			return i
+		}
+	}
+	return -1
+}
+
+func argMin(values []int) int {
+	best := 0
+	for i := range values {
+		if values[i] < values[best] {
+			best = i
+		}
+	}
+	return best
+}
+
+func outputPaging(w io.Writer, r PagingResult) {
+	outputTitle(w, r.Title)
+	table := tablewriter.NewWriter(w)
+	header := []string{"Step", "Page"}
+	if len(r.Steps) > 0 {
+		for f := range r.Steps[0].Frames {
+			header = append(header, fmt.Sprintf("Frame %d", f+1))
+		}
+	}
+	header = append(header, "Fault")
+	table.SetHeader(header)
+	for i, s := range r.Steps {
+		row := []string{fmt.Sprint(i + 1), fmt.Sprint(s.Page)}
+		for _, p := range s.Frames {
+			cell := ""
+			if p >= 0 {
+				cell = fmt.Sprint(p)
+			}
+			row = append(row, cell)
+		}
+		fault := ""
+		if s.Fault {
+			fault = "*"
+			if s.Evicted >= 0 {
+				fault = fmt.Sprintf("* (out %d)", s.Evicted)
+			}
+		}
+		table.Append(append(row, fault))
+	}
+	footer := make([]string, len(header))
+	footer[len(footer)-1] = fmt.Sprintf("Faults\n%d", r.Faults)
+	table.SetFooter(footer)
+	table.Render()
+}
+
+func outputPagingSummary(w io.Writer, results []PagingResult) {
+	_, _ = fmt.Fprintln(w, "Page faults")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Faults", "Fault rate"})
+	for _, r := range results {
+		rate := 0.0
+		if len(r.Steps) > 0 {
+			rate = float64(r.Faults) / float64(len(r.Steps))
+		}
+		table.Append([]string{r.Title, fmt.Sprint(r.Faults), fmt.Sprintf("%.2f", rate)})
+	}
+	table.Render()
+}
+
+//endregion
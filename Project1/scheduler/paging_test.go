@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func Test_pageReplacements(t *testing.T) {
+	t.Parallel()
+	// The textbook reference string.
+	refs := []int64{7, 0, 1, 2, 0, 3, 0, 4, 2, 3, 0, 3, 2, 1, 2, 0, 1, 7, 0, 1}
+	tests := []struct {
+		frames     int
+		wantFaults map[string]int
+	}{
+		{frames: 3, wantFaults: map[string]int{"FIFO": 15, "LRU": 12, "Clock": 14, "Optimal": 9}},
+		{frames: 4, wantFaults: map[string]int{"FIFO": 10, "LRU": 8, "Clock": 9, "Optimal": 8}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(fmt.Sprint(tt.frames, " frames"), func(t *testing.T) {
+			t.Parallel()
+			got := make(map[string]int)
+			for _, r := range pageReplacements(refs, tt.frames) {
+				got[r.Title] = r.Faults
+				if len(r.Steps) != len(refs) {
+					t.Errorf("%s has %d steps, want %d", r.Title, len(r.Steps), len(refs))
+				}
+			}
+			if !reflect.DeepEqual(got, tt.wantFaults) {
+				t.Errorf("faults = %v, want %v", got, tt.wantFaults)
+			}
+		})
+	}
+}
+
+func Test_replacePages_frameTable(t *testing.T) {
+	t.Parallel()
+	r := replacePages("FIFO", []int64{1, 2, 3, 1, 4}, 3, victimFIFO)
+	want := []PagingStep{
+		{Page: 1, Frames: []int64{1, -1, -1}, Fault: true, Evicted: -1},
+		{Page: 2, Frames: []int64{1, 2, -1}, Fault: true, Evicted: -1},
+		{Page: 3, Frames: []int64{1, 2, 3}, Fault: true, Evicted: -1},
+		{Page: 1, Frames: []int64{1, 2, 3}, Evicted: -1},
+		{Page: 4, Frames: []int64{4, 2, 3}, Fault: true, Evicted: 1},
+	}
+	if !reflect.DeepEqual(r.Steps, want) {
+		t.Errorf("steps = %v, want %v", r.Steps, want)
+	}
+}
@@ -0,0 +1,109 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+//region Pareto frontier
+
+// paretoAxes are the radar chart metrics the Pareto frontier is taken over.
+var paretoAxes = radarAxesNamed("Wait", "Response", "Switches", "Fairness")
+
+// radarAxesNamed returns the radar axes with the given names, in that order.
+func radarAxesNamed(names ...string) []radarAxis {
+	axes := make([]radarAxis, 0, len(names))
+	for _, name := range names {
+		for _, a := range radarAxes {
+			if a.Name == name {
+				axes = append(axes, a)
+			}
+		}
+	}
+	return axes
+}
+
+// dominates reports whether a is at least as good as b on every metric
+// and better on at least one.
+func dominates(a, b []float64, axes []radarAxis) bool {
+	better := false
+	for i, axis := range axes {
+		x, y := a[i], b[i]
+		if axis.HigherBetter {
+			x, y = -x, -y
+		}
+		if x > y {
+			return false
+		}
+		if x < y {
+			better = true
+		}
+	}
+	return better
+}
+
+// paretoFrontier measures every report on axes and returns, for each, the
+// indices of the reports that dominate it: none for the Pareto-optimal ones.
+func paretoFrontier(reports []Report, axes []radarAxis) (values [][]float64, dominatedBy [][]int) {
+	values = make([][]float64, len(reports))
+	for i, r := range reports {
+		for _, axis := range axes {
+			values[i] = append(values[i], axis.Value(r))
+		}
+	}
+	dominatedBy = make([][]int, len(reports))
+	for i := range reports {
+		for j := range reports {
+			if dominates(values[j], values[i], axes) {
+				dominatedBy[i] = append(dominatedBy[i], j)
+			}
+		}
+	}
+	return values, dominatedBy
+}
+
+// outputPareto lists every algorithm's metrics, marking those on the
+// Pareto frontier: the ones no other algorithm beats on one metric without
+// losing on another. Each of them is the best choice for some weighting
+// of the metrics.
+func outputPareto(w io.Writer, reports []Report) {
+	values, dominatedBy := paretoFrontier(reports, paretoAxes)
+	_, _ = fmt.Fprintln(w, "Pareto frontier (no algorithm does better on one metric without doing worse on another)")
+	header := []string{"Algorithm"}
+	for _, axis := range paretoAxes {
+		if axis.HigherBetter {
+			header = append(header, axis.Name+" (higher is better)")
+		} else {
+			header = append(header, axis.Name)
+		}
+	}
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(append(header, "Pareto-optimal"))
+	var optimal []string
+	for i, r := range reports {
+		line := []string{r.Title}
+		for _, v := range values[i] {
+			line = append(line, strconv.FormatFloat(math.Round(v*1000)/1000, 'f', -1, 64))
+		}
+		verdict := "yes"
+		if len(dominatedBy[i]) > 0 {
+			var by []string
+			for _, j := range dominatedBy[i] {
+				by = append(by, reports[j].Title)
+			}
+			verdict = "no, dominated by " + strings.Join(by, "; ")
+		} else {
+			optimal = append(optimal, r.Title)
+		}
+		table.Append(append(line, verdict))
+	}
+	table.Render()
+	_, _ = fmt.Fprintf(w, "Pareto-optimal: %s\n", strings.Join(optimal, "; "))
+}
+
+//endregion
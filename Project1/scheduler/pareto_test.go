@@ -0,0 +1,59 @@
+package scheduler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_dominates(t *testing.T) {
+	t.Parallel()
+	axes := []radarAxis{{Name: "Wait"}, {Name: "Fairness", HigherBetter: true}}
+	tests := []struct {
+		name string
+		a, b []float64
+		want bool
+	}{
+		{name: "better on both", a: []float64{1, 0.9}, b: []float64{2, 0.8}, want: true},
+		{name: "better on one", a: []float64{1, 0.8}, b: []float64{2, 0.8}, want: true},
+		{name: "equal", a: []float64{1, 0.8}, b: []float64{1, 0.8}},
+		{name: "trade-off", a: []float64{1, 0.7}, b: []float64{2, 0.8}},
+		{name: "worse", a: []float64{3, 0.7}, b: []float64{2, 0.8}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := dominates(tt.a, tt.b, axes); got != tt.want {
+				t.Errorf("dominates(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_paretoFrontier(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 8}, {ProcessID: 2, ArrivalTime: 2, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 4},
+	}
+	reports := []Report{
+		simulate("fcfs", processes, FCFSPolicy{}),
+		simulate("sjf", processes, SJFPolicy{}),
+		simulate("rr", processes, RRPolicy{Quantum: 2}),
+		simulate("fcfs again", processes, FCFSPolicy{}),
+	}
+	_, dominatedBy := paretoFrontier(reports, paretoAxes)
+	// SJF runs the short job before the medium one, so it waits less than
+	// FCFS with the same switches; round-robin responds sooner than both.
+	want := [][]int{{1}, nil, nil, {1}}
+	if !reflect.DeepEqual(dominatedBy, want) {
+		t.Errorf("paretoFrontier() dominated by %v, want %v", dominatedBy, want)
+	}
+
+	var b strings.Builder
+	outputPareto(&b, reports)
+	if !strings.Contains(b.String(), "Pareto-optimal: sjf; rr\n") {
+		t.Errorf("outputPareto() = %q, want sjf and rr optimal", b.String())
+	}
+}
@@ -0,0 +1,151 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//region Adaptive partitioning
+
+type (
+	// Partition is a group of processes guaranteed a share of the CPU.
+	Partition struct {
+		Name string
+		// Budget is the guaranteed percentage of every window.
+		Budget int64
+		PIDs   map[int64]bool
+	}
+
+	// AdaptivePartitionPolicy is QNX-style adaptive partitioning. Each
+	// partition is guaranteed its budget of CPU over a sliding window of
+	// Window ticks. Every tick the highest priority ready task (lowest
+	// number, then first ready) among the partitions with budget left in the
+	// window runs; if none of them has anything ready, their unused budget
+	// goes to the highest priority ready task of any partition.
+	AdaptivePartitionPolicy struct {
+		Partitions []Partition
+		Window     int64
+		// history is the partition that ran at each recent tick.
+		history []partitionTick
+		// used and borrowed count ticks run within and beyond budget, by partition.
+		used, borrowed []int64
+	}
+
+	partitionTick struct {
+		at        int64
+		partition int
+	}
+)
+
+// partitionOf returns the index of the partition t belongs to; processes in
+// no partition belong to the last.
+func (p *AdaptivePartitionPolicy) partitionOf(t *Task) int {
+	for i, part := range p.Partitions {
+		if part.PIDs[t.ProcessID] {
+			return i
+		}
+	}
+	return len(p.Partitions) - 1
+}
+
+func (p *AdaptivePartitionPolicy) Pick(now int64, running *Task, ready []*Task) *Task {
+	if p.used == nil {
+		p.used = make([]int64, len(p.Partitions))
+		p.borrowed = make([]int64, len(p.Partitions))
+	}
+	for len(p.history) > 0 && p.history[0].at <= now-p.Window {
+		p.history = p.history[1:]
+	}
+	spent := make([]int64, len(p.Partitions))
+	for _, h := range p.history {
+		spent[h.partition]++
+	}
+
+	runnable := ready
+	if running != nil {
+		runnable = append([]*Task{running}, ready...)
+	}
+	higher := func(a, b *Task) bool { return a.Priority < b.Priority }
+	var inBudget []*Task
+	for _, t := range runnable {
+		part := p.partitionOf(t)
+		if spent[part]*100 < p.Partitions[part].Budget*p.Window {
+			inBudget = append(inBudget, t)
+		}
+	}
+
+	pick := minTask(inBudget, higher)
+	if pick == nil {
+		pick = minTask(runnable, higher)
+		if pick == nil {
+			return nil
+		}
+		p.borrowed[p.partitionOf(pick)]++
+	} else {
+		p.used[p.partitionOf(pick)]++
+	}
+	p.history = append(p.history, partitionTick{at: now, partition: p.partitionOf(pick)})
+	return pick
+}
+
+// annotate shows each task's partition and how much CPU each partition
+// consumed within its budget and borrowed from idle partitions.
+func (p *AdaptivePartitionPolicy) annotate(r *Report, tasks []*Task) {
+	col := Column{Header: "Partition"}
+	for _, t := range tasks {
+		col.Values = append(col.Values, p.Partitions[p.partitionOf(t)].Name)
+	}
+	r.Columns = append(r.Columns, col)
+
+	var total int64
+	for i := range p.used {
+		total += p.used[i] + p.borrowed[i]
+	}
+	for i, part := range p.Partitions {
+		if total == 0 {
+			break
+		}
+		r.Notes = append(r.Notes, fmt.Sprintf(
+			"Partition %s (budget %d%%): ran %d ticks, %.1f%% of CPU time, %d of them borrowed from idle partitions",
+			part.Name, part.Budget, p.used[i]+p.borrowed[i],
+			100*float64(p.used[i]+p.borrowed[i])/float64(total), p.borrowed[i]))
+	}
+}
+
+// parsePartitions parses partitions given as "<name>=<budget>:<pid>,<pid>"
+// separated by semicolons. Processes in none of them go to a "system"
+// partition with the rest of the budget.
+func parsePartitions(spec string) ([]Partition, error) {
+	var (
+		partitions []Partition
+		total      int64
+	)
+	for _, f := range strings.Split(spec, ";") {
+		if f = strings.TrimSpace(f); f == "" {
+			continue
+		}
+		nameBudget := strings.SplitN(f, ":", 2)
+		kv := strings.SplitN(nameBudget[0], "=", 2)
+		if len(kv) != 2 || len(nameBudget) != 2 {
+			return nil, fmt.Errorf("%w: want <name>=<budget>:<pids>, got %q", ErrInvalidArgs, f)
+		}
+		budget, err := strconv.ParseInt(kv[1], 10, 64)
+		if err != nil || budget < 0 {
+			return nil, fmt.Errorf("%w: bad budget %q", ErrInvalidArgs, kv[1])
+		}
+		pids, err := parsePIDs(nameBudget[1])
+		if err != nil {
+			return nil, err
+		}
+		partitions = append(partitions, Partition{Name: kv[0], Budget: budget, PIDs: pids})
+		total += budget
+	}
+	if total > 100 {
+		return nil, fmt.Errorf("%w: partition budgets add up to %d%%", ErrInvalidArgs, total)
+	}
+
+	return append(partitions, Partition{Name: "system", Budget: 100 - total}), nil
+}
+
+//endregion
@@ -0,0 +1,47 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_AdaptivePartitionPolicy(t *testing.T) {
+	t.Parallel()
+	parts, err := parsePartitions("a=75:1;b=25:2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		processes []Process
+		wantGantt []TimeSlice
+	}{
+		{
+			name: "budgets are enforced over the window",
+			// Process 2 has the better priority but only a quarter of each 4 tick window.
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 6, Priority: 2},
+				{ProcessID: 2, BurstDuration: 2, Priority: 1},
+			},
+			wantGantt: []TimeSlice{{PID: 2, Start: 0, Stop: 1}, {PID: 1, Start: 1, Stop: 4}, {PID: 2, Start: 4, Stop: 5}, {PID: 1, Start: 5, Stop: 8}},
+		},
+		{
+			name: "an idle partition's budget is redistributed",
+			processes: []Process{
+				{ProcessID: 2, BurstDuration: 3, Priority: 1},
+			},
+			wantGantt: []TimeSlice{{PID: 2, Start: 0, Stop: 3}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p := &AdaptivePartitionPolicy{Partitions: parts, Window: 4}
+			r := simulate(tt.name, tt.processes, p)
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+		})
+	}
+}
@@ -0,0 +1,463 @@
+package scheduler
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+//region Periodic real-time tasks
+
+type (
+	// PeriodicTask releases a job of up to WCET ticks every Period ticks,
+	// each due Deadline ticks after its release. A sporadic task's jobs are
+	// instead released at least Period ticks apart.
+	PeriodicTask struct {
+		ID       int64
+		Period   int64
+		WCET     int64
+		Deadline int64
+		Sporadic bool
+	}
+
+	// admissionTest decides whether a task set is schedulable from its
+	// utilizations (densities, when deadlines are shorter than periods).
+	admissionTest struct {
+		Name string
+		// Bound describes the test for n tasks.
+		Bound  func(n int) string
+		Admits func(us []float64) bool
+	}
+)
+
+// admissionTests are the utilization-based tests, by -admit name.
+var admissionTests = map[string]admissionTest{
+	// Liu and Layland: rate monotonic meets every deadline if U <= n(2^(1/n) - 1).
+	"ll": {
+		Name:  "Liu & Layland (rate monotonic)",
+		Bound: func(n int) string { return fmt.Sprintf("U <= %.4f", liuLaylandBound(n)) },
+		Admits: func(us []float64) bool {
+			return totalUtilization(us) <= liuLaylandBound(len(us))+1e-9
+		},
+	},
+	// Bini, Buttazzo and Buttazzo: rate monotonic meets every deadline if Π(Ui + 1) <= 2.
+	"hyperbolic": {
+		Name:  "hyperbolic bound (rate monotonic)",
+		Bound: func(int) string { return "Π(Ui + 1) <= 2" },
+		Admits: func(us []float64) bool {
+			product := 1.0
+			for _, u := range us {
+				product *= u + 1
+			}
+			return product <= 2+1e-9
+		},
+	},
+	// none admits every task, to see what happens when the set is overloaded.
+	"none": {
+		Name:   "none",
+		Bound:  func(int) string { return "every task admitted" },
+		Admits: func([]float64) bool { return true },
+	},
+	// EDF meets every deadline if U <= 1.
+	"edf": {
+		Name:   "EDF",
+		Bound:  func(int) string { return "U <= 1" },
+		Admits: func(us []float64) bool { return totalUtilization(us) <= 1+1e-9 },
+	},
+}
+
+func liuLaylandBound(n int) float64 {
+	if n == 0 {
+		return 1
+	}
+	return float64(n) * (math.Pow(2, 1/float64(n)) - 1)
+}
+
+func totalUtilization(xs []float64) float64 {
+	var total float64
+	for _, x := range xs {
+		total += x
+	}
+	return total
+}
+
+// utilization is the share of the CPU the task needs; with a deadline
+// shorter than its period it is the density WCET / deadline, which keeps
+// the tests sufficient.
+func (t PeriodicTask) utilization() float64 {
+	return float64(t.WCET) / float64(t.Deadline)
+}
+
+// runPeriodic implements the periodic subcommand:
+//
+//	periodic [-admit ll|hyperbolic|edf|none] [-policy rm|dm|edf] [-horizon N] [-seed N] [-slack N] [-overrun-prob P] [-overrun-factor F]
+//	         [-aperiodic workload.csv [-server cbs|deferrable|background] [-server-budget Q] [-server-period T]] tasks.csv
+//
+// tasks.csv has a line per task of "<id>,<period>,<wcet>[,<deadline>[,sporadic]]",
+// where the deadline is relative to each release and defaults to the
+// period, and a sporadic task's period is its minimum inter-arrival time.
+// Tasks are admitted in file order, each only if the admission test still
+// passes with it added; the rest are rejected. The admitted tasks then
+// release jobs needing their full WCET over the hyperperiod, or the
+// horizon if that is shorter: periodic tasks every period, and sporadic
+// ones after their minimum inter-arrival time plus a random gap averaging
+// slack ticks. The jobs are scheduled rate monotonic, deadline monotonic
+// or earliest deadline first. With -overrun-prob, jobs may also need more
+// than their WCET, and the ways of handling that are compared. With
+// -aperiodic, the jobs of a workload CSV are served alongside the periodic
+// ones by a server under EDF instead.
+func runPeriodic(w io.Writer, args ...string) error {
+	fs := flag.NewFlagSet("periodic", flag.ContinueOnError)
+	fs.SetOutput(w)
+	admit := fs.String("admit", "ll", "admission test: ll (Liu & Layland), hyperbolic, edf or none")
+	policy := fs.String("policy", "rm", "rm (rate monotonic), dm (deadline monotonic) or edf (earliest deadline first)")
+	horizon := fs.Int64("horizon", 10000, "release jobs for at most this many ticks when the hyperperiod is longer")
+	seed := fs.Int64("seed", 1, "random seed for sporadic releases")
+	overrunProb := fs.Float64("overrun-prob", 0, "probability that a job runs past its WCET; compares the ways of handling it")
+	overrunFactor := fs.Float64("overrun-factor", 1.5, "how many times its WCET an overrunning job needs")
+	aperiodicPath := fs.String("aperiodic", "", "workload CSV of aperiodic jobs to serve alongside the periodic ones under EDF")
+	server := fs.String("server", serverCBS, "server for -aperiodic jobs: cbs, deferrable or background")
+	serverBudget := fs.Int64("server-budget", 2, "ticks of budget the server gets every -server-period")
+	serverPeriod := fs.Int64("server-period", 10, "the server's period")
+	slack := fs.Float64("slack", 0, "average extra gap, in ticks, between a sporadic task's releases beyond its minimum; 0 releases them as often as allowed")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	if fs.NArg() != 1 || *horizon <= 0 || *slack < 0 || *overrunProb < 0 || *overrunProb > 1 || *overrunFactor < 1 ||
+		*serverBudget <= 0 || *serverPeriod < *serverBudget {
+		return fmt.Errorf("%w: usage: periodic [flags] tasks.csv", ErrInvalidArgs)
+	}
+	test, ok := admissionTests[strings.ToLower(*admit)]
+	if !ok {
+		return fmt.Errorf("%w: unknown admission test %q", ErrInvalidArgs, *admit)
+	}
+	var (
+		title string
+		jp    Policy = FixedPriorityPolicy{}
+	)
+	switch strings.ToLower(*policy) {
+	case "rm":
+		title = "Rate monotonic"
+	case "dm":
+		title = "Deadline monotonic"
+	case "edf":
+		title, jp = "Earliest deadline first", EDFPolicy{}
+	default:
+		return fmt.Errorf("%w: unknown policy %q", ErrInvalidArgs, *policy)
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("%v: error opening task set", err)
+	}
+	defer func() { _ = f.Close() }()
+	tasks, err := loadPeriodicTasks(f)
+	if err != nil {
+		return err
+	}
+
+	admitted, rejected := admitTasks(tasks, test)
+	outputAdmission(w, test, tasks, admitted, rejected)
+	if len(admitted) == 0 {
+		return nil
+	}
+
+	span := hyperperiod(admitted)
+	if span > *horizon {
+		_, _ = fmt.Fprintf(w, "Hyperperiod %d is longer than the horizon; releasing jobs for %d ticks\n", span, *horizon)
+		span = *horizon
+	}
+	jobs, owner := releaseJobs(admitted, span, strings.EqualFold(*policy, "dm"), *slack, rand.New(rand.NewSource(*seed)))
+	if *aperiodicPath != "" {
+		return runServer(w, jobs, owner, admitted, *aperiodicPath, strings.ToLower(*server), *serverBudget, *serverPeriod)
+	}
+	r := simulate(fmt.Sprintf("%s over %d ticks", title, span), jobs, jp)
+	addJobColumns(&r, owner)
+	outputReport(w, r)
+	outputPeriodicResponse(w, admitted, periodicResponses(r, owner))
+	if *overrunProb > 0 {
+		actual := drawOverruns(jobs, *overrunProb, *overrunFactor, rand.New(rand.NewSource(*seed)))
+		outputOverruns(w, compareOverruns(jobs, owner, actual, jp))
+	}
+	return nil
+}
+
+// loadPeriodicTasks reads a task set CSV.
+func loadPeriodicTasks(r io.Reader) ([]PeriodicTask, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading CSV", err)
+	}
+
+	tasks := make([]PeriodicTask, len(rows))
+	for i, row := range rows {
+		if len(row) == 5 {
+			switch strings.ToLower(strings.TrimSpace(row[4])) {
+			case "sporadic":
+				tasks[i].Sporadic = true
+			case "periodic", "":
+			default:
+				return nil, fmt.Errorf("%w: line %d: want periodic or sporadic, got %q", ErrInvalidArgs, i+1, row[4])
+			}
+			row = row[:4]
+		}
+		if len(row) < 3 || len(row) > 4 {
+			return nil, fmt.Errorf("%w: line %d: want ID, period, WCET and optionally deadline and kind", ErrInvalidArgs, i+1)
+		}
+		fields := []*int64{&tasks[i].ID, &tasks[i].Period, &tasks[i].WCET, &tasks[i].Deadline}
+		for j := range row {
+			v, err := strconv.ParseInt(strings.TrimSpace(row[j]), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d", err, i+1)
+			}
+			*fields[j] = v
+		}
+		if tasks[i].Deadline == 0 {
+			tasks[i].Deadline = tasks[i].Period
+		}
+		if tasks[i].Period <= 0 || tasks[i].WCET <= 0 || tasks[i].Deadline <= 0 {
+			return nil, fmt.Errorf("%w: line %d: period, WCET and deadline must be positive", ErrInvalidArgs, i+1)
+		}
+		if tasks[i].Deadline > tasks[i].Period {
+			return nil, fmt.Errorf("%w: line %d: deadline beyond the period is not supported", ErrInvalidArgs, i+1)
+		}
+	}
+	return tasks, nil
+}
+
+// admitTasks admits tasks in order, rejecting each one that would make the
+// admitted set fail test.
+func admitTasks(tasks []PeriodicTask, test admissionTest) (admitted, rejected []PeriodicTask) {
+	var us []float64
+	for _, t := range tasks {
+		if test.Admits(append(us, t.utilization())) {
+			us = append(us, t.utilization())
+			admitted = append(admitted, t)
+		} else {
+			rejected = append(rejected, t)
+		}
+	}
+	return admitted, rejected
+}
+
+// runServer schedules the periodic jobs and the aperiodic workload at path
+// under EDF with the aperiodic jobs in a server, and compares the servers.
+func runServer(w io.Writer, jobs []Process, owner map[int64]PeriodicTask, tasks []PeriodicTask, path, kind string, budget, period int64) error {
+	known := false
+	for _, k := range serverKinds {
+		known = known || k == kind
+	}
+	if !known {
+		return fmt.Errorf("%w: unknown server %q", ErrInvalidArgs, kind)
+	}
+	all, aperiodic, err := loadAperiodic(path, jobs)
+	if err != nil {
+		return err
+	}
+
+	r := simulate(fmt.Sprintf("EDF with a %s server (budget %d every %d)", kind, budget, period), all,
+		&ServerPolicy{Kind: kind, Budget: budget, Period: period, Aperiodic: aperiodic})
+	addJobColumns(&r, owner)
+	outputReport(w, r)
+	outputPeriodicResponse(w, tasks, periodicResponses(r, owner))
+	outputServers(w, all, aperiodic, owner, budget, period)
+	return nil
+}
+
+// hyperperiod is the least common multiple of the tasks' periods, after
+// which their releases repeat.
+func hyperperiod(tasks []PeriodicTask) int64 {
+	l := int64(1)
+	for _, t := range tasks {
+		a, b := l, t.Period
+		for b != 0 {
+			a, b = b, a%b
+		}
+		l = l / a * t.Period
+	}
+	return l
+}
+
+// releaseJobs returns a process for every job the tasks release before
+// span, with its absolute deadline, and the task each job belongs to by
+// job ID. Sporadic tasks wait a random extra gap, exponentially
+// distributed with mean slack and drawn from rng, on top of their minimum
+// inter-arrival time. A job's priority is its task's period, or relative
+// deadline when deadlineMonotonic, so FixedPriorityPolicy runs shorter
+// ones first.
+func releaseJobs(tasks []PeriodicTask, span int64, deadlineMonotonic bool, slack float64, rng *rand.Rand) ([]Process, map[int64]PeriodicTask) {
+	next := make([]int64, len(tasks))
+	var jobs []Process
+	owner := make(map[int64]PeriodicTask)
+	for release := int64(0); release < span; release++ {
+		for i, t := range tasks {
+			if next[i] != release {
+				continue
+			}
+			next[i] += t.Period
+			if t.Sporadic && slack > 0 {
+				next[i] += int64(math.Round(rng.ExpFloat64() * slack))
+			}
+			priority := t.Period
+			if deadlineMonotonic {
+				priority = t.Deadline
+			}
+			id := int64(len(jobs) + 1)
+			jobs = append(jobs, Process{
+				ProcessID:     id,
+				ArrivalTime:   release,
+				BurstDuration: t.WCET,
+				Priority:      priority,
+				Deadline:      release + t.Deadline,
+			})
+			owner[id] = t
+		}
+	}
+	return jobs, owner
+}
+
+// FixedPriorityPolicy is preemptive fixed-priority scheduling: the ready
+// task with the lowest priority number runs, preempting the running task
+// as soon as a more urgent one is ready. Equal priorities run in the order
+// they became ready.
+type FixedPriorityPolicy struct{}
+
+func (FixedPriorityPolicy) Pick(_ int64, running *Task, ready []*Task) *Task {
+	best := minTask(ready, func(a, b *Task) bool { return a.Priority < b.Priority })
+	if running != nil && (best == nil || running.Priority <= best.Priority) {
+		return running
+	}
+	return best
+}
+
+// EDFPolicy is preemptive earliest deadline first: the ready task with the
+// earliest absolute deadline runs, preempting the running task when a more
+// urgent one is ready. Tasks without a deadline run only when no task with
+// one is ready.
+type EDFPolicy struct{}
+
+func (EDFPolicy) Pick(_ int64, running *Task, ready []*Task) *Task {
+	earlier := func(a, b *Task) bool {
+		if a.Deadline == 0 || b.Deadline == 0 {
+			return b.Deadline == 0 && a.Deadline != 0
+		}
+		return a.Deadline < b.Deadline
+	}
+	best := minTask(ready, earlier)
+	if running != nil && (best == nil || !earlier(best, running)) {
+		return running
+	}
+	return best
+}
+
+// addJobColumns adds which task each job belongs to and its deadline;
+// jobs of no task are aperiodic.
+func addJobColumns(r *Report, owner map[int64]PeriodicTask) {
+	task, deadline := Column{Header: "Task"}, Column{Header: "Deadline"}
+	for _, row := range r.Rows {
+		t, ok := owner[row.ProcessID]
+		if !ok {
+			task.Values = append(task.Values, "aperiodic")
+			deadline.Values = append(deadline.Values, "-")
+			continue
+		}
+		task.Values = append(task.Values, fmt.Sprint(t.ID))
+		d := row.Arrival + t.Deadline
+		if row.Exit > d {
+			deadline.Values = append(deadline.Values, fmt.Sprintf("%d missed", d))
+		} else {
+			deadline.Values = append(deadline.Values, fmt.Sprint(d))
+		}
+	}
+	r.Columns = append(r.Columns, task, deadline)
+}
+
+// PeriodicResponse is how one task's jobs fared.
+type PeriodicResponse struct {
+	Jobs, Missed int
+	// Worst is the longest time from a job's release to its completion.
+	Worst int64
+}
+
+// periodicResponses collects each task's worst-case response time and
+// deadline misses from a simulation of its jobs, by task ID.
+func periodicResponses(r Report, owner map[int64]PeriodicTask) map[int64]PeriodicResponse {
+	responses := make(map[int64]PeriodicResponse)
+	for _, row := range r.Rows {
+		t, ok := owner[row.ProcessID]
+		if !ok {
+			continue
+		}
+		resp := responses[t.ID]
+		resp.Jobs++
+		if row.Turnaround > resp.Worst {
+			resp.Worst = row.Turnaround
+		}
+		if row.Exit > row.Arrival+t.Deadline {
+			resp.Missed++
+		}
+		responses[t.ID] = resp
+	}
+	return responses
+}
+
+func outputPeriodicResponse(w io.Writer, tasks []PeriodicTask, responses map[int64]PeriodicResponse) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Task", "Period", "WCET", "Deadline", "Jobs", "Worst-case response", "Deadline misses"})
+	for _, t := range tasks {
+		resp := responses[t.ID]
+		table.Append([]string{
+			fmt.Sprint(t.ID), fmt.Sprint(t.Period), fmt.Sprint(t.WCET), fmt.Sprint(t.Deadline),
+			fmt.Sprint(resp.Jobs), fmt.Sprint(resp.Worst), fmt.Sprint(resp.Missed),
+		})
+	}
+	table.Render()
+}
+
+func outputAdmission(w io.Writer, test admissionTest, tasks, admitted, rejected []PeriodicTask) {
+	isRejected := make(map[int64]bool)
+	for _, t := range rejected {
+		isRejected[t.ID] = true
+	}
+	var us []float64
+	for _, t := range admitted {
+		us = append(us, t.utilization())
+	}
+
+	_, _ = fmt.Fprintf(w, "Admission test: %s, %s\n", test.Name, test.Bound(len(admitted)))
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "Period", "WCET", "Deadline", "Utilization", "Admitted"})
+	for _, t := range tasks {
+		verdict := "yes"
+		if isRejected[t.ID] {
+			verdict = "rejected"
+		}
+		table.Append([]string{
+			fmt.Sprint(t.ID), fmt.Sprint(t.Period), fmt.Sprint(t.WCET), fmt.Sprint(t.Deadline),
+			fmt.Sprintf("%.3f", t.utilization()), verdict,
+		})
+	}
+	table.SetFooter([]string{"", "", "", "Admitted", fmt.Sprintf("%.3f", totalUtilization(us)), fmt.Sprintf("%d of %d", len(admitted), len(tasks))})
+	table.Render()
+
+	if len(rejected) > 0 {
+		ids := make([]int64, len(rejected))
+		for i, t := range rejected {
+			ids[i] = t.ID
+		}
+		_, _ = fmt.Fprintf(w, "Rejected before simulation: %s\n", formatIDs(ids))
+	}
+}
+
+//endregion
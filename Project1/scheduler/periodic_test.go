@@ -0,0 +1,243 @@
+package scheduler
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_loadPeriodicTasks(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		in      string
+		want    []PeriodicTask
+		wantErr bool
+	}{
+		{
+			name: "implicit and constrained deadlines",
+			in:   "# id,period,wcet,deadline\n1,4,1\n2,10,3,8\n",
+			want: []PeriodicTask{{ID: 1, Period: 4, WCET: 1, Deadline: 4}, {ID: 2, Period: 10, WCET: 3, Deadline: 8}},
+		},
+		{
+			name: "sporadic",
+			in:   "1,4,1,4,sporadic\n2,5,2,5,periodic\n",
+			want: []PeriodicTask{{ID: 1, Period: 4, WCET: 1, Deadline: 4, Sporadic: true}, {ID: 2, Period: 5, WCET: 2, Deadline: 5}},
+		},
+		{name: "unknown kind", in: "1,4,1,4,aperiodic\n", wantErr: true},
+		{name: "missing WCET", in: "1,4\n", wantErr: true},
+		{name: "zero period", in: "1,0,1\n", wantErr: true},
+		{name: "deadline past period", in: "1,4,1,5\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadPeriodicTasks(strings.NewReader(tt.in))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadPeriodicTasks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadPeriodicTasks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_admitTasks(t *testing.T) {
+	t.Parallel()
+	tasks := []PeriodicTask{
+		{ID: 1, Period: 4, WCET: 1, Deadline: 4},
+		{ID: 2, Period: 5, WCET: 2, Deadline: 5},
+		{ID: 3, Period: 10, WCET: 3, Deadline: 10},
+		{ID: 4, Period: 20, WCET: 2, Deadline: 20},
+	}
+	tests := []struct {
+		test         string
+		wantRejected []int64
+	}{
+		// 0.25 + 0.4 + 0.3 = 0.95 is over both rate monotonic bounds, but
+		// adding 0.1 instead keeps under them.
+		{test: "ll", wantRejected: []int64{3}},
+		{test: "hyperbolic", wantRejected: []int64{3}},
+		// 0.95 + 0.1 is over 1.
+		{test: "edf", wantRejected: []int64{4}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.test, func(t *testing.T) {
+			t.Parallel()
+			admitted, rejected := admitTasks(tasks, admissionTests[tt.test])
+			var ids []int64
+			for _, r := range rejected {
+				ids = append(ids, r.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantRejected) || len(admitted)+len(rejected) != len(tasks) {
+				t.Errorf("rejected %v, want %v", ids, tt.wantRejected)
+			}
+		})
+	}
+}
+
+func Test_admissionTests_hyperbolicDominates(t *testing.T) {
+	t.Parallel()
+	// U = 0.6 + 0.25 = 0.85 is over the Liu & Layland bound for two tasks,
+	// 0.828, yet (1.6)(1.25) = 2 passes the hyperbolic bound.
+	us := []float64{0.6, 0.25}
+	if admissionTests["ll"].Admits(us) {
+		t.Errorf("Liu & Layland admitted U = 0.85 for two tasks")
+	}
+	if !admissionTests["hyperbolic"].Admits(us) {
+		t.Errorf("hyperbolic bound rejected (1.6)(1.25) = 2")
+	}
+}
+
+func Test_hyperperiod(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		periods []int64
+		want    int64
+	}{
+		{name: "one", periods: []int64{7}, want: 7},
+		{name: "coprime", periods: []int64{4, 5}, want: 20},
+		{name: "shared factors", periods: []int64{4, 6, 10}, want: 60},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var tasks []PeriodicTask
+			for _, p := range tt.periods {
+				tasks = append(tasks, PeriodicTask{Period: p})
+			}
+			if got := hyperperiod(tasks); got != tt.want {
+				t.Errorf("hyperperiod() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_periodicResponses(t *testing.T) {
+	t.Parallel()
+	tasks := []PeriodicTask{
+		{ID: 1, Period: 4, WCET: 1, Deadline: 4},
+		{ID: 2, Period: 5, WCET: 2, Deadline: 5},
+		{ID: 3, Period: 10, WCET: 3, Deadline: 10},
+		{ID: 4, Period: 20, WCET: 2, Deadline: 20},
+	}
+	tests := []struct {
+		name string
+		want map[int64]PeriodicResponse
+	}{
+		{
+			// U = 1.05: the longest period task misses its deadline.
+			name: "rate monotonic",
+			want: map[int64]PeriodicResponse{
+				1: {Jobs: 5, Worst: 1},
+				2: {Jobs: 4, Worst: 3},
+				3: {Jobs: 2, Worst: 10},
+				4: {Jobs: 1, Worst: 21, Missed: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			jobs, owner := releaseJobs(tasks, hyperperiod(tasks), false, 0, nil)
+			if len(jobs) != 12 {
+				t.Fatalf("released %d jobs, want 12", len(jobs))
+			}
+			r := simulate(tt.name, jobs, FixedPriorityPolicy{})
+			if got := periodicResponses(r, owner); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("periodicResponses() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_FixedPriorityPolicy(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 4, ArrivalTime: 0, Priority: 3},
+		{ProcessID: 2, BurstDuration: 2, ArrivalTime: 1, Priority: 1},
+		{ProcessID: 3, BurstDuration: 1, ArrivalTime: 2, Priority: 3},
+	}
+	want := []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 3}, {PID: 1, Start: 3, Stop: 6}, {PID: 3, Start: 6, Stop: 7}}
+	if got := simulate("", processes, FixedPriorityPolicy{}).Gantt; !reflect.DeepEqual(got, want) {
+		t.Errorf("Gantt = %v, want %v", got, want)
+	}
+}
+
+func Test_releaseJobs_sporadic(t *testing.T) {
+	t.Parallel()
+	tasks := []PeriodicTask{{ID: 1, Period: 4, WCET: 1, Deadline: 4, Sporadic: true}, {ID: 2, Period: 5, WCET: 1, Deadline: 5}}
+	tests := []struct {
+		name  string
+		slack float64
+	}{
+		{name: "as often as allowed", slack: 0},
+		{name: "bursty", slack: 3},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			jobs, owner := releaseJobs(tasks, 200, false, tt.slack, rand.New(rand.NewSource(9)))
+			again, _ := releaseJobs(tasks, 200, false, tt.slack, rand.New(rand.NewSource(9)))
+			if !reflect.DeepEqual(jobs, again) {
+				t.Errorf("the same seed released different jobs")
+			}
+			last := map[int64]int64{}
+			sporadic, periodic := 0, 0
+			for _, j := range jobs {
+				task := owner[j.ProcessID]
+				if prev, ok := last[task.ID]; ok {
+					gap := j.ArrivalTime - prev
+					if gap < task.Period || !task.Sporadic && gap != task.Period || tt.slack == 0 && gap != task.Period {
+						t.Errorf("task %d released %d after its previous job", task.ID, gap)
+					}
+				}
+				last[task.ID] = j.ArrivalTime
+				if task.Sporadic {
+					sporadic++
+				} else {
+					periodic++
+				}
+			}
+			if periodic != 40 || tt.slack == 0 && sporadic != 50 || tt.slack > 0 && sporadic >= 50 {
+				t.Errorf("released %d sporadic and %d periodic jobs", sporadic, periodic)
+			}
+		})
+	}
+}
+
+func Test_EDFPolicy(t *testing.T) {
+	t.Parallel()
+	// U = 2/5 + 4/7 = 0.97: schedulable by EDF but not rate monotonic.
+	tasks := []PeriodicTask{{ID: 1, Period: 5, WCET: 2, Deadline: 5}, {ID: 2, Period: 7, WCET: 4, Deadline: 7}}
+	tests := []struct {
+		name       string
+		policy     Policy
+		wantMissed int
+	}{
+		{name: "rate monotonic", policy: FixedPriorityPolicy{}, wantMissed: 1},
+		{name: "EDF", policy: EDFPolicy{}, wantMissed: 0},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			jobs, owner := releaseJobs(tasks, hyperperiod(tasks), false, 0, nil)
+			missed := 0
+			for _, resp := range periodicResponses(simulate(tt.name, jobs, tt.policy), owner) {
+				missed += resp.Missed
+			}
+			if missed != tt.wantMissed {
+				t.Errorf("%d deadlines missed, want %d", missed, tt.wantMissed)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+//region Preemptive priority
+
+// PreemptivePriorityPolicy runs the task with the lowest priority number,
+// preempting the running task as soon as one with a lower number arrives.
+// Ties keep the running task, then go to the earliest in the ready queue.
+type PreemptivePriorityPolicy struct{}
+
+func (PreemptivePriorityPolicy) Pick(_ int64, running *Task, ready []*Task) *Task {
+	best := minTask(ready, func(a, b *Task) bool { return a.Priority < b.Priority })
+	if running != nil && (best == nil || running.Priority <= best.Priority) {
+		return running
+	}
+	return best
+}
+
+func (PreemptivePriorityPolicy) auditKey() (string, func(*Task) float64) {
+	return "priority", func(t *Task) float64 { return float64(t.Priority) }
+}
+
+func (PreemptivePriorityPolicy) preemptive() {}
+
+// annotate adds the times each task ran, from which its wait follows, and
+// lists the preemption points.
+func (PreemptivePriorityPolicy) annotate(r *Report, tasks []*Task) {
+	ran := make(map[int64][]string)
+	for _, s := range r.Gantt {
+		if s.Overhead {
+			continue
+		}
+		ran[s.PID] = append(ran[s.PID], fmt.Sprintf("%d-%d", s.Start, s.Stop))
+	}
+	points := preemptionPoints(r.Gantt, tasks)
+	preempted := make(map[int64]int)
+	var notes []string
+	for _, p := range points {
+		preempted[p.Victim]++
+		notes = append(notes, fmt.Sprintf("t=%d: %d preempts %d", p.Time, p.By, p.Victim))
+	}
+
+	ranCol := Column{Header: "Ran"}
+	preemptedCol := Column{Header: "Preempted", Footer: fmt.Sprintf("Total\n%d", len(points))}
+	for _, t := range tasks {
+		ranCol.Values = append(ranCol.Values, strings.Join(ran[t.ProcessID], ", "))
+		preemptedCol.Values = append(preemptedCol.Values, fmt.Sprint(preempted[t.ProcessID]))
+	}
+	r.Columns = append(r.Columns, ranCol, preemptedCol)
+	if len(notes) > 0 {
+		r.Notes = append(r.Notes, "Preemption points: "+strings.Join(notes, ", "))
+	}
+}
+
+// preemption is a task losing the CPU to another before it finished.
+type preemption struct {
+	Time, Victim, By int64
+}
+
+// preemptionPoints finds the preemptions in a single CPU schedule: every
+// slice of an unfinished task followed straight away by another task's, or
+// by context switch overhead and then another task's. Each is timed when
+// the preempted task stopped.
+func preemptionPoints(gantt []TimeSlice, tasks []*Task) []preemption {
+	exit := make(map[int64]int64, len(tasks))
+	for _, t := range tasks {
+		exit[t.ProcessID] = t.Exit
+	}
+	var points []preemption
+	// prev is the last task's slice and until when the CPU has been busy
+	// since, switching.
+	var prev *TimeSlice
+	var until int64
+	for i := range gantt {
+		s := &gantt[i]
+		if prev != nil && s.Start != until {
+			prev = nil
+		}
+		if s.Overhead {
+			until = s.Stop
+			continue
+		}
+		if prev != nil && prev.PID != s.PID && exit[prev.PID] > prev.Stop {
+			points = append(points, preemption{Time: prev.Stop, Victim: prev.PID, By: s.PID})
+		}
+		prev, until = s, s.Stop
+	}
+	return points
+}
+
+// PreemptivePrioritySchedule outputs the preemptive priority schedule of
+// processes as a Gantt chart and a table of timing.
+func PreemptivePrioritySchedule(w io.Writer, title string, processes []Process) {
+	outputReport(w, PreemptivePriority(title, processes))
+}
+
+// PreemptivePriority schedules processes by priority, one tick at a time,
+// so a process is preempted whenever a more important one arrives.
+func PreemptivePriority(title string, processes []Process) Report {
+	return simulate(title, processes, PreemptivePriorityPolicy{})
+}
+
+//endregion
@@ -0,0 +1,115 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPreemptivePriority(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		processes   []Process
+		wantGantt   []TimeSlice
+		wantWait    []int64
+		wantColumns []Column
+		wantNotes   []string
+	}{
+		{
+			name: "preempts on a more important arrival",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 5, Priority: 3}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Priority: 1},
+				{ProcessID: 3, ArrivalTime: 2, BurstDuration: 2, Priority: 2}, {ProcessID: 4, ArrivalTime: 3, BurstDuration: 1, Priority: 0},
+			},
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 3}, {PID: 4, Start: 3, Stop: 4}, {PID: 2, Start: 4, Stop: 5}, {PID: 3, Start: 5, Stop: 7}, {PID: 1, Start: 7, Stop: 11}},
+			wantWait:  []int64{6, 1, 3, 0},
+			wantColumns: []Column{
+				{Header: "Ran", Values: []string{"0-1, 7-11", "1-3, 4-5", "5-7", "3-4"}},
+				{Header: "Preempted", Values: []string{"1", "1", "0", "0"}, Footer: "Total\n2"},
+			},
+			wantNotes: []string{"Preemption points: t=1: 2 preempts 1, t=3: 4 preempts 2"},
+		},
+		{
+			name: "equal priority waits its turn",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 3, Priority: 1}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 1, Priority: 1},
+			},
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 4}},
+			wantWait:  []int64{0, 2},
+			wantColumns: []Column{
+				{Header: "Ran", Values: []string{"0-3", "3-4"}},
+				{Header: "Preempted", Values: []string{"0", "0"}, Footer: "Total\n0"},
+			},
+		},
+		{
+			name: "finishing is not a preemption",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 2, Priority: 5}, {ProcessID: 2, ArrivalTime: 2, BurstDuration: 1, Priority: 1},
+			},
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 3}},
+			wantWait:  []int64{0, 0},
+			wantColumns: []Column{
+				{Header: "Ran", Values: []string{"0-2", "2-3"}},
+				{Header: "Preempted", Values: []string{"0", "0"}, Footer: "Total\n0"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := PreemptivePriority("Preemptive priority", tt.processes)
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("PreemptivePriority() Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			var waits []int64
+			for _, row := range r.Rows {
+				waits = append(waits, row.Wait)
+			}
+			if !reflect.DeepEqual(waits, tt.wantWait) {
+				t.Errorf("PreemptivePriority() waits = %v, want %v", waits, tt.wantWait)
+			}
+			if !reflect.DeepEqual(r.Columns, tt.wantColumns) {
+				t.Errorf("PreemptivePriority() columns = %+v, want %+v", r.Columns, tt.wantColumns)
+			}
+			if !reflect.DeepEqual(r.Notes, tt.wantNotes) {
+				t.Errorf("PreemptivePriority() notes = %q, want %q", r.Notes, tt.wantNotes)
+			}
+		})
+	}
+}
+
+func TestPreemptivePrioritySchedule(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	PreemptivePrioritySchedule(&w, "Preemptive priority", []Process{{ProcessID: 1, BurstDuration: 4, Priority: 2}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 1, Priority: 1}})
+	for _, want := range []string{"|   1   |   2   |   1   |\n0\t1\t2\t5", "PREEMPTED", "Preemption points: t=1: 2 preempts 1\n"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("PreemptivePrioritySchedule() = %q, want it to contain %q", w.String(), want)
+		}
+	}
+}
+
+func TestPreemptivePrioritySwitchCost(t *testing.T) {
+	t.Parallel()
+	w := NewWorkload(Process{ProcessID: 1, BurstDuration: 3, Priority: 2}, Process{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1, Priority: 1})
+	res, err := Simulate(context.Background(), w, PreemptivePriorityPolicy{}, WithSwitchCost(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The switch overhead between 1 and 2 is neither a process that ran nor
+	// the one that preempted 1.
+	want := []Column{
+		{Header: "Ran", Values: []string{"0-1, 4-6", "2-3"}},
+		{Header: "Preempted", Values: []string{"1", "0"}, Footer: "Total\n1"},
+	}
+	if got := res.Columns[:2]; !reflect.DeepEqual(got, want) {
+		t.Errorf("columns = %+v, want %+v", got, want)
+	}
+	if want := "Preemption points: t=1: 2 preempts 1"; !strings.Contains(strings.Join(res.Notes, "\n"), want) {
+		t.Errorf("notes = %q, want %q", res.Notes, want)
+	}
+}
@@ -0,0 +1,40 @@
+package scheduler
+
+import "fmt"
+
+//region Preemption budget
+
+// WithPreemptionBudget lets the policy preempt the running task at most n
+// times; after that every task it dispatches runs to completion, as on a
+// tickless system that stops taking timer interrupts. A negative n counts
+// preemptions without limiting them.
+func WithPreemptionBudget(n int) Option {
+	return func(e *engine) {
+		e.budgeted = true
+		e.maxPreemptions = n
+	}
+}
+
+// addPreemptionNotes adds each task's response time and how much of the
+// budget was used.
+func addPreemptionNotes(r *Report, tasks []*Task, budget, used, denied int) {
+	r.Columns = append(r.Columns, responseColumn(tasks))
+	if budget < 0 {
+		r.Notes = append(r.Notes, fmt.Sprintf("Preemptions: %d", used))
+		return
+	}
+	r.Notes = append(r.Notes, fmt.Sprintf("Preemptions: %d of %d allowed, %d more refused", used, budget, denied))
+}
+
+// preemptionReports runs round-robin with at most budget preemptions, next
+// to an unlimited run for comparison.
+func preemptionReports(processes []Process, budget int, quantum int64) []Report {
+	title := fmt.Sprintf("Round-robin, quantum %d", quantum)
+	return []Report{
+		simulate(title+" (unlimited preemptions)", processes, RRPolicy{Quantum: quantum}, WithPreemptionBudget(-1)),
+		simulate(fmt.Sprintf("%s (at most %d preemptions)", title, budget), processes,
+			RRPolicy{Quantum: quantum}, WithPreemptionBudget(budget)),
+	}
+}
+
+//endregion
@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_WithPreemptionBudget(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader("1,6,0,2\n2,6,1,1\n3,3,2,3\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		budget    int
+		wantGantt []TimeSlice
+		wantNote  string
+	}{
+		{
+			name:   "unlimited",
+			budget: -1,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 3, Start: 4, Stop: 6},
+				{PID: 1, Start: 6, Stop: 8}, {PID: 2, Start: 8, Stop: 10}, {PID: 3, Start: 10, Stop: 11},
+				{PID: 1, Start: 11, Stop: 13}, {PID: 2, Start: 13, Stop: 15},
+			},
+			wantNote: "Preemptions: 5",
+		},
+		{
+			name:   "one",
+			budget: 1,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 8}, {PID: 3, Start: 8, Stop: 11},
+				{PID: 1, Start: 11, Stop: 15},
+			},
+			wantNote: "Preemptions: 1 of 1 allowed, 3 more refused",
+		},
+		{
+			name:   "none",
+			budget: 0,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 6}, {PID: 2, Start: 6, Stop: 12}, {PID: 3, Start: 12, Stop: 15},
+			},
+			wantNote: "Preemptions: 0 of 0 allowed, 4 more refused",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := simulate(tt.name, processes, RRPolicy{Quantum: 2}, WithPreemptionBudget(tt.budget))
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			if len(r.Notes) == 0 || r.Notes[len(r.Notes)-1] != tt.wantNote {
+				t.Errorf("notes = %q, want %q", r.Notes, tt.wantNote)
+			}
+		})
+	}
+}
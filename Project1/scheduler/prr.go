@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+//region Priority with round-robin
+
+// PriorityRRPolicy runs the tasks with the lowest priority number
+// round-robin with Quantum, preempting the running task as soon as one with
+// a lower number arrives. A task preempted either way goes to the back of
+// the ready queue, behind the others of its level.
+type PriorityRRPolicy struct {
+	Quantum int64
+}
+
+func (p PriorityRRPolicy) Pick(_ int64, running *Task, ready []*Task) *Task {
+	best := minTask(ready, func(a, b *Task) bool { return a.Priority < b.Priority })
+	if running == nil || best != nil && best.Priority < running.Priority {
+		return best
+	}
+	if best == nil || best.Priority > running.Priority || p.Quantum <= 0 || running.Slice%p.Quantum != 0 {
+		return running
+	}
+	return best
+}
+
+// annotate adds how many turns each task took on the CPU, and lists the
+// tasks that took turns at each priority level.
+func (p PriorityRRPolicy) annotate(r *Report, tasks []*Task) {
+	turns := make(map[int64]int)
+	for _, s := range r.Gantt {
+		turns[s.PID]++
+	}
+	col := Column{Header: "Turns"}
+	levels := make(map[int64][]string)
+	for _, t := range tasks {
+		col.Values = append(col.Values, fmt.Sprint(turns[t.ProcessID]))
+		levels[t.Priority] = append(levels[t.Priority], fmt.Sprint(t.ProcessID))
+	}
+	r.Columns = append(r.Columns, col)
+
+	var shared []int64
+	for priority, ids := range levels {
+		if len(ids) > 1 {
+			shared = append(shared, priority)
+		}
+	}
+	sort.Slice(shared, func(i, j int) bool { return shared[i] < shared[j] })
+	var notes []string
+	for _, priority := range shared {
+		notes = append(notes, fmt.Sprintf("priority %d: %s", priority, strings.Join(levels[priority], ", ")))
+	}
+	if len(notes) > 0 {
+		r.Notes = append(r.Notes, "Round-robin within "+strings.Join(notes, "; "))
+	}
+}
+
+// PriorityRRSchedule outputs the priority with round-robin schedule of
+// processes as a Gantt chart and a table of timing.
+func PriorityRRSchedule(w io.Writer, title string, processes []Process, quantum int64) {
+	outputReport(w, PriorityRR(title, processes, quantum))
+}
+
+// PriorityRR schedules processes by priority, round-robin with quantum
+// among those of the same priority.
+func PriorityRR(title string, processes []Process, quantum int64) Report {
+	return simulate(title, processes, PriorityRRPolicy{Quantum: quantum})
+}
+
+//endregion
@@ -0,0 +1,88 @@
+package scheduler
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPriorityRR(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		quantum   int64
+		wantGantt []TimeSlice
+		wantTurns []string
+		wantNotes []string
+	}{
+		{
+			name: "textbook workload",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 4, Priority: 3}, {ProcessID: 2, BurstDuration: 5, Priority: 2},
+				{ProcessID: 3, BurstDuration: 8, Priority: 2}, {ProcessID: 4, BurstDuration: 7, Priority: 1},
+				{ProcessID: 5, BurstDuration: 3, Priority: 3},
+			},
+			quantum: 2,
+			wantGantt: []TimeSlice{
+				{PID: 4, Start: 0, Stop: 7}, {PID: 2, Start: 7, Stop: 9}, {PID: 3, Start: 9, Stop: 11},
+				{PID: 2, Start: 11, Stop: 13}, {PID: 3, Start: 13, Stop: 15}, {PID: 2, Start: 15, Stop: 16},
+				{PID: 3, Start: 16, Stop: 20}, {PID: 1, Start: 20, Stop: 22}, {PID: 5, Start: 22, Stop: 24},
+				{PID: 1, Start: 24, Stop: 26}, {PID: 5, Start: 26, Stop: 27},
+			},
+			wantTurns: []string{"2", "3", "3", "1", "2"},
+			wantNotes: []string{"Round-robin within priority 2: 2, 3; priority 3: 1, 5"},
+		},
+		{
+			name: "a more important arrival preempts mid-quantum",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 6, Priority: 2}, {ProcessID: 2, BurstDuration: 4, Priority: 2},
+				{ProcessID: 3, ArrivalTime: 1, BurstDuration: 2, Priority: 1},
+			},
+			quantum: 4,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1}, {PID: 3, Start: 1, Stop: 3}, {PID: 2, Start: 3, Stop: 7},
+				{PID: 1, Start: 7, Stop: 12},
+			},
+			wantTurns: []string{"2", "1", "1"},
+			wantNotes: []string{"Round-robin within priority 2: 1, 2"},
+		},
+		{
+			name: "distinct priorities run like preemptive priority",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 5, Priority: 3}, {ProcessID: 2, ArrivalTime: 2, BurstDuration: 2, Priority: 1},
+			},
+			quantum:   1,
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 1, Start: 4, Stop: 7}},
+			wantTurns: []string{"2", "1"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := PriorityRR("Priority with round-robin", tt.processes, tt.quantum)
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("PriorityRR() Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			if len(r.Columns) != 1 || !reflect.DeepEqual(r.Columns[0].Values, tt.wantTurns) {
+				t.Errorf("PriorityRR() columns = %v, want turns %q", r.Columns, tt.wantTurns)
+			}
+			if !reflect.DeepEqual(r.Notes, tt.wantNotes) {
+				t.Errorf("PriorityRR() notes = %q, want %q", r.Notes, tt.wantNotes)
+			}
+		})
+	}
+}
+
+func TestPriorityRRSchedule(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	PriorityRRSchedule(&w, "Priority with round-robin, quantum 1", []Process{{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, BurstDuration: 2}}, 1)
+	for _, want := range []string{"Priority with round-robin, quantum 1", "TURNS", "Round-robin within priority 0: 1, 2"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("PriorityRRSchedule() = %q, want it to contain %q", w.String(), want)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package scheduler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+//region Ready queue length
+
+// sparkMaxWidth caps the width of a sparkline; longer schedules put several
+// ticks in each column, showing the longest queue among them.
+const sparkMaxWidth = 80
+
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// queueRecorder follows a run's events to record how many processes were
+// in the ready queue, having arrived and being neither running, doing I/O
+// nor finished, at each tick.
+type queueRecorder struct {
+	lengths []int64
+	queued  map[int64]bool
+}
+
+func newQueueRecorder() *queueRecorder {
+	return &queueRecorder{queued: make(map[int64]bool)}
+}
+
+// observe records the queue up to ev and then applies it. Events must come
+// in the order they happen.
+func (q *queueRecorder) observe(ev Event) {
+	q.fill(ev.At())
+	switch ev := ev.(type) {
+	case ArriveEvent:
+		q.queued[ev.PID] = true
+	case PreemptEvent:
+		q.queued[ev.PID] = true
+	case IOWakeEvent:
+		q.queued[ev.PID] = true
+	case DispatchEvent:
+		delete(q.queued, ev.PID)
+	case CompleteEvent:
+		delete(q.queued, ev.PID)
+	case IOStartEvent:
+		delete(q.queued, ev.PID)
+	}
+}
+
+// fill records the current queue length for every tick before t.
+func (q *queueRecorder) fill(t int64) {
+	for int64(len(q.lengths)) < t {
+		q.lengths = append(q.lengths, int64(len(q.queued)))
+	}
+}
+
+// until returns the queue lengths at each tick from 0 to end.
+func (q *queueRecorder) until(end int64) []int64 {
+	q.fill(end)
+	return q.lengths[:end]
+}
+
+// sparkline draws lengths as a row of bars, scaled to the longest.
+func sparkline(lengths []int64) string {
+	step := (len(lengths) + sparkMaxWidth - 1) / sparkMaxWidth
+	var peak int64
+	for _, l := range lengths {
+		if l > peak {
+			peak = l
+		}
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(lengths); i += step {
+		var v int64
+		for j := i; j < i+step && j < len(lengths); j++ {
+			if lengths[j] > v {
+				v = lengths[j]
+			}
+		}
+		if v <= 0 || peak == 0 {
+			b.WriteRune(' ')
+			continue
+		}
+		b.WriteRune(sparkBars[(v*int64(len(sparkBars))-1)/peak])
+	}
+	return b.String()
+}
+
+// outputReadyQueue draws r's ready queue, if its run recorded one.
+func (o renderer) outputReadyQueue(w io.Writer, r Report) {
+	lengths := r.ReadyQueue
+	if lengths == nil {
+		return
+	}
+	var peak int64
+	for _, l := range lengths {
+		if l > peak {
+			peak = l
+		}
+	}
+	_, _ = fmt.Fprintf(w, o.catalog.msg("ready_queue")+"\n", peak, sparkline(lengths))
+}
+
+// writeReadyQueueCSV writes the ready queue length at each tick, one column
+// per report that recorded it.
+func writeReadyQueueCSV(path string, reports []Report) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("%w: creating CSV", err)
+	}
+	if err := outputReadyQueueCSV(f, reports); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("%w: closing CSV", err)
+	}
+	return nil
+}
+
+func outputReadyQueueCSV(w io.Writer, reports []Report) error {
+	out := csv.NewWriter(w)
+	header := []string{"time"}
+	var lengths [][]int64
+	longest := 0
+	for _, r := range reports {
+		if r.ReadyQueue == nil {
+			continue
+		}
+		header = append(header, r.Title)
+		lengths = append(lengths, r.ReadyQueue)
+		if len(r.ReadyQueue) > longest {
+			longest = len(r.ReadyQueue)
+		}
+	}
+	_ = out.Write(header)
+	for t := 0; t < longest; t++ {
+		record := []string{fmt.Sprint(t)}
+		for i := range lengths {
+			v := "0"
+			if t < len(lengths[i]) {
+				v = fmt.Sprint(lengths[i][t])
+			}
+			record = append(record, v)
+		}
+		_ = out.Write(record)
+	}
+	out.Flush()
+	if err := out.Error(); err != nil {
+		return fmt.Errorf("%w: writing CSV", err)
+	}
+	return nil
+}
+
+//endregion
@@ -0,0 +1,81 @@
+package scheduler
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func Test_queueRecorder(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 1},
+	}
+	tests := []struct {
+		name      string
+		processes []Process
+		opts      []Option
+		want      []int64
+		wantSpark string
+	}{
+		{
+			name:      "FCFS",
+			processes: processes,
+			want:      []int64{0, 2, 2, 1, 1, 0},
+			wantSpark: " ██▄▄ ",
+		},
+		{
+			name:      "switch cost",
+			processes: processes,
+			opts:      []Option{WithSwitchCost(1)},
+			want:      []int64{0, 2, 2, 1, 1, 1, 0, 0},
+			wantSpark: " ██▄▄▄  ",
+		},
+		{
+			name: "I/O isn't queueing",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 2, Bursts: []int64{1, 3, 1}},
+				{ProcessID: 2, BurstDuration: 2},
+			},
+			want:      []int64{1, 0, 0, 0, 0},
+			wantSpark: "█    ",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := simulate(tt.name, tt.processes, FCFSPolicy{}, tt.opts...)
+			if !reflect.DeepEqual(r.ReadyQueue, tt.want) {
+				t.Errorf("ReadyQueue = %v, want %v", r.ReadyQueue, tt.want)
+			}
+			if s := sparkline(r.ReadyQueue); s != tt.wantSpark {
+				t.Errorf("sparkline() = %q, want %q", s, tt.wantSpark)
+			}
+		})
+	}
+}
+
+func Test_outputReadyQueueCSV(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, BurstDuration: 1}}
+	reports := []Report{
+		simulate("FCFS", processes, FCFSPolicy{}),
+		// Priority isn't run on the engine, so it has no recorded queue.
+		SJFPriority("Priority", processes),
+	}
+	var b bytes.Buffer
+	if err := outputReadyQueueCSV(&b, reports); err != nil {
+		t.Fatal(err)
+	}
+	if want := "time,FCFS\n0,1\n1,1\n2,0\n"; b.String() != want {
+		t.Errorf("outputReadyQueueCSV() = %q, want %q", b.String(), want)
+	}
+	b.Reset()
+	renderer{}.outputReadyQueue(&b, reports[1])
+	if b.Len() != 0 {
+		t.Errorf("outputReadyQueue() = %q without a recorded queue, want nothing", b.String())
+	}
+}
@@ -0,0 +1,146 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+//region Queueing theory
+
+// QueueingModel is an M/M/c queue fitted to a workload: Poisson arrivals
+// at rate Lambda, exponential service at rate Mu per server and Servers
+// servers. ArrivalCV and ServiceCV are the coefficients of variation of
+// the workload's interarrival and burst times, which are 1 when they really
+// are exponential.
+type QueueingModel struct {
+	Lambda, Mu           float64
+	Servers              int
+	ArrivalCV, ServiceCV float64
+}
+
+// fitQueueingModel estimates the arrival and service rates of processes.
+func fitQueueingModel(processes []Process, servers int) (QueueingModel, error) {
+	if len(processes) < 2 || servers < 1 {
+		return QueueingModel{}, fmt.Errorf("%w: need at least two processes and one server", ErrInvalidArgs)
+	}
+	arrivals := make([]int64, len(processes))
+	bursts := make([]float64, len(processes))
+	for i, p := range processes {
+		arrivals[i] = p.ArrivalTime
+		bursts[i] = float64(p.BurstDuration)
+	}
+	sort.Slice(arrivals, func(i, j int) bool { return arrivals[i] < arrivals[j] })
+	span := arrivals[len(arrivals)-1] - arrivals[0]
+	if span <= 0 {
+		return QueueingModel{}, fmt.Errorf("%w: every process arrives at once, so there is no arrival rate", ErrInvalidArgs)
+	}
+	gaps := make([]float64, len(arrivals)-1)
+	for i := range gaps {
+		gaps[i] = float64(arrivals[i+1] - arrivals[i])
+	}
+
+	meanGap, sdGap := meanStdDev(gaps)
+	meanBurst, sdBurst := meanStdDev(bursts)
+	if meanBurst <= 0 {
+		return QueueingModel{}, fmt.Errorf("%w: processes need CPU time", ErrInvalidArgs)
+	}
+	return QueueingModel{
+		Lambda:    1 / meanGap,
+		Mu:        1 / meanBurst,
+		Servers:   servers,
+		ArrivalCV: sdGap / meanGap,
+		ServiceCV: sdBurst / meanBurst,
+	}, nil
+}
+
+// utilization is the offered load per server; the queue is only stable below 1.
+func (m QueueingModel) utilization() float64 {
+	return m.Lambda / (float64(m.Servers) * m.Mu)
+}
+
+// erlangC is the probability that an arrival has to wait.
+func (m QueueingModel) erlangC() float64 {
+	a := m.Lambda / m.Mu
+	rho := m.utilization()
+	term, sum := 1.0, 0.0
+	for k := 0; k < m.Servers; k++ {
+		sum += term
+		term *= a / float64(k+1)
+	}
+	top := term / (1 - rho)
+	return top / (sum + top)
+}
+
+// expected returns the expected wait in the queue and queue length, or
+// +Inf when the queue is unstable.
+func (m QueueingModel) expected() (wait, length float64) {
+	if m.utilization() >= 1 {
+		return math.Inf(1), math.Inf(1)
+	}
+	wait = m.erlangC() / (float64(m.Servers)*m.Mu - m.Lambda)
+	return wait, m.Lambda * wait
+}
+
+// averageQueueLength is the time-average length of r's ready queue from
+// the first arrival to the end.
+func averageQueueLength(r Report) float64 {
+	lengths := r.ReadyQueue
+	if len(r.Rows) == 0 {
+		return 0
+	}
+	first := r.Rows[0].Arrival
+	for _, row := range r.Rows {
+		if row.Arrival < first {
+			first = row.Arrival
+		}
+	}
+	if int64(len(lengths)) <= first {
+		return 0
+	}
+	var total int64
+	for _, l := range lengths[first:] {
+		total += l
+	}
+	return float64(total) / float64(int64(len(lengths))-first)
+}
+
+// outputQueueing simulates processes under each classic policy and prints
+// the M/M/c predictions next to their wait and queue length.
+func outputQueueing(w io.Writer, m QueueingModel, processes []Process, quantum int64) {
+	wait, length := m.expected()
+	_, _ = fmt.Fprintf(w, "M/M/%d model: λ=%.4f/t, μ=%.4f/t, utilization %.2f, expected wait %.2f, queue length %.2f\n",
+		m.Servers, m.Lambda, m.Mu, m.utilization(), wait, length)
+	if math.Abs(m.ArrivalCV-1) > 0.5 || math.Abs(m.ServiceCV-1) > 0.5 {
+		_, _ = fmt.Fprintf(w, "The workload doesn't look exponential (interarrival CV %.2f, burst CV %.2f; both are 1 for M/M/c)\n",
+			m.ArrivalCV, m.ServiceCV)
+	}
+	if m.Servers > 1 {
+		_, _ = fmt.Fprintln(w, "The simulation runs on a single CPU, so only the M/M/1 model matches it")
+	}
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Average wait", "vs model", "Average queue length", "vs model"})
+	deviation := func(sim, model float64) string {
+		if math.IsInf(model, 1) || model == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%+.1f%%", 100*(sim-model)/model)
+	}
+	for _, policy := range classicPolicies(quantum) {
+		r := simulate(policyTitle(policy), processes, policy)
+		l := averageQueueLength(r)
+		table.Append([]string{
+			r.Title,
+			fmt.Sprintf("%.2f", r.Wait),
+			deviation(r.Wait, wait),
+			fmt.Sprintf("%.2f", l),
+			deviation(l, length),
+		})
+	}
+	table.Render()
+}
+
+//endregion
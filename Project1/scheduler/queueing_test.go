@@ -0,0 +1,72 @@
+package scheduler
+
+import (
+	"math"
+	"testing"
+)
+
+func Test_QueueingModel_expected(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name              string
+		model             QueueingModel
+		wantWait, wantLen float64
+	}{
+		// ρ = 0.5: Wq = ρ/(μ-λ) = 1, Lq = ρ²/(1-ρ) = 0.5.
+		{name: "M/M/1", model: QueueingModel{Lambda: 0.5, Mu: 1, Servers: 1}, wantWait: 1, wantLen: 0.5},
+		// a = 1, ρ = 0.5: Erlang C is 1/3 and Wq = C/(cμ-λ).
+		{name: "M/M/2", model: QueueingModel{Lambda: 1, Mu: 1, Servers: 2}, wantWait: 1.0 / 3, wantLen: 1.0 / 3},
+		{name: "unstable", model: QueueingModel{Lambda: 2, Mu: 1, Servers: 1}, wantWait: math.Inf(1), wantLen: math.Inf(1)},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			near := func(got, want float64) bool { return got == want || math.Abs(got-want) < 1e-9 }
+			wait, length := tt.model.expected()
+			if !near(wait, tt.wantWait) {
+				t.Errorf("wait = %v, want %v", wait, tt.wantWait)
+			}
+			if !near(length, tt.wantLen) {
+				t.Errorf("queue length = %v, want %v", length, tt.wantLen)
+			}
+		})
+	}
+}
+
+func Test_fitQueueingModel(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		processes  []Process
+		wantLambda float64
+		wantMu     float64
+		wantErr    bool
+	}{
+		{
+			name: "regular",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+				{ProcessID: 2, ArrivalTime: 4, BurstDuration: 4},
+				{ProcessID: 3, ArrivalTime: 8, BurstDuration: 6},
+			},
+			wantLambda: 0.25,
+			wantMu:     0.25,
+		},
+		{name: "one process", processes: []Process{{ProcessID: 1, BurstDuration: 2}}, wantErr: true},
+		{name: "simultaneous", processes: []Process{{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, BurstDuration: 2}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m, err := fitQueueingModel(tt.processes, 1)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("fitQueueingModel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (m.Lambda != tt.wantLambda || m.Mu != tt.wantMu || m.ArrivalCV != 0) {
+				t.Errorf("fitQueueingModel() = %+v, want λ=%v μ=%v", m, tt.wantLambda, tt.wantMu)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+)
+
+//region Radar chart
+
+const (
+	radarSize   = 520
+	radarRadius = 170
+)
+
+// radarAxis is one metric of the radar chart. Scores are normalized so the
+// best algorithm reaches the rim: value/best when higher is better,
+// best/value when lower is.
+type radarAxis struct {
+	Name         string
+	HigherBetter bool
+	Value        func(r Report) float64
+}
+
+var radarAxes = []radarAxis{
+	{Name: "Wait", Value: func(r Report) float64 { return r.Wait }},
+	{Name: "Turnaround", Value: func(r Report) float64 { return r.Turnaround }},
+	{Name: "Response", Value: averageResponse},
+	{Name: "Fairness", HigherBetter: true, Value: func(r Report) float64 {
+		var slowdowns []float64
+		for _, row := range r.Rows {
+			if row.Burst > 0 {
+				slowdowns = append(slowdowns, float64(row.Turnaround)/float64(row.Burst))
+			}
+		}
+		return jainIndex(slowdowns)
+	}},
+	{Name: "Switches", Value: func(r Report) float64 { return float64(contextSwitches(r.Gantt)) }},
+	{Name: "Utilization", HigherBetter: true, Value: utilization},
+}
+
+// averageResponse is the average time from arrival to first running.
+func averageResponse(r Report) float64 {
+	if len(r.Rows) == 0 {
+		return 0
+	}
+	first := make(map[int64]int64)
+	for _, s := range r.Gantt {
+		if f, ok := first[s.PID]; !ok || s.Start < f {
+			first[s.PID] = s.Start
+		}
+	}
+	var total float64
+	for _, row := range r.Rows {
+		if f, ok := first[row.ProcessID]; ok {
+			total += float64(f - row.Arrival)
+		}
+	}
+	return total / float64(len(r.Rows))
+}
+
+// contextSwitches counts how often a CPU goes from running one process to another.
+func contextSwitches(gantt []TimeSlice) int {
+	n := 0
+	last := make(map[int]int64)
+	for _, s := range gantt {
+		if s.Overhead {
+			continue
+		}
+		if pid, ok := last[s.CPU]; ok && pid != s.PID {
+			n++
+		}
+		last[s.CPU] = s.PID
+	}
+	return n
+}
+
+// utilization is the fraction of the makespan the CPUs were busy running
+// processes; context switches don't count.
+func utilization(r Report) float64 {
+	span := r.makespan()
+	if span <= 0 {
+		return 0
+	}
+	cpus := 1
+	var busy int64
+	for _, s := range r.Gantt {
+		if !s.Overhead {
+			busy += s.Stop - s.Start
+		}
+		if s.CPU+1 > cpus {
+			cpus = s.CPU + 1
+		}
+	}
+	return float64(busy) / float64(span*int64(cpus))
+}
+
+// radarScores normalizes every report's metrics to (0, 1], one row per report.
+func radarScores(reports []Report) [][]float64 {
+	scores := make([][]float64, len(reports))
+	for i := range scores {
+		scores[i] = make([]float64, len(radarAxes))
+	}
+	for a, axis := range radarAxes {
+		values := make([]float64, len(reports))
+		best := math.NaN()
+		for i, r := range reports {
+			values[i] = axis.Value(r)
+			if math.IsNaN(best) || (axis.HigherBetter && values[i] > best) || (!axis.HigherBetter && values[i] < best) {
+				best = values[i]
+			}
+		}
+		for i, v := range values {
+			switch {
+			case v == best:
+				scores[i][a] = 1
+			case axis.HigherBetter:
+				scores[i][a] = v / best
+			case v > 0:
+				scores[i][a] = best / v
+			}
+		}
+	}
+	return scores
+}
+
+func writeRadar(path string, reports []Report) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("%w: creating SVG", err)
+	}
+	outputRadar(f, reports)
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("%w: closing SVG", err)
+	}
+	return nil
+}
+
+// outputRadar draws a radar chart with an axis per metric and a polygon per algorithm as SVG.
+func outputRadar(w io.Writer, reports []Report) {
+	const c = radarSize / 2
+	point := func(axis int, score float64) (float64, float64) {
+		angle := 2*math.Pi*float64(axis)/float64(len(radarAxes)) - math.Pi/2
+		return c + score*radarRadius*math.Cos(angle), c + score*radarRadius*math.Sin(angle)
+	}
+	polygon := func(scores []float64) string {
+		var pts []string
+		for a, s := range scores {
+			x, y := point(a, s)
+			pts = append(pts, fmt.Sprintf("%.1f,%.1f", x, y))
+		}
+		return strings.Join(pts, " ")
+	}
+
+	height := radarSize + 20*len(reports)
+	_, _ = fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="12">`+"\n", radarSize, height)
+	_, _ = fmt.Fprintf(w, `<rect width="100%%" height="100%%" fill="white"/>`+"\n")
+	for _, ring := range []float64{0.25, 0.5, 0.75, 1} {
+		all := make([]float64, len(radarAxes))
+		for i := range all {
+			all[i] = ring
+		}
+		_, _ = fmt.Fprintf(w, `<polygon points="%s" fill="none" stroke="#ccc"/>`+"\n", polygon(all))
+	}
+	for a, axis := range radarAxes {
+		x, y := point(a, 1)
+		lx, ly := point(a, 1.15)
+		_, _ = fmt.Fprintf(w, `<line x1="%d" y1="%d" x2="%.1f" y2="%.1f" stroke="#ccc"/>`+"\n", c, c, x, y)
+		_, _ = fmt.Fprintf(w, `<text x="%.1f" y="%.1f" text-anchor="middle">%s</text>`+"\n", lx, ly, axis.Name)
+	}
+
+	for i, scores := range radarScores(reports) {
+		r, g, b, _ := gifPalette[gifProcessColor+i%(len(gifPalette)-gifProcessColor)].RGBA()
+		color := fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+		_, _ = fmt.Fprintf(w, `<polygon points="%s" fill="%s" fill-opacity="0.15" stroke="%s" stroke-width="2"/>`+"\n", polygon(scores), color, color)
+		y := radarSize + 20*i
+		_, _ = fmt.Fprintf(w, `<rect x="20" y="%d" width="12" height="12" fill="%s"/><text x="40" y="%d">%s</text>`+"\n", y, color, y+11, xlsxEscape(reports[i].Title))
+	}
+	_, _ = fmt.Fprintln(w, "</svg>")
+}
+
+//endregion
@@ -0,0 +1,45 @@
+package scheduler
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func Test_radarScores(t *testing.T) {
+	t.Parallel()
+	reports := []Report{
+		{
+			Title: "A",
+			Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}},
+			Rows:  []Row{{ProcessID: 1, Burst: 2, Turnaround: 2, Exit: 2}, {ProcessID: 2, Burst: 2, Wait: 2, Turnaround: 4, Exit: 4}},
+			Wait:  1, Turnaround: 3,
+		},
+		{
+			Title: "B",
+			Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 2}, {PID: 1, Start: 2, Stop: 3}, {PID: 2, Start: 3, Stop: 4}},
+			Rows:  []Row{{ProcessID: 1, Burst: 2, Wait: 1, Turnaround: 3, Exit: 3}, {ProcessID: 2, Burst: 2, Wait: 2, Turnaround: 4, Exit: 4}},
+			Wait:  1.5, Turnaround: 3.5,
+		},
+	}
+	got := radarScores(reports)
+	// Wait, turnaround, response, fairness, switches, utilization.
+	want := [][]float64{
+		{1, 1, 0.5, 0.9 / 0.98, 1, 1},
+		{1 / 1.5, 3 / 3.5, 1, 1, 1.0 / 3, 1},
+	}
+	for i := range want {
+		for a := range want[i] {
+			if d := got[i][a] - want[i][a]; d > 1e-9 || d < -1e-9 {
+				t.Errorf("radarScores()[%d] = %v, want %v", i, got[i], want[i])
+				break
+			}
+		}
+	}
+
+	var w bytes.Buffer
+	outputRadar(&w, reports)
+	if n := strings.Count(w.String(), "<polygon"); n != 4+len(reports) {
+		t.Errorf("%d polygons, want %d", n, 4+len(reports))
+	}
+}
@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+)
+
+//region Random dispatch
+
+// RandomPolicy is a baseline that knows nothing about the tasks: whenever
+// the CPU frees up it dispatches one of the ready tasks chosen uniformly at
+// random, and runs it to completion. Runs with the same Seed make the same
+// choices.
+type RandomPolicy struct {
+	Seed int64
+	rng  *rand.Rand
+}
+
+func (p *RandomPolicy) Pick(_ int64, running *Task, ready []*Task) *Task {
+	if running != nil {
+		return running
+	}
+	if len(ready) == 0 {
+		return nil
+	}
+	if p.rng == nil {
+		p.rng = rand.New(rand.NewSource(p.Seed))
+	}
+	return ready[p.rng.Intn(len(ready))]
+}
+
+// RandomSchedule outputs the random dispatch schedule of processes as a
+// Gantt chart and a table of timing, noting how much the classic
+// algorithms improve on its average wait and turnaround.
+func RandomSchedule(w io.Writer, title string, processes []Process, quantum, seed int64) {
+	outputReport(w, Random(title, processes, quantum, seed))
+}
+
+// Random schedules processes by dispatching a ready one at random, from
+// seed, and notes how FCFS, SJF, priority and round-robin with quantum
+// compare with it.
+func Random(title string, processes []Process, quantum, seed int64) Report {
+	r := simulate(title, processes, &RandomPolicy{Seed: seed})
+	var compared []string
+	for _, p := range classicPolicies(quantum) {
+		c := simulate(policyTitle(p), processes, p)
+		compared = append(compared, fmt.Sprintf("%s wait %.2f (%s), turnaround %.2f (%s)",
+			c.Title, c.Wait, improvement(r.Wait, c.Wait), c.Turnaround, improvement(r.Turnaround, c.Turnaround)))
+	}
+	r.Notes = append(r.Notes, "Against random dispatch: "+strings.Join(compared, "; "))
+	return r
+}
+
+// improvement is how much lower got is than baseline, as a percentage.
+func improvement(baseline, got float64) string {
+	if baseline == 0 {
+		return "no change"
+	}
+	return fmt.Sprintf("%+.0f%%", 100*(got-baseline)/baseline)
+}
+
+//endregion
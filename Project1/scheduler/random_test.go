@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRandom(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 5}, {ProcessID: 2, BurstDuration: 1}, {ProcessID: 3, BurstDuration: 3},
+		{ProcessID: 4, ArrivalTime: 2, BurstDuration: 2}, {ProcessID: 5, ArrivalTime: 4, BurstDuration: 4},
+	}
+	for _, seed := range []int64{1, 2, 3, 42} {
+		r := Random("Random dispatch", processes, 2, seed)
+		if again := Random("Random dispatch", processes, 2, seed); !reflect.DeepEqual(r.Gantt, again.Gantt) {
+			t.Errorf("seed %d: Gantt %v, then %v", seed, r.Gantt, again.Gantt)
+		}
+		if len(r.Gantt) != len(processes) {
+			t.Errorf("seed %d: Gantt %v, want each process to run once to completion", seed, r.Gantt)
+		}
+		if r.makespan() != 15 {
+			t.Errorf("seed %d: makespan %d, want 15", seed, r.makespan())
+		}
+		if len(r.Notes) != 1 || !strings.HasPrefix(r.Notes[0], "Against random dispatch: First-come, first-serve wait ") ||
+			!strings.Contains(r.Notes[0], "; Round-robin, quantum 2 wait ") {
+			t.Errorf("seed %d: notes %q, want a comparison with the classic algorithms", seed, r.Notes)
+		}
+	}
+}
+
+func TestRandomPolicy(t *testing.T) {
+	t.Parallel()
+	a, b, c := &Task{Process: Process{ProcessID: 1}}, &Task{Process: Process{ProcessID: 2}}, &Task{Process: Process{ProcessID: 3}}
+	p := &RandomPolicy{Seed: 7}
+	if got := p.Pick(0, a, []*Task{b, c}); got != a {
+		t.Errorf("Pick() = %v, want the running task kept", got)
+	}
+	if got := p.Pick(0, nil, nil); got != nil {
+		t.Errorf("Pick() with nothing ready = %v, want nil", got)
+	}
+	seen := make(map[int64]bool)
+	for i := 0; i < 100; i++ {
+		seen[p.Pick(0, nil, []*Task{a, b, c}).ProcessID] = true
+	}
+	if len(seen) != 3 {
+		t.Errorf("100 picks chose %v, want every ready task chosen", seen)
+	}
+}
+
+func Test_improvement(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		baseline, got float64
+		want          string
+	}{
+		{baseline: 10, got: 5, want: "-50%"},
+		{baseline: 4, got: 5, want: "+25%"},
+		{baseline: 4, got: 4, want: "+0%"},
+		{baseline: 0, got: 0, want: "no change"},
+	}
+	for _, tt := range tests {
+		if got := improvement(tt.baseline, tt.got); got != tt.want {
+			t.Errorf("improvement(%g, %g) = %q, want %q", tt.baseline, tt.got, got, tt.want)
+		}
+	}
+}
+
+func TestRandomSchedule(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	RandomSchedule(&w, "Random dispatch, seed 1", []Process{{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, BurstDuration: 1}}, 10, 1)
+	for _, want := range []string{"Random dispatch, seed 1", "Against random dispatch: "} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("RandomSchedule() = %q, want it to contain %q", w.String(), want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+//region Random I/O
+
+// ioModel makes a running task issue I/O with probability Prob after each
+// tick it runs, for an exponentially distributed time averaging Mean ticks
+// (at least one). The task leaves the CPU and rejoins the ready queue when
+// the I/O completes.
+type ioModel struct {
+	Prob float64
+	Mean float64
+	Seed int64
+	rng  *rand.Rand
+	// requests counts the I/O issued by the last run.
+	requests int
+}
+
+// WithRandomIO interrupts running tasks with random I/O: after every tick
+// a task runs without finishing, it starts I/O with probability prob,
+// lasting mean ticks on average. Runs with the same seed draw the same
+// random numbers.
+func WithRandomIO(prob, mean float64, seed int64) Option {
+	return func(e *engine) {
+		e.io = &ioModel{Prob: prob, Mean: mean, Seed: seed}
+	}
+}
+
+func (m *ioModel) reset() {
+	m.rng = rand.New(rand.NewSource(m.Seed))
+	m.requests = 0
+}
+
+// draw returns how long the I/O the running task issues now lasts, or 0 if
+// it issues none.
+func (m *ioModel) draw() int64 {
+	if m.rng.Float64() >= m.Prob {
+		return 0
+	}
+	m.requests++
+	return int64(math.Max(1, math.Round(m.rng.ExpFloat64()*m.Mean)))
+}
+
+// annotate adds each task's time spent in I/O and response time. Turnaround
+// times include the I/O; waits, the time in the ready queue, don't.
+func (m *ioModel) annotate(r *Report, tasks []*Task) {
+	var total float64
+	col := Column{Header: "I/O"}
+	for _, t := range tasks {
+		col.Values = append(col.Values, fmt.Sprint(t.IO))
+		total += float64(t.IO)
+	}
+	if len(tasks) > 0 {
+		col.Footer = fmt.Sprintf("Average\n%.2f", total/float64(len(tasks)))
+	}
+	r.Columns = append(r.Columns, col, responseColumn(tasks))
+	r.Notes = append(r.Notes, fmt.Sprintf("Random I/O: %d requests (probability %g per tick, mean %g ticks, seed %d)",
+		m.requests, m.Prob, m.Mean, m.Seed))
+}
+
+// randomIOReports runs the classic policies with the same random I/O.
+func randomIOReports(processes []Process, prob, mean float64, seed, quantum int64) []Report {
+	var reports []Report
+	for _, p := range classicPolicies(quantum) {
+		reports = append(reports, simulate(policyTitle(p)+" with random I/O", processes, p, WithRandomIO(prob, mean, seed)))
+	}
+	return reports
+}
+
+//endregion
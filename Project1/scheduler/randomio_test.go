@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_WithRandomIO(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader("1,4,0,1\n2,2,0,1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		prob      float64
+		mean      float64
+		wantGantt []TimeSlice
+		wantIO    []string
+		wantWait  []int64
+	}{
+		{
+			name:      "never",
+			prob:      0,
+			mean:      5,
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 6}},
+			wantIO:    []string{"0", "0"},
+			wantWait:  []int64{0, 4},
+		},
+		{
+			// Every tick that doesn't finish a process ends in a one tick I/O.
+			name: "always",
+			prob: 1,
+			mean: 0,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 2}, {PID: 1, Start: 2, Stop: 3},
+				{PID: 2, Start: 3, Stop: 4}, {PID: 1, Start: 4, Stop: 5}, {PID: 1, Start: 6, Stop: 7},
+			},
+			wantIO:   []string{"3", "1"},
+			wantWait: []int64{0, 1},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := simulate(tt.name, processes, FCFSPolicy{}, WithRandomIO(tt.prob, tt.mean, 1))
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			if got := r.Columns[0].Values; !reflect.DeepEqual(got, tt.wantIO) {
+				t.Errorf("I/O = %q, want %q", got, tt.wantIO)
+			}
+			var waits []int64
+			for _, row := range r.Rows {
+				waits = append(waits, row.Wait)
+			}
+			if !reflect.DeepEqual(waits, tt.wantWait) {
+				t.Errorf("waits = %v, want %v", waits, tt.wantWait)
+			}
+		})
+	}
+}
+
+func Test_WithRandomIO_seeded(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader("1,20,0,1\n2,20,3,1\n3,5,6,1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := simulate("a", processes, RRPolicy{Quantum: 3}, WithRandomIO(0.3, 4, 42))
+	b := simulate("b", processes, RRPolicy{Quantum: 3}, WithRandomIO(0.3, 4, 42))
+	if !reflect.DeepEqual(a.Gantt, b.Gantt) || !reflect.DeepEqual(a.Notes, b.Notes) {
+		t.Errorf("runs with the same seed differ:\n%v\n%v", a.Gantt, b.Gantt)
+	}
+	if len(a.Notes) == 0 || !strings.HasPrefix(a.Notes[0], "Random I/O: ") {
+		t.Errorf("notes = %q", a.Notes)
+	}
+}
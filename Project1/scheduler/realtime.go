@@ -0,0 +1,110 @@
+package scheduler
+
+import (
+	"fmt"
+)
+
+//region Realtime and normal classes
+
+// The realtime classes, named after the Linux policies they model.
+const (
+	rtFIFO = "fifo"
+	rtRR   = "rr"
+)
+
+// RTPolicy dispatches realtime tasks ahead of normal ones. A ready realtime
+// task always preempts a normal task, and a realtime task of a higher
+// priority (lower number) preempts a lower one. Realtime tasks of equal
+// priority run first-come, first-served (fifo) or round-robin with Quantum
+// (rr); normal tasks run with Normal whenever no realtime task is ready.
+// A preempted normal task resumes before any other normal task.
+type RTPolicy struct {
+	Quantum int64
+	Normal  Policy
+	// background is the normal task that last ran.
+	background *Task
+}
+
+func (p *RTPolicy) Pick(now int64, running *Task, ready []*Task) *Task {
+	var realtime, normal []*Task
+	for _, t := range ready {
+		if t.RealTime != "" {
+			realtime = append(realtime, t)
+		} else {
+			normal = append(normal, t)
+		}
+	}
+	highest := minTask(realtime, func(a, b *Task) bool { return a.Priority < b.Priority })
+
+	if running != nil && running.RealTime != "" {
+		if highest != nil && highest.Priority < running.Priority {
+			return highest
+		}
+		if running.RealTime == rtRR && p.Quantum > 0 && running.Slice%p.Quantum == 0 {
+			for _, t := range realtime {
+				if t.Priority == running.Priority {
+					return t
+				}
+			}
+		}
+		return running
+	}
+	if highest != nil {
+		return highest
+	}
+	if running == nil && p.background != nil && p.background.Remaining > 0 {
+		for _, t := range normal {
+			if t == p.background {
+				return t
+			}
+		}
+	}
+	if running == nil && len(normal) == 0 {
+		return nil
+	}
+	p.background = p.Normal.Pick(now, running, normal)
+	return p.background
+}
+
+// annotate adds each task's class and response time, and reports how much
+// the realtime tasks delayed the normal ones compared with running the
+// normal tasks alone.
+func (p *RTPolicy) annotate(r *Report, tasks []*Task) {
+	class := Column{Header: "Class"}
+	var (
+		realtime, normal       int
+		normalWait, normalResp float64
+		alone                  []Process
+	)
+	for _, t := range tasks {
+		if t.RealTime != "" {
+			class.Values = append(class.Values, t.RealTime)
+			realtime++
+			continue
+		}
+		class.Values = append(class.Values, "normal")
+		normal++
+		normalWait += float64(t.Exit - t.ArrivalTime - t.BurstDuration)
+		normalResp += float64(t.FirstRun - t.ArrivalTime)
+		alone = append(alone, t.Process)
+	}
+	r.Columns = append(r.Columns, class, responseColumn(tasks))
+	if realtime == 0 || normal == 0 {
+		return
+	}
+
+	base := simulate("", alone, p.Normal)
+	n := float64(normal)
+	r.Notes = append(r.Notes, fmt.Sprintf(
+		"RT interference: %d normal processes wait %.2f on average (%.2f without the %d realtime ones), response %.2f (%.2f)",
+		normal, normalWait/n, base.Wait, realtime, normalResp/n, averageResponse(base)))
+}
+
+// rtReports runs the realtime dispatcher over the workload with normal
+// tasks under normal.
+func rtReports(processes []Process, normal Policy, quantum int64) []Report {
+	title := fmt.Sprintf("Realtime fifo/rr (quantum %d) over normal %s", quantum, policyTitle(normal))
+	return []Report{simulate(title, processes, &RTPolicy{Quantum: quantum, Normal: normal})}
+}
+
+//endregion
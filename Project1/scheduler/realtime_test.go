@@ -0,0 +1,91 @@
+package scheduler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_RTPolicy(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader(
+		"1,8,0,3,1,batch\n2,4,1,2,1,batch\n3,3,2,1,1,fifo\n4,2,3,0,1,rr\n5,2,4,0,1,rt-rr\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		normal    Policy
+		wantGantt []TimeSlice
+		wantNote  string
+	}{
+		{
+			name:   "normal FCFS",
+			normal: FCFSPolicy{},
+			// Process 3 preempts 1 and is preempted by the higher priority rr
+			// processes, which share the CPU; 1 resumes ahead of 2.
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2}, {PID: 3, Start: 2, Stop: 3}, {PID: 4, Start: 3, Stop: 4},
+				{PID: 5, Start: 4, Stop: 5}, {PID: 4, Start: 5, Stop: 6}, {PID: 5, Start: 6, Stop: 7},
+				{PID: 3, Start: 7, Stop: 9}, {PID: 1, Start: 9, Stop: 15}, {PID: 2, Start: 15, Stop: 19},
+			},
+			wantNote: "RT interference: 2 normal processes wait 10.50 on average (3.50 without the 3 realtime ones), response 7.00 (3.50)",
+		},
+		{
+			name:   "normal SJF",
+			normal: SJFPolicy{},
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2}, {PID: 3, Start: 2, Stop: 3}, {PID: 4, Start: 3, Stop: 4},
+				{PID: 5, Start: 4, Stop: 5}, {PID: 4, Start: 5, Stop: 6}, {PID: 5, Start: 6, Stop: 7},
+				{PID: 3, Start: 7, Stop: 9}, {PID: 1, Start: 9, Stop: 15}, {PID: 2, Start: 15, Stop: 19},
+			},
+			wantNote: "RT interference: 2 normal processes wait 10.50 on average (3.50 without the 3 realtime ones), response 7.00 (3.50)",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := simulate(tt.name, processes, &RTPolicy{Quantum: 1, Normal: tt.normal})
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			if want := []string{"normal", "normal", "fifo", "rr", "rr"}; !reflect.DeepEqual(r.Columns[0].Values, want) {
+				t.Errorf("classes = %v, want %v", r.Columns[0].Values, want)
+			}
+			if len(r.Notes) == 0 || r.Notes[len(r.Notes)-1] != tt.wantNote {
+				t.Errorf("notes = %q, want %q", r.Notes, tt.wantNote)
+			}
+		})
+	}
+}
+
+func Test_setClass(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "", want: "batch"},
+		{in: "I", want: "interactive"},
+		{in: "System", want: "system"},
+		{in: "rt-fifo", want: "fifo"},
+		{in: " rr ", want: "rr"},
+		{in: "deadline", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+			var p Process
+			err := p.setClass(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("setClass(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if err == nil && p.class() != tt.want {
+				t.Errorf("class() = %q, want %q", p.class(), tt.want)
+			}
+		})
+	}
+}
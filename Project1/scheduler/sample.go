@@ -0,0 +1,54 @@
+package scheduler
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+//region Workload sampling
+
+// headProcesses keeps the first n processes of the workload, in file order.
+func headProcesses(processes []Process, n int) []Process {
+	if n < len(processes) {
+		return processes[:n]
+	}
+	return processes
+}
+
+// sampleProcesses keeps n processes chosen at random by rng, in file order.
+func sampleProcesses(processes []Process, n int, rng *rand.Rand) []Process {
+	if n >= len(processes) {
+		return processes
+	}
+	picked := rng.Perm(len(processes))[:n]
+	sort.Ints(picked)
+	sample := make([]Process, n)
+	for i, j := range picked {
+		sample[i] = processes[j]
+	}
+	return sample
+}
+
+// trimWorkload applies -head and then -sample, and says what it kept.
+func trimWorkload(processes []Process, head, sample int, seed int64) ([]Process, string, error) {
+	if head < 0 || sample < 0 {
+		return nil, "", fmt.Errorf("%w: -head and -sample must not be negative", ErrInvalidArgs)
+	}
+	total := len(processes)
+	if head > 0 {
+		processes = headProcesses(processes, head)
+	}
+	if sample > 0 {
+		processes = sampleProcesses(processes, sample, rand.New(rand.NewSource(seed)))
+	}
+	if len(processes) == total {
+		return processes, "", nil
+	}
+	if sample > 0 {
+		return processes, fmt.Sprintf("Simulating a sample of %d of %d processes (seed %d)", len(processes), total, seed), nil
+	}
+	return processes, fmt.Sprintf("Simulating the first %d of %d processes", len(processes), total), nil
+}
+
+//endregion
@@ -0,0 +1,66 @@
+package scheduler
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func Test_trimWorkload(t *testing.T) {
+	t.Parallel()
+	var processes []Process
+	for i := int64(1); i <= 10; i++ {
+		processes = append(processes, Process{ProcessID: i, BurstDuration: i, ArrivalTime: i})
+	}
+	ids := func(ps []Process) []int64 {
+		var got []int64
+		for _, p := range ps {
+			got = append(got, p.ProcessID)
+		}
+		return got
+	}
+	tests := []struct {
+		name         string
+		head, sample int
+		want         []int64
+		wantNote     string
+		wantErr      bool
+	}{
+		{name: "everything", want: ids(processes)},
+		{name: "head", head: 3, want: []int64{1, 2, 3}, wantNote: "Simulating the first 3 of 10 processes"},
+		{name: "head beyond the end", head: 20, want: ids(processes)},
+		{name: "sample", sample: 4, want: ids(sampleProcesses(processes, 4, rand.New(rand.NewSource(7)))),
+			wantNote: "Simulating a sample of 4 of 10 processes (seed 7)"},
+		{name: "negative", head: -1, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, note, err := trimWorkload(processes, tt.head, tt.sample, 7)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("trimWorkload() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(ids(got), tt.want) || note != tt.wantNote {
+				t.Errorf("trimWorkload() = %v, %q, want %v, %q", ids(got), note, tt.want, tt.wantNote)
+			}
+		})
+	}
+}
+
+func Test_sampleProcesses(t *testing.T) {
+	t.Parallel()
+	var processes []Process
+	for i := int64(1); i <= 100; i++ {
+		processes = append(processes, Process{ProcessID: i})
+	}
+	got := sampleProcesses(processes, 10, rand.New(rand.NewSource(1)))
+	if len(got) != 10 {
+		t.Fatalf("len = %d, want 10", len(got))
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i].ProcessID <= got[i-1].ProcessID {
+			t.Errorf("sample not in file order: %v", got)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//region Fractional times
+
+// maxScalePlaces is the finest resolution autoScale picks: a microtick.
+const maxScalePlaces = 6
+
+// decimalPlaces counts the digits after the decimal point of s.
+func decimalPlaces(s string) int {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(strings.TrimRight(s[i+1:], "0"))
+	}
+	return 0
+}
+
+// autoScale is the smallest power of ten that makes every time whole.
+func autoScale(times [][2]string) (int64, error) {
+	places := 0
+	for _, t := range times {
+		for _, s := range t {
+			if p := decimalPlaces(s); p > places {
+				places = p
+			}
+		}
+	}
+	if places > maxScalePlaces {
+		return 0, fmt.Errorf("%w: times finer than 1e-%d are not supported", ErrInvalidArgs, maxScalePlaces)
+	}
+	scale := int64(1)
+	for i := 0; i < places; i++ {
+		scale *= 10
+	}
+	return scale, nil
+}
+
+// parseScaled converts the decimal s to a whole number of ticks, scale per
+// unit, without going through floating point.
+func parseScaled(s string, scale int64) (int64, error) {
+	s = strings.TrimSpace(s)
+	whole, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		whole, frac = s[:i], strings.TrimRight(s[i+1:], "0")
+	}
+	neg := strings.HasPrefix(whole, "-")
+	if whole == "" || whole == "-" {
+		whole += "0"
+	}
+	n, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	n *= scale
+	if frac == "" {
+		return n, nil
+	}
+
+	f, err := strconv.ParseUint(frac, 10, 32)
+	if err != nil || len(frac) > 9 {
+		return 0, fmt.Errorf("%w: %q is not a time", ErrInvalidArgs, s)
+	}
+	unit := int64(1)
+	for range frac {
+		unit *= 10
+	}
+	if int64(f)*scale%unit != 0 {
+		return 0, fmt.Errorf("%w: %q is finer than 1/%d; set -scale", ErrInvalidArgs, s, scale)
+	}
+	if neg {
+		return n - int64(f)*scale/unit, nil
+	}
+	return n + int64(f)*scale/unit, nil
+}
+
+//endregion
@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseScaled(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		s       string
+		scale   int64
+		want    int64
+		wantErr bool
+	}{
+		{s: "12", scale: 1, want: 12},
+		{s: "2.5", scale: 10, want: 25},
+		{s: "2.50", scale: 1, wantErr: true},
+		{s: "0.25", scale: 4, want: 1},
+		{s: ".001", scale: 1000000, want: 1000},
+		{s: "-1.5", scale: 2, want: -3},
+		{s: "1.2e3", scale: 10, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.s, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseScaled(tt.s, tt.scale)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseScaled() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseScaled() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_loadScaledProcesses(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		in        string
+		scale     int64
+		wantScale int64
+		want      [][2]int64 // burst and arrival of each process
+		wantErr   bool
+	}{
+		{name: "whole", in: "1,5,0\n2,3,1\n", scale: 0, wantScale: 1, want: [][2]int64{{5, 0}, {3, 1}}},
+		{name: "auto", in: "1,2.5,0\n2,0.125,1.5\n", scale: 0, wantScale: 1000, want: [][2]int64{{2500, 0}, {125, 1500}}},
+		{name: "given", in: "1,2.5,0\n2,3,1.5\n", scale: 4, wantScale: 4, want: [][2]int64{{10, 0}, {12, 6}}},
+		{name: "JSON", in: `[{"id":1,"burst":0.5,"arrival":1}]`, scale: 0, wantScale: 10, want: [][2]int64{{5, 10}}},
+		{name: "too fine", in: "1,0.0000001,0\n", scale: 0, wantErr: true},
+		{name: "strict", in: "1,2.5,0\n", scale: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			processes, scale, err := loadScaledProcesses(strings.NewReader(tt.in), tt.scale)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadScaledProcesses() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			var got [][2]int64
+			for _, p := range processes {
+				got = append(got, [2]int64{p.BurstDuration, p.ArrivalTime})
+			}
+			if scale != tt.wantScale || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadScaledProcesses() = %v at scale %d, want %v at %d", got, scale, tt.want, tt.wantScale)
+			}
+		})
+	}
+}
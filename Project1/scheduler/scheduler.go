@@ -0,0 +1,1613 @@
+// Package scheduler simulates CPU scheduling algorithms over a workload of
+// processes. Simulate runs a single policy and is the entry point for
+// embedding the simulator in another program; Main is the command line
+// interface built on top of it.
+package scheduler
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// Main runs the command line interface: a subcommand named by the first
+// argument, or every scheduler over the workload file given after the flags.
+func Main() {
+	if len(os.Args) > 1 {
+		if run, ok := subcommands[os.Args[1]]; ok {
+			if err := run(os.Stdout, os.Args[2:]...); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+	}
+
+	gifDir := flag.String("gif", "", "directory to write an animated GIF of each schedule to")
+	jsonPath := flag.String("json", "", "file to write the schedules and their metrics to as JSON")
+	xlsxPath := flag.String("xlsx", "", "Excel workbook to write the schedules, a comparison and Gantt charts to")
+	otlpDest := flag.String("otlp", "", "file or collector URL (e.g. http://localhost:4318/v1/traces) to export OTLP/JSON spans to")
+	otlpTick := flag.Duration("otlp-tick", 0, "real duration of one tick in exported spans and Chrome traces; 0 takes it from -unit, or 1ms without one")
+	human := flag.Bool("human", false, "print times in the schedule reports with units, taking a tick to last as -unit says, or 1ms without one")
+	chromeTracePath := flag.String("chrome-trace", "", "file to write the schedules to in the Trace Event Format, for chrome://tracing or ui.perfetto.dev")
+	notifyURL := flag.String("notify-url", "", "webhook to POST a JSON summary to when the run finishes")
+	doneFile := flag.String("done-file", "", "marker file to write a JSON summary to when the run finishes")
+	switchCost := flag.Int64("switch-cost", 0, "also run each algorithm with every context switch taking this many ticks of overhead")
+	dispatchLatency := flag.Int64("dispatch-latency", 0, "also run each algorithm with every dispatch, even a process's first, taking this many ticks of overhead, with -switch-cost's if given")
+	mpl := flag.Int("mpl", 0, "also run each algorithm behind a long-term scheduler admitting at most this many processes at once")
+	buffer := flag.Int64("buffer", 0, "also run each algorithm with -producers and -consumers sharing a bounded buffer of this many slots")
+	producers := flag.String("producers", "", "comma separated IDs of the processes that produce one item per tick into the buffer")
+	consumers := flag.String("consumers", "", "comma separated IDs of the processes that consume one item per tick from the buffer")
+	bankerState := flag.String("banker", "", "also run each algorithm with the processes claiming resources from this banker state file, granted only while the state stays safe")
+	mlfq := flag.String("mlfq", "", "also run a multilevel feedback queue with these comma separated per-queue quanta, top queue first; 0 is first-come, first-served")
+	mlfqAllotments := flag.String("mlfq-allotments", "", "comma separated CPU time a process may use in each -mlfq queue before demotion; defaults to the queue's quantum")
+	mlfqBoost := flag.Int64("mlfq-boost", 0, "move every process back to the top -mlfq queue this often, in ticks; 0 never does")
+	random := flag.Bool("random", false, "also run a random dispatch baseline, choosing among the ready processes with -seed, and compare the classic algorithms with it")
+	priorityRR := flag.Int64("priority-rr", 0, "also run priority scheduling that round-robins processes of the same priority with this quantum")
+	lottery := flag.Bool("lottery", false, "also run lottery scheduling, drawing a winner every -quantum ticks with the weight column as tickets and -seed")
+	stride := flag.Bool("stride", false, "also run stride scheduling, choosing every -quantum ticks with the weight column as tickets")
+	mlq := flag.String("mlq", "", "also run a multilevel queue of comma separated class=policy queues, highest first, like \"system=fcfs,interactive=rr:4,batch=fcfs\", or \"default\" for that with -quantum")
+	edf := flag.Bool("edf", false, "also run earliest deadline first on the deadline column, counting the deadlines missed")
+	wrr := flag.Int64("wrr", 0, "also run weighted round-robin with this base quantum, scaled by each process's -wrr-weights weight")
+	wrrWeights := flag.String("wrr-weights", "weight", "what -wrr weighs processes by: weight (the weight column over 100), nice (CFS weight over nice 0's) or priority (one more per level above the least important)")
+	credit := flag.Int64("credit", 0, "also run a Xen-style credit scheduler with this time slice, sharing credits by the weight column, with -io-prob's I/O if given")
+	creditPeriod := flag.Int64("credit-period", 30, "how often, in ticks, -credit shares out credits")
+	o1 := flag.Bool("o1", false, "also run the Linux O(1) scheduler, with priorities and timeslices from the nice column, with -io-prob's I/O if given")
+	srr := flag.Int64("srr", 0, "also run selfish round-robin with this quantum")
+	srrA := flag.Float64("srr-a", 2, "how fast, per tick, the priority of a process in the -srr new queue rises")
+	srrB := flag.Float64("srr-b", 1, "how fast, per tick, the priority of an accepted -srr process rises")
+	guaranteed := flag.Bool("guaranteed", false, "also run guaranteed scheduling, giving each runnable process an equal share of the CPU")
+	feedback := flag.Int("feedback", 0, "also run feedback scheduling with this many levels, each with double the quantum of the one above")
+	feedbackQuantum := flag.Int64("feedback-quantum", 1, "quantum of the top -feedback level")
+	drr := flag.Int64("drr", 0, "also run deficit round-robin with this quantum of credit per turn, next to round-robin with it, both with -io-prob's I/O if given")
+	aging := flag.Float64("aging", 0, "also run priority scheduling where waiting improves a process's priority by this much per tick")
+	agingStarve := flag.Int64("aging-starve", 0, "wait without -aging past which a process counts as starved; 0 means twice the average wait")
+	cpus := flag.Int("cpus", 1, "also run first-come, first-serve, shortest-job-first, priority and round-robin on this many CPUs sharing a ready queue")
+	cpuMode := flag.String("cpu-mode", "global", "comma separated ways the -cpus CPUs share processes: global, one ready queue for all; partitioned, a queue per CPU with processes placed first-fit by utilization; stealing, partitioned with idle CPUs stealing from the longest queue")
+	stealThreshold := flag.Int("steal-threshold", 1, "how many processes must be waiting in a queue for a -cpu-mode stealing CPU to steal from it")
+	cpuSpeeds := flag.String("cpu-speeds", "", "comma separated speed of each -cpus CPU relative to 1, like \"1,1,0.5,0.5\" for two big and two LITTLE cores; sets -cpus if it isn't given")
+	energyAware := flag.Bool("energy-aware", false, "also run the -cpus schedulers placing processes on the most energy-efficient, slowest, CPUs first")
+	migrationPenalty := flag.Int64("migration-penalty", 0, "ticks a -cpus process dispatched on another CPU than the one it last ran on spends warming its cache before doing any work")
+	gang := flag.Int("gang", 0, "also run gang scheduling on this many CPUs, dispatching the processes of each group in the group column together, for -quantum ticks at a time")
+	llf := flag.Bool("llf", false, "also run least laxity first on the deadline column, counting the deadlines missed and context switches")
+	cfs := flag.Bool("cfs", false, "also run the completely fair scheduler, weighting processes by the nice column")
+	cfsLatency := flag.Int64("cfs-latency", 24, "-cfs target latency: the period, in ticks, every runnable process should run once in")
+	eevdf := flag.Int64("eevdf", 0, "also run EEVDF, earliest eligible virtual deadline first, with this base slice, weighting processes by the nice column and comparing it with -cfs's settings")
+	cfsGranularity := flag.Int64("cfs-granularity", 3, "-cfs minimum granularity: the shortest slice, in ticks, a process runs before it can be preempted")
+	interactiveQuantum := flag.Int64("interactive-quantum", 0, "also run interactive processes round-robin with this quantum over batch processes in the background")
+	preemptions := flag.Int("preemptions", -1, "also run round-robin allowed at most this many preemptions, after which processes run to completion")
+	rtNormal := flag.String("rt", "", "also run realtime (fifo and rr class) processes ahead of normal ones, which use this policy (fcfs, sjf, priority, preemptive-priority, srtf, hrrn, rr or priority-rr)")
+	throttle := flag.Int64("throttle", 0, "also run round-robin with batch processes limited to this percentage of the CPU over -throttle-window")
+	throttleWindow := flag.Int64("throttle-window", 100, "sliding window, in ticks, the -throttle limit applies over")
+	bvt := flag.Bool("bvt", false, "also run borrowed virtual time scheduling, with and without -warp")
+	warp := flag.String("warp", "", "comma separated <pid>=<warp> virtual time each process may borrow under -bvt")
+	groups := flag.String("groups", "", "also run hierarchical fair sharing with weighted groups like \"web=300:1,2;web/api=200:4;db=100:3\" (weights are in the units of the weight column, 100 by default), which -cfs and -stride then share the CPU by too")
+	partitions := flag.String("partitions", "", "also run adaptive partitioning with budgets like \"gui=60:1,2;batch=30:3\"")
+	window := flag.Int64("partition-window", 100, "sliding window, in ticks, partition budgets apply over")
+	queueCSV := flag.String("queue-csv", "", "file to write each schedule's ready queue length at every tick to as CSV")
+	histogram := flag.Bool("histogram", false, "print a histogram of each algorithm's waiting times")
+	histogramJSON := flag.String("histogram-json", "", "file to write each algorithm's waiting time histogram to as JSON")
+	timelineCSV := flag.String("timeline-csv", "", "file to write every Gantt slice and idle gap to as CSV")
+	heatmap := flag.Bool("heatmap", false, "print a heatmap of each CPU's utilization over time, for the -cpus schedulers when there are any")
+	radarPath := flag.String("radar", "", "SVG file to draw a radar chart comparing the algorithms across metrics to")
+	queueing := flag.Bool("queueing", false, "compare each algorithm's wait and queue length with an M/M/c model fitted to the workload")
+	servers := flag.Int("servers", 1, "servers of the -queueing model")
+	worst := flag.Int("worst", 0, "list this many of each algorithm's worst-served processes")
+	sloSpec := flag.String("slo", "", "tag processes with response time targets like \"web=5:1,2;db=20:3\" and report each algorithm's attainment")
+	summaryFormat := flag.String("summary", "", "print the aggregate metrics of each algorithm as json or yaml")
+	head := flag.Int("head", 0, "simulate only the first N processes of the workload")
+	sample := flag.Int("sample", 0, "simulate only N processes of the workload chosen at random")
+	jitter := flag.Int64("jitter", 0, "move each arrival by a random amount of at most this many ticks either way")
+	jitterRuns := flag.Int("jitter-runs", 0, "also summarize each algorithm's averages across this many -jitter runs")
+	seed := flag.Int64("seed", 0, "random seed for -sample, -jitter, -io-prob, -random and -lottery; 0 uses the current time")
+	pareto := flag.Bool("pareto", false, "list the algorithms no other beats on average wait, response, context switches and fairness all at once")
+	convoys := flag.Bool("convoys", false, "look for long jobs holding up many short ones under FCFS, SJF and round-robin")
+	adviseMetric := flag.String("advise-quantum", "", "search quanta near -quantum for the one minimizing this metric (as in -assert; throughput and utilization are maximized)")
+	auditPath := flag.String("audit", "", "file to write an explanation of every decision FCFS, SJF, priority, round-robin, SRTF and preemptive priority make to, as JSON lines")
+	historyPath := flag.String("history", "", "state file of per-process burst history: also run SJF and SRTF on bursts predicted from it, then add this run's bursts")
+	predict := flag.Bool("predict", false, "also run SJF and SRTF on bursts predicted as -history does, but learning only within this run, without a history file")
+	historyMode := flag.String("history-mode", historyExponential, "how -history and -predict predict bursts: average or exponential")
+	historyAlpha := flag.Float64("history-alpha", 0.5, "weight of the latest burst in the exponential -history estimate")
+	historyTau0 := flag.Float64("history-tau0", 0, "-history estimate τ0 for processes with no history; 0 predicts the mean of the others and learns a new process's first burst as its estimate")
+	ioProb := flag.Float64("io-prob", 0, "also run each algorithm with running processes starting I/O with this probability every tick")
+	ioMean := flag.Float64("io-mean", 5, "mean duration, in ticks, of the -io-prob I/O")
+	unit := flag.String("unit", "", "real duration of the workload's time unit, like ms or 10us, which each tick is the -scale'th part of")
+	scale := flag.Int64("scale", 0, "ticks per time unit of the workload, for fractional burst and arrival times; 0 picks the smallest power of ten that makes them whole")
+	quantum := flag.Int64("quantum", DefaultQuantum, "round-robin time quantum")
+	assertSpec := flag.String("assert", "", "comma separated metric assertions like \"avg_wait<=20,round-robin.makespan<100\"; exit 1 if any fails")
+	configPath := flag.String("config", defaultConfigPath, "config file to read -profile from")
+	profileName := flag.String("profile", "", "run the named profile from the config file")
+	lang := flag.String("lang", "", "language of the schedule reports: en, de, es or fr; defaults to the locale environment variables")
+	messagesPath := flag.String("messages", "", "JSON message catalog overriding the -lang labels and text, like {\"decimal\": \",\", \"messages\": {\"wait\": \"Espera\"}, \"text\": {\"Preemptions: %d\": \"Expropiaciones: %d\"}}")
+	decimal := flag.String("decimal", "", "decimal separator of the schedule reports, overriding -lang's")
+	flag.Parse()
+	started := time.Now()
+
+	var profile Profile
+	args := append([]string{os.Args[0]}, flag.Args()...)
+	if *profileName != "" {
+		config, err := loadConfig(*configPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if profile, err = config.profile(*profileName); err != nil {
+			log.Fatal(err)
+		}
+		if err := profile.apply(flag.CommandLine); err != nil {
+			log.Fatal(err)
+		}
+		if flag.NArg() == 0 && profile.Workload != "" {
+			args = append(args, profile.Workload)
+		}
+	}
+
+	// CLI args
+	f, closeFile, err := openProcessingFile(args...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer closeFile()
+	workload := args[1]
+
+	// Load and parse processes
+	processes, metadata, err := loadWorkload(f, *scale)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := applyMetadata(flag.CommandLine, metadata); err != nil {
+		log.Fatal(err)
+	}
+	locale := *lang
+	if locale == "" {
+		if locale = localeFromEnv(); catalogs[locale].Messages == nil {
+			locale = "en"
+		}
+	}
+	cat, err := loadCatalog(locale, *messagesPath, *decimal)
+	if err != nil {
+		log.Fatal(err)
+	}
+	tick, err := tickDuration(*unit, *scale)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *otlpTick == 0 {
+		if *otlpTick = tick; tick == 0 {
+			*otlpTick = time.Millisecond
+		}
+	}
+	out := renderer{catalog: cat}
+	if *human {
+		if out.tick = tick; tick == 0 {
+			out.tick = time.Millisecond
+		}
+	}
+
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	processes, trimmed, err := trimWorkload(processes, *head, *sample, *seed)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if trimmed != "" {
+		fmt.Println(trimmed)
+	}
+	if *jitter < 0 {
+		log.Fatal("-jitter must not be negative")
+	}
+	original := processes
+	if *jitter > 0 {
+		processes = jitterArrivals(processes, *jitter, rand.New(rand.NewSource(*seed)))
+		fmt.Printf("Arrivals jittered by up to ±%d (seed %d)\n", *jitter, *seed)
+	}
+
+	slos, err := parseSLOs(*sloSpec)
+	if err != nil {
+		log.Fatal(err)
+	}
+	assertions, err := parseAssertions(*assertSpec)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// -groups also shares the CPU down the hierarchy in -cfs and -stride.
+	var groupRoot *Group
+	groupedTitle := ""
+	if *groups != "" {
+		if groupRoot, err = parseGroups(*groups); err != nil {
+			log.Fatal(err)
+		}
+		groupedTitle = ", grouped"
+	}
+
+	if err := CheckProcesses(processes); err != nil {
+		log.Fatal(err)
+	}
+	reports := RunSchedulers(processes, *quantum)
+	if *mpl > 0 {
+		reports = append(reports, twoLevelReports(processes, *mpl, *quantum)...)
+	}
+	if hasBurstCycles(processes) {
+		reports = append(reports, burstCycleReports(processes, *quantum)...)
+	}
+	if *switchCost < 0 || *dispatchLatency < 0 {
+		log.Fatal("-switch-cost and -dispatch-latency must not be negative")
+	}
+	if *switchCost > 0 || *dispatchLatency > 0 {
+		reports = append(reports, overheadReports(processes, *switchCost, *dispatchLatency, *quantum)...)
+	}
+	if *cpus < 1 {
+		log.Fatal("-cpus must be at least 1")
+	}
+	if (*cpus > 1 || *cpuSpeeds != "" || *gang > 0) && (*switchCost > 0 || *dispatchLatency > 0 || *ioProb > 0 || *buffer > 0 || *bankerState != "") {
+		log.Fatal("-cpus and -gang runs don't model -switch-cost, -dispatch-latency, -io-prob, -buffer or -banker")
+	}
+	var coreOpts []CoreOption
+	if *cpuSpeeds != "" {
+		speeds, err := parseSpeeds(*cpuSpeeds)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if *cpus == 1 {
+			*cpus = len(speeds)
+		}
+		if len(speeds) != *cpus {
+			log.Fatalf("-cpu-speeds gives %d speeds for %d CPUs", len(speeds), *cpus)
+		}
+		coreOpts = append(coreOpts, WithSpeeds(speeds))
+	}
+	if *migrationPenalty < 0 {
+		log.Fatal("-migration-penalty must not be negative")
+	}
+	if *migrationPenalty > 0 {
+		coreOpts = append(coreOpts, WithMigrationPenalty(*migrationPenalty))
+	}
+	if *cpus > 1 {
+		multi, err := multicoreReports(processes, *cpus, *quantum, *cpuMode, *stealThreshold, coreOpts...)
+		if err != nil {
+			log.Fatal(err)
+		}
+		reports = append(reports, multi...)
+		if *energyAware {
+			if multi, err = multicoreReports(processes, *cpus, *quantum, *cpuMode, *stealThreshold, append(coreOpts, WithEnergyAware())...); err != nil {
+				log.Fatal(err)
+			}
+			reports = append(reports, multi...)
+		}
+	}
+	if *interactiveQuantum > 0 {
+		reports = append(reports, classReports(processes, *interactiveQuantum)...)
+	}
+	if *preemptions >= 0 {
+		reports = append(reports, preemptionReports(processes, *preemptions, *quantum)...)
+	}
+	if *historyPath != "" || *predict {
+		predicted, err := historyReports(processes, *historyPath, *historyMode, *historyAlpha, *historyTau0)
+		if err != nil {
+			log.Fatal(err)
+		}
+		reports = append(reports, predicted...)
+	}
+	if *ioProb > 0 {
+		if *ioProb > 1 || *ioMean <= 0 {
+			log.Fatal("-io-prob must be at most 1 and -io-mean positive")
+		}
+		reports = append(reports, randomIOReports(processes, *ioProb, *ioMean, *seed, *quantum)...)
+	}
+	if *throttle > 0 {
+		if *throttle >= 100 || *throttleWindow <= 0 {
+			log.Fatal("-throttle must be below 100 and -throttle-window positive")
+		}
+		reports = append(reports, simulate(fmt.Sprintf("Round-robin, quantum %d, batch throttled to %d%%", *quantum, *throttle),
+			processes, &ThrottlePolicy{Inner: RRPolicy{Quantum: *quantum}, Limit: *throttle, Window: *throttleWindow}))
+	}
+	if *rtNormal != "" {
+		normal, err := PolicyByName(*rtNormal, *quantum)
+		if err != nil {
+			log.Fatal(err)
+		}
+		reports = append(reports, rtReports(processes, normal, *quantum)...)
+	}
+	if *mlfq != "" {
+		config, err := parseMLFQ(*mlfq, *mlfqAllotments, *mlfqBoost)
+		if err != nil {
+			log.Fatal(err)
+		}
+		p := &MLFQPolicy{Config: config}
+		reports = append(reports, simulate(p.title(), processes, p))
+	}
+	if *priorityRR > 0 {
+		reports = append(reports, PriorityRR(fmt.Sprintf("Priority with round-robin, quantum %d", *priorityRR), processes, *priorityRR))
+	}
+	if *random {
+		reports = append(reports, Random(fmt.Sprintf("Random dispatch, seed %d", *seed), processes, *quantum, *seed))
+	}
+	if *lottery {
+		reports = append(reports, Lottery(fmt.Sprintf("Lottery, quantum %d, seed %d", *quantum, *seed), processes, *quantum, *seed))
+	}
+	if *stride {
+		reports = append(reports, simulate(fmt.Sprintf("Stride, quantum %d%s", *quantum, groupedTitle), processes,
+			&StridePolicy{Quantum: *quantum, Groups: groupRoot}))
+	}
+	if *edf {
+		reports = append(reports, EDF("Earliest deadline first", processes))
+	}
+	if *wrr > 0 {
+		r, err := WRR(fmt.Sprintf("Weighted round-robin, base quantum %d by %s", *wrr, *wrrWeights), processes, *wrr, *wrrWeights)
+		if err != nil {
+			log.Fatal(err)
+		}
+		reports = append(reports, r)
+	}
+	if *drr > 0 {
+		var opts []Option
+		if *ioProb > 0 {
+			opts = append(opts, WithRandomIO(*ioProb, *ioMean, *seed))
+		}
+		reports = append(reports, drrReports(processes, *drr, opts...)...)
+	}
+	if *credit > 0 {
+		if *creditPeriod <= 0 {
+			log.Fatal(fmt.Errorf("%w: -credit-period must be positive", ErrInvalidArgs))
+		}
+		var opts []Option
+		if *ioProb > 0 {
+			opts = append(opts, WithRandomIO(*ioProb, *ioMean, *seed))
+		}
+		reports = append(reports, Credit(fmt.Sprintf("Credit scheduler, slice %d, accounting every %d", *credit, *creditPeriod), processes, *credit, *creditPeriod, opts...))
+	}
+	if *o1 {
+		var opts []Option
+		if *ioProb > 0 {
+			opts = append(opts, WithRandomIO(*ioProb, *ioMean, *seed))
+		}
+		reports = append(reports, O1("O(1) scheduler", processes, opts...))
+	}
+	if *srr > 0 {
+		if *srrA <= 0 || *srrB < 0 {
+			log.Fatal(fmt.Errorf("%w: -srr-a must be positive and -srr-b not negative", ErrInvalidArgs))
+		}
+		p := &SRRPolicy{Quantum: *srr, A: *srrA, B: *srrB}
+		reports = append(reports, simulate(p.title(), processes, p))
+	}
+	if *guaranteed {
+		reports = append(reports, Guaranteed("Guaranteed scheduling", processes))
+	}
+	if *feedback > 0 {
+		p, err := newFeedback(*feedback, *feedbackQuantum)
+		if err != nil {
+			log.Fatal(err)
+		}
+		reports = append(reports, simulate(p.title(), processes, p))
+	}
+	if *aging > 0 {
+		reports = append(reports, Aging(fmt.Sprintf("Priority with aging %g/tick", *aging), processes, *aging, *agingStarve))
+	}
+	if *gang > 0 {
+		r, err := Gang(fmt.Sprintf("Gang scheduling, %d CPUs", *gang), processes, *gang, *quantum)
+		if err != nil {
+			log.Fatal(err)
+		}
+		reports = append(reports, r)
+	}
+	if *llf {
+		reports = append(reports, LLF("Least laxity first", processes))
+	}
+	if *eevdf > 0 {
+		if *cfsLatency <= 0 || *cfsGranularity <= 0 {
+			log.Fatal("-cfs-latency and -cfs-granularity must be positive")
+		}
+		reports = append(reports, EEVDF(fmt.Sprintf("EEVDF, base slice %d", *eevdf), processes, *eevdf, *cfsLatency, *cfsGranularity))
+	}
+	if *cfs {
+		if *cfsLatency <= 0 || *cfsGranularity <= 0 {
+			log.Fatal("-cfs-latency and -cfs-granularity must be positive")
+		}
+		reports = append(reports, simulate(fmt.Sprintf("CFS, latency %d, granularity %d%s", *cfsLatency, *cfsGranularity, groupedTitle), processes,
+			&CFSPolicy{Latency: *cfsLatency, Granularity: *cfsGranularity, Groups: groupRoot}))
+	}
+	if *mlq != "" {
+		p, err := parseMLQ(*mlq, *quantum)
+		if err != nil {
+			log.Fatal(err)
+		}
+		reports = append(reports, simulate(p.title(), processes, p))
+	}
+	if *bvt {
+		warps, err := parseWarps(*warp)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if *cfsLatency <= 0 || *cfsGranularity <= 0 {
+			log.Fatal("-cfs-latency and -cfs-granularity must be positive")
+		}
+		reports = append(reports, bvtReports(processes, warps, *cfsLatency, *cfsGranularity)...)
+	}
+	if groupRoot != nil {
+		reports = append(reports, simulate("Hierarchical fair share", processes, &GroupPolicy{Root: groupRoot}))
+	}
+	if *partitions != "" {
+		parts, err := parsePartitions(*partitions)
+		if err != nil {
+			log.Fatal(err)
+		}
+		reports = append(reports, simulate("Adaptive partitioning", processes,
+			&AdaptivePartitionPolicy{Partitions: parts, Window: *window}))
+	}
+	if *buffer > 0 {
+		p, err := parsePIDs(*producers)
+		if err != nil {
+			log.Fatal(err)
+		}
+		c, err := parsePIDs(*consumers)
+		if err != nil {
+			log.Fatal(err)
+		}
+		reports = append(reports, bufferReports(processes, *buffer, p, c, *quantum)...)
+	}
+	if *bankerState != "" {
+		f, err := os.Open(*bankerState)
+		if err != nil {
+			log.Fatal(err)
+		}
+		state, err := loadResourceState(f)
+		_ = f.Close()
+		if err != nil {
+			log.Fatal(err)
+		}
+		reports = append(reports, bankerReports(processes, state, *quantum)...)
+	}
+	reports = profile.selectReports(reports)
+	switch {
+	case tick > 0:
+		fmt.Printf("Times are in ticks of %s\n", formatDuration(float64(tick)))
+	case *scale > 1:
+		fmt.Printf("Times are in ticks of 1/%d of the workload's time unit\n", *scale)
+	}
+	for _, r := range reports {
+		out.outputReport(os.Stdout, r)
+	}
+
+	if *jitter > 0 && *jitterRuns > 0 {
+		outputJitterSpreads(os.Stdout, jitterSpreads(original, *jitter, *jitterRuns, *seed, *quantum),
+			*jitter, *jitterRuns, *seed)
+	}
+	if *queueing {
+		model, err := fitQueueingModel(processes, *servers)
+		if err != nil {
+			log.Fatal(err)
+		}
+		outputQueueing(os.Stdout, model, processes, *quantum)
+	}
+	if len(slos) > 0 {
+		outputSLOs(os.Stdout, reports, slos)
+	}
+	if *pareto {
+		outputPareto(os.Stdout, reports)
+	}
+	if *adviseMetric != "" {
+		advice, err := adviseQuantum(processes, *quantum, *adviseMetric)
+		if err != nil {
+			log.Fatal(err)
+		}
+		outputQuantumAdvice(os.Stdout, advice)
+	}
+	if *convoys {
+		outputConvoys(os.Stdout, convoyReports(processes, *quantum))
+	}
+	if *worst > 0 {
+		outputWorstServed(os.Stdout, reports, *worst)
+	}
+	if *heatmap {
+		for _, r := range heatmapReports(reports) {
+			outputHeatmap(os.Stdout, r)
+		}
+	}
+	if *histogram || *histogramJSON != "" {
+		histograms := waitHistograms(reports)
+		if *histogram {
+			for _, h := range histograms {
+				outputHistogram(os.Stdout, h)
+			}
+		}
+		if *histogramJSON != "" {
+			if err := writeJSON(*histogramJSON, histograms); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+	if *jsonPath != "" {
+		if err := writeReports(*jsonPath, reports); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *radarPath != "" {
+		if err := writeRadar(*radarPath, reports); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *timelineCSV != "" {
+		if err := writeTimelineCSV(*timelineCSV, reports); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *queueCSV != "" {
+		if err := writeReadyQueueCSV(*queueCSV, reports); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *xlsxPath != "" {
+		if err := writeXLSX(*xlsxPath, reports); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *otlpDest != "" {
+		if err := exportOTLP(*otlpDest, reports, time.Now(), *otlpTick); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *auditPath != "" {
+		if err := writeAuditLog(*auditPath, processes, *quantum); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *chromeTracePath != "" {
+		if err := writeChromeTrace(*chromeTracePath, reports, *otlpTick); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *gifDir != "" {
+		if err := writeGIFs(*gifDir, processes, reports); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	summary := func() Summary {
+		s := summarize(workload, started, time.Now(), reports)
+		s.addSLOs(reports, slos)
+		return s
+	}
+	switch *summaryFormat {
+	case "":
+	case "json":
+		if err := outputJSON(os.Stdout, summary()); err != nil {
+			log.Fatal(err)
+		}
+	case "yaml":
+		outputSummaryYAML(os.Stdout, summary())
+	default:
+		log.Fatalf("%v: -summary must be json or yaml", ErrInvalidArgs)
+	}
+
+	if *notifyURL != "" || *doneFile != "" {
+		if err := notifyCompletion(*notifyURL, *doneFile, summary()); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if failures := checkAssertions(assertions, reports); len(failures) > 0 {
+		for _, f := range failures {
+			log.Print("assertion failed: ", f)
+		}
+		os.Exit(1)
+	}
+}
+
+// subcommands run instead of the schedulers when named by the first argument.
+var subcommands = map[string]func(w io.Writer, args ...string) error{
+	"banker":         runBanker,
+	"compare":        runCompare,
+	"disk":           runDisk,
+	"grade":          runGrade,
+	"import-trace":   runImportTrace,
+	"memory":         runMemory,
+	"paging":         runPaging,
+	"tune":           runTune,
+	"run":            runCheckpointed,
+	"snapshot":       runSnapshot,
+	"threads":        runThreads,
+	"workload":       runWorkload,
+	"import-cgroups": runImportCgroups,
+	"periodic":       runPeriodic,
+	"watch":          runWatch,
+	"exec":           runExec,
+	"apply":          runApply,
+	"view":           runView,
+}
+
+// DefaultQuantum is the round-robin time quantum unless -quantum or the workload sets another.
+const DefaultQuantum = 10
+
+// CheckProcesses reports whether RunSchedulers can schedule processes: the
+// algorithms it runs need every burst to be positive, and never finish
+// otherwise.
+func CheckProcesses(processes []Process) error {
+	for _, p := range processes {
+		if p.BurstDuration <= 0 {
+			return fmt.Errorf("%w: process %d needs a positive burst", ErrInvalidArgs, p.ProcessID)
+		}
+	}
+	return nil
+}
+
+// RunSchedulers runs every scheduler over processes, in the order they are
+// reported. It works on a copy, so processes is left as it was; check them
+// with CheckProcesses first.
+func RunSchedulers(processes []Process, quantum int64) []Report {
+	processes = append([]Process(nil), processes...)
+	return []Report{
+		// First-come, first-serve scheduling
+		FCFS("First-come, first-serve", processes),
+
+		//Shortest job first scheduling
+		SJF("Shortest-job-first", processes),
+
+		// Shortest remaining time first scheduling
+		SRTF("Shortest-remaining-time-first", processes),
+
+		// Highest response ratio next scheduling
+		HRRN("Highest response ratio next", processes),
+
+		//Shortest job priority sscheduing
+		SJFPriority("Priority", processes),
+
+		// Preemptive priority scheduling
+		PreemptivePriority("Preemptive priority", processes),
+
+		// Round robin Scheduling
+		RR("Round-robin", processes, quantum),
+	}
+}
+
+// twoLevelReports runs each short-term policy behind an admission limit of mpl processes.
+func twoLevelReports(processes []Process, mpl int, quantum int64) []Report {
+	suffix := fmt.Sprintf(" (two-level, MPL %d)", mpl)
+	return []Report{
+		simulate("First-come, first-serve"+suffix, processes, FCFSPolicy{}, WithMultiprogramming(mpl)),
+		simulate("Shortest-job-first"+suffix, processes, SJFPolicy{}, WithMultiprogramming(mpl)),
+		simulate("Priority"+suffix, processes, PriorityPolicy{}, WithMultiprogramming(mpl)),
+		simulate("Round-robin"+suffix, processes, RRPolicy{Quantum: quantum}, WithMultiprogramming(mpl)),
+	}
+}
+
+func openProcessingFile(args ...string) (*os.File, func(), error) {
+	if len(args) != 2 {
+		return nil, nil, fmt.Errorf("%w: must give a scheduling file to process", ErrInvalidArgs)
+	}
+	// Read in CSV process CSV file
+	f, err := os.Open(args[1])
+	if err != nil {
+		return nil, nil, fmt.Errorf("%v: error opening scheduling file", err)
+	}
+	closeFn := func() {
+		if err := f.Close(); err != nil {
+			log.Fatalf("%v: error closing scheduling file", err)
+		}
+	}
+
+	return f, closeFn, nil
+}
+
+type (
+	Process struct {
+		ProcessID     int64 `json:"id"`
+		ArrivalTime   int64 `json:"arrival"`
+		BurstDuration int64 `json:"burst"`
+		Priority      int64 `json:"priority"`
+		// Weight is the process's CPU share for proportional-share schedulers;
+		// zero means defaultWeight.
+		Weight int64 `json:"weight,omitempty"`
+		// Interactive marks a latency-sensitive process; others are batch.
+		Interactive bool `json:"interactive,omitempty"`
+		// System marks an operating system process, for the multilevel queue.
+		System bool `json:"system,omitempty"`
+		// RealTime is rtFIFO or rtRR for a realtime process and empty for a normal one.
+		RealTime string `json:"realtime,omitempty"`
+		// Nice is the process's nice value, -20 to 19, which sets its weight
+		// under CFS.
+		Nice int64 `json:"nice,omitempty"`
+		// Deadline is the absolute time the process must finish by; zero means none.
+		Deadline int64 `json:"deadline,omitempty"`
+		// Group is the gang the process is co-scheduled with; zero means none.
+		Group int64 `json:"group,omitempty"`
+		// Affinity is the mask of CPUs the process may run on, bit n for CPU
+		// n; zero means any.
+		Affinity uint64 `json:"affinity,omitempty"`
+		// Bursts is the process's burst cycle, alternating CPU and I/O
+		// bursts that start and end with CPU, BurstDuration being the CPU
+		// bursts' total; empty means a single CPU burst.
+		Bursts []int64 `json:"bursts,omitempty"`
+	}
+	TimeSlice struct {
+		PID   int64 `json:"pid"`
+		Start int64 `json:"start"`
+		Stop  int64 `json:"stop"`
+		// CPU is the core the slice ran on; single-CPU schedules leave it 0.
+		CPU int `json:"cpu,omitempty"`
+		// Overhead marks a context switch, when the CPU ran no process.
+		Overhead bool `json:"overhead,omitempty"`
+	}
+	// Row is one line of the schedule table.
+	Row struct {
+		ProcessID  int64 `json:"id"`
+		Priority   int64 `json:"priority"`
+		Burst      int64 `json:"burst"`
+		Arrival    int64 `json:"arrival"`
+		Wait       int64 `json:"wait"`
+		Turnaround int64 `json:"turnaround"`
+		Exit       int64 `json:"exit"`
+		// Deadline is the absolute time the process had to finish by; zero means none.
+		Deadline int64 `json:"deadline,omitempty"`
+	}
+	// Column is an extra column of the schedule table, with a value per row.
+	Column struct {
+		Header string   `json:"header"`
+		Values []string `json:"values"`
+		Footer string   `json:"footer,omitempty"`
+	}
+	// Report is the outcome of running a scheduler: its Gantt chart, the rows
+	// of the schedule table, and the averages shown in the table footer.
+	// Some schedulers add columns to the table and notes printed after it.
+	Report struct {
+		Title      string      `json:"title"`
+		Gantt      []TimeSlice `json:"gantt"`
+		Rows       []Row       `json:"rows"`
+		Wait       float64     `json:"average_wait"`
+		Turnaround float64     `json:"average_turnaround"`
+		Throughput float64     `json:"throughput"`
+		Columns    []Column    `json:"columns,omitempty"`
+		Notes      []string    `json:"notes,omitempty"`
+		// ReadyQueue is how many processes were in the ready queue at each
+		// tick, for runs on the simulation engine.
+		ReadyQueue []int64 `json:"ready_queue,omitempty"`
+	}
+)
+
+//region Schedulers
+
+// FCFSSchedule outputs a schedule of processes in a GANTT chart and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+func FCFSSchedule(w io.Writer, title string, processes []Process) {
+	outputReport(w, FCFS(title, processes))
+}
+
+// FCFS schedules processes first-come, first-serve and returns the resulting report.
+func FCFS(title string, processes []Process) Report {
+	var (
+		serviceTime     int64
+		totalWait       float64
+		totalTurnaround float64
+		lastCompletion  float64
+		waitingTime     int64
+		schedule        = make([]Row, len(processes))
+		gantt           = make([]TimeSlice, 0)
+	)
+	for i := range processes {
+		if processes[i].ArrivalTime > 0 {
+			waitingTime = serviceTime - processes[i].ArrivalTime
+		}
+		totalWait += float64(waitingTime)
+
+		start := waitingTime + processes[i].ArrivalTime
+
+		turnaround := processes[i].BurstDuration + waitingTime
+		totalTurnaround += float64(turnaround)
+
+		completion := processes[i].BurstDuration + processes[i].ArrivalTime + waitingTime
+		lastCompletion = float64(completion)
+
+		schedule[i] = Row{
+			ProcessID:  processes[i].ProcessID,
+			Priority:   processes[i].Priority,
+			Burst:      processes[i].BurstDuration,
+			Arrival:    processes[i].ArrivalTime,
+			Deadline:   processes[i].Deadline,
+			Wait:       waitingTime,
+			Turnaround: turnaround,
+			Exit:       completion,
+		}
+		serviceTime += processes[i].BurstDuration
+
+		gantt = append(gantt, TimeSlice{
+			PID:   processes[i].ProcessID,
+			Start: start,
+			Stop:  serviceTime,
+		})
+	}
+
+	count := float64(len(processes))
+	aveWait := totalWait / count
+	aveTurnaround := totalTurnaround / count
+	aveThroughput := count / lastCompletion
+
+	return Report{
+		Title:      title,
+		Gantt:      gantt,
+		Rows:       schedule,
+		Wait:       aveWait,
+		Turnaround: aveTurnaround,
+		Throughput: aveThroughput,
+	}
+}
+
+func SJFPrioritySchedule(w io.Writer, title string, processes []Process) {
+	outputReport(w, SJFPriority(title, processes))
+}
+
+// SJFPriority schedules processes by priority one tick at a time and returns the resulting report.
+func SJFPriority(title string, processes []Process) Report {
+	var (
+		serviceTime     int64
+		totalTurnaround float64
+		lastCompletion  float64
+		waitingTime     = make([]int64, len(processes))
+		remainingTime   = make([]int64, len(processes))
+		schedule        = make([]Row, 0)
+		gantt           = make([]TimeSlice, 0)
+	)
+
+	// Remaining time set to burst duration
+	for i, p := range processes {
+		remainingTime[i] = p.BurstDuration
+	}
+
+	for serviceTime < lastArrivalTime(processes) || len(schedule) < len(processes) {
+		var (
+			selected  = -1
+			Shortest  = math.MaxInt64
+			completed = 0
+		)
+
+		//Selecting the process with the shortest burst
+		for i := range processes {
+			if processes[i].ArrivalTime <= serviceTime && remainingTime[i] > 0 && processes[i].Priority < int64(Shortest) {
+				selected = i
+				Shortest = int(processes[i].Priority)
+			}
+		}
+
+		if selected >= 0 {
+			if waitingTime[selected] == 0 {
+				waitingTime[selected] = serviceTime - processes[selected].ArrivalTime
+			}
+
+			if !containsPID(schedule, processes[selected].ProcessID) {
+				schedule = append(schedule, Row{
+					ProcessID:  processes[selected].ProcessID,
+					Priority:   processes[selected].Priority,
+					Burst:      processes[selected].BurstDuration,
+					Arrival:    processes[selected].ArrivalTime,
+					Deadline:   processes[selected].Deadline,
+					Wait:       waitingTime[selected],
+					Turnaround: int64(totalTurnaround) + serviceTime - processes[selected].ArrivalTime,
+					Exit:       int64(totalTurnaround) + serviceTime - processes[selected].ArrivalTime + processes[selected].BurstDuration,
+				})
+			}
+
+			if remainingTime[selected] > 1 {
+				remainingTime[selected]--
+			} else {
+				remainingTime[selected] = 0
+				completed = 1
+				totalTurnaround += float64(serviceTime - processes[selected].ArrivalTime + 1)
+				lastCompletion = float64(serviceTime + 1)
+			}
+
+			gantt = append(gantt, TimeSlice{
+				PID:   processes[selected].ProcessID,
+				Start: serviceTime,
+				Stop:  serviceTime + 1,
+			})
+		} else {
+			serviceTime++
+		}
+
+		for i := range processes {
+			if processes[i].ArrivalTime == serviceTime && remainingTime[i] > 0 && !containsPID(schedule, processes[i].ProcessID) {
+				waitingTime[i] = 0
+			}
+		}
+
+		if completed == 1 {
+			selected = -1
+			Shortest = math.MaxInt64
+
+			for i := range processes {
+				if processes[i].ArrivalTime <= serviceTime && remainingTime[i] > 0 && processes[i].Priority < int64(Shortest) {
+					selected = i
+					Shortest = int(processes[i].Priority)
+				}
+			}
+		}
+	}
+
+	count := float64(len(processes))
+	averageWait := totalTurnaround / count
+	averageThroughput := count / lastCompletion
+	averageTurnaround := totalTurnaround / count
+
+	return Report{
+		Title:      title,
+		Gantt:      gantt,
+		Rows:       schedule,
+		Wait:       averageWait,
+		Turnaround: averageTurnaround,
+		Throughput: averageThroughput,
+	}
+}
+
+// Shortest job first priority scheduler
+func SJFSchedule(w io.Writer, title string, processes []Process) {
+	outputReport(w, SJF(title, processes))
+}
+
+// SJF schedules processes shortest job first and returns the resulting report.
+func SJF(title string, processes []Process) Report {
+	var (
+		serviceTime     int64
+		totalWait       float64
+		totalTurnaround float64
+		lastCompletion  float64
+		waitingTime     int64
+		schedule        = make([]Row, len(processes))
+		gantt           = make([]TimeSlice, 0)
+	)
+
+	// Sorting the process by the shortes job first
+	sort.Slice(processes, func(i, j int) bool {
+		return processes[i].BurstDuration < processes[j].BurstDuration
+	})
+
+	for i := range processes {
+		if processes[i].ArrivalTime > 0 {
+			waitingTime = serviceTime - processes[i].ArrivalTime
+		}
+
+		totalWait += float64(waitingTime)
+
+		start := waitingTime + processes[i].ArrivalTime
+
+		turnaround := processes[i].BurstDuration + waitingTime
+		totalTurnaround += float64(turnaround)
+
+		completion := processes[i].BurstDuration + processes[i].ArrivalTime + waitingTime
+		lastCompletion = float64(completion)
+
+		schedule[i] = Row{
+			ProcessID:  processes[i].ProcessID,
+			Priority:   processes[i].Priority,
+			Burst:      processes[i].BurstDuration,
+			Arrival:    processes[i].ArrivalTime,
+			Deadline:   processes[i].Deadline,
+			Wait:       waitingTime,
+			Turnaround: turnaround,
+			Exit:       completion,
+		}
+
+		serviceTime += processes[i].BurstDuration
+
+		gantt = append(gantt, TimeSlice{
+			PID:   processes[i].ProcessID,
+			Start: start,
+			Stop:  serviceTime,
+		})
+	}
+
+	count := float64(len(processes))
+	aveWait := totalWait / count
+	aveTurnaround := totalTurnaround / count
+	aveThroughput := count / lastCompletion
+
+	return Report{
+		Title:      title,
+		Gantt:      gantt,
+		Rows:       schedule,
+		Wait:       aveWait,
+		Turnaround: aveTurnaround,
+		Throughput: aveThroughput,
+	}
+}
+
+// func RRSchedule(w io.Writer, title string, processes []Process) { }
+
+func RRSchedule(w io.Writer, title string, processes []Process, timeQuantum int64) {
+	outputReport(w, RR(title, processes, timeQuantum))
+}
+
+// RR schedules processes round-robin with the given time quantum and returns the resulting report.
+func RR(title string, processes []Process, timeQuantum int64) Report {
+
+	var (
+		serviceTime     int64
+		totalTurnaround float64
+		lastCompletion  float64
+		waitingTime     = make([]int64, len(processes))
+		remainingTime   = make([]int64, len(processes))
+		schedule        = make([]Row, 0)
+		gantt           = make([]TimeSlice, 0)
+	)
+
+	// Setting the remaining time to burst duration of every process
+	for i, p := range processes {
+		remainingTime[i] = p.BurstDuration
+	}
+
+	// Round robin process execution below
+	for serviceTime < lastArrivalTime(processes) || len(schedule) < len(processes) {
+		completed := false
+
+		// Processing all that arrived before the current service time
+		for i := range processes {
+			if processes[i].ArrivalTime <= serviceTime && remainingTime[i] > 0 {
+				//Begin a new process
+				if waitingTime[i] == 0 {
+					waitingTime[i] = serviceTime - processes[i].ArrivalTime
+				}
+
+				// Add the processes to the schedule
+				if !containsPID(schedule, processes[i].ProcessID) {
+					schedule = append(schedule, Row{
+						ProcessID:  processes[i].ProcessID,
+						Priority:   processes[i].Priority,
+						Burst:      processes[i].BurstDuration,
+						Arrival:    processes[i].ArrivalTime,
+						Deadline:   processes[i].Deadline,
+						Wait:       int64(totalTurnaround),
+						Turnaround: int64(totalTurnaround) + processes[i].ArrivalTime,
+					})
+				}
+
+				//Here we check the given processes for
+				if remainingTime[i] > timeQuantum {
+					serviceTime += timeQuantum
+					remainingTime[i] -= timeQuantum
+				} else {
+					serviceTime += remainingTime[i]
+					totalTurnaround += float64(serviceTime - processes[i].ArrivalTime)
+					remainingTime[i] = 0
+					completed = true
+					lastCompletion = float64(serviceTime)
+					schedule[rowIndex(schedule, processes[i].ProcessID)].Exit = serviceTime
+				}
+
+				//Adding to our gantt chart
+				gantt = append(gantt, TimeSlice{
+					PID:   processes[i].ProcessID,
+					Start: serviceTime - timeQuantum,
+					Stop:  serviceTime,
+				})
+			}
+		}
+
+		// Moving to next process if none were completed
+		if !completed {
+			serviceTime++
+		}
+
+		for i := range processes {
+			if processes[i].ArrivalTime == serviceTime && remainingTime[i] > 0 && !containsPID(schedule, processes[i].ProcessID) {
+				waitingTime[i] = 0
+			}
+		}
+	}
+
+	count := float64(len(processes))
+	averageWait := totalTurnaround / count
+	averageThroughput := count / lastCompletion
+	averageTurnaround := totalTurnaround / count
+
+	return Report{
+		Title:      title,
+		Gantt:      gantt,
+		Rows:       schedule,
+		Wait:       averageWait,
+		Turnaround: averageTurnaround,
+		Throughput: averageThroughput,
+	}
+}
+
+//endregion
+
+// Checkers for RR function
+func containsPID(schedule []Row, pid int64) bool {
+	return rowIndex(schedule, pid) >= 0
+}
+func rowIndex(schedule []Row, pid int64) int {
+	for i := range schedule {
+		if schedule[i].ProcessID == pid {
+			return i
+		}
+	}
+	return -1
+}
+func lastArrivalTime(processes []Process) int64 {
+	lastArrival := int64(0)
+	for _, p := range processes {
+		if p.ArrivalTime > lastArrival {
+			lastArrival = p.ArrivalTime
+		}
+	}
+	return lastArrival
+}
+
+//region Output helpers
+
+// renderer is how reports are printed. The zero renderer prints raw ticks
+// in English.
+type renderer struct {
+	// tick is the real duration of a tick when -human prints times with
+	// units, or 0 to print them as raw ticks.
+	tick time.Duration
+	// catalog is the language of the labels, column headers, footers and
+	// notes.
+	catalog Catalog
+}
+
+func outputReport(w io.Writer, r Report) {
+	renderer{}.outputReport(w, r)
+}
+
+func (o renderer) outputReport(w io.Writer, r Report) {
+	outputTitle(w, r.Title)
+	o.outputGantt(w, r.Gantt)
+	o.outputSchedule(w, r)
+	_, _ = fmt.Fprintf(w, o.catalog.msg("makespan")+"\n", o.formatTicks(r.makespan()))
+	o.outputReadyQueue(w, r)
+	for _, note := range r.Notes {
+		_, _ = fmt.Fprintln(w, o.catalog.text(note))
+	}
+}
+
+func outputTitle(w io.Writer, title string) {
+	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
+	_, _ = fmt.Fprintln(w, strings.Repeat(" ", len(title)/2), title)
+	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
+}
+
+func (o renderer) outputGantt(w io.Writer, gantt []TimeSlice) {
+	_, _ = fmt.Fprintln(w, o.catalog.msg("gantt"))
+	cpus := cpuCount(gantt)
+	if cpus == 1 {
+		o.outputGanttLane(w, gantt, nil)
+		_, _ = fmt.Fprintf(w, "\n\n")
+		return
+	}
+
+	// One lane per CPU, marking a slice with * when its process last ran on another CPU.
+	migrated := migrations(gantt)
+	for cpu := 0; cpu < cpus; cpu++ {
+		var lane []TimeSlice
+		var marks []string
+		for i, s := range gantt {
+			if s.CPU == cpu {
+				lane = append(lane, s)
+				mark := ""
+				if migrated[i] {
+					mark = "*"
+				}
+				marks = append(marks, mark)
+			}
+		}
+		_, _ = fmt.Fprintf(w, o.catalog.msg("cpu")+"\n", cpu)
+		o.outputGanttLane(w, lane, marks)
+		_, _ = fmt.Fprintln(w)
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// cpuCount returns how many CPUs gantt ran on: one more than the highest
+// CPU a slice names.
+func cpuCount(gantt []TimeSlice) int {
+	cpus := 1
+	for _, s := range gantt {
+		if s.CPU+1 > cpus {
+			cpus = s.CPU + 1
+		}
+	}
+	return cpus
+}
+
+// migrations returns the indexes of the slices in gantt whose process last
+// ran on another CPU.
+func migrations(gantt []TimeSlice) map[int]bool {
+	migrated := make(map[int]bool)
+	lastCPU := make(map[int64]int)
+	order := make([]int, len(gantt))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return gantt[order[a]].Start < gantt[order[b]].Start })
+	for _, i := range order {
+		if gantt[i].Overhead {
+			continue
+		}
+		if c, ok := lastCPU[gantt[i].PID]; ok && c != gantt[i].CPU {
+			migrated[i] = true
+		}
+		lastCPU[gantt[i].PID] = gantt[i].CPU
+	}
+	return migrated
+}
+
+// outputGanttLane prints one row of slices and their start times. Each
+// slice's entry in marks, if any, follows its process ID.
+func (o renderer) outputGanttLane(w io.Writer, gantt []TimeSlice, marks []string) {
+	_, _ = fmt.Fprint(w, "|")
+	for i := range gantt {
+		pid := fmt.Sprint(gantt[i].PID)
+		if gantt[i].Overhead {
+			pid = "CS"
+		}
+		if i < len(marks) {
+			pid += marks[i]
+		}
+		padding := strings.Repeat(" ", (8-len(pid))/2)
+		_, _ = fmt.Fprint(w, padding, pid, padding, "|")
+	}
+	_, _ = fmt.Fprintln(w)
+	for i := range gantt {
+		_, _ = fmt.Fprint(w, o.formatTicks(gantt[i].Start), "\t")
+		if len(gantt)-1 == i {
+			_, _ = fmt.Fprint(w, o.formatTicks(gantt[i].Stop))
+		}
+	}
+}
+
+func (o renderer) outputSchedule(w io.Writer, r Report) {
+	_, _ = fmt.Fprintln(w, o.catalog.msg("schedule"))
+	table := tablewriter.NewWriter(w)
+	header := []string{o.catalog.msg("id"), o.catalog.msg("priority"), o.catalog.msg("burst"), o.catalog.msg("arrival"),
+		o.catalog.msg("wait"), o.catalog.msg("turnaround"), o.catalog.msg("exit"), o.catalog.msg("response_ratio")}
+	average := o.catalog.msg("average")
+	footer := []string{"", "", "", "",
+		average + "\n" + o.formatAverageTicks(r.Wait),
+		average + "\n" + o.formatAverageTicks(r.Turnaround),
+		o.catalog.msg("throughput") + "\n" + o.formatThroughput(r.Throughput),
+		average + "\n" + o.catalog.float(r.averageResponseRatio(), 2)}
+	for _, c := range r.Columns {
+		header = append(header, o.catalog.text(c.Header))
+		if label, value, ok := strings.Cut(c.Footer, "\n"); ok {
+			footer = append(footer, o.catalog.text(label)+"\n"+o.catalog.number(value))
+		} else {
+			footer = append(footer, o.catalog.text(c.Footer))
+		}
+	}
+	if o.tick > 0 {
+		// Upper-case the labels but not the units after the times, so
+		// "s" doesn't read as siemens.
+		table.SetAutoFormatHeaders(false)
+		for i := range header {
+			header[i] = tablewriter.Title(header[i])
+		}
+		for i, f := range footer {
+			if label, value, ok := strings.Cut(f, "\n"); ok && i >= 4 && i < 7 {
+				footer[i] = tablewriter.Title(label) + "\n" + value
+			} else {
+				footer[i] = tablewriter.Title(f)
+			}
+		}
+	}
+	table.SetHeader(header)
+	for i, row := range r.Rows {
+		cells := []string{
+			fmt.Sprint(row.ProcessID),
+			fmt.Sprint(row.Priority),
+			o.formatTicks(row.Burst),
+			o.formatTicks(row.Arrival),
+			o.formatTicks(row.Wait),
+			o.formatTicks(row.Turnaround),
+			o.formatTicks(row.Exit),
+			o.catalog.float(row.responseRatio(), 2),
+		}
+		for _, c := range r.Columns {
+			v := ""
+			if i < len(c.Values) {
+				v = o.catalog.number(c.Values[i])
+			}
+			cells = append(cells, v)
+		}
+		table.Append(cells)
+	}
+	table.SetFooter(footer)
+	table.Render()
+}
+
+// responseRatio is (wait + burst) / burst: how many times longer than its
+// own burst the process took. Short jobs that wait long score badly.
+func (row Row) responseRatio() float64 {
+	if row.Burst <= 0 {
+		return 1
+	}
+	return float64(row.Wait+row.Burst) / float64(row.Burst)
+}
+
+// makespan is the length of the schedule, from the first arrival to the last exit.
+func (r Report) makespan() int64 {
+	if len(r.Rows) == 0 {
+		return 0
+	}
+	first, last := r.Rows[0].Arrival, r.Rows[0].Exit
+	for _, row := range r.Rows {
+		if row.Arrival < first {
+			first = row.Arrival
+		}
+		if row.Exit > last {
+			last = row.Exit
+		}
+	}
+	return last - first
+}
+
+// speedupNote compares the makespan on cpus CPUs with the makespan on one:
+// the speedup is how many times shorter it is, and the efficiency the
+// speedup per CPU.
+func speedupNote(single, multi int64, cpus int) string {
+	speedup := 1.0
+	if multi > 0 {
+		speedup = float64(single) / float64(multi)
+	}
+	return fmt.Sprintf("Speedup %.2f over 1 CPU (makespan %d), efficiency %.1f%%", speedup, single, 100*speedup/float64(cpus))
+}
+
+func (r Report) averageResponseRatio() float64 {
+	if len(r.Rows) == 0 {
+		return 0
+	}
+	var total float64
+	for _, row := range r.Rows {
+		total += row.responseRatio()
+	}
+	return total / float64(len(r.Rows))
+}
+
+//endregion
+
+//region Loading processes.
+
+var ErrInvalidArgs = errors.New("invalid args")
+
+// defaultWeight is the weight of a process that doesn't give one, matching
+// the cgroup v2 default cpu.weight.
+const defaultWeight = 100
+
+// weight returns the process's weight, or defaultWeight if it has none.
+func (p Process) weight() int64 {
+	if p.Weight <= 0 {
+		return defaultWeight
+	}
+	return p.Weight
+}
+
+// loadProcesses reads a workload CSV, or the JSON array of processes
+// written by the workload builder, with whole-tick times.
+func loadProcesses(r io.Reader) ([]Process, error) {
+	processes, _, err := loadScaledProcesses(r, 1)
+	return processes, err
+}
+
+// loadScaledProcesses reads a workload like loadProcesses, whose burst and
+// arrival times may be decimals, converting them to scale ticks per unit.
+// A scale of 0 picks the smallest power of ten that makes every time whole.
+// It returns the scale used.
+func loadScaledProcesses(r io.Reader, scale int64) ([]Process, int64, error) {
+	var (
+		processes []Process
+		// times holds each process's burst and arrival as written,
+		// deadlines each process's deadline column and cycles each
+		// process's burst cycle, if its burst column is one.
+		times     [][2]string
+		deadlines []string
+		cycles    []string
+	)
+	br := bufio.NewReader(r)
+	if isJSONArray(br) {
+		var decoded []struct {
+			Process
+			Burst   json.Number `json:"burst"`
+			Arrival json.Number `json:"arrival"`
+		}
+		if err := json.NewDecoder(br).Decode(&decoded); err != nil {
+			return nil, 0, fmt.Errorf("%w: reading JSON", err)
+		}
+		for _, d := range decoded {
+			if d.Nice < -20 || d.Nice > 19 {
+				return nil, 0, fmt.Errorf("%w: process %d: nice must be -20 to 19, got %d", ErrInvalidArgs, d.ProcessID, d.Nice)
+			}
+			if err := checkBurstCycle(d.Bursts); err != nil {
+				return nil, 0, fmt.Errorf("%w: process %d", err, d.ProcessID)
+			}
+			processes = append(processes, d.Process)
+			times = append(times, [2]string{d.Burst.String(), d.Arrival.String()})
+		}
+	} else {
+		cr := csv.NewReader(br)
+		cr.Comment = '#'
+		rows, err := cr.ReadAll()
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: reading CSV", err)
+		}
+
+		processes = make([]Process, len(rows))
+		times = make([][2]string, len(rows))
+		deadlines = make([]string, len(rows))
+		cycles = make([]string, len(rows))
+		for i := range rows {
+			if len(rows[i]) > 9 {
+				affinity, err := parseAffinity(rows[i][9])
+				if err != nil {
+					return nil, 0, fmt.Errorf("%w: line %d", err, i+1)
+				}
+				processes[i].Affinity = affinity
+				rows[i] = rows[i][:9]
+			}
+			if len(rows[i]) > 8 {
+				group, err := parseGroup(rows[i][8])
+				if err != nil {
+					return nil, 0, fmt.Errorf("%w: line %d", err, i+1)
+				}
+				processes[i].Group = group
+				rows[i] = rows[i][:8]
+			}
+			if len(rows[i]) > 7 {
+				deadlines[i] = rows[i][7]
+				rows[i] = rows[i][:7]
+			}
+			if len(rows[i]) > 6 {
+				nice, err := parseNice(rows[i][6])
+				if err != nil {
+					return nil, 0, fmt.Errorf("%w: line %d", err, i+1)
+				}
+				processes[i].Nice = nice
+				rows[i] = rows[i][:6]
+			}
+			if len(rows[i]) > 5 {
+				if err := processes[i].setClass(rows[i][5]); err != nil {
+					return nil, 0, fmt.Errorf("%w: line %d", err, i+1)
+				}
+				rows[i] = rows[i][:5]
+			}
+			if len(rows[i]) < 3 {
+				return nil, 0, fmt.Errorf("%w: line %d: want at least ID, burst and arrival", ErrInvalidArgs, i+1)
+			}
+			times[i] = [2]string{rows[i][1], rows[i][2]}
+			if isBurstCycle(rows[i][1]) {
+				cycles[i], times[i][0] = rows[i][1], ""
+			}
+			fields := []*int64{
+				&processes[i].ProcessID,
+				nil, // burst and arrival are scaled below
+				nil,
+				&processes[i].Priority,
+				&processes[i].Weight,
+			}
+			for j := range fields {
+				if j >= len(rows[i]) {
+					break
+				}
+				// Priority and weight may be left empty to give later columns.
+				if fields[j] == nil || j > 2 && strings.TrimSpace(rows[i][j]) == "" {
+					continue
+				}
+				v, err := strconv.ParseInt(rows[i][j], 10, 64)
+				if err != nil {
+					return nil, 0, fmt.Errorf("%w: line %d", err, i+1)
+				}
+				*fields[j] = v
+			}
+		}
+	}
+
+	if scale == 0 {
+		all := times
+		for _, c := range cycles {
+			for _, t := range burstCycleTimes(c) {
+				all = append(all, [2]string{t, ""})
+			}
+		}
+		var err error
+		if scale, err = autoScale(all); err != nil {
+			return nil, 0, err
+		}
+	}
+	for i := range processes {
+		var err error
+		if processes[i].BurstDuration, err = parseScaled(times[i][0], scale); err != nil {
+			return nil, 0, fmt.Errorf("%w: process %d burst", err, i+1)
+		}
+		if processes[i].ArrivalTime, err = parseScaled(times[i][1], scale); err != nil {
+			return nil, 0, fmt.Errorf("%w: process %d arrival", err, i+1)
+		}
+		if i < len(deadlines) {
+			if processes[i].Deadline, err = parseDeadline(deadlines[i], processes[i].ArrivalTime, scale); err != nil {
+				return nil, 0, fmt.Errorf("%w: process %d deadline", err, i+1)
+			}
+		}
+		if i < len(cycles) && cycles[i] != "" {
+			bursts, err := parseBurstCycle(cycles[i], scale)
+			if err != nil {
+				return nil, 0, fmt.Errorf("%w: process %d burst", err, i+1)
+			}
+			processes[i].BurstDuration = cpuTotal(bursts)
+			if len(bursts) > 1 {
+				processes[i].Bursts = bursts
+			}
+		} else if len(processes[i].Bursts) > 0 {
+			// JSON bursts are in units, like its burst and arrival.
+			for j := range processes[i].Bursts {
+				processes[i].Bursts[j] *= scale
+			}
+			processes[i].BurstDuration = cpuTotal(processes[i].Bursts)
+		}
+	}
+
+	return processes, scale, nil
+}
+
+// loadWorkload reads a workload like loadScaledProcesses, along with the
+// "#key=value" metadata lines at the top of the file. A "#scale=N" line
+// sets the scale when scale is 0, and the scale used is recorded in the
+// metadata when it is not 1.
+func loadWorkload(r io.Reader, scale int64) ([]Process, map[string]string, error) {
+	br := bufio.NewReader(r)
+	metadata := make(map[string]string)
+	for {
+		b, err := br.Peek(1)
+		if err != nil || b[0] != '#' {
+			break
+		}
+		line, err := br.ReadString('\n')
+		if kv := strings.SplitN(strings.TrimSpace(strings.TrimPrefix(line, "#")), "=", 2); len(kv) == 2 {
+			metadata[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	if v, ok := metadata["scale"]; ok && scale == 0 {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 {
+			return nil, nil, fmt.Errorf("%w: scale must be a positive number of ticks per unit, got %q", ErrInvalidArgs, v)
+		}
+		scale = n
+	}
+	processes, scale, err := loadScaledProcesses(br, scale)
+	if err == nil && scale != 1 {
+		metadata["scale"] = fmt.Sprint(scale)
+	}
+	return processes, metadata, err
+}
+
+// isJSONArray reports whether the next non-space byte of r starts a JSON array.
+func isJSONArray(r *bufio.Reader) bool {
+	for n := 1; ; n++ {
+		b, err := r.Peek(n)
+		if err != nil || len(b) < n {
+			return false
+		}
+		switch b[n-1] {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '[':
+			return true
+		}
+		return false
+	}
+}
+
+//endregion
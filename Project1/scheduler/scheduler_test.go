@@ -1,4 +1,4 @@
-package main
+package scheduler
 
 import (
 	"bytes"
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path"
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 	"testing/iotest"
@@ -64,6 +65,69 @@ func TestFCFSSchedule(t *testing.T) {
 	}
 }
 
+func Test_speedupNote(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		single int64
+		multi  int64
+		cpus   int
+		want   string
+	}{
+		{name: "twice as fast on two CPUs", single: 20, multi: 10, cpus: 2, want: "Speedup 2.00 over 1 CPU (makespan 20), efficiency 100.0%"},
+		{name: "no faster on four CPUs", single: 6, multi: 6, cpus: 4, want: "Speedup 1.00 over 1 CPU (makespan 6), efficiency 25.0%"},
+		{name: "nothing to run", cpus: 2, want: "Speedup 1.00 over 1 CPU (makespan 0), efficiency 50.0%"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := speedupNote(tt.single, tt.multi, tt.cpus); got != tt.want {
+				t.Errorf("speedupNote() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckProcesses(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		wantErr   bool
+	}{
+		{name: "positive bursts", processes: []Process{{ProcessID: 1, BurstDuration: 5}, {ProcessID: 2, BurstDuration: 1, ArrivalTime: 3}}},
+		{name: "zero burst", processes: []Process{{ProcessID: 1, BurstDuration: 5}, {ProcessID: 2, ArrivalTime: 3}}, wantErr: true},
+		{name: "negative burst", processes: []Process{{ProcessID: 1, BurstDuration: -1}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := CheckProcesses(tt.processes)
+			if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrInvalidArgs)) {
+				t.Errorf("CheckProcesses() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunSchedulers_keepsInput(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 9, Priority: 2},
+		{ProcessID: 2, BurstDuration: 2, ArrivalTime: 1, Priority: 1},
+		{ProcessID: 3, BurstDuration: 4, ArrivalTime: 1, Priority: 3},
+	}
+	want := append([]Process(nil), processes...)
+	if reports := RunSchedulers(processes, 2); len(reports) != 7 {
+		t.Fatalf("RunSchedulers() = %d reports, want 7", len(reports))
+	}
+	if !reflect.DeepEqual(processes, want) {
+		t.Errorf("RunSchedulers() changed its input to %v, want %v", processes, want)
+	}
+}
+
 func Test_loadProcesses(t *testing.T) {
 	t.Parallel()
 	type args struct {
@@ -82,6 +146,13 @@ func Test_loadProcesses(t *testing.T) {
 			},
 			wantErr: io.ErrUnexpectedEOF,
 		},
+		{
+			name: "bad number",
+			args: args{
+				r: strings.NewReader(`1,five,0,2`),
+			},
+			wantErr: strconv.ErrSyntax,
+		},
 		{
 			name: "success",
 			args: args{
@@ -205,3 +276,32 @@ func Test_openProcessingFile1(t *testing.T) {
 		})
 	}
 }
+
+func Test_outputGantt_multicore(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	renderer{}.outputGantt(&w, []TimeSlice{
+		{PID: 1, Start: 0, Stop: 4, CPU: 0},
+		{PID: 2, Start: 0, Stop: 2, CPU: 1},
+		{PID: 3, Start: 2, Stop: 5, CPU: 1},
+		{PID: 2, Start: 4, Stop: 6, CPU: 0},
+	})
+	want := "Gantt schedule\nCPU 0\n|   1   |   2*   |\n0\t4\t6\nCPU 1\n|   2   |   3   |\n0\t2\t5\n\n"
+	if got := w.String(); got != want {
+		t.Errorf("outputGantt() = %q, want %q", got, want)
+	}
+}
+
+func Test_loadWorkload(t *testing.T) {
+	t.Parallel()
+	processes, metadata, err := loadWorkload(strings.NewReader("#quantum=4\n# cpus = 2\n# a plain comment\n1,5,0\n# another\n2,3,1\n"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"quantum": "4", "cpus": "2"}; !reflect.DeepEqual(metadata, want) {
+		t.Errorf("metadata = %v, want %v", metadata, want)
+	}
+	if len(processes) != 2 || processes[1].ProcessID != 2 {
+		t.Errorf("processes = %v", processes)
+	}
+}
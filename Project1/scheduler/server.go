@@ -0,0 +1,196 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+//region Aperiodic servers
+
+// The servers aperiodic jobs can run in.
+const (
+	// serverCBS is a Constant Bandwidth Server (Abeni and Buttazzo).
+	serverCBS = "cbs"
+	// serverDeferrable is a deferrable server.
+	serverDeferrable = "deferrable"
+	// serverBackground runs aperiodic jobs only when no periodic job is ready.
+	serverBackground = "background"
+)
+
+var serverKinds = []string{serverBackground, serverDeferrable, serverCBS}
+
+// ServerPolicy schedules periodic jobs earliest deadline first alongside a
+// server that runs the aperiodic jobs, first come first served, with a
+// budget of Budget ticks every Period. The server competes under EDF with
+// its own deadline, so the periodic jobs keep their guarantees as long as
+// their utilization plus Budget/Period is at most 1.
+//
+// A deferrable server's budget is refilled and its deadline moved to the
+// end of the period at every multiple of Period; once the budget is spent,
+// aperiodic jobs wait for the refill. A CBS instead gives itself a new
+// deadline a period away when work arrives and its budget can't be used up
+// by the current deadline, and when the budget runs out refills it at once
+// and postpones the deadline by a period, so it never stops competing but
+// is never allowed more than its bandwidth.
+type ServerPolicy struct {
+	Kind           string
+	Budget, Period int64
+	Aperiodic      map[int64]bool
+	// Events records every budget refill and deadline change.
+	Events []string
+
+	budget, deadline int64
+	started, busy    bool
+}
+
+func (p *ServerPolicy) event(now int64, format string, args ...interface{}) {
+	p.Events = append(p.Events, fmt.Sprintf("t=%d: ", now)+fmt.Sprintf(format, args...))
+}
+
+func (p *ServerPolicy) Pick(now int64, running *Task, ready []*Task) *Task {
+	if !p.started {
+		p.started = true
+		p.budget = p.Budget
+		if p.Kind == serverDeferrable {
+			p.deadline = p.Period
+		}
+	}
+	if p.Kind == serverDeferrable && now >= p.deadline {
+		p.deadline = (now/p.Period + 1) * p.Period
+		if p.budget < p.Budget {
+			p.event(now, "budget refilled to %d, deadline %d", p.Budget, p.deadline)
+		}
+		p.budget = p.Budget
+	}
+
+	runnable := ready
+	if running != nil {
+		runnable = append([]*Task{running}, ready...)
+	}
+	var (
+		aperiodic []*Task
+		periodic  *Task
+	)
+	for _, t := range runnable {
+		switch {
+		case p.Aperiodic[t.ProcessID]:
+			aperiodic = append(aperiodic, t)
+		case periodic == nil || t.Deadline < periodic.Deadline:
+			periodic = t
+		}
+	}
+
+	if len(aperiodic) == 0 {
+		p.busy = false
+		return periodic
+	}
+	if p.Kind == serverBackground {
+		if periodic != nil {
+			return periodic
+		}
+		return aperiodic[0]
+	}
+	if p.Kind == serverCBS {
+		if !p.busy && (now >= p.deadline || p.budget*p.Period >= (p.deadline-now)*p.Budget) {
+			p.deadline, p.budget = now+p.Period, p.Budget
+			p.event(now, "work arrived, budget %d, deadline %d", p.budget, p.deadline)
+		}
+		if p.budget == 0 {
+			p.deadline += p.Period
+			p.budget = p.Budget
+			p.event(now, "budget exhausted, refilled to %d, deadline postponed to %d", p.budget, p.deadline)
+		}
+	}
+	p.busy = true
+
+	if p.budget > 0 && (periodic == nil || p.deadline < periodic.Deadline ||
+		p.deadline == periodic.Deadline && running == aperiodic[0]) {
+		p.budget--
+		return aperiodic[0]
+	}
+	return periodic
+}
+
+// annotate adds the server's events and how the aperiodic jobs fared.
+func (p *ServerPolicy) annotate(r *Report, tasks []*Task) {
+	r.Notes = append(r.Notes, p.Events...)
+	if s := aperiodicSummary(*r, p.Aperiodic); s.Jobs > 0 {
+		r.Notes = append(r.Notes, fmt.Sprintf("Aperiodic: %d jobs, average response %.2f, average turnaround %.2f",
+			s.Jobs, s.Response, s.Turnaround))
+	}
+}
+
+// AperiodicSummary is how the aperiodic jobs fared under a server.
+type AperiodicSummary struct {
+	Jobs                 int
+	Response, Turnaround float64
+}
+
+func aperiodicSummary(r Report, aperiodic map[int64]bool) AperiodicSummary {
+	first := make(map[int64]int64)
+	for _, s := range r.Gantt {
+		if f, ok := first[s.PID]; !ok || s.Start < f {
+			first[s.PID] = s.Start
+		}
+	}
+	var s AperiodicSummary
+	for _, row := range r.Rows {
+		if !aperiodic[row.ProcessID] {
+			continue
+		}
+		s.Jobs++
+		s.Response += float64(first[row.ProcessID] - row.Arrival)
+		s.Turnaround += float64(row.Turnaround)
+	}
+	if s.Jobs > 0 {
+		s.Response /= float64(s.Jobs)
+		s.Turnaround /= float64(s.Jobs)
+	}
+	return s
+}
+
+// loadAperiodic reads aperiodic jobs from a workload file, renumbering them
+// after the periodic jobs.
+func loadAperiodic(path string, jobs []Process) ([]Process, map[int64]bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%v: error opening aperiodic workload", err)
+	}
+	defer func() { _ = f.Close() }()
+	processes, err := loadProcesses(f)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	all := append([]Process(nil), jobs...)
+	aperiodic := make(map[int64]bool)
+	for _, p := range processes {
+		p.ProcessID = int64(len(all) + 1)
+		p.Deadline = 0
+		aperiodic[p.ProcessID] = true
+		all = append(all, p)
+	}
+	return all, aperiodic, nil
+}
+
+// outputServers compares every server by how the aperiodic jobs fared and
+// how many periodic deadlines were missed.
+func outputServers(w io.Writer, jobs []Process, aperiodic map[int64]bool, owner map[int64]PeriodicTask, budget, period int64) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Server", "Aperiodic response", "Aperiodic turnaround", "Periodic deadline misses"})
+	for _, kind := range serverKinds {
+		r := simulate(kind, jobs, &ServerPolicy{Kind: kind, Budget: budget, Period: period, Aperiodic: aperiodic})
+		missed := 0
+		for _, resp := range periodicResponses(r, owner) {
+			missed += resp.Missed
+		}
+		s := aperiodicSummary(r, aperiodic)
+		table.Append([]string{kind, fmt.Sprintf("%.2f", s.Response), fmt.Sprintf("%.2f", s.Turnaround), fmt.Sprint(missed)})
+	}
+	table.Render()
+}
+
+//endregion
@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_ServerPolicy(t *testing.T) {
+	t.Parallel()
+	// A periodic task needing 2 of every 4 ticks, and an aperiodic job of 4
+	// ticks arriving at 0, served with a budget of 1 every 4.
+	periodic, owner := releaseJobs([]PeriodicTask{{ID: 1, Period: 4, WCET: 2, Deadline: 4}}, 16, false, 0, nil)
+	jobs := append(periodic, Process{ProcessID: 5, BurstDuration: 4})
+	aperiodic := map[int64]bool{5: true}
+	tests := []struct {
+		kind      string
+		wantGantt []TimeSlice
+	}{
+		{
+			// The aperiodic job only runs when no periodic job is ready.
+			kind: serverBackground,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2}, {PID: 5, Start: 2, Stop: 4}, {PID: 2, Start: 4, Stop: 6},
+				{PID: 5, Start: 6, Stop: 8}, {PID: 3, Start: 8, Stop: 10}, {PID: 4, Start: 12, Stop: 14},
+			},
+		},
+		{
+			// A tick of budget per period; after spending it the job waits
+			// for the refill at the next multiple of 4.
+			kind: serverDeferrable,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2}, {PID: 5, Start: 2, Stop: 3}, {PID: 2, Start: 4, Stop: 6},
+				{PID: 5, Start: 6, Stop: 7}, {PID: 3, Start: 8, Stop: 10}, {PID: 5, Start: 10, Stop: 11},
+				{PID: 4, Start: 12, Stop: 14}, {PID: 5, Start: 14, Stop: 15},
+			},
+		},
+		{
+			// The budget is refilled at once with a later deadline, which
+			// still beats an idle CPU.
+			kind: serverCBS,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2}, {PID: 5, Start: 2, Stop: 4}, {PID: 2, Start: 4, Stop: 6},
+				{PID: 5, Start: 6, Stop: 8}, {PID: 3, Start: 8, Stop: 10}, {PID: 4, Start: 12, Stop: 14},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.kind, func(t *testing.T) {
+			t.Parallel()
+			p := &ServerPolicy{Kind: tt.kind, Budget: 1, Period: 4, Aperiodic: aperiodic}
+			r := simulate(tt.kind, jobs, p)
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			for id, resp := range periodicResponses(r, owner) {
+				if resp.Missed > 0 {
+					t.Errorf("task %d missed %d deadlines", id, resp.Missed)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+//region Latency targets
+
+type (
+	// SLO tags processes with a target response time: the time from arrival
+	// to first getting the CPU.
+	SLO struct {
+		Tag    string
+		Target int64
+		PIDs   map[int64]bool
+	}
+
+	// SLOAttainment is how many of a tag's processes met its target under
+	// one algorithm.
+	SLOAttainment struct {
+		Tag        string  `json:"tag"`
+		Target     int64   `json:"target"`
+		Processes  int     `json:"processes"`
+		Met        int     `json:"met"`
+		Attainment float64 `json:"attainment"`
+	}
+)
+
+// parseSLOs parses a semicolon separated list of latency targets like
+// "web=5:1,2;db=20:3": each a tag, its target response time and the IDs of
+// the processes it tags.
+func parseSLOs(spec string) ([]SLO, error) {
+	var slos []SLO
+	for _, f := range strings.Split(spec, ";") {
+		if f = strings.TrimSpace(f); f == "" {
+			continue
+		}
+		tagTarget := strings.SplitN(f, ":", 2)
+		kv := strings.SplitN(tagTarget[0], "=", 2)
+		if len(kv) != 2 || len(tagTarget) != 2 {
+			return nil, fmt.Errorf("%w: want <tag>=<target>:<pids>, got %q", ErrInvalidArgs, f)
+		}
+		target, err := strconv.ParseInt(strings.TrimSpace(kv[1]), 10, 64)
+		if err != nil || target < 0 {
+			return nil, fmt.Errorf("%w: bad target %q", ErrInvalidArgs, kv[1])
+		}
+		pids, err := parsePIDs(tagTarget[1])
+		if err != nil {
+			return nil, err
+		}
+		slos = append(slos, SLO{Tag: strings.TrimSpace(kv[0]), Target: target, PIDs: pids})
+	}
+
+	return slos, nil
+}
+
+// sloAttainment reports, for each SLO, the share of its processes in r whose
+// response time met the target.
+func sloAttainment(r Report, slos []SLO) []SLOAttainment {
+	firstRun := make(map[int64]int64)
+	for _, s := range r.Gantt {
+		if _, ok := firstRun[s.PID]; !ok {
+			firstRun[s.PID] = s.Start
+		}
+	}
+
+	attainments := make([]SLOAttainment, 0, len(slos))
+	for _, slo := range slos {
+		a := SLOAttainment{Tag: slo.Tag, Target: slo.Target}
+		for _, row := range r.Rows {
+			start, ok := firstRun[row.ProcessID]
+			if !slo.PIDs[row.ProcessID] || !ok {
+				continue
+			}
+			a.Processes++
+			if start-row.Arrival <= slo.Target {
+				a.Met++
+			}
+		}
+		if a.Processes > 0 {
+			a.Attainment = 100 * float64(a.Met) / float64(a.Processes)
+		}
+		attainments = append(attainments, a)
+	}
+	return attainments
+}
+
+// addSLOs adds each algorithm's SLO attainment to the summary.
+func (s *Summary) addSLOs(reports []Report, slos []SLO) {
+	if len(slos) == 0 {
+		return
+	}
+	for i := range s.Algorithms {
+		s.Algorithms[i].SLOs = sloAttainment(reports[i], slos)
+	}
+}
+
+// outputSLOs compares the algorithms by the percentage of each tag's
+// processes that met its response time target.
+func outputSLOs(w io.Writer, reports []Report, slos []SLO) {
+	_, _ = fmt.Fprintln(w, "SLO attainment (processes meeting their response time target)")
+	header := []string{"Algorithm"}
+	for _, slo := range slos {
+		header = append(header, fmt.Sprintf("%s (<= %d)", slo.Tag, slo.Target))
+	}
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(header)
+	for _, r := range reports {
+		line := []string{r.Title}
+		for _, a := range sloAttainment(r, slos) {
+			line = append(line, fmt.Sprintf("%d/%d (%.1f%%)", a.Met, a.Processes, a.Attainment))
+		}
+		table.Append(line)
+	}
+	table.Render()
+}
+
+//endregion
@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_sloAttainment(t *testing.T) {
+	t.Parallel()
+	r := Report{
+		Gantt: []TimeSlice{
+			{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 3, Start: 4, Stop: 6},
+			{PID: 1, Start: 6, Stop: 8},
+		},
+		Rows: []Row{{ProcessID: 1}, {ProcessID: 2, Arrival: 1}, {ProcessID: 3, Arrival: 1}},
+	}
+	tests := []struct {
+		name string
+		spec string
+		want []SLOAttainment
+	}{
+		{
+			name: "tight",
+			spec: "web=1:1,2,3",
+			want: []SLOAttainment{{Tag: "web", Target: 1, Processes: 3, Met: 2, Attainment: 200.0 / 3}},
+		},
+		{
+			name: "per tag",
+			spec: "web=0:1;db=3:2,3,9",
+			want: []SLOAttainment{
+				{Tag: "web", Target: 0, Processes: 1, Met: 1, Attainment: 100},
+				{Tag: "db", Target: 3, Processes: 2, Met: 2, Attainment: 100},
+			},
+		},
+		{
+			name: "no processes",
+			spec: "idle=5:7",
+			want: []SLOAttainment{{Tag: "idle", Target: 5}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			slos, err := parseSLOs(tt.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := sloAttainment(r, slos); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sloAttainment() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseSLOs_errors(t *testing.T) {
+	t.Parallel()
+	for _, spec := range []string{"web", "web=5", "web=-1:1", "web=x:1", "web=5:a"} {
+		if _, err := parseSLOs(spec); err == nil {
+			t.Errorf("parseSLOs(%q) succeeded", spec)
+		}
+	}
+}
@@ -0,0 +1,299 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+//region Snapshots
+
+// Snapshot is the complete state of a simulation between two ticks. Tasks
+// are referred to by their index in Tasks. Only runs of the stateless
+// policies (fcfs, sjf, priority, preemptive-priority, srtf, hrrn and rr) without a Synchronizer can be
+// snapshotted, since those hold no state of their own; the simulator has no
+// random number generator whose state would need saving.
+type Snapshot struct {
+	Policy      string      `json:"policy"`
+	Quantum     int64       `json:"quantum,omitempty"`
+	MaxAdmitted int         `json:"max_admitted,omitempty"`
+	Now         int64       `json:"now"`
+	Tasks       []Task      `json:"tasks"`
+	Arrived     []int       `json:"arrived"`
+	Admitted    []int       `json:"admitted"`
+	Ready       []int       `json:"ready"`
+	Running     int         `json:"running"`
+	Pool        int         `json:"pool"`
+	Done        int         `json:"done"`
+	Gantt       []TimeSlice `json:"gantt"`
+	// ReadyQueue is the ready queue length at each tick recorded so far.
+	ReadyQueue []int64 `json:"ready_queue,omitempty"`
+}
+
+// WithSnapshotAt calls save with the state of the run before the first tick at or after at.
+func WithSnapshotAt(at int64, save func(Snapshot)) Option {
+	return func(e *engine) {
+		taken := false
+		e.observers = append(e.observers, func(s *engineState) {
+			if !taken && s.now >= at {
+				if snap, err := e.snapshot(s); err == nil {
+					save(snap)
+				}
+				taken = true
+			}
+		})
+	}
+}
+
+// WithCheckpoints calls save with the state of the run every so many ticks
+// of simulated time, so an interrupted run can be resumed from the last one.
+func WithCheckpoints(every int64, save func(Snapshot)) Option {
+	return func(e *engine) {
+		next := int64(-1)
+		e.observers = append(e.observers, func(s *engineState) {
+			if next < 0 {
+				// Start counting from where the run (or resumed run) began.
+				next = s.now + every
+				return
+			}
+			if s.now >= next {
+				if snap, err := e.snapshot(s); err == nil {
+					save(snap)
+				}
+				next = s.now + every
+			}
+		})
+	}
+}
+
+// policyName names a stateless policy the way PolicyByName does.
+func policyName(p Policy) (name string, quantum int64, ok bool) {
+	switch p := p.(type) {
+	case FCFSPolicy:
+		return "fcfs", 0, true
+	case SJFPolicy:
+		return "sjf", 0, true
+	case PriorityPolicy:
+		return "priority", 0, true
+	case SRTFPolicy:
+		return "srtf", 0, true
+	case PreemptivePriorityPolicy:
+		return "preemptive-priority", 0, true
+	case HRRNPolicy:
+		return "hrrn", 0, true
+	case RRPolicy:
+		return "rr", p.Quantum, true
+	case PriorityRRPolicy:
+		return "priority-rr", p.Quantum, true
+	}
+	return "", 0, false
+}
+
+func (e *engine) snapshot(s *engineState) (Snapshot, error) {
+	name, quantum, ok := policyName(e.policy)
+	if !ok || e.sync != nil || e.io != nil {
+		return Snapshot{}, fmt.Errorf("%w: %s runs can't be snapshotted", ErrInvalidArgs, policyTitle(e.policy))
+	}
+	index := make(map[*Task]int, len(s.tasks))
+	snap := Snapshot{
+		Policy:      name,
+		Quantum:     quantum,
+		MaxAdmitted: e.maxAdmitted,
+		Now:         s.now,
+		Running:     -1,
+		Pool:        s.pool,
+		Done:        s.done,
+		Gantt:       append([]TimeSlice(nil), e.slices...),
+	}
+	if e.queue != nil {
+		snap.ReadyQueue = append([]int64(nil), e.queue.lengths...)
+	}
+	for i, t := range s.tasks {
+		index[t] = i
+		snap.Tasks = append(snap.Tasks, *t)
+	}
+	indexes := func(tasks []*Task) []int {
+		is := make([]int, len(tasks))
+		for i, t := range tasks {
+			is[i] = index[t]
+		}
+		return is
+	}
+	snap.Arrived = indexes(s.arrived)
+	snap.Admitted = indexes(s.admitted)
+	snap.Ready = indexes(s.ready)
+	if s.running != nil {
+		snap.Running = index[s.running]
+	}
+	return snap, nil
+}
+
+// restore rebuilds the engine and run state a snapshot was taken from.
+func restore(snap Snapshot) (*engine, *engineState, error) {
+	policy, err := PolicyByName(snap.Policy, snap.Quantum)
+	if err != nil {
+		return nil, nil, err
+	}
+	e := &engine{policy: policy, maxAdmitted: snap.MaxAdmitted, slices: snap.Gantt}
+	s := &engineState{pool: snap.Pool, done: snap.Done, now: snap.Now}
+	for i := range snap.Tasks {
+		t := snap.Tasks[i]
+		s.tasks = append(s.tasks, &t)
+	}
+	tasks := func(is []int) ([]*Task, error) {
+		ts := make([]*Task, len(is))
+		for i, j := range is {
+			if j < 0 || j >= len(s.tasks) {
+				return nil, fmt.Errorf("%w: snapshot refers to task %d of %d", ErrInvalidArgs, j, len(s.tasks))
+			}
+			ts[i] = s.tasks[j]
+		}
+		return ts, nil
+	}
+	if s.arrived, err = tasks(snap.Arrived); err != nil {
+		return nil, nil, err
+	}
+	if s.admitted, err = tasks(snap.Admitted); err != nil {
+		return nil, nil, err
+	}
+	if s.ready, err = tasks(snap.Ready); err != nil {
+		return nil, nil, err
+	}
+	if snap.Running >= 0 {
+		running, err := tasks([]int{snap.Running})
+		if err != nil {
+			return nil, nil, err
+		}
+		s.running = running[0]
+	}
+	e.queue = newQueueRecorder()
+	e.queue.lengths = snap.ReadyQueue
+	for _, t := range s.admitted {
+		e.queue.queued[t.ProcessID] = true
+	}
+	for _, t := range s.ready {
+		e.queue.queued[t.ProcessID] = true
+	}
+	return e, s, nil
+}
+
+// resumeSnapshot runs a snapshot to completion.
+func resumeSnapshot(title string, snap Snapshot, opts ...Option) (Report, error) {
+	e, s, err := restore(snap)
+	if err != nil {
+		return Report{}, err
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e.report(title, e.resume(s)), nil
+}
+
+func readSnapshot(path string) (Snapshot, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("%w: reading snapshot", err)
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(b, &snap); err != nil {
+		return Snapshot{}, fmt.Errorf("%w: parsing snapshot", err)
+	}
+	return snap, nil
+}
+
+// runSnapshot implements the snapshot subcommand:
+//
+//	snapshot -at 20 [-policy rr] [-quantum 10] [-mpl 0] -o state.json processes.csv
+//	snapshot -show state.json
+//	snapshot -resume state.json
+//
+// The first form simulates until tick 20 and saves the state there; -show
+// prints a saved state and -resume runs it to completion.
+func runSnapshot(w io.Writer, args ...string) error {
+	fs := flag.NewFlagSet("snapshot", flag.ContinueOnError)
+	fs.SetOutput(w)
+	at := fs.Int64("at", 0, "tick to take the snapshot at")
+	policyFlag := fs.String("policy", "rr", "scheduling policy: fcfs, sjf, priority, preemptive-priority, srtf, hrrn, rr or priority-rr")
+	quantum := fs.Int64("quantum", 10, "round-robin time quantum")
+	mpl := fs.Int("mpl", 0, "admit at most this many processes at once")
+	out := fs.String("o", "", "file to save the snapshot to")
+	show := fs.String("show", "", "snapshot to print")
+	resume := fs.String("resume", "", "snapshot to run to completion")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+
+	switch {
+	case *show != "":
+		snap, err := readSnapshot(*show)
+		if err != nil {
+			return err
+		}
+		outputSnapshot(w, snap)
+		return nil
+	case *resume != "":
+		snap, err := readSnapshot(*resume)
+		if err != nil {
+			return err
+		}
+		r, err := resumeSnapshot(fmt.Sprintf("%s (resumed at %d)", snap.Policy, snap.Now), snap)
+		if err != nil {
+			return err
+		}
+		outputReport(w, r)
+		return nil
+	}
+
+	if fs.NArg() != 1 || *out == "" {
+		return fmt.Errorf("%w: usage: snapshot -at N [-policy name] [-quantum N] [-mpl N] -o state.json processes.csv", ErrInvalidArgs)
+	}
+	policy, err := PolicyByName(*policyFlag, *quantum)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("%v: error opening scheduling file", err)
+	}
+	defer func() { _ = f.Close() }()
+	processes, err := loadProcesses(f)
+	if err != nil {
+		return err
+	}
+
+	var snap *Snapshot
+	simulate("", processes, policy, WithMultiprogramming(*mpl), WithSnapshotAt(*at, func(s Snapshot) { snap = &s }))
+	if snap == nil {
+		return fmt.Errorf("%w: the run finished before tick %d", ErrInvalidArgs, *at)
+	}
+	return writeJSON(*out, snap)
+}
+
+func outputSnapshot(w io.Writer, snap Snapshot) {
+	_, _ = fmt.Fprintf(w, "Policy %s", snap.Policy)
+	if snap.Policy == "rr" {
+		_, _ = fmt.Fprintf(w, " (quantum %d)", snap.Quantum)
+	}
+	_, _ = fmt.Fprintf(w, ", clock %d, %d of %d tasks done\n", snap.Now, snap.Done, len(snap.Tasks))
+	ids := func(is []int) []int64 {
+		out := make([]int64, len(is))
+		for i, j := range is {
+			out[i] = snap.Tasks[j].ProcessID
+		}
+		return out
+	}
+	if snap.Running >= 0 {
+		t := snap.Tasks[snap.Running]
+		_, _ = fmt.Fprintf(w, "Running: %d (%d remaining, %d into its slice)\n", t.ProcessID, t.Remaining, t.Slice)
+	} else {
+		_, _ = fmt.Fprintln(w, "Running: none")
+	}
+	_, _ = fmt.Fprintf(w, "Ready: %s\n", formatIDs(ids(snap.Ready)))
+	_, _ = fmt.Fprintf(w, "Awaiting admission: %s\n", formatIDs(ids(snap.Admitted)))
+	_, _ = fmt.Fprintf(w, "Not yet arrived: %s\n", formatIDs(ids(snap.Arrived)))
+	renderer{}.outputGantt(w, snap.Gantt)
+}
+
+//endregion
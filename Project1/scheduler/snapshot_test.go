@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func Test_Snapshot(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	for _, at := range []int64{0, 5, 7, 19} {
+		var snap Snapshot
+		want := simulate("RR", processes, RRPolicy{Quantum: 4}, WithMultiprogramming(2),
+			WithSnapshotAt(at, func(s Snapshot) { snap = s }))
+
+		// Round trip through JSON, as saving and loading a file would.
+		b, err := json.Marshal(snap)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var loaded Snapshot
+		if err := json.Unmarshal(b, &loaded); err != nil {
+			t.Fatal(err)
+		}
+		if loaded.Now != at {
+			t.Errorf("snapshot at %d taken at %d", at, loaded.Now)
+		}
+		got, err := resumeSnapshot("RR", loaded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("resumed at %d = %+v, want %+v", at, got, want)
+		}
+	}
+}
+
+func Test_WithCheckpoints(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 7},
+		{ProcessID: 2, ArrivalTime: 2, BurstDuration: 7},
+		{ProcessID: 3, ArrivalTime: 30, BurstDuration: 3},
+	}
+	var taken []int64
+	var last Snapshot
+	want := simulate("FCFS", processes, FCFSPolicy{}, WithCheckpoints(5, func(s Snapshot) {
+		taken = append(taken, s.Now)
+		last = s
+	}))
+	// The run skips ahead over the idle gap before process 3.
+	if w := []int64{5, 10, 30}; !reflect.DeepEqual(taken, w) {
+		t.Errorf("checkpoints at %v, want %v", taken, w)
+	}
+
+	// Resuming from the last checkpoint finishes the same way.
+	got, err := resumeSnapshot("FCFS", last)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("resumed = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,171 @@
+package scheduler
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+//region Selfish round-robin
+
+type (
+	// SRRPolicy is selfish round-robin. Arriving tasks wait in a new
+	// queue, their priority rising from 0 by A every tick, while tasks in
+	// the accepted queue, which run round-robin with Quantum, rise by B. A
+	// new task is accepted once its priority reaches that of the lowest
+	// accepted task, or at once when no task is accepted. With B below A the
+	// accepted tasks selfishly hold off newcomers for a while; with B at 0
+	// it is plain round-robin, and with B at A or above, first-come,
+	// first-served in batches.
+	SRRPolicy struct {
+		Quantum int64
+		A, B    float64
+		// accepted is when each accepted task was accepted, and acceptedAt
+		// its priority then.
+		accepted   map[*Task]int64
+		acceptedAt map[*Task]float64
+		// queue is the accepted queue, the running task excluded.
+		queue      []*Task
+		dispatches []srrDispatch
+	}
+
+	// srrDispatch is a task dispatched at Time, straight from the new queue
+	// if New.
+	srrDispatch struct {
+		Time int64
+		PID  int64
+		New  bool
+	}
+)
+
+// priority is t's priority at now.
+func (p *SRRPolicy) priority(now int64, t *Task) float64 {
+	if at, ok := p.accepted[t]; ok {
+		return p.acceptedAt[t] + p.B*float64(now-at)
+	}
+	return p.A * float64(now-t.ArrivalTime)
+}
+
+// accept moves t to the back of the accepted queue.
+func (p *SRRPolicy) accept(now int64, t *Task) {
+	p.acceptedAt[t] = p.priority(now, t)
+	p.accepted[t] = now
+	p.queue = append(p.queue, t)
+}
+
+func (p *SRRPolicy) Pick(now int64, running *Task, ready []*Task) *Task {
+	if p.accepted == nil {
+		p.accepted, p.acceptedAt = make(map[*Task]int64), make(map[*Task]float64)
+	}
+
+	// Keep the accepted queue to the tasks still ready, and put accepted
+	// tasks back in it when they wake from I/O.
+	inReady := make(map[*Task]bool, len(ready))
+	for _, t := range ready {
+		inReady[t] = true
+	}
+	queued := make(map[*Task]bool, len(p.queue))
+	kept := p.queue[:0]
+	for _, t := range p.queue {
+		if inReady[t] {
+			kept = append(kept, t)
+			queued[t] = true
+		}
+	}
+	p.queue = kept
+	for _, t := range ready {
+		if _, ok := p.accepted[t]; ok && !queued[t] {
+			p.queue = append(p.queue, t)
+		}
+	}
+
+	// Accept the new tasks that have caught up with the accepted ones, or
+	// the longest waiting one if none is accepted.
+	fromNew := make(map[*Task]bool)
+	for {
+		var lowest, candidate *Task
+		if running != nil {
+			lowest = running
+		}
+		for _, t := range p.queue {
+			if lowest == nil || p.priority(now, t) < p.priority(now, lowest) {
+				lowest = t
+			}
+		}
+		for _, t := range ready {
+			if _, ok := p.accepted[t]; ok {
+				continue
+			}
+			if candidate == nil || p.priority(now, t) > p.priority(now, candidate) {
+				candidate = t
+			}
+		}
+		if candidate == nil || lowest != nil && p.priority(now, candidate) < p.priority(now, lowest)-1e-9 {
+			break
+		}
+		p.accept(now, candidate)
+		fromNew[candidate] = len(p.queue) == 1 && running == nil
+	}
+
+	if running != nil && (len(p.queue) == 0 || p.Quantum <= 0 || running.Slice%p.Quantum != 0) {
+		return running
+	}
+	if running != nil {
+		p.queue = append(p.queue, running)
+	}
+	if len(p.queue) == 0 {
+		return nil
+	}
+	pick := p.queue[0]
+	p.queue = p.queue[1:]
+	if pick != running {
+		p.dispatches = append(p.dispatches, srrDispatch{Time: now, PID: pick.ProcessID, New: fromNew[pick]})
+	}
+	return pick
+}
+
+// annotate adds when each task was accepted and how long it waited in the
+// new queue, and draws the Gantt chart marking each slice with the queue
+// its task was dispatched from.
+func (p *SRRPolicy) annotate(r *Report, tasks []*Task) {
+	accepted, waited := Column{Header: "Accepted"}, Column{Header: "Waited new"}
+	for _, t := range tasks {
+		at := p.accepted[t]
+		accepted.Values = append(accepted.Values, fmt.Sprintf("t=%d", at))
+		waited.Values = append(waited.Values, fmt.Sprint(at-t.ArrivalTime))
+	}
+	r.Columns = append(r.Columns, accepted, waited)
+
+	marks := make([]string, len(r.Gantt))
+	for i, s := range r.Gantt {
+		marks[i] = "(A)"
+		for _, d := range p.dispatches {
+			if d.Time == s.Start && d.PID == s.PID && d.New {
+				marks[i] = "(N)"
+			}
+		}
+	}
+	var lane bytes.Buffer
+	renderer{}.outputGanttLane(&lane, r.Gantt, marks)
+	r.Notes = append(r.Notes, "Dispatched from the (N)ew or (A)ccepted queue:\n"+strings.TrimRight(lane.String(), "\n"))
+}
+
+// title names selfish round-robin after its quantum and rates.
+func (p *SRRPolicy) title() string {
+	return fmt.Sprintf("Selfish round-robin, quantum %d, a %g, b %g", p.Quantum, p.A, p.B)
+}
+
+// SRRSchedule outputs the selfish round-robin schedule of processes as a
+// Gantt chart and a table of timing.
+func SRRSchedule(w io.Writer, title string, processes []Process, quantum int64, a, b float64) {
+	outputReport(w, SRR(title, processes, quantum, a, b))
+}
+
+// SRR schedules processes by selfish round-robin with quantum, new
+// processes' priority rising by a every tick and accepted ones' by b.
+func SRR(title string, processes []Process, quantum int64, a, b float64) Report {
+	return simulate(title, processes, &SRRPolicy{Quantum: quantum, A: a, B: b})
+}
+
+//endregion
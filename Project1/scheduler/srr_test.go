@@ -0,0 +1,93 @@
+package scheduler
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSRR(t *testing.T) {
+	t.Parallel()
+	textbook := []Process{
+		{ProcessID: 1, BurstDuration: 5}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 5},
+		{ProcessID: 3, ArrivalTime: 3, BurstDuration: 3}, {ProcessID: 4, ArrivalTime: 4, BurstDuration: 4},
+	}
+	tests := []struct {
+		name         string
+		processes    []Process
+		quantum      int64
+		a, b         float64
+		wantGantt    []TimeSlice
+		wantAccepted []string
+		wantLane     string
+	}{
+		{
+			name:      "textbook, a 2 and b 1",
+			processes: textbook,
+			quantum:   1,
+			a:         2,
+			b:         1,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 3}, {PID: 1, Start: 3, Stop: 4},
+				{PID: 2, Start: 4, Stop: 5}, {PID: 1, Start: 5, Stop: 6}, {PID: 2, Start: 6, Stop: 7},
+				{PID: 3, Start: 7, Stop: 8}, {PID: 1, Start: 8, Stop: 9}, {PID: 2, Start: 9, Stop: 10},
+				{PID: 4, Start: 10, Stop: 11}, {PID: 3, Start: 11, Stop: 12}, {PID: 2, Start: 12, Stop: 13},
+				{PID: 4, Start: 13, Stop: 14}, {PID: 3, Start: 14, Stop: 15}, {PID: 4, Start: 15, Stop: 17},
+			},
+			wantAccepted: []string{"t=0", "t=2", "t=6", "t=8"},
+			wantLane:     "|  1(N)  |  2(A)  |  1(A)  |  2(A)  |  1(A)  |  2(A)  |  3(A)  |  1(A)  |  2(A)  |  4(A)  |  3(A)  |  2(A)  |  4(A)  |  3(A)  |  4(A)  |",
+		},
+		{
+			name:         "b 0 is round-robin",
+			processes:    textbook,
+			quantum:      2,
+			a:            1,
+			b:            0,
+			wantGantt:    simulate("", textbook, RRPolicy{Quantum: 2}).Gantt,
+			wantAccepted: []string{"t=0", "t=1", "t=3", "t=4"},
+		},
+		{
+			name:      "b at a is first-come, first-served",
+			processes: textbook,
+			quantum:   1,
+			a:         1,
+			b:         1,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 5, Stop: 10}, {PID: 3, Start: 10, Stop: 13}, {PID: 4, Start: 13, Stop: 17},
+			},
+			wantAccepted: []string{"t=0", "t=5", "t=10", "t=13"},
+			wantLane:     "|  1(N)  |  2(N)  |  3(N)  |  4(N)  |",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := SRR("Selfish round-robin", tt.processes, tt.quantum, tt.a, tt.b)
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("SRR() Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			if len(r.Columns) != 2 || !reflect.DeepEqual(r.Columns[0].Values, tt.wantAccepted) {
+				t.Errorf("SRR() columns = %v, want accepted %q", r.Columns, tt.wantAccepted)
+			}
+			if len(r.Notes) != 1 || !strings.HasPrefix(r.Notes[0], "Dispatched from the (N)ew or (A)ccepted queue:\n") {
+				t.Fatalf("SRR() notes = %q, want the Gantt chart by queue", r.Notes)
+			}
+			if tt.wantLane != "" && strings.Split(r.Notes[0], "\n")[1] != tt.wantLane {
+				t.Errorf("SRR() lane = %q, want %q", strings.Split(r.Notes[0], "\n")[1], tt.wantLane)
+			}
+		})
+	}
+}
+
+func TestSRRSchedule(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	SRRSchedule(&w, "Selfish round-robin, quantum 1, a 2, b 1", []Process{{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 1}}, 1, 2, 1)
+	for _, want := range []string{"Selfish round-robin, quantum 1, a 2, b 1", "WAITED NEW", "|  1(N)  |  2(N)  |"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("SRRSchedule() = %q, want it to contain %q", w.String(), want)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package scheduler
+
+import "io"
+
+//region Shortest remaining time first
+
+// SRTFPolicy runs the task with the least remaining time, preempting the
+// running task as soon as one arrives that needs less than it has left.
+// Ties keep the running task, then go to the earliest in the ready queue.
+type SRTFPolicy struct{}
+
+func (SRTFPolicy) Pick(_ int64, running *Task, ready []*Task) *Task {
+	best := minTask(ready, func(a, b *Task) bool { return a.Remaining < b.Remaining })
+	if running != nil && (best == nil || running.Remaining <= best.Remaining) {
+		return running
+	}
+	return best
+}
+
+func (SRTFPolicy) auditKey() (string, func(*Task) float64) {
+	return "remaining", func(t *Task) float64 { return float64(t.Remaining) }
+}
+
+func (SRTFPolicy) preemptive() {}
+
+// SRTFSchedule outputs the preemptive shortest-remaining-time-first
+// schedule of processes as a Gantt chart and a table of timing.
+func SRTFSchedule(w io.Writer, title string, processes []Process) {
+	outputReport(w, SRTF(title, processes))
+}
+
+// SRTF schedules processes shortest remaining time first, one tick at a
+// time, so a process is preempted whenever a shorter one arrives.
+func SRTF(title string, processes []Process) Report {
+	return simulate(title, processes, SRTFPolicy{})
+}
+
+//endregion
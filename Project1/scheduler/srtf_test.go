@@ -0,0 +1,99 @@
+package scheduler
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSRTF(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		wantGantt []TimeSlice
+		wantWait  []int64
+		wantAvg   float64
+	}{
+		{
+			name: "preempts on a shorter arrival",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 8}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 4},
+				{ProcessID: 3, ArrivalTime: 2, BurstDuration: 9}, {ProcessID: 4, ArrivalTime: 3, BurstDuration: 5},
+			},
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 5}, {PID: 4, Start: 5, Stop: 10}, {PID: 1, Start: 10, Stop: 17}, {PID: 3, Start: 17, Stop: 26}},
+			wantWait:  []int64{9, 0, 15, 2},
+			wantAvg:   6.5,
+		},
+		{
+			name: "ties keep the running process",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 4}, {ProcessID: 2, ArrivalTime: 2, BurstDuration: 2},
+			},
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, Start: 4, Stop: 6}},
+			wantWait:  []int64{0, 2},
+			wantAvg:   1,
+		},
+		{
+			name: "longer arrivals wait",
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 3}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 6},
+				{ProcessID: 3, ArrivalTime: 5, BurstDuration: 1},
+			},
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 5}, {PID: 3, Start: 5, Stop: 6}, {PID: 2, Start: 6, Stop: 10}},
+			wantWait:  []int64{0, 3, 0},
+			wantAvg:   1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := SRTF("SRTF", tt.processes)
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("SRTF() Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			var waits []int64
+			for _, row := range r.Rows {
+				waits = append(waits, row.Wait)
+				if row.Turnaround != row.Wait+row.Burst || row.Exit != row.Arrival+row.Turnaround {
+					t.Errorf("SRTF() row %+v is inconsistent", row)
+				}
+			}
+			if !reflect.DeepEqual(waits, tt.wantWait) || r.Wait != tt.wantAvg {
+				t.Errorf("SRTF() waits = %v (average %v), want %v (average %v)", waits, r.Wait, tt.wantWait, tt.wantAvg)
+			}
+		})
+	}
+}
+
+func TestSRTFSchedule(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	SRTFSchedule(&w, "Shortest-remaining-time-first", []Process{{ProcessID: 1, BurstDuration: 8}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 4}})
+	for _, want := range []string{"Shortest-remaining-time-first\n", "|   1   |   2   |   1   |\n0\t1\t5\t12"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("SRTFSchedule() = %q, want it to contain %q", w.String(), want)
+		}
+	}
+}
+
+func Test_auditReason_srtf(t *testing.T) {
+	t.Parallel()
+	var entries []AuditEntry
+	simulate("", []Process{{ProcessID: 1, BurstDuration: 8}, {ProcessID: 2, ArrivalTime: 1, BurstDuration: 4}, {ProcessID: 3, ArrivalTime: 2, BurstDuration: 7}},
+		SRTFPolicy{}, WithAudit(func(e AuditEntry) { entries = append(entries, e) }))
+	want := []string{
+		"lowest remaining, 4; preempts process 1",
+		"lowest remaining, 3; the running task keeps the CPU",
+	}
+	if len(entries) < len(want) {
+		t.Fatalf("got %d audit entries, want at least %d", len(entries), len(want))
+	}
+	for i, w := range want {
+		if entries[i].Reason != w {
+			t.Errorf("entry %d reason = %q, want %q", i, entries[i].Reason, w)
+		}
+	}
+}
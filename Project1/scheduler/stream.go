@@ -0,0 +1,89 @@
+package scheduler
+
+import "context"
+
+//region Streaming events
+
+type (
+	// Event is something that happened during a simulation. It is one of
+	// ArriveEvent, DispatchEvent, PreemptEvent, CompleteEvent, IOStartEvent,
+	// IOWakeEvent or IdleEvent.
+	Event interface {
+		// At is when the event happened.
+		At() int64
+	}
+
+	// ArriveEvent is a process arriving.
+	ArriveEvent struct {
+		Time int64 `json:"time"`
+		PID  int64 `json:"pid"`
+	}
+	// DispatchEvent is a process getting the CPU.
+	DispatchEvent struct {
+		Time int64 `json:"time"`
+		PID  int64 `json:"pid"`
+	}
+	// PreemptEvent is a process losing the CPU before it finished. It is
+	// followed by a DispatchEvent for the process replacing it, unless the
+	// CPU is left idle.
+	PreemptEvent struct {
+		Time int64 `json:"time"`
+		PID  int64 `json:"pid"`
+	}
+	// CompleteEvent is a process finishing.
+	CompleteEvent struct {
+		Time int64 `json:"time"`
+		PID  int64 `json:"pid"`
+	}
+	// IOStartEvent is a process leaving the CPU to do I/O until Stop.
+	IOStartEvent struct {
+		Time int64 `json:"time"`
+		PID  int64 `json:"pid"`
+		Stop int64 `json:"stop"`
+	}
+	// IOWakeEvent is a process's I/O completing, putting it back in the
+	// ready queue.
+	IOWakeEvent struct {
+		Time int64 `json:"time"`
+		PID  int64 `json:"pid"`
+	}
+	// IdleEvent is the CPU running nothing from Start until Stop.
+	IdleEvent struct {
+		Start int64 `json:"start"`
+		Stop  int64 `json:"stop"`
+	}
+)
+
+func (e ArriveEvent) At() int64   { return e.Time }
+func (e DispatchEvent) At() int64 { return e.Time }
+func (e PreemptEvent) At() int64  { return e.Time }
+func (e CompleteEvent) At() int64 { return e.Time }
+func (e IOStartEvent) At() int64  { return e.Time }
+func (e IOWakeEvent) At() int64   { return e.Time }
+func (e IdleEvent) At() int64     { return e.Start }
+
+// SimulateStream runs the same simulation as Simulate in the background and
+// sends its events, in the order they happen, on the returned channel,
+// which is closed when the run finishes. The run blocks until each event
+// is received; cancelling ctx stops it and closes the channel early.
+func SimulateStream(ctx context.Context, w Workload, policy Policy, opts ...Option) (<-chan Event, error) {
+	e, err := newEngine(ctx, w, policy, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan Event)
+	e.emit = func(ev Event) {
+		select {
+		case events <- ev:
+		case <-ctx.Done():
+		}
+	}
+	go func() {
+		defer close(events)
+		e.run(w.Processes)
+	}()
+	return events, nil
+}
+
+//endregion
@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_SimulateStream(t *testing.T) {
+	t.Parallel()
+	w, err := ReadWorkload(strings.NewReader("1,3,0,1\n2,1,1,1\n3,1,6,1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name   string
+		policy Policy
+		want   []Event
+	}{
+		{
+			name:   "round-robin",
+			policy: RRPolicy{Quantum: 2},
+			want: []Event{
+				ArriveEvent{Time: 0, PID: 1}, DispatchEvent{Time: 0, PID: 1},
+				ArriveEvent{Time: 1, PID: 2},
+				PreemptEvent{Time: 2, PID: 1}, DispatchEvent{Time: 2, PID: 2}, CompleteEvent{Time: 3, PID: 2},
+				DispatchEvent{Time: 3, PID: 1}, CompleteEvent{Time: 4, PID: 1},
+				IdleEvent{Start: 4, Stop: 6},
+				ArriveEvent{Time: 6, PID: 3}, DispatchEvent{Time: 6, PID: 3}, CompleteEvent{Time: 7, PID: 3},
+			},
+		},
+		{
+			name:   "first-come, first-serve",
+			policy: FCFSPolicy{},
+			want: []Event{
+				ArriveEvent{Time: 0, PID: 1}, DispatchEvent{Time: 0, PID: 1},
+				ArriveEvent{Time: 1, PID: 2},
+				CompleteEvent{Time: 3, PID: 1}, DispatchEvent{Time: 3, PID: 2}, CompleteEvent{Time: 4, PID: 2},
+				IdleEvent{Start: 4, Stop: 6},
+				ArriveEvent{Time: 6, PID: 3}, DispatchEvent{Time: 6, PID: 3}, CompleteEvent{Time: 7, PID: 3},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			events, err := SimulateStream(context.Background(), w, tt.policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []Event
+			for ev := range events {
+				got = append(got, ev)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("events = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_SimulateStream_cancel(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := SimulateStream(ctx, NewWorkload(Process{ProcessID: 1, BurstDuration: 1000}), FCFSPolicy{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-events
+	cancel()
+	for range events {
+	}
+}
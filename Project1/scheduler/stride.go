@@ -0,0 +1,147 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+)
+
+//region Stride scheduling
+
+// strideOne is the large constant strides are divided from, so a task's
+// stride is inversely proportional to its tickets with little rounding.
+const strideOne = 1 << 20
+
+// StridePolicy is the deterministic counterpart of LotteryPolicy. Each
+// task's stride is strideOne divided by its tickets (its weight), and its
+// pass advances by its stride for every tick it runs. Every Quantum ticks
+// the task with the lowest pass runs, so tasks get CPU time in proportion
+// to their tickets, with an error of at most one quantum. The global pass
+// advances by strideOne over the tickets of all the tasks in the system for
+// every tick the CPU is busy, and a task joining starts at it, so it can't
+// claim the time it wasn't there for. Ties keep the running task, then go
+// to the earliest in the ready queue. With Groups, the tickets are
+// flattened from the group hierarchy at every pick, so each group gets its
+// share whichever of its tasks are runnable.
+type StridePolicy struct {
+	Quantum int64
+	Groups  *Group
+	pass    map[*Task]int64
+	global  int64
+	// tickets are the flattened tickets at the last pick with Groups.
+	tickets map[*Task]int64
+}
+
+// ticketsOf is t's tickets at the last pick: its weight, flattened from
+// the groups if there are any.
+func (p *StridePolicy) ticketsOf(t *Task) int64 {
+	if n, ok := p.tickets[t]; ok && p.Groups != nil {
+		return n
+	}
+	return t.weight()
+}
+
+func (p *StridePolicy) Pick(_ int64, running *Task, ready []*Task) *Task {
+	if p.pass == nil {
+		p.pass = make(map[*Task]int64)
+	}
+	candidates := ready
+	if running != nil {
+		candidates = append([]*Task{running}, ready...)
+		p.pass[running] += strideOne / p.ticketsOf(running)
+		// The tasks that shared the tick just run: the candidates, less
+		// any that only joined now.
+		var tickets int64
+		for _, t := range candidates {
+			if _, ok := p.pass[t]; ok {
+				tickets += p.ticketsOf(t)
+			}
+		}
+		p.global += strideOne / tickets
+	}
+	if p.Groups != nil {
+		p.tickets = p.Groups.flatten(candidates, (*Task).weight, defaultWeight)
+	}
+	for _, t := range candidates {
+		if _, ok := p.pass[t]; !ok {
+			p.pass[t] = p.global
+		}
+	}
+
+	quantum := p.Quantum
+	if quantum <= 0 {
+		quantum = 1
+	}
+	if running != nil && running.Slice%quantum != 0 {
+		return running
+	}
+	return minTask(candidates, func(a, b *Task) bool { return p.pass[a] < p.pass[b] })
+}
+
+// annotate adds each task's stride and its CPU share against the share its
+// tickets entitle it to.
+func (p *StridePolicy) annotate(r *Report, tasks []*Task) {
+	if p.Groups != nil {
+		// Tickets change with the runnable tasks, so only the shares they
+		// came to are shown.
+		r.Columns = append(r.Columns, groupColumn(p.Groups, tasks), shareColumns(tasks)[0])
+		return
+	}
+	stride := Column{Header: "Stride"}
+	for _, t := range tasks {
+		stride.Values = append(stride.Values, fmt.Sprint(strideOne/t.weight()))
+	}
+	r.Columns = append(append(r.Columns, stride), shareColumns(tasks)...)
+}
+
+// shareColumns compares the CPU share each task got while it was in the
+// system, its burst over its turnaround, with the share its tickets
+// entitled it to: at every tick, its tickets over those of all the tasks in
+// the system, averaged over its turnaround.
+func shareColumns(tasks []*Task) []Column {
+	var end int64
+	for _, t := range tasks {
+		if t.Exit > end {
+			end = t.Exit
+		}
+	}
+	entitled := make([]float64, len(tasks))
+	for now := int64(0); now < end; now++ {
+		var total int64
+		for _, t := range tasks {
+			if t.ArrivalTime <= now && now < t.Exit {
+				total += t.weight()
+			}
+		}
+		for i, t := range tasks {
+			if t.ArrivalTime <= now && now < t.Exit {
+				entitled[i] += float64(t.weight()) / float64(total)
+			}
+		}
+	}
+
+	got, want := Column{Header: "CPU share"}, Column{Header: "Entitled share"}
+	for i, t := range tasks {
+		lifetime := float64(t.Exit - t.ArrivalTime)
+		if lifetime <= 0 {
+			got.Values, want.Values = append(got.Values, ""), append(want.Values, "")
+			continue
+		}
+		got.Values = append(got.Values, fmt.Sprintf("%.1f%%", 100*float64(t.BurstDuration)/lifetime))
+		want.Values = append(want.Values, fmt.Sprintf("%.1f%%", 100*entitled[i]/lifetime))
+	}
+	return []Column{got, want}
+}
+
+// StrideSchedule outputs the stride schedule of processes as a Gantt chart
+// and a table of timing.
+func StrideSchedule(w io.Writer, title string, processes []Process, quantum int64) {
+	outputReport(w, Stride(title, processes, quantum))
+}
+
+// Stride schedules processes by stride scheduling with tickets from the
+// weight column, choosing every quantum ticks.
+func Stride(title string, processes []Process, quantum int64) Report {
+	return simulate(title, processes, &StridePolicy{Quantum: quantum})
+}
+
+//endregion
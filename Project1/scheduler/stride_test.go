@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStride(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		processes    []Process
+		quantum      int64
+		wantGantt    []TimeSlice
+		wantShare    []string
+		wantEntitled []string
+	}{
+		{
+			name:      "twice the tickets, twice the ticks",
+			processes: []Process{{ProcessID: 1, BurstDuration: 6, Weight: 200}, {ProcessID: 2, BurstDuration: 3, Weight: 100}},
+			quantum:   1,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 2}, {PID: 1, Start: 2, Stop: 4}, {PID: 2, Start: 4, Stop: 5},
+				{PID: 1, Start: 5, Stop: 7}, {PID: 2, Start: 7, Stop: 8}, {PID: 1, Start: 8, Stop: 9},
+			},
+			wantShare:    []string{"66.7%", "37.5%"},
+			wantEntitled: []string{"70.4%", "33.3%"},
+		},
+		{
+			name:         "a late arrival starts at the global pass",
+			processes:    []Process{{ProcessID: 1, BurstDuration: 6}, {ProcessID: 2, ArrivalTime: 4, BurstDuration: 2}},
+			quantum:      1,
+			wantGantt:    []TimeSlice{{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 5, Stop: 7}, {PID: 1, Start: 7, Stop: 8}},
+			wantShare:    []string{"75.0%", "66.7%"},
+			wantEntitled: []string{"81.2%", "50.0%"},
+		},
+		{
+			name:         "the quantum spaces the choices",
+			processes:    []Process{{ProcessID: 1, BurstDuration: 4, Weight: 300}, {ProcessID: 2, BurstDuration: 4, Weight: 100}},
+			quantum:      2,
+			wantGantt:    []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 1, Start: 4, Stop: 6}, {PID: 2, Start: 6, Stop: 8}},
+			wantShare:    []string{"66.7%", "50.0%"},
+			wantEntitled: []string{"75.0%", "43.8%"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := Stride("Stride", tt.processes, tt.quantum)
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("Stride() Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			if got := r.Columns[1].Values; !reflect.DeepEqual(got, tt.wantShare) {
+				t.Errorf("Stride() CPU share = %q, want %q", got, tt.wantShare)
+			}
+			if got := r.Columns[2].Values; !reflect.DeepEqual(got, tt.wantEntitled) {
+				t.Errorf("Stride() entitled share = %q, want %q", got, tt.wantEntitled)
+			}
+		})
+	}
+}
+
+func TestStrideSchedule(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	StrideSchedule(&w, "Stride", []Process{{ProcessID: 1, BurstDuration: 2, Weight: 50}}, 1)
+	for _, want := range []string{"STRIDE", "|  20971 | 100.0%    | 100.0%         |"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("StrideSchedule() = %q, want it to contain %q", w.String(), want)
+		}
+	}
+}
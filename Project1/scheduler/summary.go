@@ -0,0 +1,86 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+//region Summary
+
+type (
+	// Summary is the aggregate outcome of a run, without per-process detail.
+	Summary struct {
+		Workload   string             `json:"workload"`
+		Started    time.Time          `json:"started"`
+		Finished   time.Time          `json:"finished"`
+		Algorithms []AlgorithmSummary `json:"algorithms"`
+	}
+	// AlgorithmSummary holds one algorithm's footer metrics.
+	AlgorithmSummary struct {
+		Title             string  `json:"title"`
+		Processes         int     `json:"processes"`
+		AverageWait       float64 `json:"average_wait"`
+		AverageTurnaround float64 `json:"average_turnaround"`
+		Throughput        float64 `json:"throughput"`
+		Makespan          int64   `json:"makespan"`
+		// SLOs is the attainment of each -slo target, if any were given.
+		SLOs []SLOAttainment `json:"slos,omitempty"`
+	}
+)
+
+// summarize reduces reports to their footer metrics.
+func summarize(workload string, started, finished time.Time, reports []Report) Summary {
+	s := Summary{
+		Workload: workload,
+		Started:  started,
+		Finished: finished,
+	}
+	for _, r := range reports {
+		s.Algorithms = append(s.Algorithms, AlgorithmSummary{
+			Title:             r.Title,
+			Processes:         len(r.Rows),
+			AverageWait:       r.Wait,
+			AverageTurnaround: r.Turnaround,
+			Throughput:        r.Throughput,
+			Makespan:          r.makespan(),
+		})
+	}
+
+	return s
+}
+
+// outputSummaryYAML writes s as YAML with the same keys as its JSON encoding.
+func outputSummaryYAML(w io.Writer, s Summary) {
+	float := func(f float64) string { return strconv.FormatFloat(f, 'f', -1, 64) }
+	_, _ = fmt.Fprintf(w, "workload: %s\n", strconv.Quote(s.Workload))
+	_, _ = fmt.Fprintf(w, "started: %s\n", s.Started.Format(time.RFC3339Nano))
+	_, _ = fmt.Fprintf(w, "finished: %s\n", s.Finished.Format(time.RFC3339Nano))
+	if len(s.Algorithms) == 0 {
+		_, _ = fmt.Fprintln(w, "algorithms: []")
+		return
+	}
+	_, _ = fmt.Fprintln(w, "algorithms:")
+	for _, a := range s.Algorithms {
+		_, _ = fmt.Fprintf(w, "  - title: %s\n", strconv.Quote(a.Title))
+		_, _ = fmt.Fprintf(w, "    processes: %d\n", a.Processes)
+		_, _ = fmt.Fprintf(w, "    average_wait: %s\n", float(a.AverageWait))
+		_, _ = fmt.Fprintf(w, "    average_turnaround: %s\n", float(a.AverageTurnaround))
+		_, _ = fmt.Fprintf(w, "    throughput: %s\n", float(a.Throughput))
+		_, _ = fmt.Fprintf(w, "    makespan: %d\n", a.Makespan)
+		if len(a.SLOs) == 0 {
+			continue
+		}
+		_, _ = fmt.Fprintln(w, "    slos:")
+		for _, slo := range a.SLOs {
+			_, _ = fmt.Fprintf(w, "      - tag: %s\n", strconv.Quote(slo.Tag))
+			_, _ = fmt.Fprintf(w, "        target: %d\n", slo.Target)
+			_, _ = fmt.Fprintf(w, "        processes: %d\n", slo.Processes)
+			_, _ = fmt.Fprintf(w, "        met: %d\n", slo.Met)
+			_, _ = fmt.Fprintf(w, "        attainment: %s\n", float(slo.Attainment))
+		}
+	}
+}
+
+//endregion
@@ -0,0 +1,33 @@
+package scheduler
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func Test_outputSummaryYAML(t *testing.T) {
+	t.Parallel()
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	s := summarize("w.csv", at, at.Add(time.Second), []Report{{
+		Title: "Round-robin",
+		Rows:  []Row{{ProcessID: 1, Arrival: 0, Exit: 4}},
+		Wait:  1.5, Turnaround: 4, Throughput: 0.25,
+	}})
+	want := `workload: "w.csv"
+started: 2024-01-02T03:04:05Z
+finished: 2024-01-02T03:04:06Z
+algorithms:
+  - title: "Round-robin"
+    processes: 1
+    average_wait: 1.5
+    average_turnaround: 4
+    throughput: 0.25
+    makespan: 4
+`
+	var w bytes.Buffer
+	outputSummaryYAML(&w, s)
+	if w.String() != want {
+		t.Errorf("outputSummaryYAML() = %q, want %q", w.String(), want)
+	}
+}
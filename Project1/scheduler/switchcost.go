@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+)
+
+//region Context switch cost and dispatch latency
+
+// WithSwitchCost makes every context switch, whenever the CPU goes from
+// running one task to running another, take n ticks in which no task runs.
+// The switches appear in the Gantt chart as overhead slices.
+func WithSwitchCost(n int64) Option {
+	return func(e *engine) {
+		e.switchCost = n
+	}
+}
+
+// WithDispatchLatency makes every dispatch take n ticks in which no task
+// runs, including a task's first and dispatches onto an idle CPU, on top of
+// any switch cost. Unlike the switch cost it is paid however rarely the
+// policy preempts.
+func WithDispatchLatency(n int64) Option {
+	return func(e *engine) {
+		e.dispatchLatency = n
+	}
+}
+
+// overheadOf returns how many ticks dispatching a task takes when previous
+// ran the last tick, or nil if the CPU was idle, and counts what it paid.
+func (e *engine) overheadOf(previous *Task) int64 {
+	var n int64
+	if e.dispatchLatency > 0 {
+		n += e.dispatchLatency
+		e.dispatches++
+	}
+	if e.switchCost > 0 && previous != nil {
+		n += e.switchCost
+		e.switches++
+	}
+	return n
+}
+
+// addOverheadNotes adds how much of the run went on dispatches and context
+// switches and how busy the CPU was with useful work.
+func (e *engine) addOverheadNotes(r *Report) {
+	span := r.makespan()
+	share := func(ticks int64) float64 {
+		if span <= 0 {
+			return 0
+		}
+		return 100 * float64(ticks) / float64(span)
+	}
+	if e.dispatchLatency > 0 {
+		ticks := int64(e.dispatches) * e.dispatchLatency
+		r.Notes = append(r.Notes, fmt.Sprintf("Dispatch latency %d: %d dispatches took %d ticks, %.1f%% of the makespan",
+			e.dispatchLatency, e.dispatches, ticks, share(ticks)))
+	}
+	if e.switchCost > 0 {
+		ticks := int64(e.switches) * e.switchCost
+		r.Notes = append(r.Notes, fmt.Sprintf("Switch cost %d: %d context switches took %d ticks, %.1f%% of the makespan",
+			e.switchCost, e.switches, ticks, share(ticks)))
+	}
+	var useful int64
+	for _, s := range r.Gantt {
+		if !s.Overhead {
+			useful += s.Stop - s.Start
+		}
+	}
+	r.Notes = append(r.Notes, fmt.Sprintf("Effective CPU utilization: %.1f%%", share(useful)))
+}
+
+// overheadReports runs the classic policies with every context switch
+// costing cost ticks and every dispatch latency ticks.
+func overheadReports(processes []Process, cost, latency, quantum int64) []Report {
+	var opts []Option
+	var parts []string
+	if latency > 0 {
+		opts = append(opts, WithDispatchLatency(latency))
+		parts = append(parts, fmt.Sprintf("dispatch latency %d", latency))
+	}
+	if cost > 0 {
+		opts = append(opts, WithSwitchCost(cost))
+		parts = append(parts, fmt.Sprintf("switch cost %d", cost))
+	}
+	suffix := " (" + strings.Join(parts, ", ") + ")"
+	var reports []Report
+	for _, p := range classicPolicies(quantum) {
+		reports = append(reports, simulate(policyTitle(p)+suffix, processes, p, opts...))
+	}
+	return reports
+}
+
+//endregion
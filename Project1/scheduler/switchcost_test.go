@@ -0,0 +1,140 @@
+package scheduler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_WithSwitchCost(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader("1,3,0,1\n2,2,0,2\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		policy    Policy
+		cost      int64
+		latency   int64
+		wantGantt []TimeSlice
+		wantNotes []string
+	}{
+		{
+			name:   "FCFS",
+			policy: FCFSPolicy{},
+			cost:   1,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 3}, {Start: 3, Stop: 4, Overhead: true}, {PID: 2, Start: 4, Stop: 6},
+			},
+			wantNotes: []string{
+				"Switch cost 1: 1 context switches took 1 ticks, 16.7% of the makespan",
+				"Effective CPU utilization: 83.3%",
+			},
+		},
+		{
+			name:   "RR",
+			policy: RRPolicy{Quantum: 2},
+			cost:   1,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2}, {Start: 2, Stop: 3, Overhead: true}, {PID: 2, Start: 3, Stop: 5},
+				{Start: 5, Stop: 6, Overhead: true}, {PID: 1, Start: 6, Stop: 7},
+			},
+			wantNotes: []string{
+				"Switch cost 1: 2 context switches took 2 ticks, 28.6% of the makespan",
+				"Effective CPU utilization: 71.4%",
+			},
+		},
+		{
+			name:   "RR costly",
+			policy: RRPolicy{Quantum: 2},
+			cost:   3,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2}, {Start: 2, Stop: 5, Overhead: true}, {PID: 2, Start: 5, Stop: 7},
+				{Start: 7, Stop: 10, Overhead: true}, {PID: 1, Start: 10, Stop: 11},
+			},
+			wantNotes: []string{
+				"Switch cost 3: 2 context switches took 6 ticks, 54.5% of the makespan",
+				"Effective CPU utilization: 45.5%",
+			},
+		},
+		{
+			name:    "FCFS dispatch latency",
+			policy:  FCFSPolicy{},
+			latency: 1,
+			wantGantt: []TimeSlice{
+				{Start: 0, Stop: 1, Overhead: true}, {PID: 1, Start: 1, Stop: 4}, {Start: 4, Stop: 5, Overhead: true},
+				{PID: 2, Start: 5, Stop: 7},
+			},
+			wantNotes: []string{
+				"Dispatch latency 1: 2 dispatches took 2 ticks, 28.6% of the makespan",
+				"Effective CPU utilization: 71.4%",
+			},
+		},
+		{
+			name:    "RR both",
+			policy:  RRPolicy{Quantum: 2},
+			cost:    1,
+			latency: 1,
+			wantGantt: []TimeSlice{
+				{Start: 0, Stop: 1, Overhead: true}, {PID: 1, Start: 1, Stop: 3}, {Start: 3, Stop: 5, Overhead: true},
+				{PID: 2, Start: 5, Stop: 7}, {Start: 7, Stop: 9, Overhead: true}, {PID: 1, Start: 9, Stop: 10},
+			},
+			wantNotes: []string{
+				"Dispatch latency 1: 3 dispatches took 3 ticks, 30.0% of the makespan",
+				"Switch cost 1: 2 context switches took 2 ticks, 20.0% of the makespan",
+				"Effective CPU utilization: 50.0%",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := simulate(tt.name, processes, tt.policy, WithSwitchCost(tt.cost), WithDispatchLatency(tt.latency))
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			if n := len(tt.wantNotes); len(r.Notes) < n || !reflect.DeepEqual(r.Notes[len(r.Notes)-n:], tt.wantNotes) {
+				t.Errorf("notes = %q, want them to end with %q", r.Notes, tt.wantNotes)
+			}
+			if got, want := contextSwitches(r.Gantt), len(r.Gantt)/2-int(tt.latency); got != want {
+				t.Errorf("contextSwitches() = %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func Test_WithSwitchCostIdle(t *testing.T) {
+	t.Parallel()
+	// The CPU idles from 2 to 10, so dispatching 2 there is no switch.
+	processes := []Process{{ProcessID: 1, BurstDuration: 2}, {ProcessID: 2, ArrivalTime: 10, BurstDuration: 2}}
+	r := simulate("FCFS", processes, FCFSPolicy{}, WithSwitchCost(1))
+	if want := []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 10, Stop: 12}}; !reflect.DeepEqual(r.Gantt, want) {
+		t.Errorf("Gantt = %v, want %v", r.Gantt, want)
+	}
+	if want := "Switch cost 1: 0 context switches took 0 ticks, 0.0% of the makespan"; len(r.Notes) < 2 || r.Notes[len(r.Notes)-2] != want {
+		t.Errorf("notes = %q, want %q", r.Notes, want)
+	}
+}
+
+func Test_overheadReports(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader("1,3,0,1\n2,2,0,2\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reports := overheadReports(processes, 1, 0, 2)
+	if len(reports) != 4 {
+		t.Fatalf("overheadReports() = %d reports, want 4", len(reports))
+	}
+	if want := "Round-robin, quantum 2 (switch cost 1)"; reports[3].Title != want {
+		t.Errorf("title = %q, want %q", reports[3].Title, want)
+	}
+	if got := utilization(reports[0]); got != 5.0/6 {
+		t.Errorf("utilization() = %v, want %v", got, 5.0/6)
+	}
+	if want := "First-come, first-serve (dispatch latency 2, switch cost 1)"; overheadReports(processes, 1, 2, 2)[0].Title != want {
+		t.Errorf("title = %q, want %q", overheadReports(processes, 1, 2, 2)[0].Title, want)
+	}
+}
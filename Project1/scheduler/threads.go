@@ -0,0 +1,346 @@
+package scheduler
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+//region Thread scheduling
+
+type (
+	// Thread is one thread of a process. It becomes runnable when its process arrives.
+	Thread struct {
+		ProcessID     int64
+		ThreadID      int64
+		BurstDuration int64
+	}
+
+	// ThreadSlice is a span of time a thread ran for.
+	ThreadSlice struct {
+		PID   int64 `json:"pid"`
+		TID   int64 `json:"tid"`
+		Start int64 `json:"start"`
+		Stop  int64 `json:"stop"`
+	}
+
+	// ThreadRow is the schedule of one thread.
+	ThreadRow struct {
+		ProcessID  int64 `json:"pid"`
+		ThreadID   int64 `json:"tid"`
+		Burst      int64 `json:"burst"`
+		Arrival    int64 `json:"arrival"`
+		Wait       int64 `json:"wait"`
+		Turnaround int64 `json:"turnaround"`
+		Exit       int64 `json:"exit"`
+	}
+
+	// ThreadReport is a thread-level schedule with per-thread and per-process metrics.
+	ThreadReport struct {
+		Title     string        `json:"title"`
+		Gantt     []ThreadSlice `json:"gantt"`
+		Threads   []ThreadRow   `json:"threads"`
+		Processes []Row         `json:"processes"`
+	}
+)
+
+// runThreads implements the threads subcommand:
+//
+//	threads [-scope both|pcs|scs] [-policy rr] [-quantum 10] processes.csv threads.csv
+//
+// threads.csv has a "<pid>,<tid>,<burst>" line per thread; a process with
+// no threads listed runs as a single thread 1. With process-contention scope
+// the policy schedules processes and each process's threads share its CPU
+// time, run to completion one after another by the thread library. With
+// system-contention scope every thread is scheduled by the policy directly,
+// inheriting its process's arrival and priority.
+func runThreads(w io.Writer, args ...string) error {
+	fs := flag.NewFlagSet("threads", flag.ContinueOnError)
+	fs.SetOutput(w)
+	scope := fs.String("scope", "both", "contention scope: pcs, scs or both")
+	policyName := fs.String("policy", "rr", "scheduling policy: fcfs, sjf, priority, preemptive-priority, srtf, hrrn, rr or priority-rr")
+	quantum := fs.Int64("quantum", 10, "round-robin time quantum")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	if fs.NArg() != 2 {
+		return fmt.Errorf("%w: usage: threads [-scope both|pcs|scs] [-policy name] [-quantum N] processes.csv threads.csv", ErrInvalidArgs)
+	}
+	policy, err := PolicyByName(*policyName, *quantum)
+	if err != nil {
+		return err
+	}
+
+	pf, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("%v: error opening processes file", err)
+	}
+	defer func() { _ = pf.Close() }()
+	processes, err := loadProcesses(pf)
+	if err != nil {
+		return err
+	}
+	tf, err := os.Open(fs.Arg(1))
+	if err != nil {
+		return fmt.Errorf("%v: error opening threads file", err)
+	}
+	defer func() { _ = tf.Close() }()
+	threads, err := loadThreads(tf)
+	if err != nil {
+		return err
+	}
+	processes, threads = withThreads(processes, threads)
+
+	var reports []ThreadReport
+	if *scope == "pcs" || *scope == "both" {
+		reports = append(reports, processScope(processes, threads, policy))
+	}
+	if *scope == "scs" || *scope == "both" {
+		reports = append(reports, systemScope(processes, threads, policy))
+	}
+	if len(reports) == 0 {
+		return fmt.Errorf("%w: unknown scope %q", ErrInvalidArgs, *scope)
+	}
+	for _, r := range reports {
+		outputThreadReport(w, r)
+	}
+	return nil
+}
+
+func loadThreads(r io.Reader) ([]Thread, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = 3
+	cr.TrimLeadingSpace = true
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading CSV", err)
+	}
+
+	threads := make([]Thread, len(rows))
+	for i, row := range rows {
+		var v [3]int64
+		for j := range v {
+			if v[j], err = strconv.ParseInt(row[j], 10, 64); err != nil {
+				return nil, fmt.Errorf("%w: line %d", err, i+1)
+			}
+		}
+		threads[i] = Thread{ProcessID: v[0], ThreadID: v[1], BurstDuration: v[2]}
+	}
+	return threads, nil
+}
+
+// withThreads gives each process with no threads a single thread 1 doing all
+// its work, drops threads of unknown processes, and sets each process's burst
+// to the total of its threads'.
+func withThreads(processes []Process, threads []Thread) ([]Process, []Thread) {
+	total := make(map[int64]int64)
+	known := make(map[int64]bool)
+	for _, p := range processes {
+		known[p.ProcessID] = true
+	}
+	var kept []Thread
+	for _, t := range threads {
+		if known[t.ProcessID] {
+			kept = append(kept, t)
+			total[t.ProcessID] += t.BurstDuration
+		}
+	}
+
+	out := make([]Process, len(processes))
+	for i, p := range processes {
+		if _, ok := total[p.ProcessID]; ok {
+			p.BurstDuration = total[p.ProcessID]
+		} else {
+			kept = append(kept, Thread{ProcessID: p.ProcessID, ThreadID: 1, BurstDuration: p.BurstDuration})
+		}
+		out[i] = p
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		if kept[i].ProcessID != kept[j].ProcessID {
+			return kept[i].ProcessID < kept[j].ProcessID
+		}
+		return kept[i].ThreadID < kept[j].ThreadID
+	})
+	return out, kept
+}
+
+// processScope schedules processes with policy and runs each process's
+// threads in turn inside the time it is given.
+func processScope(processes []Process, threads []Thread, policy Policy) ThreadReport {
+	r := simulate("", processes, policy)
+
+	queues := make(map[int64][]Thread)
+	for _, t := range threads {
+		if t.BurstDuration > 0 {
+			queues[t.ProcessID] = append(queues[t.ProcessID], t)
+		}
+	}
+	var gantt []ThreadSlice
+	for _, s := range r.Gantt {
+		for now := s.Start; now < s.Stop; {
+			q := queues[s.PID]
+			if len(q) == 0 {
+				// The process was given more time than its threads need.
+				break
+			}
+			run := q[0].BurstDuration
+			if now+run > s.Stop {
+				run = s.Stop - now
+			}
+			gantt = appendThreadSlice(gantt, ThreadSlice{PID: s.PID, TID: q[0].ThreadID, Start: now, Stop: now + run})
+			q[0].BurstDuration -= run
+			if q[0].BurstDuration == 0 {
+				queues[s.PID] = q[1:]
+			}
+			now += run
+		}
+	}
+
+	return threadReport(fmt.Sprintf("Process-contention scope (%s)", policyTitle(policy)), processes, threads, gantt)
+}
+
+// systemScope schedules every thread with policy as if it were a process.
+func systemScope(processes []Process, threads []Thread, policy Policy) ThreadReport {
+	parent := make(map[int64]Process)
+	for _, p := range processes {
+		parent[p.ProcessID] = p
+	}
+	entities := make([]Process, len(threads))
+	for i, t := range threads {
+		p := parent[t.ProcessID]
+		p.ProcessID = int64(i)
+		p.BurstDuration = t.BurstDuration
+		entities[i] = p
+	}
+
+	var gantt []ThreadSlice
+	for _, s := range simulate("", entities, policy).Gantt {
+		t := threads[s.PID]
+		gantt = append(gantt, ThreadSlice{PID: t.ProcessID, TID: t.ThreadID, Start: s.Start, Stop: s.Stop})
+	}
+
+	return threadReport(fmt.Sprintf("System-contention scope (%s)", policyTitle(policy)), processes, threads, gantt)
+}
+
+// appendThreadSlice appends s, extending the last slice when the same thread continues.
+func appendThreadSlice(gantt []ThreadSlice, s ThreadSlice) []ThreadSlice {
+	if n := len(gantt); n > 0 && gantt[n-1].PID == s.PID && gantt[n-1].TID == s.TID && gantt[n-1].Stop == s.Start {
+		gantt[n-1].Stop = s.Stop
+		return gantt
+	}
+	return append(gantt, s)
+}
+
+// threadReport derives per-thread and per-process metrics from a thread Gantt chart.
+func threadReport(title string, processes []Process, threads []Thread, gantt []ThreadSlice) ThreadReport {
+	type key struct{ pid, tid int64 }
+	exit := make(map[key]int64)
+	for _, s := range gantt {
+		if s.Stop > exit[key{s.PID, s.TID}] {
+			exit[key{s.PID, s.TID}] = s.Stop
+		}
+	}
+	arrival := make(map[int64]int64)
+	for _, p := range processes {
+		arrival[p.ProcessID] = p.ArrivalTime
+	}
+
+	r := ThreadReport{Title: title, Gantt: gantt}
+	processExit := make(map[int64]int64)
+	for _, t := range threads {
+		e, ok := exit[key{t.ProcessID, t.ThreadID}]
+		if !ok {
+			e = arrival[t.ProcessID]
+		}
+		turnaround := e - arrival[t.ProcessID]
+		r.Threads = append(r.Threads, ThreadRow{
+			ProcessID:  t.ProcessID,
+			ThreadID:   t.ThreadID,
+			Burst:      t.BurstDuration,
+			Arrival:    arrival[t.ProcessID],
+			Wait:       turnaround - t.BurstDuration,
+			Turnaround: turnaround,
+			Exit:       e,
+		})
+		if e > processExit[t.ProcessID] {
+			processExit[t.ProcessID] = e
+		}
+	}
+	for _, p := range processes {
+		e := processExit[p.ProcessID]
+		if e < p.ArrivalTime {
+			e = p.ArrivalTime
+		}
+		r.Processes = append(r.Processes, Row{
+			ProcessID:  p.ProcessID,
+			Priority:   p.Priority,
+			Burst:      p.BurstDuration,
+			Arrival:    p.ArrivalTime,
+			Deadline:   p.Deadline,
+			Wait:       e - p.ArrivalTime - p.BurstDuration,
+			Turnaround: e - p.ArrivalTime,
+			Exit:       e,
+		})
+	}
+
+	return r
+}
+
+func outputThreadReport(w io.Writer, r ThreadReport) {
+	outputTitle(w, r.Title)
+	_, _ = fmt.Fprintln(w, "Gantt schedule (pid.tid)")
+	var labels, times []string
+	for _, s := range r.Gantt {
+		labels = append(labels, fmt.Sprintf("%d.%d", s.PID, s.TID))
+		times = append(times, fmt.Sprint(s.Start))
+	}
+	if n := len(r.Gantt); n > 0 {
+		times = append(times, fmt.Sprint(r.Gantt[n-1].Stop))
+	}
+	_, _ = fmt.Fprintf(w, "|%s|\n%s\n\n", strings.Join(labels, "|"), strings.Join(times, "\t"))
+
+	_, _ = fmt.Fprintln(w, "Threads")
+	var wait, turnaround float64
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"PID", "TID", "Burst", "Arrival", "Wait", "Turnaround", "Exit"})
+	for _, t := range r.Threads {
+		table.Append([]string{
+			fmt.Sprint(t.ProcessID), fmt.Sprint(t.ThreadID), fmt.Sprint(t.Burst), fmt.Sprint(t.Arrival),
+			fmt.Sprint(t.Wait), fmt.Sprint(t.Turnaround), fmt.Sprint(t.Exit),
+		})
+		wait += float64(t.Wait)
+		turnaround += float64(t.Turnaround)
+	}
+	if n := float64(len(r.Threads)); n > 0 {
+		table.SetFooter([]string{"", "", "", "",
+			fmt.Sprintf("Average\n%.2f", wait/n), fmt.Sprintf("Average\n%.2f", turnaround/n), ""})
+	}
+	table.Render()
+
+	_, _ = fmt.Fprintln(w, "Processes")
+	wait, turnaround = 0, 0
+	table = tablewriter.NewWriter(w)
+	table.SetHeader([]string{"PID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Exit"})
+	for _, p := range r.Processes {
+		table.Append([]string{
+			fmt.Sprint(p.ProcessID), fmt.Sprint(p.Priority), fmt.Sprint(p.Burst), fmt.Sprint(p.Arrival),
+			fmt.Sprint(p.Wait), fmt.Sprint(p.Turnaround), fmt.Sprint(p.Exit),
+		})
+		wait += float64(p.Wait)
+		turnaround += float64(p.Turnaround)
+	}
+	if n := float64(len(r.Processes)); n > 0 {
+		table.SetFooter([]string{"", "", "", "",
+			fmt.Sprintf("Average\n%.2f", wait/n), fmt.Sprintf("Average\n%.2f", turnaround/n), ""})
+	}
+	table.Render()
+}
+
+//endregion
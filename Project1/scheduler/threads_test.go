@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_threadScopes(t *testing.T) {
+	t.Parallel()
+	threads, err := loadThreads(strings.NewReader("1,1,2\n1,2,2\n1,3,2\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	processes, threads := withThreads([]Process{
+		{ProcessID: 1, ArrivalTime: 0},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2},
+	}, threads)
+	policy := RRPolicy{Quantum: 2}
+
+	tests := []struct {
+		name        string
+		scope       func([]Process, []Thread, Policy) ThreadReport
+		wantGantt   []ThreadSlice
+		wantProcess []int64 // exit times
+	}{
+		{
+			name:  "process contention",
+			scope: processScope,
+			// Process 1 gets a quantum like process 2 and spends it on its current thread.
+			wantGantt: []ThreadSlice{
+				{PID: 1, TID: 1, Start: 0, Stop: 2}, {PID: 2, TID: 1, Start: 2, Stop: 4},
+				{PID: 1, TID: 2, Start: 4, Stop: 6}, {PID: 1, TID: 3, Start: 6, Stop: 8},
+			},
+			wantProcess: []int64{8, 4},
+		},
+		{
+			name:  "system contention",
+			scope: systemScope,
+			wantGantt: []ThreadSlice{
+				{PID: 1, TID: 1, Start: 0, Stop: 2}, {PID: 1, TID: 2, Start: 2, Stop: 4},
+				{PID: 1, TID: 3, Start: 4, Stop: 6}, {PID: 2, TID: 1, Start: 6, Stop: 8},
+			},
+			wantProcess: []int64{6, 8},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := tt.scope(processes, threads, policy)
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			var exits []int64
+			for _, p := range r.Processes {
+				exits = append(exits, p.Exit)
+			}
+			if !reflect.DeepEqual(exits, tt.wantProcess) {
+				t.Errorf("process exits = %v, want %v", exits, tt.wantProcess)
+			}
+		})
+	}
+}
+
+func Test_processScopeShortThreads(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: 1, BurstDuration: 4}}
+	threads := []Thread{{ProcessID: 1, ThreadID: 1, BurstDuration: 2}}
+	r := processScope(processes, threads, FCFSPolicy{})
+	want := []ThreadSlice{{PID: 1, TID: 1, Start: 0, Stop: 2}}
+	if !reflect.DeepEqual(r.Gantt, want) {
+		t.Errorf("Gantt = %v, want %v", r.Gantt, want)
+	}
+}
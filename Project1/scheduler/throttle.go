@@ -0,0 +1,95 @@
+package scheduler
+
+import "fmt"
+
+//region Background throttling
+
+// ThrottlePolicy runs tasks under Inner but lets background (batch) tasks
+// use at most Limit percent of the CPU over any Window ticks. Once they
+// reach the limit they are held back, even if that leaves the CPU idle,
+// until enough of their ticks have left the window.
+type ThrottlePolicy struct {
+	Inner  Policy
+	Limit  int64
+	Window int64
+	// history is when a background task ran within the window.
+	history []int64
+}
+
+func isBackground(t *Task) bool {
+	return !t.Interactive && !t.System && t.RealTime == ""
+}
+
+func (p *ThrottlePolicy) Pick(now int64, running *Task, ready []*Task) *Task {
+	for len(p.history) > 0 && p.history[0] <= now-p.Window {
+		p.history = p.history[1:]
+	}
+	if int64(len(p.history))*100 >= p.Limit*p.Window {
+		var foreground []*Task
+		for _, t := range ready {
+			if !isBackground(t) {
+				foreground = append(foreground, t)
+			}
+		}
+		ready = foreground
+		if running != nil && isBackground(running) {
+			running = nil
+		}
+	}
+	if running == nil && len(ready) == 0 {
+		return nil
+	}
+
+	pick := p.Inner.Pick(now, running, ready)
+	if pick != nil && isBackground(pick) {
+		p.history = append(p.history, now)
+	}
+	return pick
+}
+
+// annotate adds each task's class and response time, and compares the
+// foreground's response time and the background's turnaround with an
+// unthrottled run.
+func (p *ThrottlePolicy) annotate(r *Report, tasks []*Task) {
+	class := Column{Header: "Class"}
+	processes := make([]Process, len(tasks))
+	for i, t := range tasks {
+		processes[i] = t.Process
+		if isBackground(t) {
+			class.Values = append(class.Values, "background")
+		} else {
+			class.Values = append(class.Values, "foreground")
+		}
+	}
+	r.Columns = append(r.Columns, class, responseColumn(tasks))
+
+	fg, bg := throttleMetrics(tasks)
+	baseFG, baseBG := throttleMetrics((&engine{policy: p.Inner}).run(processes))
+	r.Notes = append(r.Notes,
+		fmt.Sprintf("Foreground: average response %.2f (%.2f unthrottled)", fg, baseFG),
+		fmt.Sprintf("Background: average turnaround %.2f (%.2f unthrottled)", bg, baseBG))
+}
+
+// throttleMetrics is the foreground tasks' average response time and the
+// background tasks' average turnaround.
+func throttleMetrics(tasks []*Task) (foreground, background float64) {
+	var nf, nb int
+	for _, t := range tasks {
+		if isBackground(t) {
+			nb++
+			background += float64(t.Exit - t.ArrivalTime)
+		} else {
+			nf++
+			foreground += float64(t.FirstRun - t.ArrivalTime)
+		}
+	}
+	if nf > 0 {
+		foreground /= float64(nf)
+	}
+	if nb > 0 {
+		background /= float64(nb)
+	}
+	return foreground, background
+}
+
+//endregion
@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_ThrottlePolicy(t *testing.T) {
+	t.Parallel()
+	processes, err := loadProcesses(strings.NewReader("1,6,0,0,0,batch\n2,2,1,0,0,interactive\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		limit     int64
+		wantGantt []TimeSlice
+		wantNotes []string
+	}{
+		{
+			name:  "half",
+			limit: 50,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 1, Start: 4, Stop: 6},
+				{PID: 1, Start: 8, Stop: 10},
+			},
+			wantNotes: []string{
+				"Foreground: average response 1.00 (5.00 unthrottled)",
+				"Background: average turnaround 10.00 (6.00 unthrottled)",
+			},
+		},
+		{
+			name:  "unlimited",
+			limit: 100,
+			wantGantt: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 6}, {PID: 2, Start: 6, Stop: 8},
+			},
+			wantNotes: []string{
+				"Foreground: average response 5.00 (5.00 unthrottled)",
+				"Background: average turnaround 6.00 (6.00 unthrottled)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := simulate(tt.name, processes, &ThrottlePolicy{Inner: RRPolicy{Quantum: 10}, Limit: tt.limit, Window: 4})
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			if !reflect.DeepEqual(r.Notes, tt.wantNotes) {
+				t.Errorf("notes = %q, want %q", r.Notes, tt.wantNotes)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+//region Timeline CSV
+
+// writeTimelineCSV writes every report's Gantt chart to the file at path, one row per slice.
+func writeTimelineCSV(path string, reports []Report) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("%w: creating CSV", err)
+	}
+	if err := outputTimelineCSV(f, reports); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("%w: closing CSV", err)
+	}
+	return nil
+}
+
+// outputTimelineCSV writes algorithm,pid,cpu,start,stop,kind rows: a "run"
+// row per slice, a "switch" row, with no pid, for every context switch, and
+// an "idle" row, also with no pid, for every gap in which a CPU ran nothing.
+func outputTimelineCSV(w io.Writer, reports []Report) error {
+	out := csv.NewWriter(w)
+	_ = out.Write([]string{"algorithm", "pid", "cpu", "start", "stop", "kind"})
+	for _, r := range reports {
+		slices := append([]TimeSlice(nil), r.Gantt...)
+		sort.SliceStable(slices, func(i, j int) bool {
+			if slices[i].CPU != slices[j].CPU {
+				return slices[i].CPU < slices[j].CPU
+			}
+			return slices[i].Start < slices[j].Start
+		})
+		free := make(map[int]int64) // when each CPU last became idle
+		for _, s := range slices {
+			cpu := fmt.Sprint(s.CPU)
+			if s.Start > free[s.CPU] {
+				_ = out.Write([]string{r.Title, "", cpu, fmt.Sprint(free[s.CPU]), fmt.Sprint(s.Start), "idle"})
+			}
+			pid, kind := fmt.Sprint(s.PID), "run"
+			if s.Overhead {
+				pid, kind = "", "switch"
+			}
+			_ = out.Write([]string{r.Title, pid, cpu, fmt.Sprint(s.Start), fmt.Sprint(s.Stop), kind})
+			free[s.CPU] = s.Stop
+		}
+	}
+	out.Flush()
+	if err := out.Error(); err != nil {
+		return fmt.Errorf("%w: writing CSV", err)
+	}
+	return nil
+}
+
+//endregion
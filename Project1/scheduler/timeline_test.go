@@ -0,0 +1,27 @@
+package scheduler
+
+import (
+	"bytes"
+	"testing"
+)
+
+func Test_outputTimelineCSV(t *testing.T) {
+	t.Parallel()
+	reports := []Report{{
+		Title: "FCFS",
+		Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 3}, {Start: 3, Stop: 4, Overhead: true}, {PID: 2, Start: 5, Stop: 7}},
+	}}
+	want := `algorithm,pid,cpu,start,stop,kind
+FCFS,1,0,0,3,run
+FCFS,,0,3,4,switch
+FCFS,,0,4,5,idle
+FCFS,2,0,5,7,run
+`
+	var w bytes.Buffer
+	if err := outputTimelineCSV(&w, reports); err != nil {
+		t.Fatal(err)
+	}
+	if w.String() != want {
+		t.Errorf("outputTimelineCSV() = %q, want %q", w.String(), want)
+	}
+}
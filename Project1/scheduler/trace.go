@@ -0,0 +1,342 @@
+package scheduler
+
+import (
+	"bufio"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//region Trace import
+
+// traceBurst is one CPU burst of a traced process and the I/O wait that ended it.
+// All times are in microseconds from the start of the trace.
+type traceBurst struct {
+	Start int64
+	CPU   int64
+	IO    int64
+}
+
+// blockingSyscalls are the syscalls whose duration in an strace log counts as I/O.
+var blockingSyscalls = map[string]bool{
+	"read": true, "write": true, "pread64": true, "pwrite64": true, "readv": true, "writev": true,
+	"recvfrom": true, "recvmsg": true, "sendto": true, "sendmsg": true, "accept": true, "accept4": true,
+	"connect": true, "poll": true, "ppoll": true, "select": true, "pselect6": true, "epoll_wait": true,
+	"epoll_pwait": true, "nanosleep": true, "clock_nanosleep": true, "futex": true, "wait4": true,
+	"waitid": true, "fsync": true, "fdatasync": true, "openat": true, "open": true,
+}
+
+var (
+	// 1234  12:00:00.123456 read(3, "..."..., 4096) = 10 <0.000123>
+	straceLine = regexp.MustCompile(`^(?:\[pid\s+)?(\d+)\]?\s+(\d+):(\d+):(\d+\.\d+)\s+(.*)$`)
+	// The call name and, when the call finished on this line, its duration.
+	straceCall     = regexp.MustCompile(`^(?:<\.\.\. )?(\w+)[ (]`)
+	straceDuration = regexp.MustCompile(`<(\d+\.\d+)>\s*$`)
+	// bash-123 [000] d..3 1234.567890: sched_switch: prev_comm=bash prev_pid=123 ... prev_state=S ==> next_comm=x next_pid=456 ...
+	ftraceLine = regexp.MustCompile(`\s(\d+\.\d+):\s+(sched_switch|sched_wakeup|sched_waking):\s+(.*)$`)
+	ftraceKV   = regexp.MustCompile(`(\w+)=(\S+)`)
+)
+
+// runImportTrace implements the import-trace subcommand:
+//
+//	import-trace [-tick-usec 1000] [-min-io-usec 100] trace.log
+//
+// It reads an `strace -f -tt -T` log or an ftrace/trace-cmd report with
+// sched_switch and sched_wakeup events, reconstructs each PID's alternating
+// CPU and I/O bursts, and writes them as a workload CSV. Each PID becomes a
+// process arriving when it first ran in the trace, with its bursts as a
+// burst cycle; the process ID to traced PID mapping is written to stderr.
+func runImportTrace(w io.Writer, args ...string) error {
+	fs := flag.NewFlagSet("import-trace", flag.ContinueOnError)
+	fs.SetOutput(w)
+	tick := fs.Int64("tick-usec", 1000, "microseconds per tick")
+	minIO := fs.Int64("min-io-usec", 100, "blocking shorter than this counts as CPU time")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	if fs.NArg() != 1 || *tick <= 0 {
+		return fmt.Errorf("%w: usage: import-trace [-tick-usec N] [-min-io-usec N] trace.log", ErrInvalidArgs)
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("%v: error opening trace", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	bursts, err := parseTrace(f, *minIO)
+	if err != nil {
+		return err
+	}
+
+	return outputTraceWorkload(w, os.Stderr, bursts, *tick)
+}
+
+// parseTrace detects the trace format and returns each PID's bursts.
+func parseTrace(r io.Reader, minIO int64) (map[int64][]traceBurst, error) {
+	var lines []string
+	ftrace := false
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+		if !ftrace && strings.Contains(sc.Text(), "sched_switch:") {
+			ftrace = true
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("%w: reading trace", err)
+	}
+
+	if ftrace {
+		return parseFtrace(lines, minIO), nil
+	}
+	return parseStrace(lines, minIO)
+}
+
+// traceBuilder accumulates bursts per PID from run and block intervals.
+type traceBuilder struct {
+	minIO  int64
+	bursts map[int64][]traceBurst
+	// open is the burst each PID is currently accumulating CPU into.
+	open map[int64]*traceBurst
+}
+
+func newTraceBuilder(minIO int64) *traceBuilder {
+	return &traceBuilder{
+		minIO:  minIO,
+		bursts: make(map[int64][]traceBurst),
+		open:   make(map[int64]*traceBurst),
+	}
+}
+
+func (b *traceBuilder) run(pid, start, d int64) {
+	if d <= 0 {
+		return
+	}
+	if b.open[pid] == nil {
+		b.open[pid] = &traceBurst{Start: start}
+	}
+	b.open[pid].CPU += d
+}
+
+func (b *traceBuilder) block(pid, start, d int64) {
+	if d < b.minIO {
+		b.run(pid, start, d)
+		return
+	}
+	burst := b.open[pid]
+	if burst == nil {
+		// Back-to-back waits extend the previous burst's I/O.
+		if bs := b.bursts[pid]; len(bs) > 0 {
+			bs[len(bs)-1].IO += d
+		}
+		return
+	}
+	burst.IO = d
+	b.bursts[pid] = append(b.bursts[pid], *burst)
+	b.open[pid] = nil
+}
+
+func (b *traceBuilder) finish() map[int64][]traceBurst {
+	for pid, burst := range b.open {
+		if burst != nil {
+			b.bursts[pid] = append(b.bursts[pid], *burst)
+		}
+	}
+	return b.bursts
+}
+
+// parseStrace treats the time between syscalls as CPU and blocking syscalls as I/O.
+func parseStrace(lines []string, minIO int64) (map[int64][]traceBurst, error) {
+	var (
+		b          = newTraceBuilder(minIO)
+		origin     = int64(-1)
+		last       = make(map[int64]int64) // end of each PID's previous syscall
+		unfinished = make(map[int64]int64) // start of each PID's unfinished syscall
+	)
+	for n, line := range lines {
+		m := straceLine.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		pid, _ := strconv.ParseInt(m[1], 10, 64)
+		h, _ := strconv.ParseInt(m[2], 10, 64)
+		mins, _ := strconv.ParseInt(m[3], 10, 64)
+		sec, err := strconv.ParseFloat(m[4], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: trace line %d", err, n+1)
+		}
+		at := (h*3600+mins*60)*1e6 + int64(math.Round(sec*1e6))
+		if origin < 0 {
+			origin = at
+		}
+		at -= origin
+		rest := m[5]
+
+		call := straceCall.FindStringSubmatch(rest)
+		if call == nil {
+			continue
+		}
+		if strings.HasSuffix(rest, "<unfinished ...>") {
+			unfinished[pid] = at
+			b.run(pid, startOf(last, pid, at), at-startOf(last, pid, at))
+			last[pid] = at
+			continue
+		}
+
+		start := at
+		if s, ok := unfinished[pid]; ok && strings.HasPrefix(rest, "<...") {
+			start = s
+			delete(unfinished, pid)
+		} else {
+			b.run(pid, startOf(last, pid, at), at-startOf(last, pid, at))
+		}
+
+		var d int64
+		if dm := straceDuration.FindStringSubmatch(rest); dm != nil {
+			secs, _ := strconv.ParseFloat(dm[1], 64)
+			d = int64(math.Round(secs * 1e6))
+		}
+		end := start + d
+		if end < at {
+			end = at
+		}
+		if blockingSyscalls[call[1]] {
+			b.block(pid, start, end-start)
+		} else {
+			b.run(pid, start, end-start)
+		}
+		last[pid] = end
+	}
+
+	return b.finish(), nil
+}
+
+func startOf(last map[int64]int64, pid, at int64) int64 {
+	if l, ok := last[pid]; ok {
+		return l
+	}
+	return at
+}
+
+// parseFtrace uses sched_switch to find when each PID runs and sleeps, and
+// sched_wakeup to find when its sleep (I/O) ends.
+func parseFtrace(lines []string, minIO int64) map[int64][]traceBurst {
+	var (
+		b       = newTraceBuilder(minIO)
+		origin  = int64(-1)
+		running = make(map[int64]int64) // switch-in time of each running PID
+		asleep  = make(map[int64]int64) // switch-out time of each blocked PID
+	)
+	for _, line := range lines {
+		m := ftraceLine.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		secs, _ := strconv.ParseFloat(m[1], 64)
+		at := int64(math.Round(secs * 1e6))
+		if origin < 0 {
+			origin = at
+		}
+		at -= origin
+		kv := make(map[string]string)
+		for _, f := range ftraceKV.FindAllStringSubmatch(m[3], -1) {
+			kv[f[1]] = f[2]
+		}
+
+		wake := func(pid int64) {
+			if s, ok := asleep[pid]; ok {
+				b.block(pid, s, at-s)
+				delete(asleep, pid)
+			}
+		}
+		switch m[2] {
+		case "sched_wakeup", "sched_waking":
+			pid, _ := strconv.ParseInt(kv["pid"], 10, 64)
+			wake(pid)
+		case "sched_switch":
+			prev, _ := strconv.ParseInt(kv["prev_pid"], 10, 64)
+			next, _ := strconv.ParseInt(kv["next_pid"], 10, 64)
+			if s, ok := running[prev]; ok && prev != 0 {
+				b.run(prev, s, at-s)
+				delete(running, prev)
+				// Anything but R(+) means the task blocked rather than being preempted.
+				if state := kv["prev_state"]; state != "" && !strings.HasPrefix(state, "R") {
+					asleep[prev] = at
+				}
+			}
+			if next != 0 {
+				wake(next)
+				running[next] = at
+			}
+		}
+	}
+
+	return b.finish()
+}
+
+// outputTraceWorkload writes one process per traced PID, in order of its
+// first burst, converting microseconds to ticks. A PID that blocked gets a
+// burst cycle like "cpu:3,io:5,cpu:2" in its burst column; the I/O after its
+// last CPU burst is dropped, since a cycle ends with CPU.
+func outputTraceWorkload(w, mapping io.Writer, bursts map[int64][]traceBurst, tick int64) error {
+	ticks := func(us int64) int64 {
+		if t := (us + tick/2) / tick; t > 0 {
+			return t
+		}
+		return 1
+	}
+	var pids []int64
+	for pid, bs := range bursts {
+		if len(bs) > 0 {
+			pids = append(pids, pid)
+		}
+	}
+	if len(pids) == 0 {
+		return fmt.Errorf("%w: no CPU bursts found in trace", ErrInvalidArgs)
+	}
+	sort.Slice(pids, func(i, j int) bool {
+		a, b := bursts[pids[i]][0].Start, bursts[pids[j]][0].Start
+		if a != b {
+			return a < b
+		}
+		return pids[i] < pids[j]
+	})
+
+	out := csv.NewWriter(w)
+	for i, pid := range pids {
+		bs := bursts[pid]
+		var cycle []int64
+		for n, b := range bs {
+			cycle = append(cycle, ticks(b.CPU))
+			if n < len(bs)-1 {
+				cycle = append(cycle, ticks(b.IO))
+			}
+		}
+		burst := fmt.Sprint(cycle[0])
+		if len(cycle) > 1 {
+			burst = formatBurstCycle(cycle)
+		}
+		_, _ = fmt.Fprintf(mapping, "%d\tpid %d\n", i+1, pid)
+		if err := out.Write([]string{
+			fmt.Sprint(i + 1),
+			burst,
+			fmt.Sprint(bs[0].Start / tick),
+		}); err != nil {
+			return fmt.Errorf("%w: writing CSV", err)
+		}
+	}
+	out.Flush()
+
+	return out.Error()
+}
+
+//endregion
@@ -0,0 +1,99 @@
+package scheduler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_parseTrace(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		trace string
+		want  map[int64][]traceBurst
+	}{
+		{
+			name: "strace",
+			trace: `100 10:00:00.000000 execve("./app", ["./app"], 0x7ffd /* 1 var */) = 0 <0.000200>
+100 10:00:00.005000 read(3, "abc", 4096) = 3 <0.010000>
+100 10:00:00.017000 getpid() = 100 <0.000010>
+100 10:00:00.020000 nanosleep({tv_sec=0, tv_nsec=5000000},  <unfinished ...>
+200 10:00:00.021000 write(1, "x", 1) = 1 <0.000050>
+100 10:00:00.025000 <... nanosleep resumed>NULL) = 0 <0.005000>
+100 10:00:00.029000 +++ exited with 0 +++`,
+			want: map[int64][]traceBurst{
+				100: {
+					{Start: 0, CPU: 5000, IO: 10000},
+					{Start: 15000, CPU: 5000, IO: 5000},
+				},
+				200: {
+					{Start: 21000, CPU: 50},
+				},
+			},
+		},
+		{
+			name: "ftrace",
+			trace: `  <idle>-0     [000] d..3  1000.000000: sched_switch: prev_comm=swapper prev_pid=0 prev_prio=120 prev_state=R ==> next_comm=app next_pid=100 next_prio=120
+     app-100   [000] d..3  1000.004000: sched_switch: prev_comm=app prev_pid=100 prev_prio=120 prev_state=R+ ==> next_comm=db next_pid=200 next_prio=120
+      db-200   [000] d..3  1000.006000: sched_switch: prev_comm=db prev_pid=200 prev_prio=120 prev_state=S ==> next_comm=app next_pid=100 next_prio=120
+     app-100   [000] d..3  1000.009000: sched_switch: prev_comm=app prev_pid=100 prev_prio=120 prev_state=D ==> next_comm=swapper next_pid=0 next_prio=120
+  <idle>-0     [000] dNh3  1000.015000: sched_wakeup: comm=app pid=100 prio=120 target_cpu=000
+  <idle>-0     [000] d..3  1000.015000: sched_switch: prev_comm=swapper prev_pid=0 prev_prio=120 prev_state=R ==> next_comm=app next_pid=100 next_prio=120
+     app-100   [000] d..3  1000.017000: sched_switch: prev_comm=app prev_pid=100 prev_prio=120 prev_state=S ==> next_comm=swapper next_pid=0 next_prio=120`,
+			want: map[int64][]traceBurst{
+				100: {
+					{Start: 0, CPU: 7000, IO: 6000},
+					{Start: 15000, CPU: 2000},
+				},
+				200: {
+					{Start: 4000, CPU: 2000},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseTrace(strings.NewReader(tt.trace), 100)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseTrace() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_outputTraceWorkload(t *testing.T) {
+	t.Parallel()
+	bursts := map[int64][]traceBurst{
+		200: {{Start: 21000, CPU: 50}},
+		100: {
+			{Start: 0, CPU: 5000, IO: 10000},
+			{Start: 15000, CPU: 5000, IO: 5000},
+			{Start: 25000, CPU: 4000, IO: 3000},
+		},
+	}
+	var w, mapping strings.Builder
+	if err := outputTraceWorkload(&w, &mapping, bursts, 1000); err != nil {
+		t.Fatal(err)
+	}
+	want := "1,\"cpu:5,io:10,cpu:5,io:5,cpu:4\",0\n2,1,21\n"
+	if w.String() != want {
+		t.Errorf("workload = %q, want %q", w.String(), want)
+	}
+	if want := "1\tpid 100\n2\tpid 200\n"; mapping.String() != want {
+		t.Errorf("mapping = %q, want %q", mapping.String(), want)
+	}
+
+	processes, err := loadProcesses(strings.NewReader(w.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := processes[0].Bursts, []int64{5, 10, 5, 5, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("process 1 bursts = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,436 @@
+package scheduler
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+//region Parameter tuning
+
+const (
+	searchGrid   = "grid"
+	searchRandom = "random"
+	searchBayes  = "bayes"
+)
+
+type (
+	// paramRange is the values a tuned parameter may take: the integers
+	// from Lo to Hi.
+	paramRange struct {
+		Name   string
+		Lo, Hi int64
+	}
+
+	// tunable is an algorithm tune can search the parameters of. Params
+	// are named after the main flags that set them, with their default
+	// ranges, so the best values can be saved as a profile.
+	tunable struct {
+		Params []paramRange
+		Run    func(processes []Process, v map[string]int64) Report
+		// Flags converts values to the main flags that reproduce them,
+		// when the params aren't flags themselves.
+		Flags func(v map[string]int64) map[string]string
+	}
+
+	// tuneTrial is one configuration tried and the metric it scored.
+	tuneTrial struct {
+		Values map[string]int64
+		Value  float64
+		Title  string
+	}
+)
+
+// tunables are the algorithms tune knows, by name.
+var tunables = map[string]tunable{
+	"round-robin": {
+		Params: []paramRange{{Name: "quantum", Lo: 1, Hi: 20}},
+		Run: func(processes []Process, v map[string]int64) Report {
+			return simulate("Round-robin", processes, RRPolicy{Quantum: v["quantum"]})
+		},
+	},
+	"throttle": {
+		Params: []paramRange{{Name: "quantum", Lo: 1, Hi: 20}, {Name: "throttle", Lo: 5, Hi: 95}, {Name: "throttle-window", Lo: 10, Hi: 200}},
+		Run: func(processes []Process, v map[string]int64) Report {
+			return simulate(fmt.Sprintf("Round-robin, quantum %d, batch throttled to %d%%", v["quantum"], v["throttle"]),
+				processes, &ThrottlePolicy{Inner: RRPolicy{Quantum: v["quantum"]}, Limit: v["throttle"], Window: v["throttle-window"]})
+		},
+	},
+	"aging": {
+		// Every interval ticks waiting improve a priority by one level.
+		Params: []paramRange{{Name: "interval", Lo: 1, Hi: 50}},
+		Run: func(processes []Process, v map[string]int64) Report {
+			increment := 1 / float64(v["interval"])
+			return Aging(fmt.Sprintf("Priority with aging %g/tick", increment), processes, increment, 0)
+		},
+		Flags: func(v map[string]int64) map[string]string {
+			return map[string]string{"aging": fmt.Sprint(1 / float64(v["interval"]))}
+		},
+	},
+	"mlfq": {
+		Params: []paramRange{{Name: "levels", Lo: 2, Hi: 5}, {Name: "quantum", Lo: 1, Hi: 20}, {Name: "boost", Lo: 10, Hi: 500}},
+		Run: func(processes []Process, v map[string]int64) Report {
+			p := &MLFQPolicy{Config: tunedMLFQ(v)}
+			return simulate(p.title(), processes, p)
+		},
+		Flags: func(v map[string]int64) map[string]string {
+			c := tunedMLFQ(v)
+			quanta := make([]string, len(c.Quanta))
+			for i, q := range c.Quanta {
+				quanta[i] = fmt.Sprint(q)
+			}
+			return map[string]string{"mlfq": strings.Join(quanta, ","), "mlfq-boost": fmt.Sprint(c.Boost)}
+		},
+	},
+}
+
+// tunedMLFQ is the MLFQ tune tries for v: levels queues whose quanta
+// double from quantum down to a first-come, first-served bottom queue.
+func tunedMLFQ(v map[string]int64) MLFQConfig {
+	c := MLFQConfig{Boost: v["boost"]}
+	for l, q := int64(0), v["quantum"]; l < v["levels"]; l, q = l+1, q*2 {
+		if l == v["levels"]-1 {
+			q = 0
+		}
+		c.Quanta = append(c.Quanta, q)
+	}
+	return c
+}
+
+// parseParamRanges parses a comma separated list of ranges like
+// "quantum=1:50,throttle=20:80" over the defaults of t.
+func parseParamRanges(spec string, t tunable) ([]paramRange, error) {
+	ranges := append([]paramRange(nil), t.Params...)
+	for _, f := range strings.Split(spec, ",") {
+		if f = strings.TrimSpace(f); f == "" {
+			continue
+		}
+		kv := strings.SplitN(f, "=", 2)
+		bounds := strings.SplitN(kv[len(kv)-1], ":", 2)
+		if len(kv) != 2 || len(bounds) != 2 {
+			return nil, fmt.Errorf("%w: want <param>=<lo>:<hi>, got %q", ErrInvalidArgs, f)
+		}
+		lo, errLo := strconv.ParseInt(strings.TrimSpace(bounds[0]), 10, 64)
+		hi, errHi := strconv.ParseInt(strings.TrimSpace(bounds[1]), 10, 64)
+		if errLo != nil || errHi != nil || lo < 1 || hi < lo {
+			return nil, fmt.Errorf("%w: bad range %q", ErrInvalidArgs, kv[1])
+		}
+		i := 0
+		for i < len(ranges) && ranges[i].Name != strings.TrimSpace(kv[0]) {
+			i++
+		}
+		if i == len(ranges) {
+			return nil, fmt.Errorf("%w: unknown parameter %q", ErrInvalidArgs, kv[0])
+		}
+		ranges[i].Lo, ranges[i].Hi = lo, hi
+	}
+	return ranges, nil
+}
+
+// tuner runs trials of one algorithm, remembering every configuration it
+// has scored so searches that revisit one pay for it only once.
+type tuner struct {
+	processes []Process
+	algorithm tunable
+	ranges    []paramRange
+	metric    string
+	trials    []tuneTrial
+	seen      map[string]int
+}
+
+// try scores the configuration at point, one value per range.
+func (t *tuner) try(point []int64) tuneTrial {
+	key := fmt.Sprint(point)
+	if i, ok := t.seen[key]; ok {
+		return t.trials[i]
+	}
+	v := make(map[string]int64, len(point))
+	for i, r := range t.ranges {
+		v[r.Name] = point[i]
+	}
+	r := t.algorithm.Run(t.processes, v)
+	trial := tuneTrial{Values: v, Value: reportMetrics[t.metric](r), Title: r.Title}
+	t.seen[key] = len(t.trials)
+	t.trials = append(t.trials, trial)
+	return trial
+}
+
+// score is what the searches minimize: the metric, negated if higher is better.
+func (t *tuner) score(trial tuneTrial) float64 {
+	if maximizedMetrics[t.metric] {
+		return -trial.Value
+	}
+	return trial.Value
+}
+
+// grid tries evenly spaced values of every parameter, as many per
+// parameter as keep the grid within budget trials.
+func (t *tuner) grid(budget int) {
+	n := int64(math.Floor(math.Pow(float64(budget), 1/float64(len(t.ranges)))))
+	if n < 1 {
+		n = 1
+	}
+	axes := make([][]int64, len(t.ranges))
+	for i, r := range t.ranges {
+		k := n
+		if k > r.Hi-r.Lo+1 {
+			k = r.Hi - r.Lo + 1
+		}
+		if k == 1 {
+			axes[i] = []int64{(r.Lo + r.Hi) / 2}
+			continue
+		}
+		for j := int64(0); j < k; j++ {
+			axes[i] = append(axes[i], r.Lo+int64(math.Round(float64(j*(r.Hi-r.Lo))/float64(k-1))))
+		}
+	}
+
+	point := make([]int64, len(axes))
+	var walk func(d int)
+	walk = func(d int) {
+		if d == len(axes) {
+			t.try(append([]int64(nil), point...))
+			return
+		}
+		for _, v := range axes[d] {
+			point[d] = v
+			walk(d + 1)
+		}
+	}
+	walk(0)
+}
+
+// random tries budget configurations drawn uniformly from the ranges.
+func (t *tuner) random(budget int, rng *rand.Rand) {
+	for i := 0; i < budget; i++ {
+		t.try(t.uniform(rng))
+	}
+}
+
+func (t *tuner) uniform(rng *rand.Rand) []int64 {
+	point := make([]int64, len(t.ranges))
+	for i, r := range t.ranges {
+		point[i] = r.Lo + rng.Int63n(r.Hi-r.Lo+1)
+	}
+	return point
+}
+
+// bayes is a tree-structured Parzen estimator: after a quarter of budget
+// random trials, it splits the trials so far into the best quarter and
+// the rest, models each as a sum of Gaussians around its points, and next
+// tries whichever of a few candidates drawn near the good points is most
+// likely under the good model relative to the bad one.
+func (t *tuner) bayes(budget int, rng *rand.Rand) {
+	const candidates = 24
+	startup := budget / 4
+	if startup < 5 {
+		startup = 5
+	}
+	for i := 0; i < budget; i++ {
+		if i < startup || len(t.trials) < 2 {
+			t.try(t.uniform(rng))
+			continue
+		}
+
+		sorted := append([]tuneTrial(nil), t.trials...)
+		sort.SliceStable(sorted, func(a, b int) bool { return t.score(sorted[a]) < t.score(sorted[b]) })
+		split := (len(sorted) + 3) / 4
+		good, bad := t.points(sorted[:split]), t.points(sorted[split:])
+
+		var best []float64
+		bestRatio := math.Inf(-1)
+		for c := 0; c < candidates; c++ {
+			x := append([]float64(nil), good[rng.Intn(len(good))]...)
+			for d := range x {
+				x[d] = math.Min(1, math.Max(0, x[d]+rng.NormFloat64()*parzenWidth))
+			}
+			if ratio := math.Log(parzen(x, good)) - math.Log(parzen(x, bad)); ratio > bestRatio {
+				best, bestRatio = x, ratio
+			}
+		}
+		point := make([]int64, len(t.ranges))
+		for d, r := range t.ranges {
+			point[d] = r.Lo + int64(math.Round(best[d]*float64(r.Hi-r.Lo)))
+		}
+		if _, ok := t.seen[fmt.Sprint(point)]; ok {
+			point = t.uniform(rng)
+		}
+		t.try(point)
+	}
+}
+
+// parzenWidth is the standard deviation of the Gaussians bayes models the
+// trials with, on parameters scaled to [0, 1].
+const parzenWidth = 0.15
+
+// points scales trials' values to [0, 1] in each range.
+func (t *tuner) points(trials []tuneTrial) [][]float64 {
+	points := make([][]float64, len(trials))
+	for i, trial := range trials {
+		points[i] = make([]float64, len(t.ranges))
+		for d, r := range t.ranges {
+			if r.Hi > r.Lo {
+				points[i][d] = float64(trial.Values[r.Name]-r.Lo) / float64(r.Hi-r.Lo)
+			}
+		}
+	}
+	return points
+}
+
+// parzen is the density at x of Gaussians around points, plus a little
+// of the uniform distribution so no candidate is impossible.
+func parzen(x []float64, points [][]float64) float64 {
+	density := 0.01
+	for _, p := range points {
+		var d2 float64
+		for i := range x {
+			d2 += (x[i] - p[i]) * (x[i] - p[i])
+		}
+		density += math.Exp(-d2/(2*parzenWidth*parzenWidth)) / float64(len(points))
+	}
+	return density
+}
+
+// best returns the trial with the lowest score, the earliest on ties.
+func (t *tuner) best() tuneTrial {
+	best := t.trials[0]
+	for _, trial := range t.trials[1:] {
+		if t.score(trial) < t.score(best) {
+			best = trial
+		}
+	}
+	return best
+}
+
+// profile is the best trial as a profile running workload with its parameters.
+func (t *tuner) profile(workload string) Profile {
+	best := t.best()
+	if t.algorithm.Flags != nil {
+		return Profile{Workload: workload, Algorithms: []string{slugify(best.Title)}, Flags: t.algorithm.Flags(best.Values)}
+	}
+	flags := make(map[string]string, len(best.Values))
+	for name, v := range best.Values {
+		flags[name] = fmt.Sprint(v)
+	}
+	return Profile{Workload: workload, Algorithms: []string{slugify(best.Title)}, Flags: flags}
+}
+
+// runTune implements the tune subcommand:
+//
+//	tune [-algorithm round-robin] [-metric avg_wait] [-search grid|random|bayes]
+//	     [-trials 50] [-params quantum=1:50] [-seed 0] [-save name] processes.csv
+//
+// It searches the algorithm's parameters for the configuration doing best
+// on metric, and prints it as a config profile, adding it to -config
+// under the name given by -save.
+func runTune(w io.Writer, args ...string) error {
+	fs := flag.NewFlagSet("tune", flag.ContinueOnError)
+	fs.SetOutput(w)
+	algorithm := fs.String("algorithm", "round-robin", "algorithm to tune: round-robin, throttle, mlfq or aging")
+	metric := fs.String("metric", "avg_wait", "metric to optimize, as in -assert; throughput and utilization are maximized")
+	search := fs.String("search", searchBayes, "search strategy: grid, random or bayes")
+	budget := fs.Int("trials", 50, "configurations to try")
+	params := fs.String("params", "", "comma separated parameter ranges like \"quantum=1:50\" replacing the defaults")
+	seed := fs.Int64("seed", 0, "random seed for the random and bayes searches; 0 uses the current time")
+	configPath := fs.String("config", defaultConfigPath, "config file -save adds the profile to")
+	save := fs.String("save", "", "name to save the best configuration under in the config file")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	if fs.NArg() != 1 || *budget < 1 {
+		return fmt.Errorf("%w: usage: tune [-algorithm round-robin] [-metric avg_wait] [-search bayes] [-trials 50] processes.csv", ErrInvalidArgs)
+	}
+	t, ok := tunables[*algorithm]
+	if !ok {
+		return fmt.Errorf("%w: cannot tune %q (have round-robin, throttle, mlfq, aging)", ErrInvalidArgs, *algorithm)
+	}
+	if _, ok := reportMetrics[*metric]; !ok {
+		return fmt.Errorf("%w: unknown metric %q", ErrInvalidArgs, *metric)
+	}
+	ranges, err := parseParamRanges(*params, t)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("%v: error opening scheduling file", err)
+	}
+	defer func() { _ = f.Close() }()
+	workload, err := ReadWorkload(f)
+	if err != nil {
+		return err
+	}
+
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(*seed))
+	tn := &tuner{processes: workload.Processes, algorithm: t, ranges: ranges, metric: *metric, seen: make(map[string]int)}
+	switch *search {
+	case searchGrid:
+		tn.grid(*budget)
+	case searchRandom:
+		tn.random(*budget, rng)
+	case searchBayes:
+		tn.bayes(*budget, rng)
+	default:
+		return fmt.Errorf("%w: unknown search %q (have grid, random, bayes)", ErrInvalidArgs, *search)
+	}
+
+	outputTrials(w, tn, 5)
+	profile := tn.profile(fs.Arg(0))
+	if *save != "" {
+		config, err := loadConfig(*configPath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		if config.Profiles == nil {
+			config.Profiles = make(map[string]Profile)
+		}
+		config.Profiles[*save] = profile
+		if err := writeJSON(*configPath, config); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(w, "Saved as profile %q in %s\n", *save, *configPath)
+		return nil
+	}
+	return outputJSON(w, profile)
+}
+
+// outputTrials prints the top best-scoring of the tuner's distinct trials.
+func outputTrials(w io.Writer, t *tuner, top int) {
+	sorted := append([]tuneTrial(nil), t.trials...)
+	sort.SliceStable(sorted, func(a, b int) bool { return t.score(sorted[a]) < t.score(sorted[b]) })
+	if len(sorted) > top {
+		sorted = sorted[:top]
+	}
+
+	_, _ = fmt.Fprintf(w, "Best of %d configurations by %s\n", len(t.trials), t.metric)
+	header := []string{"Rank"}
+	for _, r := range t.ranges {
+		header = append(header, fmt.Sprintf("%s (%d-%d)", r.Name, r.Lo, r.Hi))
+	}
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(append(header, t.metric))
+	for i, trial := range sorted {
+		line := []string{fmt.Sprint(i + 1)}
+		for _, r := range t.ranges {
+			line = append(line, fmt.Sprint(trial.Values[r.Name]))
+		}
+		table.Append(append(line, fmt.Sprintf("%.2f", trial.Value)))
+	}
+	table.Render()
+}
+
+//endregion
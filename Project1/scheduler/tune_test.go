@@ -0,0 +1,140 @@
+package scheduler
+
+import (
+	"errors"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var tuneProcesses = []Process{
+	{ProcessID: 1, BurstDuration: 30}, {ProcessID: 2, BurstDuration: 30},
+	{ProcessID: 3, ArrivalTime: 5, BurstDuration: 2}, {ProcessID: 4, ArrivalTime: 12, BurstDuration: 2},
+	{ProcessID: 5, ArrivalTime: 20, BurstDuration: 3},
+}
+
+func Test_parseParamRanges(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		spec    string
+		want    []paramRange
+		wantErr bool
+	}{
+		{name: "defaults", spec: "", want: tunables["throttle"].Params},
+		{
+			name: "override",
+			spec: "throttle=20:80, quantum=2:4",
+			want: []paramRange{{Name: "quantum", Lo: 2, Hi: 4}, {Name: "throttle", Lo: 20, Hi: 80}, {Name: "throttle-window", Lo: 10, Hi: 200}},
+		},
+		{name: "unknown", spec: "levels=1:3", wantErr: true},
+		{name: "reversed", spec: "quantum=5:1", wantErr: true},
+		{name: "zero", spec: "quantum=0:5", wantErr: true},
+		{name: "malformed", spec: "quantum=5", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseParamRanges(tt.spec, tunables["throttle"])
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseParamRanges() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidArgs) {
+				t.Errorf("parseParamRanges() error = %v, want ErrInvalidArgs", err)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseParamRanges() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_tuner(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		search func(tn *tuner)
+		// wantTrials is the number of distinct configurations tried, 0 for
+		// not checked.
+		wantTrials int
+	}{
+		{name: "grid", search: func(tn *tuner) { tn.grid(20) }, wantTrials: 20},
+		{name: "small grid", search: func(tn *tuner) { tn.grid(5) }, wantTrials: 5},
+		{name: "random", search: func(tn *tuner) { tn.random(60, rand.New(rand.NewSource(1))) }},
+		{name: "bayes", search: func(tn *tuner) { tn.bayes(20, rand.New(rand.NewSource(1))) }},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tn := &tuner{
+				processes: tuneProcesses,
+				algorithm: tunables["round-robin"],
+				ranges:    tunables["round-robin"].Params,
+				metric:    "avg_wait",
+				seen:      make(map[string]int),
+			}
+			tt.search(tn)
+			if tt.wantTrials > 0 && len(tn.trials) != tt.wantTrials {
+				t.Errorf("tried %d configurations, want %d", len(tn.trials), tt.wantTrials)
+			}
+			if tt.name == "small grid" {
+				return
+			}
+			want := Profile{Workload: "w.csv", Algorithms: []string{"round-robin"}, Flags: map[string]string{"quantum": "2"}}
+			if got := tn.profile("w.csv"); !reflect.DeepEqual(got, want) {
+				t.Errorf("profile() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func Test_tuner_direction(t *testing.T) {
+	t.Parallel()
+	tn := &tuner{
+		processes: tuneProcesses,
+		algorithm: tunables["round-robin"],
+		ranges:    []paramRange{{Name: "quantum", Lo: 1, Hi: 30}},
+		metric:    "switches",
+		seen:      make(map[string]int),
+	}
+	tn.grid(30)
+	if best := tn.best(); best.Values["quantum"] != 30 {
+		t.Errorf("fewest switches at quantum %d, want 30", best.Values["quantum"])
+	}
+
+	tn = &tuner{processes: tn.processes, algorithm: tn.algorithm, ranges: tn.ranges, metric: "throughput", seen: make(map[string]int)}
+	tn.grid(30)
+	if got := tn.score(tn.best()); got >= 0 {
+		t.Errorf("score of the best throughput = %v, want it negated", got)
+	}
+}
+
+func Test_runTune_save(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	workload := filepath.Join(dir, "w.csv")
+	if err := os.WriteFile(workload, []byte("1,30,0\n2,30,0\n3,2,5\n4,2,12\n5,3,20\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config := filepath.Join(dir, "scheduler.json")
+	var b strings.Builder
+	if err := runTune(&b, "-search", "grid", "-trials", "20", "-config", config, "-save", "fast", workload); err != nil {
+		t.Fatal(err)
+	}
+	c, err := loadConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.Profiles["fast"].Flags["quantum"]; got != "2" {
+		t.Errorf("saved quantum = %q, want 2\n%s", got, b.String())
+	}
+
+	if err := runTune(&b, "-algorithm", "lottery", workload); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("runTune(-algorithm lottery) error = %v, want ErrInvalidArgs", err)
+	}
+}
@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	_ "embed"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+//region Trace viewer
+
+//go:embed viewer.html
+var viewerHTML []byte
+
+// runView implements the view subcommand:
+//
+//	view [-addr localhost:8080] results.json
+//
+// It serves a zoomable timeline of the schedules in results.json, as
+// written by -json, for exploring runs too large for the printed Gantt
+// chart: the wheel zooms, dragging pans, hovering a slice shows its times
+// and the PIDs shown can be filtered. Lanes are per process or per CPU,
+// and a notch marks slices whose process last ran on another CPU.
+func runView(w io.Writer, args ...string) error {
+	fs := flag.NewFlagSet("view", flag.ContinueOnError)
+	fs.SetOutput(w)
+	addr := fs.String("addr", "localhost:8080", "address to serve the viewer on")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: usage: view [-addr localhost:8080] results.json", ErrInvalidArgs)
+	}
+	reports, err := readReports(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(w, "Serving %d schedules from %s at http://%s/\n", len(reports), fs.Arg(0), *addr)
+	return http.ListenAndServe(*addr, viewHandler(reports))
+}
+
+// viewHandler serves the viewer page and, at /reports.json, the reports it draws.
+func viewHandler(reports []Report) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(viewerHTML)
+	})
+	mux.HandleFunc("/reports.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := outputJSON(w, reports); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+	return mux
+}
+
+//endregion
@@ -0,0 +1,53 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_viewHandler(t *testing.T) {
+	t.Parallel()
+	reports := []Report{{Title: "Round-robin", Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 3}}}}
+	srv := httptest.NewServer(viewHandler(reports))
+	t.Cleanup(srv.Close)
+	tests := []struct {
+		path     string
+		wantCode int
+		wantType string
+	}{
+		{path: "/", wantCode: http.StatusOK, wantType: "text/html; charset=utf-8"},
+		{path: "/reports.json", wantCode: http.StatusOK, wantType: "application/json"},
+		{path: "/missing", wantCode: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+			resp, err := http.Get(srv.URL + tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() { _ = resp.Body.Close() }()
+			if resp.StatusCode != tt.wantCode {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.wantCode)
+			}
+			if tt.wantType != "" && resp.Header.Get("Content-Type") != tt.wantType {
+				t.Errorf("Content-Type = %q, want %q", resp.Header.Get("Content-Type"), tt.wantType)
+			}
+			if !strings.HasSuffix(tt.path, ".json") {
+				return
+			}
+			var got []Report
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, reports) {
+				t.Errorf("reports = %+v, want %+v", got, reports)
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package scheduler
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+//region Workload builder
+
+// runWorkload implements the workload subcommand:
+//
+//	workload new workload.csv|workload.json
+//
+// It prompts for processes on stdin, starting from the file's processes if
+// it exists, and writes them as CSV, or JSON when the file name ends in .json.
+func runWorkload(w io.Writer, args ...string) error {
+	fs := flag.NewFlagSet("workload", flag.ContinueOnError)
+	fs.SetOutput(w)
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidArgs, err)
+	}
+	if fs.NArg() != 2 || fs.Arg(0) != "new" {
+		return fmt.Errorf("%w: usage: workload new workload.csv|workload.json", ErrInvalidArgs)
+	}
+	path := fs.Arg(1)
+
+	var processes []Process
+	if f, err := os.Open(path); err == nil {
+		processes, err = loadProcesses(f)
+		_ = f.Close()
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(w, "Editing %s (%d processes)\n", path, len(processes))
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("%v: error opening workload", err)
+	}
+
+	return workloadWizard(os.Stdin, w, path, processes)
+}
+
+// workloadWizard reads commands from in until quit or end of input:
+// add, delete <id>, list, save and quit.
+func workloadWizard(in io.Reader, w io.Writer, path string, processes []Process) error {
+	sc := bufio.NewScanner(in)
+	prompt := func(label, def string) (string, bool) {
+		if def != "" {
+			_, _ = fmt.Fprintf(w, "%s [%s]: ", label, def)
+		} else {
+			_, _ = fmt.Fprintf(w, "%s: ", label)
+		}
+		if !sc.Scan() {
+			return "", false
+		}
+		if v := strings.TrimSpace(sc.Text()); v != "" {
+			return v, true
+		}
+		return def, true
+	}
+	// ask prompts until the answer is a valid integer of at least least.
+	ask := func(label string, def int64, least int64) (int64, bool) {
+		for {
+			v, ok := prompt(label, fmt.Sprint(def))
+			if !ok {
+				return 0, false
+			}
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err == nil && n >= least {
+				return n, true
+			}
+			_, _ = fmt.Fprintf(w, "  %s must be a whole number of at least %d\n", label, least)
+		}
+	}
+
+	_, _ = fmt.Fprintln(w, "Commands: add, delete <id>, list, save, quit")
+	dirty := false
+	for {
+		cmd, ok := prompt(">", "")
+		if !ok {
+			if dirty {
+				_, _ = fmt.Fprintln(w, "\nQuitting without saving")
+			}
+			return nil
+		}
+		fields := strings.Fields(cmd)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "add", "a":
+			var p Process
+			next := int64(1)
+			for _, q := range processes {
+				if q.ProcessID >= next {
+					next = q.ProcessID + 1
+				}
+			}
+			for {
+				id, ok := ask("ID", next, 0)
+				if !ok {
+					return nil
+				}
+				if processIndex(processes, id) < 0 {
+					p.ProcessID = id
+					break
+				}
+				_, _ = fmt.Fprintf(w, "  process %d already exists\n", id)
+			}
+			steps := []struct {
+				label string
+				field *int64
+				def   int64
+				least int64
+			}{
+				{"Burst", &p.BurstDuration, 1, 1},
+				{"Arrival", &p.ArrivalTime, 0, 0},
+				{"Priority", &p.Priority, 1, 0},
+				{"Weight", &p.Weight, defaultWeight, 1},
+			}
+			for _, s := range steps {
+				if *s.field, ok = ask(s.label, s.def, s.least); !ok {
+					return nil
+				}
+			}
+			for {
+				v, ok := prompt("Class (batch, interactive, fifo or rr)", "batch")
+				if !ok {
+					return nil
+				}
+				if err := p.setClass(v); err == nil {
+					break
+				}
+				_, _ = fmt.Fprintln(w, "  class must be batch, interactive, fifo or rr")
+			}
+			processes = append(processes, p)
+			dirty = true
+		case "delete", "d":
+			id, err := strconv.ParseInt(strings.Join(fields[1:], ""), 10, 64)
+			i := processIndex(processes, id)
+			if err != nil || i < 0 {
+				_, _ = fmt.Fprintln(w, "  usage: delete <id> of an existing process")
+				continue
+			}
+			processes = append(processes[:i], processes[i+1:]...)
+			dirty = true
+		case "list", "l":
+			outputProcesses(w, processes)
+		case "save", "s":
+			if err := saveWorkload(path, processes); err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(w, "Wrote %d processes to %s\n", len(processes), path)
+			dirty = false
+		case "quit", "q":
+			if dirty {
+				_, _ = fmt.Fprintln(w, "Quitting without saving")
+			}
+			return nil
+		default:
+			_, _ = fmt.Fprintln(w, "Commands: add, delete <id>, list, save, quit")
+		}
+	}
+}
+
+func processIndex(processes []Process, id int64) int {
+	for i := range processes {
+		if processes[i].ProcessID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func outputProcesses(w io.Writer, processes []Process) {
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "Burst", "Arrival", "Priority", "Weight", "Class", "Nice"})
+	for _, p := range processes {
+		table.Append([]string{
+			fmt.Sprint(p.ProcessID), fmt.Sprint(p.BurstDuration), fmt.Sprint(p.ArrivalTime),
+			fmt.Sprint(p.Priority), fmt.Sprint(p.weight()), p.class(), fmt.Sprint(p.Nice),
+		})
+	}
+	table.Render()
+}
+
+// saveWorkload writes processes, ordered by arrival, as JSON if path ends in .json and CSV otherwise.
+func saveWorkload(path string, processes []Process) error {
+	sorted := append([]Process(nil), processes...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ArrivalTime < sorted[j].ArrivalTime })
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return writeJSON(path, sorted)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("%w: creating CSV", err)
+	}
+	if err := outputProcessesCSV(f, sorted); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("%w: closing CSV", err)
+	}
+	return nil
+}
+
+// outputProcessesCSV writes processes in the format loadProcesses reads,
+// leaving off the weight, class, nice, deadline, group and affinity columns
+// when no process needs them.
+func outputProcessesCSV(w io.Writer, processes []Process) error {
+	columns := 4
+	for _, p := range processes {
+		if p.Affinity != 0 {
+			columns = 10
+		} else if p.Group != 0 && columns < 9 {
+			columns = 9
+		} else if p.Deadline != 0 && columns < 8 {
+			columns = 8
+		} else if p.Nice != 0 && columns < 7 {
+			columns = 7
+		} else if (p.Interactive || p.System || p.RealTime != "") && columns < 6 {
+			columns = 6
+		} else if p.Weight > 0 && p.Weight != defaultWeight && columns < 5 {
+			columns = 5
+		}
+	}
+
+	out := csv.NewWriter(w)
+	for _, p := range processes {
+		record := []string{
+			fmt.Sprint(p.ProcessID), fmt.Sprint(p.BurstDuration), fmt.Sprint(p.ArrivalTime),
+			fmt.Sprint(p.Priority), fmt.Sprint(p.weight()), p.class(), fmt.Sprint(p.Nice), "", fmt.Sprint(p.Group), "",
+		}
+		if len(p.Bursts) > 0 {
+			record[1] = formatBurstCycle(p.Bursts)
+		}
+		if p.Deadline != 0 {
+			record[7] = fmt.Sprint(p.Deadline)
+		}
+		if p.Affinity != 0 {
+			record[9] = fmt.Sprintf("0x%x", p.Affinity)
+		}
+		_ = out.Write(record[:columns])
+	}
+	out.Flush()
+	if err := out.Error(); err != nil {
+		return fmt.Errorf("%w: writing CSV", err)
+	}
+	return nil
+}
+
+//endregion
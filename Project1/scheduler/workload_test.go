@@ -0,0 +1,65 @@
+package scheduler
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_workloadWizard(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		file  string
+		input string
+		want  string
+	}{
+		{
+			name: "CSV",
+			file: "workload.csv",
+			// The duplicate ID, zero burst and unknown class are asked for again.
+			input: "add\n\n0\n4\n\n\n\nx\n\nadd\n1\n2\n6\n3\n2\n\n\nadd\n\n5\n\n\n\ni\ndelete 1\nsave\n",
+			want:  "3,5,0,1,100,interactive\n2,6,3,2,100,batch\n",
+		},
+		{
+			name:  "JSON",
+			file:  "workload.json",
+			input: "add\n7\n3\n1\n1\n200\nb\nsave\nquit\n",
+			want:  "[\n  {\n    \"id\": 7,\n    \"arrival\": 1,\n    \"burst\": 3,\n    \"priority\": 1,\n    \"weight\": 200\n  }\n]\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := workloadWizard(strings.NewReader(tt.input), io.Discard, path, nil); err != nil {
+				t.Fatal(err)
+			}
+			b, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tt.want {
+				t.Errorf("wrote %q, want %q", b, tt.want)
+			}
+
+			processes, err := loadProcesses(bytes.NewReader(b))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var again bytes.Buffer
+			if err := outputProcessesCSV(&again, processes); err != nil {
+				t.Fatal(err)
+			}
+			reloaded, _ := loadProcesses(&again)
+			if !reflect.DeepEqual(reloaded, processes) {
+				t.Errorf("round trip = %v, want %v", reloaded, processes)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+//region Worst-served processes
+
+// worstServed returns up to n rows of r with the highest normalized
+// turnaround (response ratio), breaking ties by longer wait.
+func worstServed(r Report, n int) []Row {
+	rows := append([]Row(nil), r.Rows...)
+	sort.SliceStable(rows, func(i, j int) bool {
+		ri, rj := rows[i].responseRatio(), rows[j].responseRatio()
+		if ri != rj {
+			return ri > rj
+		}
+		return rows[i].Wait > rows[j].Wait
+	})
+	if len(rows) > n {
+		rows = rows[:n]
+	}
+	return rows
+}
+
+// outputWorstServed lists each algorithm's n worst-served processes, so
+// cases like starvation of long jobs under SJF stand out.
+func outputWorstServed(w io.Writer, reports []Report, n int) {
+	_, _ = fmt.Fprintf(w, "Worst-served processes (top %d by normalized turnaround)\n", n)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "ID", "Burst", "Wait", "Turnaround", "Normalized turnaround"})
+	for _, r := range reports {
+		for i, row := range worstServed(r, n) {
+			title := r.Title
+			if i > 0 {
+				title = ""
+			}
+			table.Append([]string{
+				title,
+				fmt.Sprint(row.ProcessID),
+				fmt.Sprint(row.Burst),
+				fmt.Sprint(row.Wait),
+				fmt.Sprint(row.Turnaround),
+				fmt.Sprintf("%.2f", row.responseRatio()),
+			})
+		}
+	}
+	table.Render()
+}
+
+//endregion
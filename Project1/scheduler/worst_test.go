@@ -0,0 +1,23 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_worstServed(t *testing.T) {
+	t.Parallel()
+	r := Report{Rows: []Row{
+		{ProcessID: 1, Burst: 10, Wait: 10},
+		{ProcessID: 2, Burst: 1, Wait: 3},
+		{ProcessID: 3, Burst: 2, Wait: 6},
+		{ProcessID: 4, Burst: 5, Wait: 0},
+	}}
+	var got []int64
+	for _, row := range worstServed(r, 3) {
+		got = append(got, row.ProcessID)
+	}
+	if want := []int64{3, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("worstServed() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,112 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+)
+
+//region Weighted round-robin
+
+// wrrWeightSources are how -wrr-weights turns a process into a weight, with
+// 1 as the weight of a typical process.
+var wrrWeightSources = map[string]func(processes []Process) func(Process) float64{
+	// weight is the weight column over its default.
+	"weight": func([]Process) func(Process) float64 {
+		return func(p Process) float64 { return float64(p.weight()) / defaultWeight }
+	},
+	// nice is the CFS load weight of the nice column over nice 0's.
+	"nice": func([]Process) func(Process) float64 {
+		return func(p Process) float64 { return float64(p.loadWeight()) / nice0Weight }
+	},
+	// priority gives the least important process 1 and every priority
+	// level above it one more.
+	"priority": func(processes []Process) func(Process) float64 {
+		var lowest int64
+		for _, p := range processes {
+			if p.Priority > lowest {
+				lowest = p.Priority
+			}
+		}
+		return func(p Process) float64 { return float64(lowest - p.Priority + 1) }
+	},
+}
+
+// WRRPolicy is round-robin where each task's quantum is the base Quantum
+// scaled by its weight, at least one tick, so heavier tasks get
+// proportionally more of every round.
+type WRRPolicy struct {
+	Quantum int64
+	Weight  func(Process) float64
+}
+
+// quantum is t's share of every round.
+func (p WRRPolicy) quantum(t *Task) int64 {
+	q := int64(math.Round(float64(p.Quantum) * p.Weight(t.Process)))
+	if q < 1 {
+		q = 1
+	}
+	return q
+}
+
+func (p WRRPolicy) Pick(_ int64, running *Task, ready []*Task) *Task {
+	if running != nil && (running.Slice < p.quantum(running) || len(ready) == 0) {
+		return running
+	}
+	if len(ready) == 0 {
+		return nil
+	}
+	return ready[0]
+}
+
+// annotate adds each task's quantum.
+func (p WRRPolicy) annotate(r *Report, tasks []*Task) {
+	col := Column{Header: "Quantum"}
+	for _, t := range tasks {
+		col.Values = append(col.Values, fmt.Sprint(p.quantum(t)))
+	}
+	r.Columns = append(r.Columns, col)
+}
+
+// newWRR returns weighted round-robin with a base quantum and weights from
+// source: weight, nice or priority.
+func newWRR(processes []Process, quantum int64, source string) (WRRPolicy, error) {
+	weigh, ok := wrrWeightSources[strings.ToLower(source)]
+	if !ok {
+		names := make([]string, 0, len(wrrWeightSources))
+		for name := range wrrWeightSources {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return WRRPolicy{}, fmt.Errorf("%w: unknown weight source %q (have %s)", ErrInvalidArgs, source, strings.Join(names, ", "))
+	}
+	if quantum <= 0 {
+		return WRRPolicy{}, fmt.Errorf("%w: weighted round-robin needs a positive quantum, got %d", ErrInvalidArgs, quantum)
+	}
+	return WRRPolicy{Quantum: quantum, Weight: weigh(processes)}, nil
+}
+
+// WRRSchedule outputs the weighted round-robin schedule of processes as a
+// Gantt chart and a table of timing.
+func WRRSchedule(w io.Writer, title string, processes []Process, quantum int64, source string) error {
+	r, err := WRR(title, processes, quantum, source)
+	if err != nil {
+		return err
+	}
+	outputReport(w, r)
+	return nil
+}
+
+// WRR schedules processes weighted round-robin, scaling quantum by the
+// weight each gets from source.
+func WRR(title string, processes []Process, quantum int64, source string) (Report, error) {
+	p, err := newWRR(processes, quantum, source)
+	if err != nil {
+		return Report{}, err
+	}
+	return simulate(title, processes, p), nil
+}
+
+//endregion
@@ -0,0 +1,82 @@
+package scheduler
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWRR(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		processes   []Process
+		quantum     int64
+		source      string
+		wantGantt   []TimeSlice
+		wantQuantum []string
+		wantErr     error
+	}{
+		{
+			name:        "weight column",
+			processes:   []Process{{ProcessID: 1, BurstDuration: 8, Weight: 300}, {ProcessID: 2, BurstDuration: 4}},
+			quantum:     2,
+			source:      "weight",
+			wantGantt:   []TimeSlice{{PID: 1, Start: 0, Stop: 6}, {PID: 2, Start: 6, Stop: 8}, {PID: 1, Start: 8, Stop: 10}, {PID: 2, Start: 10, Stop: 12}},
+			wantQuantum: []string{"6", "2"},
+		},
+		{
+			name:        "priority levels",
+			processes:   []Process{{ProcessID: 1, BurstDuration: 4, Priority: 2}, {ProcessID: 2, BurstDuration: 4}},
+			quantum:     1,
+			source:      "Priority",
+			wantGantt:   []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 4}, {PID: 1, Start: 4, Stop: 5}, {PID: 2, Start: 5, Stop: 6}, {PID: 1, Start: 6, Stop: 8}},
+			wantQuantum: []string{"1", "3"},
+		},
+		{
+			name:        "nice values, at least one tick",
+			processes:   []Process{{ProcessID: 1, BurstDuration: 3, Nice: 19}, {ProcessID: 2, BurstDuration: 3, Nice: -5}},
+			quantum:     1,
+			source:      "nice",
+			wantGantt:   []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 1, Stop: 4}, {PID: 1, Start: 4, Stop: 6}},
+			wantQuantum: []string{"1", "3"},
+		},
+		{name: "unknown source", quantum: 2, source: "tickets", wantErr: ErrInvalidArgs},
+		{name: "no quantum", quantum: 0, source: "weight", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r, err := WRR("Weighted round-robin", tt.processes, tt.quantum, tt.source)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("WRR() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(r.Gantt, tt.wantGantt) {
+				t.Errorf("WRR() Gantt = %v, want %v", r.Gantt, tt.wantGantt)
+			}
+			if !reflect.DeepEqual(r.Columns[0].Values, tt.wantQuantum) {
+				t.Errorf("WRR() quanta = %q, want %q", r.Columns[0].Values, tt.wantQuantum)
+			}
+		})
+	}
+}
+
+func TestWRRSchedule(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	if err := WRRSchedule(&w, "Weighted round-robin", []Process{{ProcessID: 1, BurstDuration: 2, Weight: 50}}, 4, "weight"); err != nil {
+		t.Fatal(err)
+	}
+	if want := "QUANTUM"; !strings.Contains(w.String(), want) {
+		t.Errorf("WRRSchedule() = %q, want it to contain %q", w.String(), want)
+	}
+	if err := WRRSchedule(&w, "Weighted round-robin", nil, 4, "size"); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("WRRSchedule(size) error = %v, want ErrInvalidArgs", err)
+	}
+}
@@ -0,0 +1,350 @@
+package scheduler
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+//region Excel export
+
+// Cell styles defined in xlsxStyles; process fills follow xlsxProcessStyle.
+const (
+	xlsxDefaultStyle = iota
+	xlsxBoldStyle
+	xlsxDecimalStyle
+	xlsxProcessStyle
+)
+
+// xlsxMaxGanttColumns caps the width of a sheet's Gantt chart; longer
+// schedules put several ticks in each column.
+const xlsxMaxGanttColumns = 200
+
+const xlsxRootRels = xml.Header +
+	`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+// xlsxCell is one cell of a sheet; a nil value leaves the cell empty.
+type xlsxCell struct {
+	Value interface{}
+	Style int
+}
+
+// xlsxSheet is a named grid of cells.
+type xlsxSheet struct {
+	Name string
+	Rows [][]xlsxCell
+}
+
+// writeXLSX writes reports to an Excel workbook at path.
+func writeXLSX(path string, reports []Report) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("%w: creating workbook", err)
+	}
+	if err := outputXLSX(f, reports); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("%w: closing workbook", err)
+	}
+
+	return nil
+}
+
+// outputXLSX writes a workbook with a comparison sheet followed by one sheet
+// per report holding its schedule table and a Gantt chart drawn with filled
+// cells, with a lane per process and, on several CPUs, one per CPU.
+func outputXLSX(w io.Writer, reports []Report) error {
+	sheets := []xlsxSheet{comparisonSheet(reports)}
+	for _, r := range reports {
+		sheets = append(sheets, reportSheet(r))
+	}
+	uniqueSheetNames(sheets)
+
+	z := zip.NewWriter(w)
+	parts := map[string]string{
+		"[Content_Types].xml":        xlsxContentTypes(len(sheets)),
+		"_rels/.rels":                xlsxRootRels,
+		"xl/workbook.xml":            xlsxWorkbook(sheets),
+		"xl/_rels/workbook.xml.rels": xlsxWorkbookRels(len(sheets)),
+		"xl/styles.xml":              xlsxStyles(),
+	}
+	for i, s := range sheets {
+		parts[fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1)] = xlsxSheetXML(s)
+	}
+	// Keep the content types first, as some readers expect.
+	order := []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml"}
+	for i := range sheets {
+		order = append(order, fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1))
+	}
+	for _, name := range order {
+		pw, err := z.Create(name)
+		if err != nil {
+			return fmt.Errorf("%w: writing workbook", err)
+		}
+		if _, err := io.WriteString(pw, parts[name]); err != nil {
+			return fmt.Errorf("%w: writing workbook", err)
+		}
+	}
+	if err := z.Close(); err != nil {
+		return fmt.Errorf("%w: writing workbook", err)
+	}
+
+	return nil
+}
+
+func comparisonSheet(reports []Report) xlsxSheet {
+	s := xlsxSheet{Name: "Comparison"}
+	s.Rows = append(s.Rows, []xlsxCell{
+		{"Algorithm", xlsxBoldStyle},
+		{"Average wait", xlsxBoldStyle},
+		{"Average turnaround", xlsxBoldStyle},
+		{"Throughput", xlsxBoldStyle},
+	})
+	for _, r := range reports {
+		s.Rows = append(s.Rows, []xlsxCell{
+			{r.Title, xlsxDefaultStyle},
+			{r.Wait, xlsxDecimalStyle},
+			{r.Turnaround, xlsxDecimalStyle},
+			{r.Throughput, xlsxDecimalStyle},
+		})
+	}
+
+	return s
+}
+
+func reportSheet(r Report) xlsxSheet {
+	s := xlsxSheet{Name: r.Title}
+	s.Rows = append(s.Rows, []xlsxCell{{r.Title, xlsxBoldStyle}}, nil)
+
+	header := []xlsxCell{}
+	for _, h := range []string{"ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Exit"} {
+		header = append(header, xlsxCell{h, xlsxBoldStyle})
+	}
+	s.Rows = append(s.Rows, header)
+	for _, row := range r.Rows {
+		s.Rows = append(s.Rows, []xlsxCell{
+			{row.ProcessID, 0}, {row.Priority, 0}, {row.Burst, 0}, {row.Arrival, 0},
+			{row.Wait, 0}, {row.Turnaround, 0}, {row.Exit, 0},
+		})
+	}
+	s.Rows = append(s.Rows, []xlsxCell{
+		{}, {}, {}, {"Average", xlsxBoldStyle},
+		{r.Wait, xlsxDecimalStyle}, {r.Turnaround, xlsxDecimalStyle}, {nil, 0},
+	}, []xlsxCell{
+		{}, {}, {}, {"Throughput", xlsxBoldStyle}, {r.Throughput, xlsxDecimalStyle},
+	}, nil)
+
+	if len(r.Gantt) == 0 {
+		return s
+	}
+	s.Rows = append(s.Rows, []xlsxCell{{"Gantt schedule", xlsxBoldStyle}})
+	origin, end := r.Gantt[0].Start, r.Gantt[0].Stop
+	for _, g := range r.Gantt {
+		if g.Start < origin {
+			origin = g.Start
+		}
+		if g.Stop > end {
+			end = g.Stop
+		}
+	}
+	step := (end - origin + xlsxMaxGanttColumns - 1) / xlsxMaxGanttColumns
+	if step < 1 {
+		step = 1
+	}
+	columns := int((end - origin + step - 1) / step)
+
+	axis := []xlsxCell{{"Time", xlsxBoldStyle}}
+	for c := 0; c < columns; c++ {
+		axis = append(axis, xlsxCell{origin + int64(c)*step, 0})
+	}
+	s.Rows = append(s.Rows, axis)
+
+	lanes, _ := gifLanes(nil, r.Gantt)
+	colors := len(gifPalette) - gifProcessColor
+	for i, pid := range lanes {
+		lane := make([]xlsxCell, columns+1)
+		lane[0] = xlsxCell{pid, xlsxBoldStyle}
+		for _, g := range r.Gantt {
+			if g.PID != pid || g.Overhead {
+				continue
+			}
+			for t := g.Start; t < g.Stop; t++ {
+				lane[1+int((t-origin)/step)].Style = xlsxProcessStyle + i%colors
+			}
+		}
+		s.Rows = append(s.Rows, lane)
+	}
+
+	// On several CPUs, a lane per CPU too, naming the process at the start
+	// of each slice, with a * if it last ran on another CPU.
+	if cpus := cpuCount(r.Gantt); cpus > 1 {
+		migrated := migrations(r.Gantt)
+		for cpu := 0; cpu < cpus; cpu++ {
+			lane := make([]xlsxCell, columns+1)
+			lane[0] = xlsxCell{fmt.Sprintf("CPU %d", cpu), xlsxBoldStyle}
+			for j, g := range r.Gantt {
+				if g.CPU != cpu || g.Overhead {
+					continue
+				}
+				style := xlsxProcessStyle + laneIndex(lanes, g.PID)%colors
+				for t := g.Start; t < g.Stop; t++ {
+					lane[1+int((t-origin)/step)].Style = style
+				}
+				label := fmt.Sprint(g.PID)
+				if migrated[j] {
+					label += "*"
+				}
+				lane[1+int((g.Start-origin)/step)].Value = label
+			}
+			s.Rows = append(s.Rows, lane)
+		}
+	}
+
+	return s
+}
+
+// uniqueSheetNames makes sheet names valid for Excel: at most 31 characters,
+// none of []:*?/\, and no two the same.
+func uniqueSheetNames(sheets []xlsxSheet) {
+	seen := make(map[string]bool)
+	for i := range sheets {
+		name := strings.Map(func(r rune) rune {
+			if strings.ContainsRune(`[]:*?/\`, r) {
+				return '-'
+			}
+			return r
+		}, sheets[i].Name)
+		name = truncateRunes(name, 31)
+		base := name
+		for n := 2; seen[strings.ToLower(name)]; n++ {
+			suffix := fmt.Sprintf(" (%d)", n)
+			name = truncateRunes(base, 31-len(suffix)) + suffix
+		}
+		seen[strings.ToLower(name)] = true
+		sheets[i].Name = name
+	}
+}
+
+// truncateRunes cuts s to at most n characters, never splitting one.
+func truncateRunes(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n])
+	}
+	return s
+}
+
+// xlsxColumn returns the column letters for a zero-based column index.
+func xlsxColumn(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+func xlsxEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func xlsxSheetXML(s xlsxSheet) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for r, row := range s.Rows {
+		fmt.Fprintf(&b, `<row r="%d">`, r+1)
+		for c, cell := range row {
+			if cell.Value == nil && cell.Style == xlsxDefaultStyle {
+				continue
+			}
+			ref := fmt.Sprintf("%s%d", xlsxColumn(c), r+1)
+			switch v := cell.Value.(type) {
+			case nil:
+				fmt.Fprintf(&b, `<c r="%s" s="%d"/>`, ref, cell.Style)
+			case string:
+				fmt.Fprintf(&b, `<c r="%s" s="%d" t="inlineStr"><is><t>%s</t></is></c>`, ref, cell.Style, xlsxEscape(v))
+			default:
+				fmt.Fprintf(&b, `<c r="%s" s="%d"><v>%v</v></c>`, ref, cell.Style, v)
+			}
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+
+	return b.String()
+}
+
+func xlsxStyles() string {
+	var fills, xfs strings.Builder
+	for _, c := range gifPalette[gifProcessColor:] {
+		r, g, bl, _ := c.RGBA()
+		fmt.Fprintf(&fills, `<fill><patternFill patternType="solid"><fgColor rgb="FF%02X%02X%02X"/></patternFill></fill>`, r>>8, g>>8, bl>>8)
+	}
+	for i := range gifPalette[gifProcessColor:] {
+		fmt.Fprintf(&xfs, `<xf numFmtId="0" fontId="0" fillId="%d" borderId="0" xfId="0" applyFill="1"/>`, i+2)
+	}
+	count := len(gifPalette) - gifProcessColor
+
+	return xml.Header +
+		`<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+		`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		fmt.Sprintf(`<fills count="%d"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill>%s</fills>`, count+2, fills.String()) +
+		`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+		fmt.Sprintf(`<cellXfs count="%d">`, count+3) +
+		`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+		`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+		`<xf numFmtId="2" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+		xfs.String() + `</cellXfs></styleSheet>`
+}
+
+func xlsxWorkbook(sheets []xlsxSheet) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for i, s := range sheets {
+		fmt.Fprintf(&b, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xlsxEscape(s.Name), i+1, i+1)
+	}
+	b.WriteString(`</sheets></workbook>`)
+
+	return b.String()
+}
+
+func xlsxWorkbookRels(sheets int) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := 1; i <= sheets; i++ {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i, i)
+	}
+	fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, sheets+1)
+	b.WriteString(`</Relationships>`)
+
+	return b.String()
+}
+
+func xlsxContentTypes(sheets int) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	b.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	b.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := 1; i <= sheets; i++ {
+		fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+	}
+	b.WriteString(`</Types>`)
+
+	return b.String()
+}
+
+//endregion
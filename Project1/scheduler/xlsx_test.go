@@ -0,0 +1,99 @@
+package scheduler
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_outputXLSX(t *testing.T) {
+	t.Parallel()
+	reports := []Report{
+		{
+			Title: "First-come, first-serve",
+			Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 5, Stop: 14}},
+			Rows:  []Row{{ProcessID: 1, Burst: 5, Turnaround: 5, Exit: 5}, {ProcessID: 2, Burst: 9, Wait: 2, Turnaround: 11, Exit: 14}},
+		},
+		{Title: "Round-robin [q=10]"},
+	}
+
+	var w bytes.Buffer
+	if err := outputXLSX(&w, reports); err != nil {
+		t.Fatal(err)
+	}
+	z, err := zip.NewReader(bytes.NewReader(w.Bytes()), int64(w.Len()))
+	if err != nil {
+		t.Fatalf("workbook is not a zip: %v", err)
+	}
+	parts := make(map[string]string)
+	for _, f := range z.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := io.ReadAll(r)
+		parts[f.Name] = string(b)
+	}
+
+	for _, want := range []string{`name="Comparison"`, `name="First-come, first-serve"`, `name="Round-robin -q=10-"`} {
+		if !strings.Contains(parts["xl/workbook.xml"], want) {
+			t.Errorf("workbook.xml missing %s", want)
+		}
+	}
+	if _, ok := parts["xl/worksheets/sheet3.xml"]; !ok {
+		t.Error("missing sheet for the second report")
+	}
+	// Process 2's lane is row 12; it runs from 5 to 14, filling column G (time 5) through O (time 13).
+	sheet := parts["xl/worksheets/sheet2.xml"]
+	for _, cell := range []string{`<c r="G12" s="4"/>`, `<c r="O12" s="4"/>`} {
+		if !strings.Contains(sheet, cell) {
+			t.Errorf("sheet2.xml missing Gantt cell %s", cell)
+		}
+	}
+	for _, ref := range []string{`r="F12"`, `r="P12"`} {
+		if strings.Contains(sheet, ref) {
+			t.Errorf("sheet2.xml has Gantt cell %s outside process 2's run", ref)
+		}
+	}
+}
+
+func Test_reportSheet_cpus(t *testing.T) {
+	t.Parallel()
+	s := reportSheet(Report{
+		Title: "Round-robin on 2 CPUs",
+		Gantt: []TimeSlice{{PID: 1, Start: 0, Stop: 1}, {PID: 2, Start: 0, Stop: 2, CPU: 1}, {PID: 1, Start: 2, Stop: 3, CPU: 1}},
+		Rows:  []Row{{ProcessID: 1}, {ProcessID: 2}},
+	})
+	// The last two rows are the CPU lanes, after the time axis and a lane per process.
+	cpu0, cpu1 := s.Rows[len(s.Rows)-2], s.Rows[len(s.Rows)-1]
+	want0 := []xlsxCell{{"CPU 0", xlsxBoldStyle}, {"1", xlsxProcessStyle}, {}, {}}
+	want1 := []xlsxCell{{"CPU 1", xlsxBoldStyle}, {"2", xlsxProcessStyle + 1}, {nil, xlsxProcessStyle + 1}, {"1*", xlsxProcessStyle}}
+	if !reflect.DeepEqual(cpu0, want0) || !reflect.DeepEqual(cpu1, want1) {
+		t.Errorf("CPU lanes =\n%v\n%v\nwant\n%v\n%v", cpu0, cpu1, want0, want1)
+	}
+}
+
+func Test_xlsxColumn(t *testing.T) {
+	t.Parallel()
+	for i, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if got := xlsxColumn(i); got != want {
+			t.Errorf("xlsxColumn(%d) = %s, want %s", i, got, want)
+		}
+	}
+}
+
+func Test_uniqueSheetNames(t *testing.T) {
+	t.Parallel()
+	long := strings.Repeat("é", 40)
+	sheets := []xlsxSheet{{Name: long}, {Name: long}, {Name: "a/b"}}
+	uniqueSheetNames(sheets)
+	want := []string{strings.Repeat("é", 31), strings.Repeat("é", 27) + " (2)", "a-b"}
+	for i, s := range sheets {
+		if s.Name != want[i] {
+			t.Errorf("sheet %d = %q, want %q", i, s.Name, want[i])
+		}
+	}
+}